@@ -10,8 +10,18 @@ import (
 	"os"
 	"sync"
 
+	"tobi.backfrak.de/internal/csvtrackbl"
+	"tobi.backfrak.de/internal/exportbl"
+	"tobi.backfrak.de/internal/fitbl"
+	"tobi.backfrak.de/internal/geojsonbl"
 	"tobi.backfrak.de/internal/gpsabl"
 	"tobi.backfrak.de/internal/gpxbl"
+	"tobi.backfrak.de/internal/igcbl"
+	"tobi.backfrak.de/internal/kmlbl"
+	"tobi.backfrak.de/internal/nmeabl"
+	"tobi.backfrak.de/internal/pltbl"
+	"tobi.backfrak.de/internal/sqlitebl"
+	"tobi.backfrak.de/internal/unicsvbl"
 )
 
 var errorMux sync.Mutex
@@ -34,6 +44,52 @@ func HandleError(err error, filePath string, skipExit bool, dontPanic bool) bool
 			fmt.Fprintln(os.Stderr, fmt.Sprintf("Error: The given track file \"%s\" is not well formatted: %s", filePath, err.Error()))
 		case *gpxbl.GpxFileError:
 			fmt.Fprintln(os.Stderr, err.Error())
+		case *fitbl.FitFileError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *fitbl.CorruptFitFileError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *kmlbl.KmlFileError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *kmlbl.KmlCoordinateError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *kmlbl.ColorColumnNotKnownError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *sqlitebl.SqliteFileError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *sqlitebl.SchemaNotKnownError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *geojsonbl.GeoJsonFileError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *geojsonbl.GeoJsonCoordinateError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *nmeabl.NmeaFileError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *igcbl.IgcFileError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *igcbl.IgcRecordError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *igcbl.AltitudeSourceNotKnownError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *pltbl.PltFileError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *pltbl.PltPointError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *unicsvbl.UnicsvFileError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *unicsvbl.UnicsvPointError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *csvtrackbl.CsvTrackFileError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *csvtrackbl.CsvTrackPointError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *csvtrackbl.ColumnMappingError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *csvtrackbl.SettingNotKnownError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *exportbl.ExportFormatError:
+			fmt.Fprintln(os.Stderr, err.Error())
+		case *exportbl.ActivityFileError:
+			fmt.Fprintln(os.Stderr, err.Error())
 		case *UnKnownFileTypeError:
 			fmt.Fprintln(os.Stderr, err.Error())
 		case *OutFileIsDirError:
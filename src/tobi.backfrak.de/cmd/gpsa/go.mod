@@ -1,5 +1,7 @@
 module "tobi.backfrak.de/cmd/gpsa"
 
+go 1.23.0
+
 
 
 require "tobi.backfrak.de/internal/gpsabl" v0.0.0
@@ -14,8 +16,41 @@ require "tobi.backfrak.de/internal/tcxbl" v0.0.0
 replace  "tobi.backfrak.de/internal/tcxbl" v0.0.0 => "../../internal/tcxbl"
 require "tobi.backfrak.de/internal/mdbl" v0.0.0
 replace  "tobi.backfrak.de/internal/mdbl" v0.0.0 => "../../internal/mdbl"
+require "tobi.backfrak.de/internal/fitbl" v0.0.0
+replace  "tobi.backfrak.de/internal/fitbl" v0.0.0 => "../../internal/fitbl"
+require "tobi.backfrak.de/internal/kmlbl" v0.0.0
+replace  "tobi.backfrak.de/internal/kmlbl" v0.0.0 => "../../internal/kmlbl"
+require "tobi.backfrak.de/internal/geojsonbl" v0.0.0
+replace  "tobi.backfrak.de/internal/geojsonbl" v0.0.0 => "../../internal/geojsonbl"
+require "tobi.backfrak.de/internal/nmeabl" v0.0.0
+replace  "tobi.backfrak.de/internal/nmeabl" v0.0.0 => "../../internal/nmeabl"
+require "tobi.backfrak.de/internal/igcbl" v0.0.0
+replace  "tobi.backfrak.de/internal/igcbl" v0.0.0 => "../../internal/igcbl"
+require "tobi.backfrak.de/internal/pltbl" v0.0.0
+replace  "tobi.backfrak.de/internal/pltbl" v0.0.0 => "../../internal/pltbl"
+require "tobi.backfrak.de/internal/unicsvbl" v0.0.0
+replace  "tobi.backfrak.de/internal/unicsvbl" v0.0.0 => "../../internal/unicsvbl"
+require "tobi.backfrak.de/internal/csvtrackbl" v0.0.0
+replace  "tobi.backfrak.de/internal/csvtrackbl" v0.0.0 => "../../internal/csvtrackbl"
+require "tobi.backfrak.de/internal/exportbl" v0.0.0
+replace  "tobi.backfrak.de/internal/exportbl" v0.0.0 => "../../internal/exportbl"
+
+require "tobi.backfrak.de/internal/htmlbl" v0.0.0
+replace  "tobi.backfrak.de/internal/htmlbl" v0.0.0 => "../../internal/htmlbl"
+require "tobi.backfrak.de/internal/sqlitebl" v0.0.0
+replace  "tobi.backfrak.de/internal/sqlitebl" v0.0.0 => "../../internal/sqlitebl"
 
 require "tobi.backfrak.de/internal/testhelper" v0.0.0
 replace  "tobi.backfrak.de/internal/testhelper" v0.0.0 => "../../internal/testhelper"
 
-
+require "github.com/dustin/go-humanize" v1.0.1 // indirect
+require "github.com/google/uuid" v1.6.0 // indirect
+require "github.com/mattn/go-isatty" v0.0.20 // indirect
+require "github.com/ncruces/go-strftime" v0.1.9 // indirect
+require "github.com/remyoudompheng/bigfft" v0.0.0-20230129092748-24d4a6f8daec // indirect
+require "golang.org/x/exp" v0.0.0-20250620022241-b7579e27df2b // indirect
+require "golang.org/x/sys" v0.34.0 // indirect
+require "modernc.org/libc" v1.66.3 // indirect
+require "modernc.org/mathutil" v1.7.1 // indirect
+require "modernc.org/memory" v1.11.0 // indirect
+require "modernc.org/sqlite" v1.39.0 // indirect
\ No newline at end of file
@@ -7,13 +7,22 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"flag"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"runtime"
 	"strings"
 
+	"tobi.backfrak.de/internal/csvtrackbl"
+	"tobi.backfrak.de/internal/exportbl"
+	"tobi.backfrak.de/internal/geojsonbl"
 	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/igcbl"
+	"tobi.backfrak.de/internal/kmlbl"
+	"tobi.backfrak.de/internal/nmeabl"
 )
 
 // HelpFlag - Tells if the program was called with -help
@@ -61,9 +70,66 @@ var MinimalMovingSpeedParameter float64
 // MinimalStepHightParameter - Tells the minimal step hight, when "steps" correction is used
 var MinimalStepHightParameter float64
 
+// NmeaMaximalTimeGapParameter - Tells the time between two NMEA fixes, that starts a new segment ( -nmea-maximal-time-gap )
+var NmeaMaximalTimeGapParameter float64
+
+// IgcAltitudeParameter - Tells which altitude of IGC flight logs is used as elevation ( -igc-altitude )
+var IgcAltitudeParameter string
+
+// CsvMappingParameter - Tells which columns of *.csv files contain the track point values ( -csv-mapping )
+var CsvMappingParameter string
+
+// CsvSeparatorParameter - The separator of the columns in *.csv files read with -csv-mapping ( -csv-separator )
+var CsvSeparatorParameter string
+
+// CsvTimeLayoutParameter - The layout of the time column in *.csv files read with -csv-mapping ( -csv-time-layout )
+var CsvTimeLayoutParameter string
+
+// CsvElevationUnitParameter - The unit of the elevation column in *.csv files read with -csv-mapping ( -csv-elevation-unit )
+var CsvElevationUnitParameter string
+
+// CsvNoHeaderFlag - Tell if the *.csv files read with -csv-mapping have no header line ( -csv-no-header )
+var CsvNoHeaderFlag bool
+
+// WalkDepthParameter - The number of sub directory levels walked below a directory given as input ( -walk-depth )
+var WalkDepthParameter int
+
+// IncludePatternParameter - A comma separated list of glob patterns, files found in directories must match one of them ( -include )
+var IncludePatternParameter string
+
+// ExcludePatternParameter - A comma separated list of glob patterns, files and directories matching one of them are skipped ( -exclude )
+var ExcludePatternParameter string
+
+// JobsParameter - The number of files that are read and processed at the same time ( -jobs )
+var JobsParameter int
+
+// NoCacheFlag - Tell if the program was called with the -no-cache flag, so files are always read and nothing is stored in the cache
+var NoCacheFlag bool
+
+// ClearCacheFlag - Tell if the program was called with the -clear-cache flag, to remove all entries from the cache before the files are read
+var ClearCacheFlag bool
+
+// CacheDirParameter - The directory the cache entries are stored in ( -cache-dir ). Empty for the default directory of gpsabl.GetDefaultCacheDirectory
+var CacheDirParameter string
+
 // PrintElevationOverDistanceFlag - Tell if the program was called with the -print-elevation-over-distance flag
 var PrintElevationOverDistanceFlag bool
 
+// PrintWaypointsFlag - Tell if the program was called with the -print-waypoints flag
+var PrintWaypointsFlag bool
+
+// WriteCorrectedElevationFlag - Tell if the program was called with the -write-corrected-elevation flag, so the gpx, geojson and kml output contains the corrected elevation
+var WriteCorrectedElevationFlag bool
+
+// KmlColorByParameter - The summary column the color of the lines in the kml output depends on ( -kml-color-by )
+var KmlColorByParameter string
+
+// KmlGxTrackFlag - Tell if the program was called with the -kml-gx-track flag, so the kml output contains gx:Track elements with time stamps
+var KmlGxTrackFlag bool
+
+// GeoJsonPrecisionParameter - The number of decimal places of the coordinates in the geojson output ( -geojson-precision )
+var GeoJsonPrecisionParameter int
+
 // StdOutFormatParameter - Tells the formant when StdOut is the output stream -std-out-format
 var StdOutFormatParameter string
 
@@ -73,44 +139,43 @@ var MinStartTime string
 // MaxStartTime - The maximum StartTime for a track to be added to the output. Formatted in "YYYY-MMM-dd HH:mm:ss", may without seconds or just a date
 var MaxStartTime string
 
+// ActivityTypeFilterParameter - A comma separated list of activity types, tracks of other types are not added to the output ( -activity-type )
+var ActivityTypeFilterParameter string
+
+// GearFilterParameter - A comma separated list of gears, tracks recorded with other gear are not added to the output ( -gear )
+var GearFilterParameter string
+
+// SortByParameter - The summary column the output lines are sorted by. The lines are in the order of the input files when empty ( -sort-by )
+var SortByParameter string
+
+// SortOrderParameter - Tells if the output lines are sorted ascending or descending ( -sort-order )
+var SortOrderParameter string
+
 // MarkdownAdditionalSummaryTrackListText - The text written before the track list table in case markdown output and '-summary=additional' is used in combination
 var MarkdownAdditionalSummaryTrackListText string
 
 // MarkdownAdditionalSummaryText - The text written before the summary table in case markdown output and '-summary=additional' is used in combination
 var MarkdownAdditionalSummaryText string
 
-// ReadInputStreamBuffer - Read an input stream and figure out what kind of files are given
+// ReadInputStreamBuffer - Read an input stream and figure out what kind of files are given. The stream may contain
+// track file content, framed as described by gpsabl.GetInputFilesFromStream, or a list of track file pathes
 func ReadInputStreamBuffer(reader *bufio.Reader) ([]gpsabl.InputFile, error) {
 	var fileArgs []gpsabl.InputFile
-	var inputBytes []byte
-	for {
-		input, errRead := reader.ReadByte()
-		if errRead != nil {
-			if errRead == io.EOF {
-				break
-			} else {
-				return nil, errRead
-			}
-		}
-
-		inputBytes = append(inputBytes, input)
+	inputBytes, errRead := ioutil.ReadAll(reader)
+	if errRead != nil {
+		return nil, errRead
 	}
 
-	buffers := getXMlFileBuffersFromInputStream(inputBytes)
-
-	if len(buffers) != 0 {
-		// fmt.Fprintln(os.Stdout, fmt.Sprintf("Got %d input files as stream", len(buffers)))
-
-		for i, buffer := range buffers {
-			res, input := gpsabl.GetInputFileFromBuffer(ValidReaders, buffer, fmt.Sprintf("Input stream buffer %d", i+1))
-			if res == true {
-				fileArgs = append(fileArgs, input)
-			}
-		}
-		if len(fileArgs) != 0 && VerboseFlag {
-			fmt.Fprintln(os.Stdout, fmt.Sprintf("Got %d files as stream", len(fileArgs)))
+	res, inputs, framing, errStream := gpsabl.GetInputFilesFromStream(ValidReaders, inputBytes)
+	if errStream != nil {
+		return nil, errStream
+	}
+	if res == true {
+		if VerboseFlag {
+			fmt.Fprintln(os.Stdout, fmt.Sprintf("Got %d file(s) from a %s input stream", len(inputs), framing))
 		}
-		return fileArgs, nil
+		printDetection(inputs)
+		return inputs, nil
 	}
 
 	fileArgsStr, errProcFileName := getFilePathFromInputStream(inputBytes)
@@ -119,9 +184,13 @@ func ReadInputStreamBuffer(reader *bufio.Reader) ([]gpsabl.InputFile, error) {
 	}
 
 	for _, fileArgStr := range fileArgsStr {
-		res, input := gpsabl.GetInputFileFromPath(ValidReaders, fileArgStr)
+		res, inputs, errExpand := gpsabl.GetInputFilesFromPath(ValidReaders, fileArgStr)
+		if errExpand != nil {
+			return nil, errExpand
+		}
 		if res == true {
-			fileArgs = append(fileArgs, input)
+			printDetection(inputs)
+			fileArgs = append(fileArgs, inputs...)
 		} else {
 			return nil, newUnKnownFileTypeError(fileArgStr)
 		}
@@ -130,40 +199,12 @@ func ReadInputStreamBuffer(reader *bufio.Reader) ([]gpsabl.InputFile, error) {
 	return fileArgs, nil
 }
 
-// getXMlFileBuffersFromInputStream - Parse the input buffer array and search for xml files
-// resturn an array of input buffer arrays, where each array of input buffer array contain exactly one xml file content
-func getXMlFileBuffersFromInputStream(inputBytes []byte) [][]byte {
-	var startBytes []int
-	for i := range inputBytes {
-		if len(inputBytes) < i+5 {
-			break
-		}
-		section := inputBytes[i : i+5]
-		if string(section) == "<?xml" {
-			startBytes = append(startBytes, i)
-		}
-	}
-
-	var retVal [][]byte
-	size := len(inputBytes)
-	numberFiles := len(startBytes)
-	for i, index := range startBytes {
-		// fmt.Println(fmt.Sprintf("New xml file at index %d", index))
-		var oneFile []byte
-		if i < numberFiles-1 {
-			oneFile = inputBytes[index:startBytes[i+1]]
-		} else {
-			oneFile = inputBytes[index:size]
-		}
-		retVal = append(retVal, oneFile)
-	}
-	return retVal
-}
-
-// getFilePathFromInputStream - parse the input bytes array and search for valid file pathes
+// getFilePathFromInputStream - parse the input bytes array and search for valid file pathes.
+// The pathes are separated by new lines or NUL bytes, like the output of "find -print0"
 // resturn the list of valid file pathes
 func getFilePathFromInputStream(inputBytes []byte) ([]string, error) {
 	var fileArgs []string
+	inputBytes = bytes.ReplaceAll(inputBytes, []byte{0}, []byte("\n"))
 	read, write, errCreate := os.Pipe()
 	if errCreate != nil {
 		return nil, errCreate
@@ -203,6 +244,25 @@ func handleComandlineOptions() {
 	// Setup the valid comandline flags
 	flag.Float64Var(&MinimalStepHightParameter, "minimal-step-hight", 10.0, "The minimal step hight. Only in use when \"steps\"  elevation correction is used. In [m]")
 	flag.Float64Var(&MinimalMovingSpeedParameter, "minimal-moving-speed", 0.3, "The minimal speed. Distances traveled with less speed are not counted. In [m/s]")
+	flag.Float64Var(&NmeaMaximalTimeGapParameter, "nmea-maximal-time-gap", nmeabl.DefaultMaximalTimeGap.Seconds(), "The maximal time between two fixes of a NMEA log. A longer gap starts a new segment. In [s]")
+	flag.StringVar(&IgcAltitudeParameter, "igc-altitude", string(igcbl.PRESSURE),
+		fmt.Sprintf("Define which altitude of IGC flight logs is used as elevation. The other one is used, when the log does not contain the given one. Possible values are [%s]", igcbl.GetValidAltitudeSourcesString()))
+	flag.StringVar(&CsvMappingParameter, "csv-mapping", "",
+		fmt.Sprintf("Read *.csv files with the given columns, like \"lat=Latitude,lon=Longitude,ele=3,time=Timestamp\". A column is given by its header name or its number, starting with 1. \"segment\" and \"track\" columns split the points into segments and tracks. Possible keys are %v", csvtrackbl.GetValidMappingKeys()))
+	flag.StringVar(&CsvSeparatorParameter, "csv-separator", csvtrackbl.DefaultSeparator, "The separator of the columns in *.csv files read with -csv-mapping. A single character or \"tab\"")
+	flag.StringVar(&CsvTimeLayoutParameter, "csv-time-layout", csvtrackbl.DefaultTimeLayout,
+		fmt.Sprintf("The layout of the time column in *.csv files read with -csv-mapping, as go time layout like \"2006-01-02 15:04:05\", or \"%s\" or \"%s\" for seconds or milliseconds since 1970-01-01 UTC", csvtrackbl.UnixTimeLayout, csvtrackbl.UnixMilliTimeLayout))
+	flag.StringVar(&CsvElevationUnitParameter, "csv-elevation-unit", string(csvtrackbl.METER),
+		fmt.Sprintf("The unit of the elevation column in *.csv files read with -csv-mapping. Possible values are [%s]", csvtrackbl.GetValidElevationUnitsString()))
+	flag.BoolVar(&CsvNoHeaderFlag, "csv-no-header", false, "The *.csv files read with -csv-mapping have no header line, all columns are given by number")
+	flag.IntVar(&WalkDepthParameter, "walk-depth", -1, "The number of sub directory levels walked below a directory or \"**\" pattern given as input. 0 reads only the files in the directory, -1 walks all levels")
+	flag.StringVar(&IncludePatternParameter, "include", "", "A comma separated list of glob patterns, like \"*.gpx,*.tcx\". Only files found in directories or by patterns that match one of them are read. Patterns without \"/\" are matched against the file name")
+	flag.StringVar(&ExcludePatternParameter, "exclude", "", "A comma separated list of glob patterns, like \"archive,**/*.bak.gpx\". Files and directories found in directories or by patterns that match one of them are skipped. Patterns without \"/\" are matched against the name")
+	flag.IntVar(&JobsParameter, "jobs", runtime.NumCPU(), "The number of files that are read and processed at the same time. A value less than 1 uses the number of CPUs")
+	flag.BoolVar(&NoCacheFlag, "no-cache", false, "Do not use the cache. All files are read, and nothing is stored in the cache")
+	flag.BoolVar(&ClearCacheFlag, "clear-cache", false, "Remove all entries from the cache before the files are read")
+	flag.StringVar(&CacheDirParameter, "cache-dir", "",
+		"The directory the cache is stored in. The cache keeps the values of files already read, so unchanged files are not read again with the same options. By default this is gpsa in the users cache directory, as given by os.UserCacheDir()")
 	flag.BoolVar(&SuppressDuplicateOutPutFlag, "suppress-duplicate-out-put", false, "Suppress the output of duplicate lines. Duplicates are detected by timestamps. Output with non valid time data may still contains duplicates.")
 	flag.BoolVar(&HelpFlag, "help", false, "Print help message and exit")
 	flag.BoolVar(&PrintVersionFlag, "version", false, "Print version of the program and exit")
@@ -218,6 +278,12 @@ func handleComandlineOptions() {
 	flag.StringVar(&CorrectionParameter, "correction", string(gpsabl.STEPS),
 		fmt.Sprintf("Define how to correct the elevation data read in from the track. Possible values are [%s]", gpsabl.GetValidCorrectionParametersString()))
 	flag.BoolVar(&PrintElevationOverDistanceFlag, "print-elevation-over-distance", false, "Tell if \"ElevationOverDistance.csv\" should be created for each track. The files will be locate in tmp dir.")
+	flag.BoolVar(&PrintWaypointsFlag, "print-waypoints", false, "Tell if the waypoints of the input files should be listed after the tracks, together with their distance along the closest track. Ignored when summary is \"only\"")
+	flag.BoolVar(&WriteCorrectedElevationFlag, "write-corrected-elevation", false, "Tell if the gpx, geojson and kml output should contain the elevation corrected by -correction, instead of the elevation read from the input file")
+	flag.StringVar(&KmlColorByParameter, "kml-color-by", string(kmlbl.DefaultColorColumn),
+		fmt.Sprintf("The summary column the color of the lines in the kml output depends on, from green for the lowest to red for the highest value. Lines without a valid value are gray, all lines are green when empty. Possible values are [%s]", getKmlColorColumnsString()))
+	flag.BoolVar(&KmlGxTrackFlag, "kml-gx-track", false, "Tell if the kml output should contain gx:Track elements with time stamps, so the tracks can be replayed in Google Earth. Tracks with points without time are written as LineString")
+	flag.IntVar(&GeoJsonPrecisionParameter, "geojson-precision", geojsonbl.DefaultPrecision, "The number of decimal places of the longitude and latitude in the geojson output. The full precision is written when negative")
 	flag.StringVar(&StdOutFormatParameter, "std-out-format", string(ValidFormaters[0].GetOutputFormaterTypes()[0]),
 		fmt.Sprintf("The output format when stdout is the used output. Ignored when out-file is given. Possible values are [%s]", getStdOutFormatParameterValuesStr()))
 	flag.StringVar(&SummaryParameter, "summary", string(gpsabl.NONE),
@@ -228,6 +294,14 @@ func handleComandlineOptions() {
 		"The minimum StartTime for a track to be added to the output. Formatted in \"YYYY-MMM-dd HH:mm:ss\", may without seconds or just a date")
 	flag.StringVar(&MaxStartTime, "maximum-start-time", "",
 		"The maximum StartTime for a track to be added to the output. Formatted in \"YYYY-MMM-dd HH:mm:ss\", may without seconds or just a date")
+	flag.StringVar(&ActivityTypeFilterParameter, "activity-type", "",
+		"A comma separated list of activity types, like \"Ride,Run\". Only tracks of this types are added to the output. The activity type is known for the activities of a bulk export")
+	flag.StringVar(&GearFilterParameter, "gear", "",
+		"A comma separated list of gears. Only tracks recorded with this gear are added to the output. The gear is known for the activities of a bulk export")
+	flag.StringVar(&SortByParameter, "sort-by", string(gpsabl.INPUTORDER),
+		fmt.Sprintf("The summary column the output lines are sorted by. The lines are written in the order of the input files when not set. Possible values are [%s]", gpsabl.GetValidSortColumnsString()))
+	flag.StringVar(&SortOrderParameter, "sort-order", string(gpsabl.ASCENDING),
+		fmt.Sprintf("Tell if the output lines are sorted ascending or descending when -sort-by is set. Lines without a valid value are always written last. Possible values are [%s]", gpsabl.GetValidSortOrdersString()))
 	flag.StringVar(&MarkdownAdditionalSummaryTrackListText, "markdown-track-list-text", "List of Tracks:",
 		"The text written before the track list table in case markdown output and '-summary=additional' is used in combination")
 	flag.StringVar(&MarkdownAdditionalSummaryText, "markdown-summary-text", "Summary table:",
@@ -248,16 +322,38 @@ func customHelpMessage() {
 	fmt.Fprintln(os.Stdout, fmt.Sprintf("Usage: %s [options] [files]", os.Args[0]))
 	fmt.Fprintln(os.Stdout, "  files")
 	fmt.Fprintln(os.Stdout, fmt.Sprintf("        One or more track files of the following type: %s", getValidTrackExtensions()))
+	fmt.Fprintln(os.Stdout, fmt.Sprintf("        The track files may be compressed as *%s or *%s, or packed into *%s or *%s archives", gpsabl.GzipFileExtension, gpsabl.Bzip2FileExtension, gpsabl.ZipFileExtension, gpsabl.TarFileExtension))
+	fmt.Fprintln(os.Stdout, "        Directories are walked recursively, glob patterns like \"tracks/**/*.gpx\" are matched. Files of unknown type found this way are skipped")
+	fmt.Fprintln(os.Stdout, "        The format of a file is detected by its content, like the xml root element or the FIT header, and by its extension")
+	fmt.Fprintln(os.Stdout, fmt.Sprintf("        The root directory of a Strava bulk export is read using its %s. The activity name, description, type and gear are added to the tracks", exportbl.ActivitiesFileName))
 	fmt.Fprintln(os.Stdout, "Options:")
 	flag.PrintDefaults()
 	fmt.Fprintln(os.Stdout)
 	fmt.Fprintln(os.Stdout, "It is also possible to pipe track file names or track file content into")
+	fmt.Fprintln(os.Stdout, "The content may be concatenated xml and json documents, with or without xml declaration, chained *.fit files, NUL separated documents or a tar archive")
 	fmt.Fprintln(os.Stdout)
 	fmt.Fprintln(os.Stdout, "Examples:")
 	fmt.Fprintln(os.Stdout, "./gpsa my/test/file.gpx")
 	fmt.Fprintln(os.Stdout, "./gpsa -verbose -out-file=gps-statistics.csv my/test/*.gpx")
 	fmt.Fprintln(os.Stdout, "find ./testdata/valid-gpx -name \"*.gpx\" | ./bin/gpsa -summary=additional -out-file=./test.json")
 	fmt.Fprintln(os.Stdout, "cat  01.gpx 01.tcx 03.tcx 02.gpx | ./bin/gpsa -out-file=./test.json")
+	fmt.Fprintln(os.Stdout, "tar -c -f - tracks | ./bin/gpsa -out-file=./test.csv")
+	fmt.Fprintln(os.Stdout, "./gpsa -sort-by=ElevationGain -sort-order=desc my/test/*.gpx")
+	fmt.Fprintln(os.Stdout, "./gpsa -summary=additional -out-file=season.html my/test/*.gpx")
+	fmt.Fprintln(os.Stdout, "./gpsa -minimum-start-time=\"2024-Apr-01\" -write-corrected-elevation -out-file=cleaned.gpx my/test/*.fit")
+	fmt.Fprintln(os.Stdout, "./gpsa -summary=additional -geojson-precision=5 -out-file=map.geojson my/test/*.gpx")
+	fmt.Fprintln(os.Stdout, "./gpsa -kml-color-by=ElevationGain -kml-gx-track -out-file=season.kml my/test/*.gpx")
+	fmt.Fprintln(os.Stdout, "./gpsa -out-file=archive.sqlite my/test/*.gpx")
+}
+
+// getKmlColorColumnsString - Get a string that contains all valid -kml-color-by values
+func getKmlColorColumnsString() string {
+	var columns []string
+	for _, column := range gpsabl.GetValidNumberColumns() {
+		columns = append(columns, string(column))
+	}
+
+	return strings.Join(columns, " ")
 }
 
 // getStdOutFormatParameterValuesStr - Get a string that contains all valid stdOutFormatParameterValues
@@ -6,16 +6,25 @@ package main
 // LICENSE file.
 
 import (
+	"archive/tar"
 	"bufio"
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
 
 	"tobi.backfrak.de/internal/csvbl"
+	"tobi.backfrak.de/internal/fitbl"
+	"tobi.backfrak.de/internal/geojsonbl"
 	"tobi.backfrak.de/internal/gpsabl"
 	"tobi.backfrak.de/internal/gpxbl"
+	"tobi.backfrak.de/internal/igcbl"
+	"tobi.backfrak.de/internal/nmeabl"
+	"tobi.backfrak.de/internal/tcxbl"
 	"tobi.backfrak.de/internal/testhelper"
 )
 
@@ -124,6 +133,322 @@ func TestReadInputStreamBufferWithTwoGPXFileContent(t *testing.T) {
 
 }
 
+func TestReadInputStreamBufferWithNulSeparatedGpxAndTcxContent(t *testing.T) {
+	gpx, errGpx := testhelper.GetValidGpxBuffer("01.gpx")
+	tcx, errTcx := testhelper.GetValidTcxBuffer("02.tcx")
+	if errGpx != nil || errTcx != nil {
+		t.Fatalf("Can not read the test files")
+	}
+
+	stream := append(append(gpx, 0), tcx...)
+	input, err := ReadInputStreamBuffer(bufio.NewReader(bytes.NewReader(stream)))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(input) != 2 {
+		t.Fatalf("The input has %d files, but %d files are expected", len(input), 2)
+	}
+
+	if input[0].Type != gpxbl.GpxBuffer || input[1].Type != tcxbl.TcxBuffer {
+		t.Errorf("The types are %s and %s, but %s and %s are expected", input[0].Type, input[1].Type, gpxbl.GpxBuffer, tcxbl.TcxBuffer)
+	}
+
+	if len(input[0].Buffer) != len(gpx) || len(input[1].Buffer) != len(tcx) {
+		t.Errorf("The buffers do not contain the documents before and after the NUL byte")
+	}
+}
+
+func TestReadInputStreamBufferWithFitFileContent(t *testing.T) {
+	buffer, errGet := testhelper.GetValidFileBuffer("fit", "01.fit")
+	if errGet != nil {
+		t.Fatal(errGet)
+	}
+
+	input, err := ReadInputStreamBuffer(bufio.NewReader(bytes.NewReader(buffer)))
+	if err != nil {
+		t.Errorf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(input) != 1 {
+		t.Fatalf("The input has %d files, but %d files are expected", len(input), 1)
+	}
+
+	if input[0].Type != fitbl.FitBuffer {
+		t.Errorf("The type is %s, but %s is expected", input[0].Type, fitbl.FitBuffer)
+	}
+
+	if len(input[0].Buffer) != len(buffer) {
+		t.Errorf("The buffer has %d bytes, but %d are expected", len(input[0].Buffer), len(buffer))
+	}
+}
+
+func TestReadInputStreamBufferWithTwoFitFileContent(t *testing.T) {
+	fit1, errFit1 := testhelper.GetValidFileBuffer("fit", "01.fit")
+	fit2, errFit2 := testhelper.GetValidFileBuffer("fit", "02.fit")
+	if errFit1 != nil || errFit2 != nil {
+		t.Fatalf("Can not read the test files")
+	}
+
+	input, err := ReadInputStreamBuffer(bufio.NewReader(bytes.NewReader(append(append([]byte{}, fit1...), fit2...))))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(input) != 2 {
+		t.Fatalf("The input has %d files, but %d files are expected", len(input), 2)
+	}
+
+	if input[0].Type != fitbl.FitBuffer || input[1].Type != fitbl.FitBuffer {
+		t.Errorf("The types are %s and %s, but %s is expected", input[0].Type, input[1].Type, fitbl.FitBuffer)
+	}
+
+	if len(input[0].Buffer) != len(fit1) || len(input[1].Buffer) != len(fit2) {
+		t.Errorf("The buffers are not split by the size in the FIT header")
+	}
+
+	// The data of a truncated FIT file is not dropped
+	_, err = ReadInputStreamBuffer(bufio.NewReader(bytes.NewReader(append(append([]byte{}, fit1...), fit2[:len(fit2)/2]...))))
+	if err == nil {
+		t.Errorf("No error when the second FIT file is truncated")
+	}
+}
+
+func TestReadInputStreamBufferWithNulSeparatedGpxAndFitContent(t *testing.T) {
+	gpx, errGpx := testhelper.GetValidGpxBuffer("01.gpx")
+	fit, errFit := testhelper.GetValidFileBuffer("fit", "01.fit")
+	if errGpx != nil || errFit != nil {
+		t.Fatalf("Can not read the test files")
+	}
+
+	input, err := ReadInputStreamBuffer(bufio.NewReader(bytes.NewReader(append(append(append([]byte{}, gpx...), 0), fit...))))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(input) != 2 || input[0].Type != gpxbl.GpxBuffer || input[1].Type != fitbl.FitBuffer {
+		t.Fatalf("The input is %v, but a gpx and a fit buffer are expected", input)
+	}
+
+	if len(input[1].Buffer) != len(fit) {
+		t.Errorf("The fit buffer has %d bytes, but %d are expected", len(input[1].Buffer), len(fit))
+	}
+}
+
+func TestReadInputStreamBufferWithGeoJsonFileContent(t *testing.T) {
+	buffer, errGet := testhelper.GetValidFileBuffer("geojson", "02.geojson")
+	if errGet != nil {
+		t.Fatal(errGet)
+	}
+
+	input, err := ReadInputStreamBuffer(bufio.NewReader(bytes.NewReader(buffer)))
+	if err != nil {
+		t.Errorf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(input) != 1 {
+		t.Fatalf("The input has %d files, but %d files are expected", len(input), 1)
+	}
+
+	if input[0].Type != geojsonbl.GeoJsonBuffer {
+		t.Errorf("The type is %s, but %s is expected", input[0].Type, geojsonbl.GeoJsonBuffer)
+	}
+}
+
+func TestReadInputStreamBufferWithNmeaFileContent(t *testing.T) {
+	buffer, errGet := testhelper.GetValidFileBuffer("nmea", "02.nmea")
+	if errGet != nil {
+		t.Fatal(errGet)
+	}
+
+	input, err := ReadInputStreamBuffer(bufio.NewReader(bytes.NewReader(buffer)))
+	if err != nil {
+		t.Errorf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(input) != 1 {
+		t.Fatalf("The input has %d files, but %d files are expected", len(input), 1)
+	}
+
+	if input[0].Type != nmeabl.NmeaBuffer {
+		t.Errorf("The type is %s, but %s is expected", input[0].Type, nmeabl.NmeaBuffer)
+	}
+}
+
+func TestReadInputStreamBufferWithIgcFileContent(t *testing.T) {
+	buffer, errGet := testhelper.GetValidFileBuffer("igc", "01.igc")
+	if errGet != nil {
+		t.Fatal(errGet)
+	}
+
+	input, err := ReadInputStreamBuffer(bufio.NewReader(bytes.NewReader(buffer)))
+	if err != nil {
+		t.Errorf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(input) != 1 {
+		t.Fatalf("The input has %d files, but %d files are expected", len(input), 1)
+	}
+
+	if input[0].Type != igcbl.IgcBuffer {
+		t.Errorf("The type is %s, but %s is expected", input[0].Type, igcbl.IgcBuffer)
+	}
+}
+
+func TestReadInputStreamBufferWithCompressedContent(t *testing.T) {
+	for _, name := range []string{"01.gpx.gz", "02.tcx.bz2", "03.fit.gz"} {
+		buffer, errGet := testhelper.GetValidFileBuffer("compressed", name)
+		if errGet != nil {
+			t.Fatal(errGet)
+		}
+
+		input, err := ReadInputStreamBuffer(bufio.NewReader(bytes.NewReader(buffer)))
+		if err != nil {
+			t.Errorf("Got error \"%s\" but expected none", err)
+		}
+
+		if len(input) != 1 {
+			t.Fatalf("The input has %d files, but %d files are expected", len(input), 1)
+		}
+
+		if input[0].Buffer == nil {
+			t.Errorf("The buffer of %s is nil", name)
+		}
+	}
+
+	// gzip stores the original file name, so the reader is chosen by this name
+	buffer, _ := testhelper.GetValidFileBuffer("compressed", "05.gz")
+	input, err := ReadInputStreamBuffer(bufio.NewReader(bytes.NewReader(buffer)))
+	if err != nil || len(input) != 1 {
+		t.Fatalf("Can not read the gzip stream")
+	}
+
+	if input[0].Name != filepath.Join("Input stream buffer 1", "05.gpx") || input[0].Type != gpxbl.GpxBuffer {
+		t.Errorf("The input is %s of type %s, but a gpx buffer named 05.gpx was expected", input[0].Name, input[0].Type)
+	}
+}
+
+func TestReadInputStreamBufferWithZipContent(t *testing.T) {
+	buffer, errGet := testhelper.GetValidFileBuffer("compressed", "04.zip")
+	if errGet != nil {
+		t.Fatal(errGet)
+	}
+
+	input, err := ReadInputStreamBuffer(bufio.NewReader(bytes.NewReader(buffer)))
+	if err != nil {
+		t.Errorf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(input) != 3 {
+		t.Fatalf("The input has %d files, but %d files are expected", len(input), 3)
+	}
+}
+
+func TestReadInputStreamBufferWithBrokenCompressedContent(t *testing.T) {
+	buffer, errGet := ioutil.ReadFile(testhelper.GetInvalidFile("compressed", "01.gpx.gz"))
+	if errGet != nil {
+		t.Fatal(errGet)
+	}
+
+	_, err := ReadInputStreamBuffer(bufio.NewReader(bytes.NewReader(buffer)))
+	switch err.(type) {
+	case *gpsabl.DecompressionError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *gpsabl.DecompressionError, got \"%v\"", err)
+	}
+}
+
+func TestReadInputStreamBufferWithConcatenatedContent(t *testing.T) {
+	tcxBuffer, _ := testhelper.GetValidTcxBuffer("01.tcx")
+	gpxBuffer, _ := testhelper.GetValidGpxBuffer("05.gpx")
+	geoJsonBuffer, _ := testhelper.GetValidFileBuffer("geojson", "02.geojson")
+	nmeaBuffer, _ := testhelper.GetValidFileBuffer("nmea", "02.nmea")
+
+	// The documents after the first one have no xml declaration
+	var stream []byte
+	stream = append(stream, tcxBuffer...)
+	stream = append(stream, removeXMLDeclaration(gpxBuffer)...)
+	stream = append(stream, removeXMLDeclaration(tcxBuffer)...)
+	stream = append(stream, geoJsonBuffer...)
+	stream = append(stream, nmeaBuffer...)
+
+	input, err := ReadInputStreamBuffer(bufio.NewReader(bytes.NewReader(stream)))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	expected := []gpsabl.InputFileType{tcxbl.TcxBuffer, gpxbl.GpxBuffer, tcxbl.TcxBuffer, geojsonbl.GeoJsonBuffer, nmeabl.NmeaBuffer}
+	if len(input) != len(expected) {
+		t.Fatalf("The input has %d files, but %d files are expected", len(input), len(expected))
+	}
+
+	for i, fileType := range expected {
+		if input[i].Type != fileType {
+			t.Errorf("The type of file %d is %s, but %s is expected", i+1, input[i].Type, fileType)
+		}
+	}
+}
+
+func TestReadInputStreamBufferWithNulSeparatedContent(t *testing.T) {
+	nmeaBuffer, _ := testhelper.GetValidFileBuffer("nmea", "02.nmea")
+	igcBuffer, _ := testhelper.GetValidFileBuffer("igc", "01.igc")
+
+	var stream []byte
+	stream = append(stream, nmeaBuffer...)
+	stream = append(stream, 0)
+	stream = append(stream, igcBuffer...)
+	stream = append(stream, 0)
+
+	input, err := ReadInputStreamBuffer(bufio.NewReader(bytes.NewReader(stream)))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(input) != 2 {
+		t.Fatalf("The input has %d files, but %d files are expected", len(input), 2)
+	}
+
+	if input[0].Type != nmeabl.NmeaBuffer || input[1].Type != igcbl.IgcBuffer {
+		t.Errorf("The types are %s and %s, but %s and %s are expected", input[0].Type, input[1].Type, nmeabl.NmeaBuffer, igcbl.IgcBuffer)
+	}
+
+	// A NUL separated file list, like "find -print0" writes it
+	file1 := testhelper.GetValidGPX("12.gpx")
+	file2 := testhelper.GetValidTcx("02.tcx")
+	input, err = ReadInputStreamBuffer(bufio.NewReader(strings.NewReader(fmt.Sprintf("%s\x00%s\x00", file1, file2))))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(input) != 2 || input[0].Name != file1 || input[1].Name != file2 {
+		t.Errorf("The input is not the expected file list: %v", input)
+	}
+}
+
+func TestReadInputStreamBufferWithTarContent(t *testing.T) {
+	fitBuffer, _ := testhelper.GetValidFileBuffer("fit", "01.fit")
+	gpxBuffer, _ := testhelper.GetValidGpxBuffer("05.gpx")
+
+	stream := getTarStream(map[string][]byte{"rides/01.fit": fitBuffer, "05.gpx": gpxBuffer, "notes.txt": []byte("no track")})
+
+	input, err := ReadInputStreamBuffer(bufio.NewReader(bytes.NewReader(stream)))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(input) != 2 {
+		t.Fatalf("The input has %d files, but %d files are expected", len(input), 2)
+	}
+
+	for _, file := range input {
+		if (file.Name == filepath.Join("rides", "01.fit") && file.Type != fitbl.FitBuffer) ||
+			(file.Name == "05.gpx" && file.Type != gpxbl.GpxBuffer) {
+			t.Errorf("The file %s has the type %s", file.Name, file.Type)
+		}
+	}
+}
+
 func TestGetValidTrackExtensions(t *testing.T) {
 	sut := getValidTrackExtensions()
 
@@ -134,6 +459,26 @@ func TestGetValidTrackExtensions(t *testing.T) {
 	if strings.Contains(sut, ".tcx") == false {
 		t.Errorf("\"%s\" does not contain \".tcx\"", sut)
 	}
+
+	if strings.Contains(sut, ".fit") == false {
+		t.Errorf("\"%s\" does not contain \".fit\"", sut)
+	}
+
+	if strings.Contains(sut, ".kmz") == false {
+		t.Errorf("\"%s\" does not contain \".kmz\"", sut)
+	}
+
+	if strings.Contains(sut, ".geojson") == false {
+		t.Errorf("\"%s\" does not contain \".geojson\"", sut)
+	}
+
+	if strings.Contains(sut, ".nmea") == false {
+		t.Errorf("\"%s\" does not contain \".nmea\"", sut)
+	}
+
+	if strings.Contains(sut, ".igc") == false {
+		t.Errorf("\"%s\" does not contain \".igc\"", sut)
+	}
 }
 
 func getValidInputGPXContentStream() (*os.File, error) {
@@ -198,3 +543,25 @@ func getTwoValidInputFilePathStream() (string, string, *os.File, error) {
 
 	return file1, file2, read, nil
 }
+
+// removeXMLDeclaration - Get the xml document without the <?xml ... ?> declaration
+func removeXMLDeclaration(buffer []byte) []byte {
+	if bytes.HasPrefix(buffer, []byte("<?xml")) {
+		return buffer[bytes.Index(buffer, []byte("?>"))+2:]
+	}
+
+	return buffer
+}
+
+// getTarStream - Get a tar archive with the given files
+func getTarStream(files map[string][]byte) []byte {
+	var stream bytes.Buffer
+	writer := tar.NewWriter(&stream)
+	for name, content := range files {
+		writer.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		writer.Write(content)
+	}
+	writer.Close()
+
+	return stream.Bytes()
+}
@@ -12,16 +12,29 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"time"
 
+	"tobi.backfrak.de/internal/csvtrackbl"
+	"tobi.backfrak.de/internal/exportbl"
 	"tobi.backfrak.de/internal/gpsabl"
 
 	"tobi.backfrak.de/internal/csvbl"
+	"tobi.backfrak.de/internal/htmlbl"
 	"tobi.backfrak.de/internal/jsonbl"
 	"tobi.backfrak.de/internal/mdbl"
 
+	"tobi.backfrak.de/internal/fitbl"
+	"tobi.backfrak.de/internal/geojsonbl"
 	"tobi.backfrak.de/internal/gpxbl"
+	"tobi.backfrak.de/internal/igcbl"
+	"tobi.backfrak.de/internal/kmlbl"
+	"tobi.backfrak.de/internal/nmeabl"
+	"tobi.backfrak.de/internal/pltbl"
+	"tobi.backfrak.de/internal/sqlitebl"
 	"tobi.backfrak.de/internal/tcxbl"
+	"tobi.backfrak.de/internal/unicsvbl"
 )
 
 // Authors - Information about the authors of the program. You might want to add your name here when contributing to this software
@@ -33,10 +46,17 @@ const OutputSeperator = "; "
 // The version of this program, will be set at compile time by the gradle build script
 var version = "undefined"
 
-var ValidReaders = []gpsabl.TrackReader{&gpxbl.GpxFile{}, &tcxbl.TcxFile{}}
-var ValidFormaters = []gpsabl.OutputFormater{&csvbl.CsvOutputFormater{}, &jsonbl.JSONOutputFormater{}, &mdbl.MDOutputFormater{}}
+var ValidReaders = []gpsabl.TrackReader{&gpxbl.GpxFile{}, &tcxbl.TcxFile{}, &fitbl.FitFile{}, &kmlbl.KmlFile{}, &geojsonbl.GeoJsonFile{}, &nmeabl.NmeaFile{}, &igcbl.IgcFile{}, &pltbl.PltFile{}, &csvtrackbl.CsvTrackFile{}, &unicsvbl.UnicsvFile{}}
+var ValidFormaters = []gpsabl.OutputFormater{&csvbl.CsvOutputFormater{}, &jsonbl.JSONOutputFormater{}, &mdbl.MDOutputFormater{}, &htmlbl.HTMLOutputFormater{}, &gpxbl.GpxOutputFormater{}, &geojsonbl.GeoJsonOutputFormater{}, &kmlbl.KmlOutputFormater{}, &sqlitebl.SqliteOutputFormater{}}
+
+// TrackPointFormaters - The formaters that need the track points of the files, like for the elevation profiles of the html report, the geometry of the gpx, geojson and kml export or the points table of the database.
+// The cache is not used when one of them writes the output
+var TrackPointFormaters = []gpsabl.OutputFormater{&htmlbl.HTMLOutputFormater{}, &gpxbl.GpxOutputFormater{}, &geojsonbl.GeoJsonOutputFormater{}, &kmlbl.KmlOutputFormater{}, &sqlitebl.SqliteOutputFormater{}}
 var DefinedFilters = []gpsabl.TrackFilter{}
 
+// ResultCache - The cache for the TrackFile values of the input files. Nil when no cache is used
+var ResultCache *gpsabl.TrackFileCache
+
 func main() {
 
 	var fileArgs []gpsabl.InputFile
@@ -71,6 +91,12 @@ func main() {
 		os.Exit(0)
 	}
 
+	// The readers need their options to decide which files they can read
+	setupReaders()
+
+	// Open or clear the cache, before the files are read
+	setupCache()
+
 	// If we don't have input files, we might run with stream input
 	if len(flag.Args()) != 0 {
 		fileArgs = proccessFileArgs(flag.Args())
@@ -89,7 +115,7 @@ func main() {
 		defer out.Close()
 
 		// Process the files, this will fill the buffer of the output type
-		successCount := processFiles(fileArgs, iFormater)
+		successCount := processFiles(fileArgs, ValidReaders, iFormater)
 
 		// Write the output
 		if SummaryParameter == string(gpsabl.ADDITIONAL) && iFormater.GetOutputTableLineCount() == 1 {
@@ -105,10 +131,16 @@ func main() {
 
 		if VerboseFlag == true {
 			fmt.Fprintln(os.Stdout, fmt.Sprintf("%d of %d files processed successfully.", successCount, len(fileArgs)))
+			if ResultCache != nil {
+				fmt.Fprintln(os.Stdout, fmt.Sprintf("Cache: %d hits, %d misses in %s", ResultCache.GetHits(), ResultCache.GetMisses(), ResultCache.Directory))
+			}
+			if database, isDatabase := iFormater.(*sqlitebl.SqliteOutputFormater); isDatabase {
+				fmt.Fprintln(os.Stdout, fmt.Sprintf("%d files skipped, because the database already contains them.", database.GetSkippedFileCount()))
+			}
 		}
 
-		// In case there are no entries in the outfile we remove it from disk
-		if iFormater.GetNumberOfOutputEntries() <= 0 {
+		// In case there are no entries in the outfile we remove it from disk. An existing database is kept
+		if iFormater.GetNumberOfOutputEntries() <= 0 && !isExistingDatabase(out) {
 			deleteOutFile(out)
 			if VerboseFlag == true {
 				fmt.Fprintln(os.Stdout, fmt.Sprintf("Output is empty. No output file created."))
@@ -151,18 +183,318 @@ func createFilters() bool {
 		DefinedFilters = append(DefinedFilters, &maxFilter)
 	}
 
+	if ActivityTypeFilterParameter != "" {
+		activityFilter := gpsabl.NewActivityTypeFilter(ActivityTypeFilterParameter)
+		DefinedFilters = append(DefinedFilters, &activityFilter)
+	}
+
+	if GearFilterParameter != "" {
+		gearFilter := gpsabl.NewGearFilter(GearFilterParameter)
+		DefinedFilters = append(DefinedFilters, &gearFilter)
+	}
+
 	return true
 }
 
+// setupReaders - Pass the reader specific comandline options to the ValidReaders
+func setupReaders() {
+	for _, reader := range ValidReaders {
+		switch r := reader.(type) {
+		case *nmeabl.NmeaFile:
+			r.MaximalTimeGap = time.Duration(NmeaMaximalTimeGapParameter * float64(time.Second))
+		case *igcbl.IgcFile:
+			r.AltitudeSource = igcbl.AltitudeSource(IgcAltitudeParameter)
+		case *csvtrackbl.CsvTrackFile:
+			settings, err := getCsvTrackSettings()
+			if HandleError(err, "", false, DontPanicFlag) == false {
+				r.Settings = settings
+			}
+		}
+	}
+}
+
+// setupCache - Open the cache in the -cache-dir and clear it when -clear-cache is given. The cache is not used
+// with -no-cache or when the track points are needed, since the cache entries do not contain them
+func setupCache() {
+	ResultCache = nil
+	if NoCacheFlag && !ClearCacheFlag {
+		return
+	}
+
+	directory := CacheDirParameter
+	if directory == "" {
+		directory = gpsabl.GetDefaultCacheDirectory()
+	}
+
+	cache, errCache := gpsabl.NewTrackFileCache(directory)
+	if ClearCacheFlag {
+		if HandleError(errCache, directory, false, DontPanicFlag) == true {
+			return
+		}
+
+		removed, errClear := cache.Clear()
+		if HandleError(errClear, directory, false, DontPanicFlag) == true {
+			return
+		}
+		if VerboseFlag {
+			fmt.Println(fmt.Sprintf("Removed %d entries from the cache %s", removed, directory))
+		}
+	}
+
+	if NoCacheFlag {
+		return
+	}
+
+	if errCache != nil {
+		// The files can be read without the cache
+		if VerboseFlag {
+			fmt.Println(fmt.Sprintf("The cache is not used: %s", errCache.Error()))
+		}
+		return
+	}
+
+	if PrintElevationOverDistanceFlag || PrintWaypointsFlag {
+		if VerboseFlag {
+			fmt.Println("The cache is not used, because the track points are needed for -print-elevation-over-distance and -print-waypoints")
+		}
+		return
+	}
+
+	if outputNeedsTrackPoints() {
+		if VerboseFlag {
+			fmt.Println("The cache is not used, because the track points are needed for the output format")
+		}
+		return
+	}
+
+	ResultCache = cache
+}
+
+// setupDropTrackPoints - Tell the GPX and TCX readers to drop the track points once the values of a segment are calculated,
+// when neither the formater nor -print-elevation-over-distance or -print-waypoints need them
+func setupDropTrackPoints(readers []gpsabl.TrackReader, iFormater gpsabl.OutputFormater) {
+	dropTrackPoints := !(PrintElevationOverDistanceFlag || PrintWaypointsFlag || formaterNeedsTrackPoints(iFormater))
+	for _, reader := range readers {
+		switch r := reader.(type) {
+		case *gpxbl.GpxFile:
+			r.DropTrackPoints = dropTrackPoints
+		case *tcxbl.TcxFile:
+			r.DropTrackPoints = dropTrackPoints
+		}
+	}
+}
+
+// formaterNeedsTrackPoints - Tell if the formater is one of the TrackPointFormaters
+func formaterNeedsTrackPoints(iFormater gpsabl.OutputFormater) bool {
+	for _, formater := range TrackPointFormaters {
+		for _, formaterType := range iFormater.GetOutputFormaterTypes() {
+			if formater.CheckOutputFormaterType(formaterType) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// outputNeedsTrackPoints - Tell if the output is written by one of the TrackPointFormaters
+func outputNeedsTrackPoints() bool {
+	for _, formater := range TrackPointFormaters {
+		if OutFileParameter != "" && formater.CheckFileExtension(OutFileParameter) {
+			return true
+		}
+
+		if OutFileParameter == "" && formater.CheckOutputFormaterType(gpsabl.OutputFormaterType(strings.ToUpper(StdOutFormatParameter))) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// outputIsDatabase - Tell if the output is written as SQLite database. The files are identified by the hash of their content then
+func outputIsDatabase() bool {
+	database := sqlitebl.SqliteOutputFormater{}
+	if OutFileParameter != "" {
+		return database.CheckFileExtension(OutFileParameter)
+	}
+
+	return database.CheckOutputFormaterType(gpsabl.OutputFormaterType(strings.ToUpper(StdOutFormatParameter)))
+}
+
+// isExistingDatabase - Tell if the output file is a database, that is not empty. New files are appended to it, so it is never deleted
+func isExistingDatabase(outFile *os.File) bool {
+	if outFile == os.Stdout || !outputIsDatabase() {
+		return false
+	}
+
+	info, errStat := outFile.Stat()
+	if errStat != nil {
+		return false
+	}
+
+	return info.Size() > 0
+}
+
+// getCacheOptions - Get the options, that change the TrackFile the reader reads, beside the correction, minimal moving speed and step hight
+func getCacheOptions(reader gpsabl.TrackReader) []string {
+	return []string{
+		fmt.Sprintf("%T", reader),
+		IgcAltitudeParameter,
+		fmt.Sprintf("%g", NmeaMaximalTimeGapParameter),
+		CsvMappingParameter,
+		CsvSeparatorParameter,
+		CsvTimeLayoutParameter,
+		CsvElevationUnitParameter,
+		fmt.Sprintf("%t", CsvNoHeaderFlag),
+	}
+}
+
+// getCsvTrackSettings - Get the settings for csv files with track points from the comandline options.
+// The settings are empty when no -csv-mapping is given, so *.csv files are read as GPSBabel unicsv files
+func getCsvTrackSettings() (csvtrackbl.Settings, error) {
+	if CsvMappingParameter == "" {
+		return csvtrackbl.Settings{}, nil
+	}
+
+	mapping, errMapping := csvtrackbl.ParseColumnMapping(CsvMappingParameter)
+	if errMapping != nil {
+		return csvtrackbl.Settings{}, errMapping
+	}
+
+	separator, errSeparator := csvtrackbl.ParseSeparator(CsvSeparatorParameter)
+	if errSeparator != nil {
+		return csvtrackbl.Settings{}, errSeparator
+	}
+
+	unit := csvtrackbl.ElevationUnit(CsvElevationUnitParameter)
+	if !csvtrackbl.CheckValidElevationUnit(unit) {
+		return csvtrackbl.Settings{}, csvtrackbl.NewSettingNotKnownError("csv-elevation-unit", CsvElevationUnitParameter, csvtrackbl.GetValidElevationUnitsString())
+	}
+
+	settings := csvtrackbl.NewSettings(mapping)
+	settings.Separator = separator
+	settings.TimeLayout = CsvTimeLayoutParameter
+	settings.ElevationUnit = unit
+	settings.HasHeader = !CsvNoHeaderFlag
+
+	return settings, nil
+}
+
+// proccessFileArgs - Get the input files for the given arguments. Directories are walked, glob patterns are matched,
+// and files of an unknown type found in directories or by patterns are skipped. A file of unknown type given by its path is an error
 func proccessFileArgs(args []string) []gpsabl.InputFile {
 	var fileArgs []gpsabl.InputFile
-	for _, file := range args {
-		res, input := gpsabl.GetInputFileFromPath(ValidReaders, file)
-		if res == true {
-			fileArgs = append(fileArgs, input)
-		} else {
-			HandleError(newUnKnownFileTypeError(file), file, SkipErrorExitFlag, DontPanicFlag)
+	options, errOptions := getInputPathOptions()
+	if HandleError(errOptions, "", false, DontPanicFlag) == true {
+		return fileArgs
+	}
+
+	for _, arg := range args {
+		if exportbl.IsExportDirectory(arg) {
+			fileArgs = append(fileArgs, proccessExportDirectory(arg)...)
+			continue
+		}
+
+		files, skipped, errPath := gpsabl.ExpandInputPath(arg, options)
+		if HandleError(errPath, arg, SkipErrorExitFlag, DontPanicFlag) == true {
+			continue
+		}
+		if VerboseFlag {
+			for _, skip := range skipped {
+				fmt.Println(fmt.Sprintf("Skipped the directory %s: %s", skip.Path, skip.Reason))
+			}
+			if len(files) != 1 || files[0] != arg {
+				fmt.Println(fmt.Sprintf("Found %d files for %s", len(files), arg))
+			}
+		}
+
+		for _, file := range files {
+			res, inputs, errExpand := gpsabl.GetInputFilesFromPath(ValidReaders, file)
+			if HandleError(errExpand, file, SkipErrorExitFlag, DontPanicFlag) == true {
+				continue
+			}
+			if res == true {
+				if VerboseFlag && (len(inputs) > 1 || inputs[0].Name != file) {
+					fmt.Println(fmt.Sprintf("Decompressed %d track files from %s", len(inputs), file))
+				}
+				printDetection(inputs)
+				fileArgs = append(fileArgs, inputs...)
+			} else if file == arg {
+				HandleError(newUnKnownFileTypeError(file), file, SkipErrorExitFlag, DontPanicFlag)
+			} else if VerboseFlag {
+				fmt.Println(fmt.Sprintf("Skipped the file %s: The type of the file is not known", file))
+			}
+		}
+	}
+
+	return fileArgs
+}
+
+// printDetection - Tell how the format of the input files was detected, in case of verbose output
+func printDetection(inputs []gpsabl.InputFile) {
+	if !VerboseFlag {
+		return
+	}
+
+	for _, input := range inputs {
+		if input.Detection != "" {
+			fmt.Println(fmt.Sprintf("Detected the format of %s by %s", input.Name, input.Detection))
+		}
+	}
+}
+
+// getInputPathOptions - Get the options to expand directories and glob patterns from the comandline options
+func getInputPathOptions() (gpsabl.InputPathOptions, error) {
+	options := gpsabl.InputPathOptions{MaximalDepth: WalkDepthParameter}
+	options.Include = splitPatternList(IncludePatternParameter)
+	options.Exclude = splitPatternList(ExcludePatternParameter)
+	for _, pattern := range append(options.Include, options.Exclude...) {
+		if err := gpsabl.CheckPathPattern(pattern); err != nil {
+			return options, err
+		}
+	}
+
+	return options, nil
+}
+
+func splitPatternList(list string) []string {
+	var ret []string
+	for _, pattern := range strings.Split(list, ",") {
+		if strings.TrimSpace(pattern) != "" {
+			ret = append(ret, strings.TrimSpace(pattern))
+		}
+	}
+
+	return ret
+}
+
+// proccessExportDirectory - Get the input files of all activities listed in the activities.csv of a bulk export
+func proccessExportDirectory(exportDir string) []gpsabl.InputFile {
+	var fileArgs []gpsabl.InputFile
+	activities, errRead := exportbl.ReadActivities(exportDir)
+	if HandleError(errRead, exportDir, SkipErrorExitFlag, DontPanicFlag) == true {
+		return fileArgs
+	}
+
+	for _, activity := range activities {
+		if !activity.HasFile() {
+			if VerboseFlag {
+				fmt.Println(fmt.Sprintf("Activity %s \"%s\" has no track file", activity.ID, activity.Name))
+			}
+			continue
+		}
+
+		inputs, errInput := exportbl.GetActivityInputFiles(ValidReaders, exportDir, activity)
+		if HandleError(errInput, exportDir, SkipErrorExitFlag, DontPanicFlag) == true {
+			continue
 		}
+		printDetection(inputs)
+		fileArgs = append(fileArgs, inputs...)
+	}
+
+	if VerboseFlag {
+		fmt.Println(fmt.Sprintf("Got %d track files from the %d activities of the export %s", len(fileArgs), len(activities), exportDir))
 	}
 
 	return fileArgs
@@ -196,35 +528,66 @@ func processInputStream() []gpsabl.InputFile {
 	return fileArgs
 }
 
-// processFiles - processes the input files and adds the found content to the output buffer
-func processFiles(files []gpsabl.InputFile, iFormater gpsabl.OutputFormater) int {
+// processFiles - processes the input files with the readers, that are already set up, and adds the found content to the output buffer
+func processFiles(files []gpsabl.InputFile, readers []gpsabl.TrackReader, iFormater gpsabl.OutputFormater) int {
 
 	if !gpsabl.CheckValidCorrectionParameters(gpsabl.CorrectionParameter(CorrectionParameter)) {
 		HandleError(gpsabl.NewCorrectionParameterNotKnownError(gpsabl.CorrectionParameter(CorrectionParameter)), "", false, DontPanicFlag)
 	}
 
+	if !igcbl.CheckValidAltitudeSource(igcbl.AltitudeSource(IgcAltitudeParameter)) {
+		HandleError(igcbl.NewAltitudeSourceNotKnownError(igcbl.AltitudeSource(IgcAltitudeParameter)), "", false, DontPanicFlag)
+	}
+
 	if !createFilters() {
 		os.Exit(-10)
 	}
 
+	setupDropTrackPoints(readers, iFormater)
+
 	allFiles := len(files)
 	successCount := 0
-	c := make(chan bool, allFiles)
+	jobs := getJobCount(allFiles)
+	c := make(chan processedFile, jobs)
 	countFiles := 0
 
-	// Process the files in a go routine
-	for _, filePath := range files {
-		go goProcessFile(filePath, iFormater, c)
-	}
-
-	// Read back the file processing results
-	for ret := range c {
-		if ret != false {
-			successCount++
+	// The files are handed to the workers one by one, so only as many files as workers are read at the same time.
+	// A file is only handed out, when less than jobs files are read or wait to be added to the output, so the results
+	// of a slow file do not make the others pile up
+	fileChannel := make(chan indexedInputFile)
+	window := make(chan bool, jobs)
+	for i := 0; i < jobs; i++ {
+		go goProcessFiles(fileChannel, c, readers, iFormater)
+	}
+	go func() {
+		for i, file := range files {
+			window <- true
+			fileChannel <- indexedInputFile{i, file}
 		}
+		close(fileChannel)
+	}()
+
+	// Read back the file processing results. The results are added to the output in the order of the input files,
+	// so the output does not depend on which worker is done first
+	pending := make(map[int]processedFile)
+	nextIndex := 0
+	for countFiles < allFiles {
+		result := <-c
+		pending[result.index] = result
 		countFiles++
-		if countFiles == allFiles {
-			close(c)
+
+		for {
+			next, found := pending[nextIndex]
+			if !found {
+				break
+			}
+			delete(pending, nextIndex)
+			nextIndex++
+			<-window
+
+			if addProcessedFile(next, iFormater) {
+				successCount++
+			}
 		}
 	}
 
@@ -232,15 +595,114 @@ func processFiles(files []gpsabl.InputFile, iFormater gpsabl.OutputFormater) int
 	return successCount
 }
 
-// goProcessFile - Wraper around, processFile. Use this as go routine
-func goProcessFile(file gpsabl.InputFile, formater gpsabl.OutputFormater, c chan bool) {
-	ret := processFile(file, formater)
+// indexedInputFile - An input file together with its position in the list of input files
+type indexedInputFile struct {
+	index int
+	file  gpsabl.InputFile
+}
+
+// processedFile - The result of processFile for the input file at the index
+type processedFile struct {
+	index   int
+	name    string
+	success bool
+	file    gpsabl.TrackFile
+	// hash - The hash of the file content. Only calculated when the output is a database
+	hash string
+}
 
-	c <- ret
+// goProcessFiles - Worker that calls processFile for each file of the channel, until the channel is closed. Use this as go routine.
+// When the output is a database, files it already contains are skipped without reading them
+func goProcessFiles(files <-chan indexedInputFile, c chan<- processedFile, readers []gpsabl.TrackReader, formater gpsabl.OutputFormater) {
+	for file := range files {
+		hash := ""
+		if database, isDatabase := formater.(*sqlitebl.SqliteOutputFormater); isDatabase {
+			var success bool
+			hash, success = getContentHash(file.file)
+			if success {
+				var skip bool
+				skip, success = skipImportedFile(database, file.file, hash)
+				if skip {
+					c <- processedFile{file.index, file.file.Name, true, gpsabl.TrackFile{}, hash}
+					continue
+				}
+			}
+			if !success {
+				c <- processedFile{file.index, file.file.Name, false, gpsabl.TrackFile{}, hash}
+				continue
+			}
+		}
+
+		trackFile, success := processFile(file.file, readers)
+		c <- processedFile{file.index, file.file.Name, success, trackFile, hash}
+	}
 }
 
-// processFile - processes one input file and adds the found content to the output buffer
-func processFile(inFile gpsabl.InputFile, formater gpsabl.OutputFormater) bool {
+// addProcessedFile - Add the tracks of a processed file to the output buffer. Returns false if the file was not processed successfully
+func addProcessedFile(processed processedFile, formater gpsabl.OutputFormater) bool {
+	if !processed.success {
+		return false
+	}
+
+	// Add the file to the out buffer of the formater, if it contains tracks
+	if len(processed.file.Tracks) < 1 {
+		return true
+	}
+
+	var addErr error
+	if database, isDatabase := formater.(*sqlitebl.SqliteOutputFormater); isDatabase {
+		// The input may be a stream or part of an archive, so the hash is taken from the InputFile and not from the FilePath
+		addErr = database.AddFile(processed.file, processed.hash, SuppressDuplicateOutPutFlag)
+	} else {
+		addErr = formater.AddOutPut(processed.file, gpsabl.DepthArg(DepthParameter), SuppressDuplicateOutPutFlag)
+	}
+	if HandleError(addErr, processed.name, SkipErrorExitFlag, DontPanicFlag) == true {
+		return false
+	}
+
+	return true
+}
+
+// getContentHash - Get the hash of the input file content. Returns false if the file can not be read
+func getContentHash(inFile gpsabl.InputFile) (string, bool) {
+	hash, errHash := inFile.GetContentHash()
+	if HandleError(errHash, inFile.Name, SkipErrorExitFlag, DontPanicFlag) == true {
+		return "", false
+	}
+
+	return hash, true
+}
+
+// skipImportedFile - Tell if the database already contains the input file, so it is not read. Returns false as second value,
+// if the database can not be queried
+func skipImportedFile(database *sqlitebl.SqliteOutputFormater, inFile gpsabl.InputFile, hash string) (bool, bool) {
+	skip, errSkip := database.SkipImportedFile(inFile.Name, hash)
+	if HandleError(errSkip, inFile.Name, SkipErrorExitFlag, DontPanicFlag) == true {
+		return false, false
+	}
+	if skip && VerboseFlag == true {
+		fmt.Println("Skip file: " + inFile.Name)
+	}
+
+	return skip, true
+}
+
+// getJobCount - Get the number of workers processing the files. There are not more workers than files
+func getJobCount(fileCount int) int {
+	jobs := JobsParameter
+	if jobs < 1 {
+		jobs = runtime.NumCPU()
+	}
+
+	if jobs > fileCount {
+		jobs = fileCount
+	}
+
+	return jobs
+}
+
+// processFile - processes one input file and returns the filtered content. Returns false if the file can not be processed
+func processFile(inFile gpsabl.InputFile, readers []gpsabl.TrackReader) (gpsabl.TrackFile, bool) {
 	if VerboseFlag == true {
 		fmt.Println("Read file: " + inFile.Name)
 	}
@@ -248,16 +710,21 @@ func processFile(inFile gpsabl.InputFile, formater gpsabl.OutputFormater) bool {
 	var readErr error
 
 	// Find out if we can read the file
-	reader, readerErr := getReader(inFile)
+	reader, readerErr := getReader(inFile, readers)
 	if HandleError(readerErr, inFile.Name, SkipErrorExitFlag, DontPanicFlag) == true {
-		return false
+		return gpsabl.TrackFile{}, false
 	}
 
 	// Read the *.gpx into a TrackFile type, using the interface
-	file, readErr = reader.ReadTracks(gpsabl.CorrectionParameter(CorrectionParameter), MinimalMovingSpeedParameter, MinimalStepHightParameter)
+	file, readErr = readTrackFile(inFile, reader)
 
 	if HandleError(readErr, inFile.Name, SkipErrorExitFlag, DontPanicFlag) == true {
-		return false
+		return gpsabl.TrackFile{}, false
+	}
+
+	// Add the activity data of a bulk export
+	if !inFile.Metadata.IsEmpty() {
+		gpsabl.ApplyTrackMetadata(&file, inFile.Metadata)
 	}
 
 	// Filter the track
@@ -265,17 +732,11 @@ func processFile(inFile gpsabl.InputFile, formater gpsabl.OutputFormater) bool {
 		file = gpsabl.FilterTrackFile(file, DefinedFilters)
 	}
 
-	// Add the file to the out buffer of the formater, if it contains tracks
 	if len(file.Tracks) < 1 {
 		if VerboseFlag {
 			fmt.Println(fmt.Sprintf("File \"%s\" does not contain any tracks after applying the given filters", inFile.Name))
 		}
-		return true
-	}
-
-	addErr := formater.AddOutPut(file, gpsabl.DepthArg(DepthParameter), SuppressDuplicateOutPutFlag)
-	if HandleError(addErr, inFile.Name, SkipErrorExitFlag, DontPanicFlag) == true {
-		return false
+		return file, true
 	}
 
 	if PrintElevationOverDistanceFlag {
@@ -284,18 +745,65 @@ func processFile(inFile gpsabl.InputFile, formater gpsabl.OutputFormater) bool {
 		outPath := getElevationOverDistanceFileName(file)
 		out, createErr := os.Create(outPath)
 		if HandleError(createErr, outPath, SkipErrorExitFlag, DontPanicFlag) == true {
-			return false
+			return gpsabl.TrackFile{}, false
 		}
 
 		// Write the ElevationOverDistance.csv
 		fmt.Println(fmt.Sprintf("Create %s", outPath))
 		printErr := csvbl.WriteElevationOverDistance(file, out, OutputSeperator)
 		if HandleError(printErr, outPath, SkipErrorExitFlag, DontPanicFlag) == true {
-			return false
+			return gpsabl.TrackFile{}, false
 		}
 	}
 
-	return true
+	return file, true
+}
+
+// readTrackFile - Read the TrackFile with the reader. When the ResultCache contains the file, read with the same options,
+// the TrackFile is taken from the cache instead. Files read are stored in the cache
+func readTrackFile(inFile gpsabl.InputFile, reader gpsabl.TrackReader) (gpsabl.TrackFile, error) {
+	correction := gpsabl.CorrectionParameter(CorrectionParameter)
+	if ResultCache == nil {
+		return reader.ReadTracks(correction, MinimalMovingSpeedParameter, MinimalStepHightParameter)
+	}
+
+	key, errKey := getCacheKey(inFile, reader)
+	if errKey != nil {
+		// The reader will report the error, when the file can not be read
+		return reader.ReadTracks(correction, MinimalMovingSpeedParameter, MinimalStepHightParameter)
+	}
+
+	if file, found := ResultCache.Get(key); found {
+		if VerboseFlag {
+			fmt.Println(fmt.Sprintf("Cache hit for %s", inFile.Name))
+		}
+		// The same content may be cached for an other path
+		file.FilePath = inFile.Name
+		return file, nil
+	}
+
+	if VerboseFlag {
+		fmt.Println(fmt.Sprintf("Cache miss for %s", inFile.Name))
+	}
+	file, readErr := reader.ReadTracks(correction, MinimalMovingSpeedParameter, MinimalStepHightParameter)
+	if readErr == nil {
+		if errPut := ResultCache.Put(key, file); errPut != nil && VerboseFlag {
+			fmt.Println(fmt.Sprintf("Can not store %s in the cache: %s", inFile.Name, errPut.Error()))
+		}
+	}
+
+	return file, readErr
+}
+
+// getCacheKey - Get the key of the input file in the ResultCache, from its content and the options used to read it
+func getCacheKey(inFile gpsabl.InputFile, reader gpsabl.TrackReader) (string, error) {
+	content, errOpen := inFile.OpenContent()
+	if errOpen != nil {
+		return "", errOpen
+	}
+	defer content.Close()
+
+	return gpsabl.GetTrackFileCacheKey(content, gpsabl.CorrectionParameter(CorrectionParameter), MinimalMovingSpeedParameter, MinimalStepHightParameter, getCacheOptions(reader))
 }
 
 func getElevationOverDistanceFileName(file gpsabl.TrackFile) string {
@@ -338,10 +846,36 @@ func getOutPutFormater(outFile os.File) gpsabl.OutputFormater {
 	case *mdbl.MDOutputFormater:
 		(iFormater.(*mdbl.MDOutputFormater)).SummaryText = MarkdownAdditionalSummaryText
 		(iFormater.(*mdbl.MDOutputFormater)).TrackListText = MarkdownAdditionalSummaryTrackListText
+	case *gpxbl.GpxOutputFormater:
+		(iFormater.(*gpxbl.GpxOutputFormater)).CorrectedElevation = WriteCorrectedElevationFlag
+	case *geojsonbl.GeoJsonOutputFormater:
+		(iFormater.(*geojsonbl.GeoJsonOutputFormater)).CorrectedElevation = WriteCorrectedElevationFlag
+		(iFormater.(*geojsonbl.GeoJsonOutputFormater)).Precision = GeoJsonPrecisionParameter
+	case *kmlbl.KmlOutputFormater:
+		colorColumn, errColor := kmlbl.NewColorColumn(KmlColorByParameter)
+		if errColor != nil {
+			HandleError(errColor, "", false, DontPanicFlag)
+		}
+		(iFormater.(*kmlbl.KmlOutputFormater)).ColorColumn = colorColumn
+		(iFormater.(*kmlbl.KmlOutputFormater)).UseTrack = KmlGxTrackFlag
+		(iFormater.(*kmlbl.KmlOutputFormater)).CorrectedElevation = WriteCorrectedElevationFlag
+	case *sqlitebl.SqliteOutputFormater:
+		if outFile != *os.Stdout {
+			errRead := (iFormater.(*sqlitebl.SqliteOutputFormater)).ReadDatabase(&outFile)
+			if errRead != nil {
+				HandleError(errRead, outFile.Name(), false, DontPanicFlag)
+			}
+		}
 	}
 	if iFormater == nil {
 		HandleError(newUnKnownFileTypeError(outFile.Name()), "", false, DontPanicFlag)
 	}
+	iFormater.SetAddWaypoints(PrintWaypointsFlag)
+	sorting, errSorting := gpsabl.NewOutputSorting(SortByParameter, SortOrderParameter)
+	if errSorting != nil {
+		HandleError(errSorting, "", false, DontPanicFlag)
+	}
+	iFormater.SetSorting(sorting)
 	return iFormater
 }
 
@@ -384,6 +918,13 @@ func getOutPutStream() *os.File {
 	var errCreate error
 	if OutFileParameter == "" {
 		out = os.Stdout
+	} else if outputIsDatabase() {
+		// New files are appended to an existing database, so the file is opened for reading and writing and not removed
+		outFileExists(OutFileParameter)
+		out, errOpen = os.OpenFile(OutFileParameter, os.O_CREATE|os.O_RDWR, 0666)
+		if errOpen != nil {
+			HandleError(errOpen, OutFileParameter, false, DontPanicFlag)
+		}
 	} else {
 		if outFileExists(OutFileParameter) {
 			errDel := os.Remove(OutFileParameter)
@@ -421,10 +962,10 @@ func deleteOutFile(outFile *os.File) error {
 	return nil
 }
 
-// Get the interface that can read a given input file
-func getReader(file gpsabl.InputFile) (gpsabl.TrackReader, error) {
+// Get the interface of the readers that can read a given input file
+func getReader(file gpsabl.InputFile, readers []gpsabl.TrackReader) (gpsabl.TrackReader, error) {
 
-	res, reader := gpsabl.GetNewReader(ValidReaders, file)
+	res, reader := gpsabl.GetNewReader(readers, file)
 	if res == true {
 		return reader, nil
 	}
@@ -1,20 +1,31 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"crypto/rand"
 	"fmt"
+	"io/ioutil"
 	"math/big"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
+	"tobi.backfrak.de/internal/geojsonbl"
 	"tobi.backfrak.de/internal/gpxbl"
+	"tobi.backfrak.de/internal/htmlbl"
 	"tobi.backfrak.de/internal/jsonbl"
+	"tobi.backfrak.de/internal/kmlbl"
 	"tobi.backfrak.de/internal/mdbl"
+	"tobi.backfrak.de/internal/sqlitebl"
+	"tobi.backfrak.de/internal/tcxbl"
 
 	"tobi.backfrak.de/internal/csvbl"
+	"tobi.backfrak.de/internal/csvtrackbl"
 	"tobi.backfrak.de/internal/gpsabl"
 	"tobi.backfrak.de/internal/testhelper"
 )
@@ -82,6 +93,98 @@ func TestHandleComandlineOptions(t *testing.T) {
 	if MinimalMovingSpeedParameter != 0.3 {
 		t.Errorf("The MinimalMovingSpeedParameter is \"%f\" but \"10.0\" was expected", MinimalMovingSpeedParameter)
 	}
+
+	if NmeaMaximalTimeGapParameter != 60.0 {
+		t.Errorf("The NmeaMaximalTimeGapParameter is \"%f\" but \"60.0\" was expected", NmeaMaximalTimeGapParameter)
+	}
+
+	if IgcAltitudeParameter != "pressure" {
+		t.Errorf("The IgcAltitudeParameter is \"%s\" but \"pressure\" was expected", IgcAltitudeParameter)
+	}
+
+	if PrintWaypointsFlag == true {
+		t.Errorf("The PrintWaypointsFlag is set to true but false was expected")
+	}
+
+	if WriteCorrectedElevationFlag == true {
+		t.Errorf("The WriteCorrectedElevationFlag is set to true but false was expected")
+	}
+
+	if GeoJsonPrecisionParameter != geojsonbl.DefaultPrecision {
+		t.Errorf("The GeoJsonPrecisionParameter is %d, but %d was expected", GeoJsonPrecisionParameter, geojsonbl.DefaultPrecision)
+	}
+
+	if KmlColorByParameter != string(kmlbl.DefaultColorColumn) {
+		t.Errorf("The KmlColorByParameter is %s, but %s was expected", KmlColorByParameter, kmlbl.DefaultColorColumn)
+	}
+
+	if KmlGxTrackFlag == true {
+		t.Errorf("The KmlGxTrackFlag is set to true but false was expected")
+	}
+
+	if CsvMappingParameter != "" {
+		t.Errorf("The CsvMappingParameter is \"%s\" but \"\" was expected", CsvMappingParameter)
+	}
+
+	if CsvSeparatorParameter != "," {
+		t.Errorf("The CsvSeparatorParameter is \"%s\" but \",\" was expected", CsvSeparatorParameter)
+	}
+
+	if CsvTimeLayoutParameter != time.RFC3339 {
+		t.Errorf("The CsvTimeLayoutParameter is \"%s\" but \"%s\" was expected", CsvTimeLayoutParameter, time.RFC3339)
+	}
+
+	if CsvElevationUnitParameter != "m" {
+		t.Errorf("The CsvElevationUnitParameter is \"%s\" but \"m\" was expected", CsvElevationUnitParameter)
+	}
+
+	if CsvNoHeaderFlag == true {
+		t.Errorf("The CsvNoHeaderFlag is set to true but false was expected")
+	}
+
+	if WalkDepthParameter != -1 {
+		t.Errorf("The WalkDepthParameter is %d but -1 was expected", WalkDepthParameter)
+	}
+
+	if JobsParameter != runtime.NumCPU() {
+		t.Errorf("The JobsParameter is %d but %d was expected", JobsParameter, runtime.NumCPU())
+	}
+
+	if NoCacheFlag == true {
+		t.Errorf("The NoCacheFlag is set to true but false was expected")
+	}
+
+	if ClearCacheFlag == true {
+		t.Errorf("The ClearCacheFlag is set to true but false was expected")
+	}
+
+	if CacheDirParameter != "" {
+		t.Errorf("The CacheDirParameter is \"%s\" but \"\" was expected", CacheDirParameter)
+	}
+
+	if IncludePatternParameter != "" {
+		t.Errorf("The IncludePatternParameter is \"%s\" but \"\" was expected", IncludePatternParameter)
+	}
+
+	if ExcludePatternParameter != "" {
+		t.Errorf("The ExcludePatternParameter is \"%s\" but \"\" was expected", ExcludePatternParameter)
+	}
+
+	if ActivityTypeFilterParameter != "" {
+		t.Errorf("The ActivityTypeFilterParameter is \"%s\" but \"\" was expected", ActivityTypeFilterParameter)
+	}
+
+	if GearFilterParameter != "" {
+		t.Errorf("The GearFilterParameter is \"%s\" but \"\" was expected", GearFilterParameter)
+	}
+
+	if SortByParameter != "" {
+		t.Errorf("The SortByParameter is \"%s\" but \"\" was expected", SortByParameter)
+	}
+
+	if SortOrderParameter != string(gpsabl.ASCENDING) {
+		t.Errorf("The SortOrderParameter is \"%s\" but \"%s\" was expected", SortOrderParameter, gpsabl.ASCENDING)
+	}
 }
 
 func TestCostumHelpMessage(t *testing.T) {
@@ -131,7 +234,7 @@ func TestProcessValidFiles(t *testing.T) {
 	for _, file := range fileStrs {
 		files = append(files, *gpsabl.NewInputFileWithPath(file))
 	}
-	successCount := processFiles(files, iFormater)
+	successCount := processFiles(files, ValidReaders, iFormater)
 	if successCount != 3 {
 		t.Errorf("Not all files were processed successfully as expected")
 	}
@@ -145,6 +248,358 @@ func TestProcessValidFiles(t *testing.T) {
 	CorrectionParameter = oldCorrectionPAr
 }
 
+func TestProcessFilesWithJobs(t *testing.T) {
+	ErrorsHandled = false
+	oldFlagValue := SkipErrorExitFlag
+	SkipErrorExitFlag = true
+	oldDepthValue := DepthParameter
+	DepthParameter = "file"
+	oldJobsValue := JobsParameter
+
+	fileStrs, _ := filepath.Glob(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-gpx", "*.gpx"))
+	fileStrs = append(fileStrs, testhelper.GetInvalidGPX("01.gpx"))
+	var files []gpsabl.InputFile
+	for _, file := range fileStrs {
+		files = append(files, *gpsabl.NewInputFileWithPath(file))
+	}
+
+	for _, jobs := range []int{1, 3, 0, len(files) + 5} {
+		JobsParameter = jobs
+		formater := csvbl.NewCsvOutputFormater(";", false)
+		successCount := processFiles(files, ValidReaders, gpsabl.OutputFormater(formater))
+		if successCount != len(files)-1 {
+			t.Errorf("%d files were processed successfull with %d jobs, but %d should", successCount, jobs, len(files)-1)
+		}
+
+		if len(formater.GetLines()) != len(files)-1 {
+			t.Errorf("The formater contains %d lines with %d jobs, but should contain %d", len(formater.GetLines()), jobs, len(files)-1)
+		}
+	}
+
+	if ErrorsHandled == false {
+		t.Errorf("No errors occured where errors were expected")
+	}
+
+	ErrorsHandled = false
+	SkipErrorExitFlag = oldFlagValue
+	DepthParameter = oldDepthValue
+	JobsParameter = oldJobsValue
+}
+
+func TestProcessFilesKeepsInputOrder(t *testing.T) {
+	ErrorsHandled = false
+	oldFlagValue := SkipErrorExitFlag
+	SkipErrorExitFlag = true
+	oldDepthValue := DepthParameter
+	DepthParameter = "file"
+	oldJobsValue := JobsParameter
+
+	fileStrs, _ := filepath.Glob(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-gpx", "*.gpx"))
+	var files []gpsabl.InputFile
+	for _, file := range fileStrs {
+		files = append(files, *gpsabl.NewInputFileWithPath(file))
+	}
+
+	var expected []gpsabl.OutputLine
+	for _, jobs := range []int{1, len(files)} {
+		JobsParameter = jobs
+		formater := jsonbl.NewJSONOutputFormater()
+		processFiles(files, ValidReaders, gpsabl.OutputFormater(formater))
+		output, _ := formater.GetOutput(gpsabl.NONE)
+		if len(output.Statistics) != len(files) {
+			t.Errorf("The output contains %d lines with %d jobs, but should contain %d", len(output.Statistics), jobs, len(files))
+			continue
+		}
+
+		if expected == nil {
+			expected = output.Statistics
+		}
+		for i, line := range output.Statistics {
+			if line.Name != expected[i].Name {
+				t.Errorf("The line %d is \"%s\" with %d jobs, but should be \"%s\"", i, line.Name, jobs, expected[i].Name)
+			}
+		}
+	}
+
+	ErrorsHandled = false
+	SkipErrorExitFlag = oldFlagValue
+	DepthParameter = oldDepthValue
+	JobsParameter = oldJobsValue
+}
+
+func TestProcessFilesLimitsTheFilesInWork(t *testing.T) {
+	oldReaders := ValidReaders
+	oldJobsValue := JobsParameter
+	reader := blockingReaderMock{}
+	reader.state = &blockingReaderState{release: make(chan bool)}
+	ValidReaders = append([]gpsabl.TrackReader{&reader}, oldReaders...)
+	JobsParameter = 2
+
+	var files []gpsabl.InputFile
+	for i := 0; i < 10; i++ {
+		files = append(files, gpsabl.InputFile{Name: fmt.Sprintf("%d", i), Type: blockingReaderType})
+	}
+
+	done := make(chan int)
+	go func() {
+		done <- processFiles(files, ValidReaders, csvbl.NewCsvOutputFormater(";", false))
+	}()
+
+	// The first file is not done, so the other files wait to be added to the output, and no more files should be read
+	time.Sleep(100 * time.Millisecond)
+	started := reader.state.getStarted()
+	close(reader.state.release)
+	successCount := <-done
+
+	if started != 2 {
+		t.Errorf("%d files were read while the first file was not done, but %d should", started, 2)
+	}
+
+	if successCount != len(files) {
+		t.Errorf("%d files were processed successfull, but %d should", successCount, len(files))
+	}
+
+	ValidReaders = oldReaders
+	JobsParameter = oldJobsValue
+}
+
+// blockingReaderType - The gpsabl.InputFileType the blockingReaderMock reads
+const blockingReaderType gpsabl.InputFileType = "BlockingMock"
+
+// blockingReaderState - The state shared by all readers of a blockingReaderMock
+type blockingReaderState struct {
+	mux     sync.Mutex
+	started int
+	// release - Close this to finish the reading of the file named "0"
+	release chan bool
+}
+
+func (state *blockingReaderState) getStarted() int {
+	state.mux.Lock()
+	defer state.mux.Unlock()
+
+	return state.started
+}
+
+// blockingReaderMock - A reader that counts the files it reads, and does not finish the file named "0" before released
+type blockingReaderMock struct {
+	gpxbl.GpxFile
+	input gpsabl.InputFile
+	state *blockingReaderState
+}
+
+func (reader *blockingReaderMock) NewReader(data gpsabl.InputFile) gpsabl.TrackReader {
+	return &blockingReaderMock{input: data, state: reader.state}
+}
+
+func (reader *blockingReaderMock) CheckInputFile(input gpsabl.InputFile) bool {
+	return input.Type == blockingReaderType
+}
+
+func (reader *blockingReaderMock) ReadTracks(correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	reader.state.mux.Lock()
+	reader.state.started++
+	reader.state.mux.Unlock()
+	if reader.input.Name == "0" {
+		<-reader.state.release
+	}
+
+	return gpsabl.NewTrackFile(reader.input.Name), nil
+}
+
+func TestGetOutPutFormaterSorting(t *testing.T) {
+	oldSortByParameter := SortByParameter
+	oldSortOrderParameter := SortOrderParameter
+	SortByParameter = "distance"
+	SortOrderParameter = "desc"
+
+	frt := getOutPutFormater(*os.Stdout)
+	switch formater := frt.(type) {
+	case *csvbl.CsvOutputFormater:
+		if formater.Sorting.Column != gpsabl.DISTANCE || formater.Sorting.Order != gpsabl.DESCENDING {
+			t.Errorf("The Sorting of the formater is %v, but should be %s %s", formater.Sorting, gpsabl.DISTANCE, gpsabl.DESCENDING)
+		}
+	default:
+		t.Errorf("Did not receive the expected formater")
+	}
+
+	SortByParameter = oldSortByParameter
+	SortOrderParameter = oldSortOrderParameter
+}
+
+func TestProcessFilesWithCache(t *testing.T) {
+	ErrorsHandled = false
+	oldFlagValue := SkipErrorExitFlag
+	SkipErrorExitFlag = true
+	oldDepthValue := DepthParameter
+	DepthParameter = "segment"
+	dir, errDir := ioutil.TempDir("", "gpsa")
+	if errDir != nil {
+		t.Fatalf("Can not create a temp dir: %s", errDir)
+	}
+	defer os.RemoveAll(dir)
+
+	var files []gpsabl.InputFile
+	for _, file := range []string{testhelper.GetValidGPX("01.gpx"), testhelper.GetValidTcx("02.tcx"), testhelper.GetInvalidGPX("01.gpx")} {
+		files = append(files, *gpsabl.NewInputFileWithPath(file))
+	}
+
+	withoutCache := csvbl.NewCsvOutputFormater(";", false)
+	processFiles(files, ValidReaders, gpsabl.OutputFormater(withoutCache))
+
+	ResultCache, _ = gpsabl.NewTrackFileCache(dir)
+	for run, expectedHits := range []int{0, 2} {
+		formater := csvbl.NewCsvOutputFormater(";", false)
+		successCount := processFiles(files, ValidReaders, gpsabl.OutputFormater(formater))
+		if successCount != 2 {
+			t.Errorf("%d files were processed successfull in run %d, but %d should", successCount, run, 2)
+		}
+
+		if ResultCache.GetHits() != expectedHits {
+			t.Errorf("The cache has %d hits after run %d, but should have %d", ResultCache.GetHits(), run, expectedHits)
+		}
+
+		if strings.Join(formater.GetLines(), "") != strings.Join(withoutCache.GetLines(), "") {
+			t.Errorf("The output in run %d with cache is not the same as without cache", run)
+		}
+	}
+
+	// The file that can not be read is not stored, all files are read in the first run
+	if ResultCache.GetMisses() != 4 {
+		t.Errorf("The cache has %d misses, but should have %d", ResultCache.GetMisses(), 4)
+	}
+
+	ResultCache = nil
+	ErrorsHandled = false
+	SkipErrorExitFlag = oldFlagValue
+	DepthParameter = oldDepthValue
+}
+
+func TestProcessFilesWithCacheOtherCorrection(t *testing.T) {
+	oldCorrectionValue := CorrectionParameter
+	dir, errDir := ioutil.TempDir("", "gpsa")
+	if errDir != nil {
+		t.Fatalf("Can not create a temp dir: %s", errDir)
+	}
+	defer os.RemoveAll(dir)
+
+	files := []gpsabl.InputFile{*gpsabl.NewInputFileWithPath(testhelper.GetValidGPX("01.gpx"))}
+	ResultCache, _ = gpsabl.NewTrackFileCache(dir)
+	for _, correction := range []string{"linear", "steps", "linear"} {
+		CorrectionParameter = correction
+		processFiles(files, ValidReaders, gpsabl.OutputFormater(csvbl.NewCsvOutputFormater(";", false)))
+	}
+
+	if ResultCache.GetHits() != 1 || ResultCache.GetMisses() != 2 {
+		t.Errorf("The cache has %d hits and %d misses, but should have 1 hit and 2 misses", ResultCache.GetHits(), ResultCache.GetMisses())
+	}
+
+	ResultCache = nil
+	CorrectionParameter = oldCorrectionValue
+}
+
+func TestSetupCache(t *testing.T) {
+	oldCacheDirValue := CacheDirParameter
+	oldNoCacheValue := NoCacheFlag
+	oldClearCacheValue := ClearCacheFlag
+	oldPrintWaypointsValue := PrintWaypointsFlag
+	oldOutFileValue := OutFileParameter
+	dir, errDir := ioutil.TempDir("", "gpsa")
+	if errDir != nil {
+		t.Fatalf("Can not create a temp dir: %s", errDir)
+	}
+	defer os.RemoveAll(dir)
+
+	CacheDirParameter = filepath.Join(dir, "cache")
+	setupCache()
+	if ResultCache == nil || ResultCache.Directory != CacheDirParameter {
+		t.Fatalf("The cache in %s is not used", CacheDirParameter)
+	}
+	ResultCache.Put("abc", gpsabl.NewTrackFile("my/file.gpx"))
+
+	NoCacheFlag = true
+	setupCache()
+	if ResultCache != nil {
+		t.Errorf("The cache is used, with the -no-cache flag")
+	}
+
+	NoCacheFlag = false
+	PrintWaypointsFlag = true
+	setupCache()
+	if ResultCache != nil {
+		t.Errorf("The cache is used, with the -print-waypoints flag")
+	}
+
+	PrintWaypointsFlag = false
+	OutFileParameter = "my/report.html"
+	setupCache()
+	if ResultCache != nil {
+		t.Errorf("The cache is used, with the html output")
+	}
+
+	OutFileParameter = oldOutFileValue
+	ClearCacheFlag = true
+	setupCache()
+	if ResultCache == nil {
+		t.Fatalf("The cache in %s is not used", CacheDirParameter)
+	}
+	if _, found := ResultCache.Get("abc"); found {
+		t.Errorf("The cache entry was found after the cache was cleared")
+	}
+
+	ResultCache = nil
+	CacheDirParameter = oldCacheDirValue
+	NoCacheFlag = oldNoCacheValue
+	ClearCacheFlag = oldClearCacheValue
+	PrintWaypointsFlag = oldPrintWaypointsValue
+}
+
+func TestSetupDropTrackPoints(t *testing.T) {
+	oldPrintWaypointsValue := PrintWaypointsFlag
+	gpx := ValidReaders[0].(*gpxbl.GpxFile)
+	tcx := ValidReaders[1].(*tcxbl.TcxFile)
+
+	setupDropTrackPoints(ValidReaders, csvbl.NewCsvOutputFormater(";", false))
+	if !gpx.DropTrackPoints || !tcx.DropTrackPoints {
+		t.Errorf("The track points are not dropped for the csv output")
+	}
+
+	setupDropTrackPoints(ValidReaders, htmlbl.NewHTMLOutputFormater())
+	if gpx.DropTrackPoints || tcx.DropTrackPoints {
+		t.Errorf("The track points are dropped for the html output")
+	}
+
+	PrintWaypointsFlag = true
+	setupDropTrackPoints(ValidReaders, csvbl.NewCsvOutputFormater(";", false))
+	if gpx.DropTrackPoints || tcx.DropTrackPoints {
+		t.Errorf("The track points are dropped with the -print-waypoints flag")
+	}
+
+	PrintWaypointsFlag = oldPrintWaypointsValue
+	gpx.DropTrackPoints = false
+	tcx.DropTrackPoints = false
+}
+
+func TestGetJobCount(t *testing.T) {
+	oldJobsValue := JobsParameter
+
+	JobsParameter = 4
+	if getJobCount(10) != 4 {
+		t.Errorf("The job count is %d, but should be %d", getJobCount(10), 4)
+	}
+
+	if getJobCount(2) != 2 {
+		t.Errorf("The job count is %d, but should be %d", getJobCount(2), 2)
+	}
+
+	JobsParameter = 0
+	if getJobCount(1000) != runtime.NumCPU() {
+		t.Errorf("The job count is %d, but should be %d", getJobCount(1000), runtime.NumCPU())
+	}
+
+	JobsParameter = oldJobsValue
+}
+
 func TestProcessValidFilesWithEmpyElements(t *testing.T) {
 	ErrorsHandled = false
 	oldFlagValue := SkipErrorExitFlag
@@ -164,7 +619,7 @@ func TestProcessValidFilesWithEmpyElements(t *testing.T) {
 	for _, file := range fileStrs {
 		files = append(files, *gpsabl.NewInputFileWithPath(file))
 	}
-	successCount := processFiles(files, iFormater)
+	successCount := processFiles(files, ValidReaders, iFormater)
 	if successCount != 2 {
 		t.Errorf("Not all files were processed successfully as expected")
 	}
@@ -205,7 +660,7 @@ func TestProcessValidFilesWithDuplicateElementsDetectionenabeled(t *testing.T) {
 	for _, file := range fileStrs {
 		files = append(files, *gpsabl.NewInputFileWithPath(file))
 	}
-	successCount := processFiles(files, iFormater)
+	successCount := processFiles(files, ValidReaders, iFormater)
 	if successCount != 1 {
 		t.Errorf("Not all files were processed successfully as expected")
 	}
@@ -247,7 +702,7 @@ func TestProcessValidFilesWithDuplicateElementsDetectionDisabeled(t *testing.T)
 	for _, file := range fileStrs {
 		files = append(files, *gpsabl.NewInputFileWithPath(file))
 	}
-	successCount := processFiles(files, iFormater)
+	successCount := processFiles(files, ValidReaders, iFormater)
 	if successCount != 1 {
 		t.Errorf("Not all files were processed successfully as expected")
 	}
@@ -285,7 +740,7 @@ func TestProcessFilesDifferentCorrection(t *testing.T) {
 	CorrectionParameter = "none"
 	formater1 := csvbl.NewCsvOutputFormater(";", false)
 	iFormater1 := gpsabl.OutputFormater(formater1)
-	successCount1 := processFiles(files, iFormater1)
+	successCount1 := processFiles(files, ValidReaders, iFormater1)
 	if successCount1 != 2 {
 		t.Errorf("Not all files were processed successfully as expected")
 	}
@@ -293,7 +748,7 @@ func TestProcessFilesDifferentCorrection(t *testing.T) {
 	CorrectionParameter = "linear"
 	formater2 := csvbl.NewCsvOutputFormater(";", false)
 	iFormater2 := gpsabl.OutputFormater(formater2)
-	successCount2 := processFiles(files, iFormater2)
+	successCount2 := processFiles(files, ValidReaders, iFormater2)
 	if successCount2 != 2 {
 		t.Errorf("Not all files were processed successfully as expected")
 	}
@@ -335,7 +790,7 @@ func TestProcessFilesDifferentMovingSpeed(t *testing.T) {
 	MinimalMovingSpeedParameter = 0.1
 	formater1 := csvbl.NewCsvOutputFormater(";", false)
 	iFormater1 := gpsabl.OutputFormater(formater1)
-	successCount1 := processFiles(files, iFormater1)
+	successCount1 := processFiles(files, ValidReaders, iFormater1)
 	if successCount1 != 2 {
 		t.Errorf("Not all files were processed successfully as expected")
 	}
@@ -343,7 +798,7 @@ func TestProcessFilesDifferentMovingSpeed(t *testing.T) {
 	MinimalMovingSpeedParameter = 0.9
 	formater2 := csvbl.NewCsvOutputFormater(";", false)
 	iFormater2 := gpsabl.OutputFormater(formater2)
-	successCount2 := processFiles(files, iFormater2)
+	successCount2 := processFiles(files, ValidReaders, iFormater2)
 	if successCount2 != 2 {
 		t.Errorf("Not all files were processed successfully as expected")
 	}
@@ -390,7 +845,7 @@ func TestProcessFilesDifferentStepHight(t *testing.T) {
 	MinimalStepHightParameter = 20.0
 	formater1 := csvbl.NewCsvOutputFormater(";", false)
 	iFormater1 := gpsabl.OutputFormater(formater1)
-	successCount1 := processFiles(files, iFormater1)
+	successCount1 := processFiles(files, ValidReaders, iFormater1)
 	if successCount1 != 2 {
 		t.Errorf("Not all files were processed successfully as expected")
 	}
@@ -398,7 +853,7 @@ func TestProcessFilesDifferentStepHight(t *testing.T) {
 	MinimalStepHightParameter = 0.5
 	formater2 := csvbl.NewCsvOutputFormater(";", false)
 	iFormater2 := gpsabl.OutputFormater(formater2)
-	successCount2 := processFiles(files, iFormater2)
+	successCount2 := processFiles(files, ValidReaders, iFormater2)
 	if successCount2 != 2 {
 		t.Errorf("Not all files were processed successfully as expected")
 	}
@@ -446,7 +901,7 @@ func TestProcessFilesStepHightEffectsOther(t *testing.T) {
 	MinimalStepHightParameter = 20.0
 	formater1 := csvbl.NewCsvOutputFormater(";", false)
 	iFormater1 := gpsabl.OutputFormater(formater1)
-	successCount1 := processFiles(files, iFormater1)
+	successCount1 := processFiles(files, ValidReaders, iFormater1)
 	if successCount1 != 2 {
 		t.Errorf("Not all files were processed successfully as expected")
 	}
@@ -454,7 +909,7 @@ func TestProcessFilesStepHightEffectsOther(t *testing.T) {
 	MinimalStepHightParameter = 0.5
 	formater2 := csvbl.NewCsvOutputFormater(";", false)
 	iFormater2 := gpsabl.OutputFormater(formater2)
-	successCount2 := processFiles(files, iFormater2)
+	successCount2 := processFiles(files, ValidReaders, iFormater2)
 	if successCount2 != 2 {
 		t.Errorf("Not all files were processed successfully as expected")
 	}
@@ -499,7 +954,7 @@ func TestProcessMixedFiles(t *testing.T) {
 	for _, file := range fileStrs {
 		files = append(files, *gpsabl.NewInputFileWithPath(file))
 	}
-	successCount := processFiles(files, iFormater)
+	successCount := processFiles(files, ValidReaders, iFormater)
 	if successCount != 1 {
 		t.Errorf("More or less than two files were processed with error - expected exactly two of them")
 	}
@@ -515,69 +970,360 @@ func TestProcessMixedFiles(t *testing.T) {
 	CorrectionParameter = oldCorrectionPAr
 }
 
-func TestProcessInValidFiles(t *testing.T) {
+func TestProcessFitFiles(t *testing.T) {
 	ErrorsHandled = false
 	oldFlagValue := SkipErrorExitFlag
 	SkipErrorExitFlag = true
 	oldDepthValue := DepthParameter
-	DepthParameter = "file"
-	oldCorrectionPAr := CorrectionParameter
-	CorrectionParameter = "linear"
+	DepthParameter = "segment"
 
 	formater := csvbl.NewCsvOutputFormater(";", false)
 	iFormater := gpsabl.OutputFormater(formater)
 
-	fileStrs := []string{testhelper.GetInvalidGPX("01.gpx"), testhelper.GetInvalidGPX("02.gpx")}
-	var files []gpsabl.InputFile
-	for _, file := range fileStrs {
-		files = append(files, *gpsabl.NewInputFileWithPath(file))
-	}
-	successCount := processFiles(files, iFormater)
-	if successCount != 0 {
-		t.Errorf("Not all files were processed with error as expected")
+	fileStrs := []string{testhelper.GetValidFile("fit", "01.fit"), testhelper.GetValidFile("fit", "02.fit"), testhelper.GetInvalidFile("fit", "01.fit")}
+	files := proccessFileArgs(fileStrs)
+	successCount := processFiles(files, ValidReaders, iFormater)
+	if successCount != 2 {
+		t.Errorf("%d files were processed successfull, but %d should", successCount, 2)
 	}
 
 	if ErrorsHandled == false {
-		t.Errorf("No errors occured were errors were expected")
+		t.Errorf("No errors occured where errors were expected")
 	}
+
+	if len(formater.GetLines()) != 15 {
+		t.Errorf("The formater contains %d lines, but should contain %d", len(formater.GetLines()), 15)
+	}
+
 	ErrorsHandled = false
 	SkipErrorExitFlag = oldFlagValue
 	DepthParameter = oldDepthValue
-	CorrectionParameter = oldCorrectionPAr
 }
 
-func TestGetOutPutStream_StdOut(t *testing.T) {
+func TestProcessKmlFiles(t *testing.T) {
 	ErrorsHandled = false
-	oldOutFileParameter := OutFileParameter
-	OutFileParameter = ""
-	str := getOutPutStream()
+	oldFlagValue := SkipErrorExitFlag
+	SkipErrorExitFlag = true
+	oldDepthValue := DepthParameter
+	DepthParameter = "track"
 
-	switch os.File(*str) {
-	case *os.Stdout:
-		fmt.Println("OK")
-	default:
-		t.Errorf("Did not receive the expected stream")
-	}
-	ErrorsHandled = false
-	OutFileParameter = oldOutFileParameter
+	formater := csvbl.NewCsvOutputFormater(";", false)
+	iFormater := gpsabl.OutputFormater(formater)
 
-}
+	fileStrs := []string{testhelper.GetValidFile("kml", "01.kml"), testhelper.GetValidFile("kml", "04.kmz"), testhelper.GetInvalidFile("kml", "01.kml")}
+	files := proccessFileArgs(fileStrs)
+	successCount := processFiles(files, ValidReaders, iFormater)
+	if successCount != 2 {
+		t.Errorf("%d files were processed successfull, but %d should", successCount, 2)
+	}
 
-func TestGetOutPutStream_AFile(t *testing.T) {
-	if runtime.GOOS == "windows" {
-		t.Skip("Skip this test on windows")
-	} else {
-		ErrorsHandled = false
-		oldOutFileParameter := OutFileParameter
-		var big big.Int
-		big.SetInt64(10000)
-		max, _ := rand.Int(rand.Reader, &big)
-		filePath := filepath.Join(testhelper.GetProjectRoot(), "testdata", fmt.Sprintf("test-out-%d.csv", max))
-		OutFileParameter = filePath
+	if ErrorsHandled == false {
+		t.Errorf("No errors occured where errors were expected")
+	}
 
-		// Make sure the tests can be executed in parallel
-		outFileMux.Lock()
-		defer outFileMux.Unlock()
+	if len(formater.GetLines()) != 2 {
+		t.Errorf("The formater contains %d lines, but should contain %d", len(formater.GetLines()), 2)
+	}
+
+	ErrorsHandled = false
+	SkipErrorExitFlag = oldFlagValue
+	DepthParameter = oldDepthValue
+}
+
+func TestProcessNmeaFiles(t *testing.T) {
+	ErrorsHandled = false
+	oldFlagValue := SkipErrorExitFlag
+	SkipErrorExitFlag = true
+	oldDepthValue := DepthParameter
+	DepthParameter = "segment"
+	oldGapValue := NmeaMaximalTimeGapParameter
+	NmeaMaximalTimeGapParameter = 3600.0
+	setupReaders()
+
+	formater := csvbl.NewCsvOutputFormater(";", false)
+	iFormater := gpsabl.OutputFormater(formater)
+
+	fileStrs := []string{testhelper.GetValidFile("nmea", "01.nmea"), testhelper.GetValidFile("nmea", "02.nmea"), testhelper.GetInvalidFile("nmea", "02.nmea")}
+	files := proccessFileArgs(fileStrs)
+	successCount := processFiles(files, ValidReaders, iFormater)
+	if successCount != 2 {
+		t.Errorf("%d files were processed successfull, but %d should", successCount, 2)
+	}
+
+	if ErrorsHandled == false {
+		t.Errorf("No errors occured where errors were expected")
+	}
+
+	// 01.nmea has 2 segments with a gap of one hour, 02.nmea 2 segments because of a fix loss
+	if len(formater.GetLines()) != 4 {
+		t.Errorf("The formater contains %d lines, but should contain %d", len(formater.GetLines()), 4)
+	}
+
+	ErrorsHandled = false
+	SkipErrorExitFlag = oldFlagValue
+	DepthParameter = oldDepthValue
+	NmeaMaximalTimeGapParameter = oldGapValue
+	setupReaders()
+}
+
+func TestProcessPltAndUnicsvFiles(t *testing.T) {
+	ErrorsHandled = false
+	oldFlagValue := SkipErrorExitFlag
+	SkipErrorExitFlag = true
+	oldDepthValue := DepthParameter
+	DepthParameter = "file"
+
+	formater := csvbl.NewCsvOutputFormater(";", false)
+	iFormater := gpsabl.OutputFormater(formater)
+
+	fileStrs := []string{testhelper.GetValidFile("plt", "01.plt"), testhelper.GetValidFile("unicsv", "01.csv"), testhelper.GetInvalidFile("plt", "02.plt"), testhelper.GetInvalidFile("unicsv", "01.csv")}
+	files := proccessFileArgs(fileStrs)
+	successCount := processFiles(files, ValidReaders, iFormater)
+	if successCount != 2 {
+		t.Errorf("%d files were processed successfull, but %d should", successCount, 2)
+	}
+
+	if ErrorsHandled == false {
+		t.Errorf("No errors occured where errors were expected")
+	}
+
+	lines := formater.GetLines()
+	if len(lines) != 2 {
+		t.Fatalf("The formater contains %d lines, but should contain %d", len(lines), 2)
+	}
+
+	// Both files were created out of testdata/valid-gpx/12.gpx, the missing altitudes must not change the MinimumAltitude
+	for _, line := range lines {
+		if !strings.Contains(line, ";245.00;304.0") {
+			t.Errorf("The line \"%s\" does not contain the expected altitudes", line)
+		}
+	}
+
+	ErrorsHandled = false
+	SkipErrorExitFlag = oldFlagValue
+	DepthParameter = oldDepthValue
+}
+
+func TestProcessCsvTrackFiles(t *testing.T) {
+	ErrorsHandled = false
+	oldFlagValue := SkipErrorExitFlag
+	SkipErrorExitFlag = true
+	oldDepthValue := DepthParameter
+	DepthParameter = "track"
+	oldMappingValue := CsvMappingParameter
+	CsvMappingParameter = "track=1,segment=2,time=3,lat=4,lon=5,ele=6"
+	oldSeparatorValue := CsvSeparatorParameter
+	CsvSeparatorParameter = ";"
+	oldTimeLayoutValue := CsvTimeLayoutParameter
+	CsvTimeLayoutParameter = "unixms"
+	oldUnitValue := CsvElevationUnitParameter
+	CsvElevationUnitParameter = "ft"
+	CsvNoHeaderFlag = true
+	setupReaders()
+
+	formater := csvbl.NewCsvOutputFormater(";", false)
+	iFormater := gpsabl.OutputFormater(formater)
+
+	files := proccessFileArgs([]string{testhelper.GetValidFile("csvtrack", "02.csv")})
+	successCount := processFiles(files, ValidReaders, iFormater)
+	if successCount != 1 {
+		t.Errorf("%d files were processed successfull, but %d should", successCount, 1)
+	}
+
+	if ErrorsHandled == true {
+		t.Errorf("Errors occured where no errors were expected")
+	}
+
+	lines := formater.GetLines()
+	if len(lines) != 2 {
+		t.Fatalf("The formater contains %d lines, but should contain %d", len(lines), 2)
+	}
+
+	if !strings.Contains(lines[0], ": A;") || !strings.Contains(lines[1], ": B;") {
+		t.Errorf("The lines \"%s\" do not contain the track IDs as names", lines)
+	}
+
+	ErrorsHandled = false
+	SkipErrorExitFlag = oldFlagValue
+	DepthParameter = oldDepthValue
+	CsvMappingParameter = oldMappingValue
+	CsvSeparatorParameter = oldSeparatorValue
+	CsvTimeLayoutParameter = oldTimeLayoutValue
+	CsvElevationUnitParameter = oldUnitValue
+	CsvNoHeaderFlag = false
+	setupReaders()
+}
+
+func TestGetCsvTrackSettings(t *testing.T) {
+	oldMappingValue := CsvMappingParameter
+	oldUnitValue := CsvElevationUnitParameter
+
+	CsvMappingParameter = ""
+	settings, err := getCsvTrackSettings()
+	if err != nil || settings.IsConfigured() != false {
+		t.Errorf("Got configured settings or the error \"%v\" without a mapping", err)
+	}
+
+	CsvMappingParameter = "lat=Lat,lon=Lon"
+	settings, err = getCsvTrackSettings()
+	if err != nil || settings.IsConfigured() != true || settings.Separator != ',' || settings.HasHeader != true {
+		t.Errorf("Got the settings %v and the error \"%v\"", settings, err)
+	}
+
+	CsvElevationUnitParameter = "yard"
+	_, err = getCsvTrackSettings()
+	switch err.(type) {
+	case *csvtrackbl.SettingNotKnownError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *csvtrackbl.SettingNotKnownError, got \"%v\"", err)
+	}
+
+	CsvMappingParameter = "lat=Lat"
+	_, err = getCsvTrackSettings()
+	switch err.(type) {
+	case *csvtrackbl.ColumnMappingError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *csvtrackbl.ColumnMappingError, got \"%v\"", err)
+	}
+
+	CsvMappingParameter = oldMappingValue
+	CsvElevationUnitParameter = oldUnitValue
+}
+
+func TestProcessIgcFiles(t *testing.T) {
+	ErrorsHandled = false
+	oldFlagValue := SkipErrorExitFlag
+	SkipErrorExitFlag = true
+	oldDepthValue := DepthParameter
+	DepthParameter = "file"
+	oldAltitudeValue := IgcAltitudeParameter
+	IgcAltitudeParameter = "gnss"
+	setupReaders()
+
+	formater := csvbl.NewCsvOutputFormater(";", false)
+	iFormater := gpsabl.OutputFormater(formater)
+
+	fileStrs := []string{testhelper.GetValidFile("igc", "01.igc"), testhelper.GetValidFile("igc", "02.igc"), testhelper.GetInvalidFile("igc", "03.igc")}
+	files := proccessFileArgs(fileStrs)
+	successCount := processFiles(files, ValidReaders, iFormater)
+	if successCount != 2 {
+		t.Errorf("%d files were processed successfull, but %d should", successCount, 2)
+	}
+
+	if ErrorsHandled == false {
+		t.Errorf("No errors occured where errors were expected")
+	}
+
+	lines := formater.GetLines()
+	if len(lines) != 2 {
+		t.Fatalf("The formater contains %d lines, but should contain %d", len(lines), 2)
+	}
+
+	// The pilot is used as name of the file
+	if !strings.HasPrefix(lines[0], "Max Mustermann") && !strings.HasPrefix(lines[1], "Max Mustermann") {
+		t.Errorf("The lines \"%s\" do not start with the pilots name", lines)
+	}
+
+	ErrorsHandled = false
+	SkipErrorExitFlag = oldFlagValue
+	DepthParameter = oldDepthValue
+	IgcAltitudeParameter = oldAltitudeValue
+	setupReaders()
+}
+
+func TestProcessTarStream(t *testing.T) {
+	fitBuffer, _ := testhelper.GetValidFileBuffer("fit", "01.fit")
+	tcxBuffer, _ := testhelper.GetValidTcxBuffer("02.tcx")
+	oldDepthValue := DepthParameter
+	DepthParameter = "file"
+
+	files, err := ReadInputStreamBuffer(bufio.NewReader(bytes.NewReader(getTarStream(map[string][]byte{"rides/01.fit": fitBuffer, "runs/02.tcx": tcxBuffer}))))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	formater := csvbl.NewCsvOutputFormater(";", false)
+	successCount := processFiles(files, ValidReaders, gpsabl.OutputFormater(formater))
+	if successCount != 2 {
+		t.Errorf("%d files were processed successfull, but %d should", successCount, 2)
+	}
+
+	// The files keep their name from the archive
+	output := strings.Join(formater.GetLines(), "")
+	for _, name := range []string{filepath.Join("rides", "01.fit"), filepath.Join("runs", "02.tcx")} {
+		if strings.Contains(output, name) == false {
+			t.Errorf("The output does not contain the name %s", name)
+		}
+	}
+
+	DepthParameter = oldDepthValue
+}
+
+func TestProcessInValidFiles(t *testing.T) {
+	ErrorsHandled = false
+	oldFlagValue := SkipErrorExitFlag
+	SkipErrorExitFlag = true
+	oldDepthValue := DepthParameter
+	DepthParameter = "file"
+	oldCorrectionPAr := CorrectionParameter
+	CorrectionParameter = "linear"
+
+	formater := csvbl.NewCsvOutputFormater(";", false)
+	iFormater := gpsabl.OutputFormater(formater)
+
+	fileStrs := []string{testhelper.GetInvalidGPX("01.gpx"), testhelper.GetInvalidGPX("02.gpx")}
+	var files []gpsabl.InputFile
+	for _, file := range fileStrs {
+		files = append(files, *gpsabl.NewInputFileWithPath(file))
+	}
+	successCount := processFiles(files, ValidReaders, iFormater)
+	if successCount != 0 {
+		t.Errorf("Not all files were processed with error as expected")
+	}
+
+	if ErrorsHandled == false {
+		t.Errorf("No errors occured were errors were expected")
+	}
+	ErrorsHandled = false
+	SkipErrorExitFlag = oldFlagValue
+	DepthParameter = oldDepthValue
+	CorrectionParameter = oldCorrectionPAr
+}
+
+func TestGetOutPutStream_StdOut(t *testing.T) {
+	ErrorsHandled = false
+	oldOutFileParameter := OutFileParameter
+	OutFileParameter = ""
+	str := getOutPutStream()
+
+	switch os.File(*str) {
+	case *os.Stdout:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Did not receive the expected stream")
+	}
+	ErrorsHandled = false
+	OutFileParameter = oldOutFileParameter
+
+}
+
+func TestGetOutPutStream_AFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skip this test on windows")
+	} else {
+		ErrorsHandled = false
+		oldOutFileParameter := OutFileParameter
+		var big big.Int
+		big.SetInt64(10000)
+		max, _ := rand.Int(rand.Reader, &big)
+		filePath := filepath.Join(testhelper.GetProjectRoot(), "testdata", fmt.Sprintf("test-out-%d.csv", max))
+		OutFileParameter = filePath
+
+		// Make sure the tests can be executed in parallel
+		outFileMux.Lock()
+		defer outFileMux.Unlock()
 
 		if outFileExists(filePath) {
 			err := os.Remove(filePath)
@@ -710,6 +1456,118 @@ func TestGetOutPutFormaterCSV(t *testing.T) {
 	}
 }
 
+func TestGetOutPutFormaterPrintWaypoints(t *testing.T) {
+	oldPrintWaypointsFlag := PrintWaypointsFlag
+	PrintWaypointsFlag = true
+	frt := getOutPutFormater(*os.Stdout)
+
+	switch frt.(type) {
+	case *csvbl.CsvOutputFormater:
+		if (frt.(*csvbl.CsvOutputFormater)).AddWaypoints != true {
+			t.Errorf("The AddWaypoints of the formater is false, but true was expected")
+		}
+	default:
+		t.Errorf("Did not receive the expected formater")
+	}
+	PrintWaypointsFlag = oldPrintWaypointsFlag
+}
+
+func TestProcessRouteWithWaypoints(t *testing.T) {
+	ErrorsHandled = false
+	oldDepthValue := DepthParameter
+	DepthParameter = "track"
+
+	formater := csvbl.NewCsvOutputFormater(";", false)
+	formater.SetAddWaypoints(true)
+	iFormater := gpsabl.OutputFormater(formater)
+
+	files := []gpsabl.InputFile{*gpsabl.NewInputFileWithPath(testhelper.GetValidGPX("21.gpx"))}
+	successCount := processFiles(files, ValidReaders, iFormater)
+	if successCount != 1 {
+		t.Errorf("Not all files were processed successfully as expected")
+	}
+
+	if ErrorsHandled == true {
+		t.Errorf("Errors occurred that were not expected")
+	}
+
+	lines, _ := formater.GetOutputLines(gpsabl.NONE)
+	if len(lines) != 5 {
+		t.Errorf("Got %d lines, but expected 5", len(lines))
+	}
+
+	ErrorsHandled = false
+	DepthParameter = oldDepthValue
+}
+
+func TestProccessFileArgsExportDirectory(t *testing.T) {
+	ErrorsHandled = false
+	files := proccessFileArgs([]string{testhelper.GetValidFile("export", "01")})
+
+	if len(files) != 2 {
+		t.Fatalf("Got %d files from the export, but expected 2", len(files))
+	}
+
+	if files[0].Metadata.Name != "Morning Ride" || files[1].Metadata.ActivityType != "Run" {
+		t.Errorf("The metadata of the export files is %v and %v", files[0].Metadata, files[1].Metadata)
+	}
+
+	if ErrorsHandled == true {
+		t.Errorf("Errors occurred that were not expected")
+	}
+}
+
+func TestProccessFileArgsInvalidExportDirectory(t *testing.T) {
+	ErrorsHandled = false
+	oldSkipErrorExitFlag := SkipErrorExitFlag
+	SkipErrorExitFlag = true
+
+	files := proccessFileArgs([]string{testhelper.GetInvalidFile("export", "01")})
+	if len(files) != 0 {
+		t.Errorf("Got %d files from the export, but expected 0", len(files))
+	}
+
+	if ErrorsHandled == false {
+		t.Errorf("The missing activity file was not handled as error")
+	}
+
+	ErrorsHandled = false
+	SkipErrorExitFlag = oldSkipErrorExitFlag
+}
+
+func TestProcessExportWithActivityFilter(t *testing.T) {
+	outFileMux.Lock()
+	defer outFileMux.Unlock()
+	ErrorsHandled = false
+	oldActivityType := ActivityTypeFilterParameter
+	ActivityTypeFilterParameter = "ride"
+	if !createFilters() {
+		t.Errorf("The activity type filter could not be created")
+	}
+
+	formater := csvbl.NewCsvOutputFormater(";", false)
+	iFormater := gpsabl.OutputFormater(formater)
+
+	files := proccessFileArgs([]string{testhelper.GetValidFile("export", "01")})
+	successCount := processFiles(files, ValidReaders, iFormater)
+	if successCount != 2 {
+		t.Errorf("Not all files were processed successfully as expected")
+	}
+
+	lines := formater.GetLines()
+	if len(lines) != 1 {
+		t.Fatalf("Got %d lines, but expected 1", len(lines))
+	}
+
+	if !strings.HasPrefix(lines[0], "Morning Ride:") || !strings.HasSuffix(lines[0], ";Ride;My Bike;"+csvbl.GetNewLine()) {
+		t.Errorf("The line of the filtered export is \"%s\"", lines[0])
+	}
+
+	ActivityTypeFilterParameter = oldActivityType
+	DefinedFilters = []gpsabl.TrackFilter{}
+	ErrorsHandled = false
+}
+
 func TestGetOutPutFormaterCSVStdOut(t *testing.T) {
 	oldStdOutFormatParameter := StdOutFormatParameter
 	StdOutFormatParameter = string(csvbl.CSVOutputFormatertype)
@@ -752,6 +1610,223 @@ func TestGetOutPutFormaterMDStdOut(t *testing.T) {
 	StdOutFormatParameter = oldStdOutFormatParameter
 }
 
+func TestGetOutPutFormaterHTMLStdOut(t *testing.T) {
+	oldStdOutFormatParameter := StdOutFormatParameter
+	StdOutFormatParameter = "html"
+	frt := getOutPutFormater(*os.Stdout)
+
+	switch frt.(type) {
+	case *htmlbl.HTMLOutputFormater:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Did not receive the expected formater")
+	}
+	StdOutFormatParameter = oldStdOutFormatParameter
+}
+
+func TestGetOutPutFormaterGPXStdOut(t *testing.T) {
+	oldStdOutFormatParameter := StdOutFormatParameter
+	oldWriteCorrectedElevationFlag := WriteCorrectedElevationFlag
+	StdOutFormatParameter = "gpx"
+	WriteCorrectedElevationFlag = true
+	frt := getOutPutFormater(*os.Stdout)
+
+	switch frt.(type) {
+	case *gpxbl.GpxOutputFormater:
+		if (frt.(*gpxbl.GpxOutputFormater)).CorrectedElevation == false {
+			t.Errorf("The gpx formater does not write the corrected elevation")
+		}
+	default:
+		t.Errorf("Did not receive the expected formater")
+	}
+	StdOutFormatParameter = oldStdOutFormatParameter
+	WriteCorrectedElevationFlag = oldWriteCorrectedElevationFlag
+}
+
+func TestGetOutPutFormaterGeoJsonStdOut(t *testing.T) {
+	oldStdOutFormatParameter := StdOutFormatParameter
+	oldGeoJsonPrecisionParameter := GeoJsonPrecisionParameter
+	StdOutFormatParameter = "geojson"
+	GeoJsonPrecisionParameter = 4
+	frt := getOutPutFormater(*os.Stdout)
+
+	switch frt.(type) {
+	case *geojsonbl.GeoJsonOutputFormater:
+		if (frt.(*geojsonbl.GeoJsonOutputFormater)).Precision != 4 {
+			t.Errorf("The Precision of the geojson formater is %d, but should be %d", (frt.(*geojsonbl.GeoJsonOutputFormater)).Precision, 4)
+		}
+	default:
+		t.Errorf("Did not receive the expected formater")
+	}
+	StdOutFormatParameter = oldStdOutFormatParameter
+	GeoJsonPrecisionParameter = oldGeoJsonPrecisionParameter
+}
+
+func TestGetOutPutFormaterKmlStdOut(t *testing.T) {
+	oldStdOutFormatParameter := StdOutFormatParameter
+	oldKmlColorByParameter := KmlColorByParameter
+	oldKmlGxTrackFlag := KmlGxTrackFlag
+	StdOutFormatParameter = "kml"
+	KmlColorByParameter = "elevationgain"
+	KmlGxTrackFlag = true
+	frt := getOutPutFormater(*os.Stdout)
+
+	switch frt.(type) {
+	case *kmlbl.KmlOutputFormater:
+		formater := frt.(*kmlbl.KmlOutputFormater)
+		if formater.ColorColumn != gpsabl.ELEVATIONGAIN || formater.UseTrack == false {
+			t.Errorf("The kml formater colors by %s and uses gx:Track %t, but should color by %s and use gx:Track", formater.ColorColumn, formater.UseTrack, gpsabl.ELEVATIONGAIN)
+		}
+	default:
+		t.Errorf("Did not receive the expected formater")
+	}
+	StdOutFormatParameter = oldStdOutFormatParameter
+	KmlColorByParameter = oldKmlColorByParameter
+	KmlGxTrackFlag = oldKmlGxTrackFlag
+}
+
+func TestOutputNeedsTrackPoints(t *testing.T) {
+	oldStdOutFormatParameter := StdOutFormatParameter
+	oldOutFileParameter := OutFileParameter
+
+	StdOutFormatParameter = "csv"
+	if outputNeedsTrackPoints() {
+		t.Errorf("The csv output needs the track points")
+	}
+
+	StdOutFormatParameter = "html"
+	if !outputNeedsTrackPoints() {
+		t.Errorf("The html output does not need the track points")
+	}
+
+	OutFileParameter = "my/report.md"
+	if outputNeedsTrackPoints() {
+		t.Errorf("The md output file needs the track points")
+	}
+
+	OutFileParameter = "my/report.html"
+	StdOutFormatParameter = "csv"
+	if !outputNeedsTrackPoints() {
+		t.Errorf("The html output file does not need the track points")
+	}
+
+	OutFileParameter = "my/cleaned.gpx"
+	if !outputNeedsTrackPoints() {
+		t.Errorf("The gpx output file does not need the track points")
+	}
+
+	OutFileParameter = "my/map.geojson"
+	if !outputNeedsTrackPoints() {
+		t.Errorf("The geojson output file does not need the track points")
+	}
+
+	OutFileParameter = "my/season.kml"
+	if !outputNeedsTrackPoints() {
+		t.Errorf("The kml output file does not need the track points")
+	}
+
+	OutFileParameter = "my/archive.sqlite"
+	if !outputNeedsTrackPoints() {
+		t.Errorf("The sqlite output file does not need the track points")
+	}
+
+	StdOutFormatParameter = oldStdOutFormatParameter
+	OutFileParameter = oldOutFileParameter
+}
+
+func TestOutputIsDatabase(t *testing.T) {
+	oldStdOutFormatParameter := StdOutFormatParameter
+	oldOutFileParameter := OutFileParameter
+
+	OutFileParameter = ""
+	StdOutFormatParameter = "sqlite"
+	if !outputIsDatabase() {
+		t.Errorf("The sqlite output is not a database")
+	}
+
+	OutFileParameter = "my/archive.db"
+	StdOutFormatParameter = "csv"
+	if !outputIsDatabase() {
+		t.Errorf("The db output file is not a database")
+	}
+
+	OutFileParameter = "my/report.html"
+	if outputIsDatabase() {
+		t.Errorf("The html output file is a database")
+	}
+
+	StdOutFormatParameter = oldStdOutFormatParameter
+	OutFileParameter = oldOutFileParameter
+}
+
+func TestProcessFilesSqliteAppend(t *testing.T) {
+	ErrorsHandled = false
+	oldOutFileParameter := OutFileParameter
+	dir, errDir := ioutil.TempDir("", "gpsa")
+	if errDir != nil {
+		t.Fatalf("Can not create a temp dir: %s", errDir)
+	}
+	defer os.RemoveAll(dir)
+	OutFileParameter = filepath.Join(dir, "archive.sqlite")
+
+	inputs := [][]string{{"01.gpx"}, {"01.gpx", "02.gpx"}}
+	for i, names := range inputs {
+		files := []gpsabl.InputFile{}
+		for _, name := range names {
+			files = append(files, *gpsabl.NewInputFileWithPath(testhelper.GetValidGPX(name)))
+		}
+
+		out := getOutPutStream()
+		iFormater := getOutPutFormater(*out)
+		if processFiles(files, ValidReaders, iFormater) != len(files) {
+			t.Errorf("Not all files were processed successfully")
+		}
+		if errWrite := iFormater.WriteOutput(out, gpsabl.NONE); errWrite != nil {
+			t.Fatalf("Error while writing the database: %s", errWrite.Error())
+		}
+
+		formater := iFormater.(*sqlitebl.SqliteOutputFormater)
+		if formater.GetSkippedFileCount() != i || formater.GetNumberOfOutputEntries() != i+1 {
+			t.Errorf("%d files were skipped and the database contains %d files, but %d should be skipped and %d contained", formater.GetSkippedFileCount(), formater.GetNumberOfOutputEntries(), i, i+1)
+		}
+		if !isExistingDatabase(out) {
+			t.Errorf("The output file is not an existing database after writing")
+		}
+		out.Close()
+	}
+
+	if ErrorsHandled {
+		t.Errorf("Errors occured where no errors were expected")
+	}
+	OutFileParameter = oldOutFileParameter
+}
+
+func TestProcessFilesHTMLReport(t *testing.T) {
+	ErrorsHandled = false
+	oldDepthValue := DepthParameter
+	DepthParameter = "track"
+
+	files := []gpsabl.InputFile{*gpsabl.NewInputFileWithPath(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-gpx", "01.gpx"))}
+	formater := htmlbl.NewHTMLOutputFormater()
+	if processFiles(files, ValidReaders, gpsabl.OutputFormater(formater)) != 1 {
+		t.Errorf("The file was not processed successfully")
+	}
+
+	lines, err := formater.GetOutputLines(gpsabl.ADDITIONAL)
+	if err != nil {
+		t.Fatalf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+	document := strings.Join(lines, "")
+	if !strings.Contains(document, "<figure id=\"profile-1\">") || !strings.Contains(document, "<svg") {
+		t.Errorf("The html report does not contain the elevation profile of the track")
+	}
+
+	if ErrorsHandled {
+		t.Errorf("Errors were handled, but none was expected")
+	}
+	DepthParameter = oldDepthValue
+}
+
 func TestGetOutPutFormaterJSON(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("Skip this test on windows")
@@ -879,7 +1954,7 @@ func TestInputStreamBufferWithTwoGPXFileContent(t *testing.T) {
 		t.Errorf("%d files expected, but got %d", 2, len(inFiles))
 	}
 
-	successCount := processFiles(inFiles, gpsabl.OutputFormater(formater))
+	successCount := processFiles(inFiles, ValidReaders, gpsabl.OutputFormater(formater))
 
 	if successCount != len(inFiles) {
 		t.Errorf("only %d files processed successfull, but %d should", successCount, len(inFiles))
@@ -905,6 +1980,193 @@ func TestProccessFileArgs(t *testing.T) {
 	}
 }
 
+func TestProccessFileArgsCompressed(t *testing.T) {
+	fileargs := []string{testhelper.GetValidFile("compressed", "01.gpx.gz"), testhelper.GetValidFile("compressed", "04.zip")}
+
+	inputFiles := proccessFileArgs(fileargs)
+
+	if len(inputFiles) != 4 {
+		t.Fatalf("The number of inputFiles is %d, but should be %d", len(inputFiles), 4)
+	}
+
+	expectedName := strings.TrimSuffix(fileargs[0], ".gz")
+	if inputFiles[0].Name != expectedName || inputFiles[0].Type != gpxbl.GpxBuffer {
+		t.Errorf("The inputFiles[0] is %s of type %s, but should be %s of type %s", inputFiles[0].Name, inputFiles[0].Type, expectedName, gpxbl.GpxBuffer)
+	}
+
+	formater := csvbl.NewCsvOutputFormater(";", false)
+	successCount := processFiles(inputFiles, ValidReaders, gpsabl.OutputFormater(formater))
+	if successCount != 4 {
+		t.Errorf("Only %d of %d files were processed successfully", successCount, 4)
+	}
+}
+
+func TestProccessFileArgsDirectoryAndPattern(t *testing.T) {
+	ErrorsHandled = false
+	testdata := filepath.Join(testhelper.GetProjectRoot(), "testdata")
+
+	inputFiles := proccessFileArgs([]string{filepath.Join(testdata, "valid-gpx")})
+	if len(inputFiles) != 21 {
+		t.Errorf("The number of inputFiles is %d, but should be %d", len(inputFiles), 21)
+	}
+
+	inputFiles = proccessFileArgs([]string{filepath.Join(testdata, "**", "0[12].plt")})
+	if len(inputFiles) != 4 {
+		t.Errorf("The number of inputFiles is %d, but should be %d", len(inputFiles), 4)
+	}
+
+	if ErrorsHandled == true {
+		t.Errorf("Errors occured where no errors were expected")
+	}
+}
+
+func TestProccessFileArgsIncludeExclude(t *testing.T) {
+	ErrorsHandled = false
+	oldIncludeValue := IncludePatternParameter
+	IncludePatternParameter = "*.plt, *.igc"
+	oldExcludeValue := ExcludePatternParameter
+	ExcludePatternParameter = "invalid-*"
+	oldDepthValue := WalkDepthParameter
+	WalkDepthParameter = 1
+
+	inputFiles := proccessFileArgs([]string{filepath.Join(testhelper.GetProjectRoot(), "testdata")})
+	for _, input := range inputFiles {
+		if strings.Contains(input.Name, "invalid-") || !(strings.HasSuffix(input.Name, ".plt") || strings.HasSuffix(input.Name, ".igc")) {
+			t.Errorf("The input %s should not be found", input.Name)
+		}
+	}
+
+	if len(inputFiles) == 0 {
+		t.Errorf("No inputFiles were found")
+	}
+
+	WalkDepthParameter = 0
+	inputFiles = proccessFileArgs([]string{filepath.Join(testhelper.GetProjectRoot(), "testdata")})
+	if len(inputFiles) != 0 {
+		t.Errorf("The number of inputFiles is %d, but should be %d", len(inputFiles), 0)
+	}
+
+	if ErrorsHandled == true {
+		t.Errorf("Errors occured where no errors were expected")
+	}
+
+	IncludePatternParameter = oldIncludeValue
+	ExcludePatternParameter = oldExcludeValue
+	WalkDepthParameter = oldDepthValue
+}
+
+func TestProccessFileArgsUnknownFileType(t *testing.T) {
+	ErrorsHandled = false
+	fileargs := []string{filepath.Join(testhelper.GetProjectRoot(), "Re*.md"), testhelper.GetValidGPX("13.gpx")}
+
+	inputFiles := proccessFileArgs(fileargs)
+
+	if len(inputFiles) != 1 || inputFiles[0].Name != fileargs[1] {
+		t.Errorf("The inputFiles are %v, but should only contain %s", inputFiles, fileargs[1])
+	}
+
+	if ErrorsHandled == true {
+		t.Errorf("Errors occured for a file of unknown type found by a pattern, but it should be skipped")
+	}
+}
+
+func TestProccessFileArgsUnknownFileTypeGivenByPath(t *testing.T) {
+	ErrorsHandled = false
+	oldFlagValue := SkipErrorExitFlag
+	SkipErrorExitFlag = true
+	fileargs := []string{filepath.Join(testhelper.GetProjectRoot(), "ReadMe.md"), testhelper.GetValidGPX("13.gpx")}
+
+	inputFiles := proccessFileArgs(fileargs)
+
+	if len(inputFiles) != 1 || inputFiles[0].Name != fileargs[1] {
+		t.Errorf("The inputFiles are %v, but should only contain %s", inputFiles, fileargs[1])
+	}
+
+	if ErrorsHandled == false {
+		t.Errorf("No error occured for a file of unknown type given by its path")
+	}
+
+	ErrorsHandled = false
+	SkipErrorExitFlag = oldFlagValue
+}
+
+func TestProccessFileArgsMisnamedFiles(t *testing.T) {
+	ErrorsHandled = false
+	dir, errDir := ioutil.TempDir("", "gpsa")
+	if errDir != nil {
+		t.Fatalf("Got error \"%s\" while creating a temp dir", errDir)
+	}
+	defer os.RemoveAll(dir)
+
+	sources := map[string]string{"track.xml": testhelper.GetValidGPX("01.gpx"), "activity": testhelper.GetValidTcx("01.tcx"), "ride.gpx": testhelper.GetValidFile("fit", "01.fit")}
+	for name, source := range sources {
+		content, errRead := ioutil.ReadFile(source)
+		if errRead != nil {
+			t.Fatalf("Got error \"%s\" while reading %s", errRead, source)
+		}
+		if errWrite := ioutil.WriteFile(filepath.Join(dir, name), content, 0644); errWrite != nil {
+			t.Fatalf("Got error \"%s\" while writing %s", errWrite, name)
+		}
+	}
+
+	inputFiles := proccessFileArgs([]string{dir})
+	if len(inputFiles) != 3 {
+		t.Fatalf("The number of inputFiles is %d, but should be %d", len(inputFiles), 3)
+	}
+
+	for _, input := range inputFiles {
+		if input.Detection == "" {
+			t.Errorf("The Detection of %s is empty", input.Name)
+		}
+	}
+
+	formater := csvbl.NewCsvOutputFormater(";", false)
+	successCount := processFiles(inputFiles, ValidReaders, gpsabl.OutputFormater(formater))
+	if successCount != 3 {
+		t.Errorf("Only %d of %d files were processed successfully", successCount, 3)
+	}
+
+	if ErrorsHandled == true {
+		t.Errorf("Errors occured where no errors were expected")
+	}
+}
+
+func TestGetInputPathOptionsInvalidPattern(t *testing.T) {
+	oldExcludeValue := ExcludePatternParameter
+	ExcludePatternParameter = "*.bak,[a"
+
+	_, err := getInputPathOptions()
+	switch err.(type) {
+	case *gpsabl.PathPatternError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *gpsabl.PathPatternError, got \"%v\"", err)
+	}
+
+	ExcludePatternParameter = oldExcludeValue
+}
+
+func TestProccessFileArgsBrokenCompressed(t *testing.T) {
+	ErrorsHandled = false
+	oldFlagValue := SkipErrorExitFlag
+	SkipErrorExitFlag = true
+
+	fileargs := []string{testhelper.GetInvalidFile("compressed", "01.gpx.gz"), testhelper.GetInvalidFile("compressed", "02.zip"), testhelper.GetValidGPX("13.gpx")}
+
+	inputFiles := proccessFileArgs(fileargs)
+
+	if len(inputFiles) != 1 {
+		t.Errorf("The number of inputFiles is %d, but should be %d", len(inputFiles), 1)
+	}
+
+	if ErrorsHandled == false {
+		t.Errorf("No error was handled, but errors were expected")
+	}
+
+	ErrorsHandled = false
+	SkipErrorExitFlag = oldFlagValue
+}
+
 func TestCreateFiltersWithValidMinStartTimeFilterString(t *testing.T) {
 	outFileMux.Lock()
 	defer outFileMux.Unlock()
@@ -965,6 +2227,42 @@ func TestCreateFiltersWithValidMaxStartTimeFilterString(t *testing.T) {
 	DefinedFilters = []gpsabl.TrackFilter{}
 }
 
+func TestCreateFiltersWithActivityTypeAndGear(t *testing.T) {
+	outFileMux.Lock()
+	defer outFileMux.Unlock()
+	oldActivityType := ActivityTypeFilterParameter
+	oldGear := GearFilterParameter
+
+	ActivityTypeFilterParameter = "Ride,Run"
+	GearFilterParameter = "My Bike"
+
+	if !createFilters() {
+		t.Errorf("The activity type and gear filters could not be created")
+	}
+
+	if len(DefinedFilters) != 2 {
+		t.Fatalf("There are %d filters defined, but 2 are expected", len(DefinedFilters))
+	}
+
+	switch ty := DefinedFilters[0].(type) {
+	default:
+		t.Errorf("The Defined filter is of type %s, but gpsabl.ActivityTypeFilter is expected", ty)
+	case *gpsabl.ActivityTypeFilter:
+		fmt.Println("OK")
+	}
+
+	switch ty := DefinedFilters[1].(type) {
+	default:
+		t.Errorf("The Defined filter is of type %s, but gpsabl.GearFilter is expected", ty)
+	case *gpsabl.GearFilter:
+		fmt.Println("OK")
+	}
+
+	ActivityTypeFilterParameter = oldActivityType
+	GearFilterParameter = oldGear
+	DefinedFilters = []gpsabl.TrackFilter{}
+}
+
 func TestCreateFiltersWithValidMinAndMaxStartTimeFilterString(t *testing.T) {
 	outFileMux.Lock()
 	defer outFileMux.Unlock()
@@ -1051,7 +2349,7 @@ func TestProcessValidFilesWithStartTimeFilters(t *testing.T) {
 	for _, file := range fileStrs {
 		files = append(files, *gpsabl.NewInputFileWithPath(file))
 	}
-	successCount := processFiles(files, iFormater)
+	successCount := processFiles(files, ValidReaders, iFormater)
 	if successCount != 3 {
 		t.Errorf("Not all files were processed successfully as expected")
 	}
@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"os"
 	"runtime"
-	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -44,11 +43,18 @@ type CsvOutputFormater struct {
 	// Tell if the CSV header should be added to the output
 	AddHeader bool
 
+	// Tell if the waypoints of the TrackFiles should be listed after the track lines
+	AddWaypoints bool
+
+	// Sorting - The order the track lines are written in. The lines are written in the order they are added by default
+	Sorting gpsabl.OutputSorting
+
 	timeFormater gpsabl.TimeFormat
 
 	writtenEntiresCount int
 	entriesToWriteCount int
 	lineBuffer          []gpsabl.OutputLine
+	waypointBuffer      []gpsabl.Waypoint
 	mux                 sync.Mutex
 }
 
@@ -61,6 +67,7 @@ func NewCsvOutputFormater(separator string, addHeader bool) *CsvOutputFormater {
 	ret.AddHeader = addHeader
 	ret.timeFormater = gpsabl.RFC3339
 	ret.lineBuffer = []gpsabl.OutputLine{}
+	ret.waypointBuffer = []gpsabl.Waypoint{}
 
 	return &ret
 }
@@ -96,6 +103,16 @@ func (formater *CsvOutputFormater) SetAddHeader(value bool) {
 	formater.AddHeader = value
 }
 
+// SetAddWaypoints - Set the value of formater.AddWaypoints
+func (formater *CsvOutputFormater) SetAddWaypoints(value bool) {
+	formater.AddWaypoints = value
+}
+
+// SetSorting - Set the value of formater.Sorting
+func (formater *CsvOutputFormater) SetSorting(sorting gpsabl.OutputSorting) {
+	formater.Sorting = sorting
+}
+
 // GetSeperator - Get the value of formater.Separator
 func (formater *CsvOutputFormater) GetSeperator() string {
 	return formater.Separator
@@ -142,6 +159,12 @@ func (formater *CsvOutputFormater) AddOutPut(trackFile gpsabl.TrackFile, depth g
 		lines = linesFromFile
 	}
 
+	if formater.AddWaypoints && len(trackFile.Waypoints) > 0 {
+		formater.mux.Lock()
+		formater.waypointBuffer = append(formater.waypointBuffer, trackFile.Waypoints...)
+		formater.mux.Unlock()
+	}
+
 	if len(lines) > 0 {
 		formater.mux.Lock()
 		defer formater.mux.Unlock()
@@ -199,9 +222,7 @@ func (formater *CsvOutputFormater) GetLines() []string {
 	ret := []string{}
 	formater.mux.Lock()
 	defer formater.mux.Unlock()
-	sort.Slice(formater.lineBuffer, func(i, j int) bool {
-		return formater.lineBuffer[i].Data.GetStartTime().Before(formater.lineBuffer[j].Data.GetStartTime())
-	})
+	gpsabl.SortOutputLines(formater.lineBuffer, formater.Sorting)
 	for _, line := range formater.lineBuffer {
 		ret = append(ret, formater.FormatTrackSummary(line.Data, line.Name))
 	}
@@ -245,6 +266,10 @@ func (formater *CsvOutputFormater) GetOutputLines(summary gpsabl.SummaryArg) ([]
 
 	formater.entriesToWriteCount = len(lines)
 
+	if summary != gpsabl.ONLY {
+		lines = append(lines, formater.GetWaypointLines()...)
+	}
+
 	if formater.AddHeader && (formater.entriesToWriteCount > 0) {
 		var headerLines []string
 		headerLines = append(headerLines, formater.GetHeader())
@@ -254,6 +279,53 @@ func (formater *CsvOutputFormater) GetOutputLines(summary gpsabl.SummaryArg) ([]
 	return lines, nil
 }
 
+// GetWaypointLines - Get the waypoint list that is written after the track lines. Empty if formater.AddWaypoints is false or no waypoint was added
+func (formater *CsvOutputFormater) GetWaypointLines() []string {
+	ret := []string{}
+	formater.mux.Lock()
+	defer formater.mux.Unlock()
+	if !formater.AddWaypoints || len(formater.waypointBuffer) == 0 {
+		return ret
+	}
+
+	ret = append(ret, fmt.Sprintf("%s%s%s", "Waypoints:", formater.Separator, GetNewLine()))
+	if formater.AddHeader {
+		header := ""
+		for _, name := range gpsabl.GetWaypointHeaders() {
+			header = fmt.Sprintf("%s%s%s", header, name, formater.Separator)
+		}
+		ret = append(ret, fmt.Sprintf("%s%s", header, GetNewLine()))
+	}
+	for _, wpt := range gpsabl.GetSortedWaypoints(formater.waypointBuffer) {
+		ret = append(ret, formater.FormatWaypoint(wpt))
+	}
+
+	return ret
+}
+
+// FormatWaypoint - Create the output line for a gpsabl.Waypoint
+func (formater *CsvOutputFormater) FormatWaypoint(wpt gpsabl.Waypoint) string {
+	trackName := NotValidValue
+	distanceAlongTrack := NotValidValue
+	distanceFromTrack := NotValidValue
+	if wpt.TrackValuesValid {
+		trackName = wpt.TrackName
+		distanceAlongTrack = fmt.Sprintf("%.2f", gpsabl.RoundFloat64To2Digits(wpt.DistanceAlongTrack/1000))
+		distanceFromTrack = fmt.Sprintf("%.2f", gpsabl.RoundFloat64To2Digits(wpt.DistanceFromTrack))
+	}
+
+	return fmt.Sprintf("%s%s%s%s%s%s%s%s%f%s%f%s%.2f%s%s",
+		wpt.Name, formater.Separator,
+		trackName, formater.Separator,
+		distanceAlongTrack, formater.Separator,
+		distanceFromTrack, formater.Separator,
+		wpt.Latitude, formater.Separator,
+		wpt.Longitude, formater.Separator,
+		gpsabl.RoundFloat64To2Digits(float64(wpt.Elevation)), formater.Separator,
+		GetNewLine(),
+	)
+}
+
 // Get the number of output lines in the normal output table
 func (formater *CsvOutputFormater) GetOutputTableLineCount() int {
 	return len(formater.lineBuffer)
@@ -271,7 +343,7 @@ func (formater *CsvOutputFormater) GetHeader() string {
 	movingTimeHeader, _ := formater.getTimeDurationHeader("MovingTime")
 	upwardsTimeHeader, _ := formater.getTimeDurationHeader("UpwardsTime")
 	downwardsTimeHeader, _ := formater.getTimeDurationHeader("DownwardsTime")
-	ret := fmt.Sprintf("%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s",
+	ret := fmt.Sprintf("%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s",
 		"Name", formater.Separator,
 		"StartTime", formater.Separator,
 		"EndTime", formater.Separator,
@@ -291,6 +363,8 @@ func (formater *CsvOutputFormater) GetHeader() string {
 		"AverageSpeed (km/h)", formater.Separator,
 		"UpwardsSpeed (km/h)", formater.Separator,
 		"DownwardsSpeed (km/h)", formater.Separator,
+		formater.getSensorHeader(),
+		formater.getActivityHeader(),
 		GetNewLine(),
 	)
 
@@ -305,7 +379,7 @@ func (formater *CsvOutputFormater) FormatTrackSummary(info gpsabl.TrackSummaryPr
 		moveTime, _ := formater.formatTimeDuration(info.GetMovingTime())
 		upTime, _ := formater.formatTimeDuration(info.GetUpwardsTime())
 		downTime, _ := formater.formatTimeDuration(info.GetDownwardsTime())
-		ret = fmt.Sprintf("%s%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%s",
+		ret = fmt.Sprintf("%s%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%s%s%s",
 			name, formater.Separator,
 			info.GetStartTime().Format(string(formater.timeFormater)), formater.Separator,
 			info.GetEndTime().Format(string(formater.timeFormater)), formater.Separator,
@@ -325,10 +399,12 @@ func (formater *CsvOutputFormater) FormatTrackSummary(info gpsabl.TrackSummaryPr
 			gpsabl.RoundFloat64To2Digits(info.GetAvarageSpeed()*3.6), formater.Separator,
 			gpsabl.RoundFloat64To2Digits(info.GetUpwardsSpeed()*3.6), formater.Separator,
 			gpsabl.RoundFloat64To2Digits(info.GetDownwardsSpeed()*3.6), formater.Separator,
+			formater.formatSensorSummary(info.GetSensorSummary()),
+			formater.formatActivity(info.GetActivityType(), info.GetGear()),
 			GetNewLine(),
 		)
 	} else {
-		ret = fmt.Sprintf("%s%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%s%s%s%s%s%s%s%s%s%s%s%s%s",
+		ret = fmt.Sprintf("%s%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s",
 			name, formater.Separator,
 			NotValidValue, formater.Separator,
 			NotValidValue, formater.Separator,
@@ -348,6 +424,8 @@ func (formater *CsvOutputFormater) FormatTrackSummary(info gpsabl.TrackSummaryPr
 			NotValidValue, formater.Separator,
 			NotValidValue, formater.Separator,
 			NotValidValue, formater.Separator,
+			formater.formatSensorSummary(info.GetSensorSummary()),
+			formater.formatActivity(info.GetActivityType(), info.GetGear()),
 			GetNewLine(),
 		)
 	}
@@ -362,7 +440,7 @@ func (formater *CsvOutputFormater) formatMinMaxSummary(info gpsabl.ExtendedTrack
 		moveTime, _ := formater.formatTimeDuration(info.MovingTime)
 		upTime, _ := formater.formatTimeDuration(info.UpwardsTime)
 		downTime, _ := formater.formatTimeDuration(info.DownwardsTime)
-		ret = fmt.Sprintf("%s%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%s",
+		ret = fmt.Sprintf("%s%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%s%s%s",
 			name, formater.Separator,
 			info.StartTime.Format(string(formater.timeFormater)), formater.Separator,
 			info.EndTime.Format(string(formater.timeFormater)), formater.Separator,
@@ -382,10 +460,12 @@ func (formater *CsvOutputFormater) formatMinMaxSummary(info gpsabl.ExtendedTrack
 			gpsabl.RoundFloat64To2Digits(info.AverageSpeed*3.6), formater.Separator,
 			gpsabl.RoundFloat64To2Digits(info.UpwardsSpeed*3.6), formater.Separator,
 			gpsabl.RoundFloat64To2Digits(info.DownwardsSpeed*3.6), formater.Separator,
+			formater.formatSensorSummary(info.SensorSummary),
+			formater.formatActivity("", ""),
 			GetNewLine(),
 		)
 	} else {
-		ret = fmt.Sprintf("%s%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%s%s%s%s%s%s%s%s%s%s%s%s%s",
+		ret = fmt.Sprintf("%s%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%.2f%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s",
 			name, formater.Separator,
 			NotValidValue, formater.Separator,
 			NotValidValue, formater.Separator,
@@ -405,6 +485,8 @@ func (formater *CsvOutputFormater) formatMinMaxSummary(info gpsabl.ExtendedTrack
 			NotValidValue, formater.Separator,
 			NotValidValue, formater.Separator,
 			NotValidValue, formater.Separator,
+			formater.formatSensorSummary(info.SensorSummary),
+			formater.formatActivity("", ""),
 			GetNewLine(),
 		)
 	}
@@ -420,7 +502,7 @@ func (formater *CsvOutputFormater) formatAverageSummary(info gpsabl.ExtendedTrac
 		moveTime, _ := formater.formatTimeDuration(info.MovingTime)
 		upTime, _ := formater.formatTimeDuration(info.UpwardsTime)
 		downTime, _ := formater.formatTimeDuration(info.DownwardsTime)
-		ret = fmt.Sprintf("%s%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%s%s%s%s%.2f%s%.2f%s%.2f%s%.2f%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%s",
+		ret = fmt.Sprintf("%s%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%s%s%s%s%.2f%s%.2f%s%.2f%s%.2f%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%s%s%s",
 			"Average:", formater.Separator,
 			"-", formater.Separator,
 			"-", formater.Separator,
@@ -440,10 +522,12 @@ func (formater *CsvOutputFormater) formatAverageSummary(info gpsabl.ExtendedTrac
 			gpsabl.RoundFloat64To2Digits(info.AverageSpeed*3.6), formater.Separator,
 			gpsabl.RoundFloat64To2Digits(info.UpwardsSpeed*3.6), formater.Separator,
 			gpsabl.RoundFloat64To2Digits(info.DownwardsSpeed*3.6), formater.Separator,
+			formater.formatSensorSummary(info.SensorSummary),
+			formater.formatActivity("", ""),
 			GetNewLine(),
 		)
 	} else {
-		ret = fmt.Sprintf("%s%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%s%s%s%s%.2f%s%.2f%s%.2f%s%.2f%s%s%s%s%s%s%s%s%s%s%s%s%s%s",
+		ret = fmt.Sprintf("%s%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%s%s%s%s%.2f%s%.2f%s%.2f%s%.2f%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s",
 			"Average:", formater.Separator,
 			NotValidValue, formater.Separator,
 			NotValidValue, formater.Separator,
@@ -463,6 +547,8 @@ func (formater *CsvOutputFormater) formatAverageSummary(info gpsabl.ExtendedTrac
 			NotValidValue, formater.Separator,
 			NotValidValue, formater.Separator,
 			NotValidValue, formater.Separator,
+			formater.formatSensorSummary(info.SensorSummary),
+			formater.formatActivity("", ""),
 			GetNewLine(),
 		)
 	}
@@ -477,7 +563,7 @@ func (formater *CsvOutputFormater) formatSumSummary(info gpsabl.ExtendedTrackSum
 		moveTime, _ := formater.formatTimeDuration(info.MovingTime)
 		upTime, _ := formater.formatTimeDuration(info.UpwardsTime)
 		downTime, _ := formater.formatTimeDuration(info.DownwardsTime)
-		ret = fmt.Sprintf("%s%s%s%s%s%s%s%s%.2f%s%.2f%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%.2f%s%s%s%s%s%s%s%s%s%s%s%s%s%s",
+		ret = fmt.Sprintf("%s%s%s%s%s%s%s%s%.2f%s%.2f%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%.2f%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s",
 			"Sum:", formater.Separator,
 			"-", formater.Separator,
 			"-", formater.Separator,
@@ -497,10 +583,12 @@ func (formater *CsvOutputFormater) formatSumSummary(info gpsabl.ExtendedTrackSum
 			"-", formater.Separator,
 			"-", formater.Separator,
 			"-", formater.Separator,
+			formater.formatSensorSummary(gpsabl.SensorSummary{}),
+			formater.formatActivity("", ""),
 			GetNewLine(),
 		)
 	} else {
-		ret = fmt.Sprintf("%s%s%s%s%s%s%s%s%.2f%s%.2f%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%.2f%s%s%s%s%s%s%s%s%s%s%s%s%s%s",
+		ret = fmt.Sprintf("%s%s%s%s%s%s%s%s%.2f%s%.2f%s%s%s%s%s%s%s%.2f%s%.2f%s%.2f%s%.2f%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s",
 			"Sum:", formater.Separator,
 			NotValidValue, formater.Separator,
 			NotValidValue, formater.Separator,
@@ -520,6 +608,8 @@ func (formater *CsvOutputFormater) formatSumSummary(info gpsabl.ExtendedTrackSum
 			NotValidValue, formater.Separator,
 			NotValidValue, formater.Separator,
 			NotValidValue, formater.Separator,
+			formater.formatSensorSummary(gpsabl.SensorSummary{}),
+			formater.formatActivity("", ""),
 			GetNewLine(),
 		)
 	}
@@ -527,6 +617,56 @@ func (formater *CsvOutputFormater) formatSumSummary(info gpsabl.ExtendedTrackSum
 	return ret
 }
 
+// getSensorHeader - Get the header columns of the sensor values
+func (formater *CsvOutputFormater) getSensorHeader() string {
+	ret := ""
+	for _, header := range gpsabl.GetSensorHeaders() {
+		ret = fmt.Sprintf("%s%s%s", ret, header, formater.Separator)
+	}
+
+	return ret
+}
+
+// formatSensorSummary - Get the columns of the sensor values. Values without samples are written as "-"
+func (formater *CsvOutputFormater) formatSensorSummary(sensor gpsabl.SensorSummary) string {
+	ret := ""
+	for _, values := range []gpsabl.SensorValues{sensor.HeartRate, sensor.Cadence, sensor.Power} {
+		if values.Valid() {
+			ret = fmt.Sprintf("%s%.2f%s%.2f%s%.2f%s", ret,
+				gpsabl.RoundFloat64To2Digits(values.Minimum), formater.Separator,
+				gpsabl.RoundFloat64To2Digits(values.Average), formater.Separator,
+				gpsabl.RoundFloat64To2Digits(values.Maximum), formater.Separator)
+		} else {
+			ret = fmt.Sprintf("%s%s%s%s%s%s%s", ret, "-", formater.Separator, "-", formater.Separator, "-", formater.Separator)
+		}
+	}
+
+	return ret
+}
+
+// getActivityHeader - Get the header columns of the activity values
+func (formater *CsvOutputFormater) getActivityHeader() string {
+	ret := ""
+	for _, header := range gpsabl.GetActivityHeaders() {
+		ret = fmt.Sprintf("%s%s%s", ret, header, formater.Separator)
+	}
+
+	return ret
+}
+
+// formatActivity - Get the columns of the activity values. Unknown values are written as "-"
+func (formater *CsvOutputFormater) formatActivity(activityType string, gear string) string {
+	ret := ""
+	for _, value := range []string{activityType, gear} {
+		if value == "" {
+			value = "-"
+		}
+		ret = fmt.Sprintf("%s%s%s", ret, value, formater.Separator)
+	}
+
+	return ret
+}
+
 // GetNewLine - Get the new line string depending on the OS
 func GetNewLine() string {
 	if runtime.GOOS == "windows" {
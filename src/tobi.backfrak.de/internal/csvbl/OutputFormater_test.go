@@ -15,7 +15,7 @@ import (
 // by a BSD-style license that can be found in the
 // LICENSE file.
 
-const numberOfSemicolonExpected = 19
+const numberOfSemicolonExpected = 30
 const numberOfNotValideExpected = 9
 
 func TestTextOutputFormater(t *testing.T) {
@@ -600,44 +600,44 @@ func TestAddOutPutMixedTimeAndNoTime(t *testing.T) {
 		t.Errorf("The number of lines was not expected. Got %d, expected %d", len(lines), 1)
 	}
 
-	if strings.Count(lines[1], ";") != numberOfSemicolonExpected {
-		t.Errorf("The Number of semicolons in the line is %d but %d was expected", strings.Count(lines[0], ";"), numberOfSemicolonExpected)
+	if strings.Count(lines[0], ";") != numberOfSemicolonExpected {
+		t.Errorf("The Number of semicolons in the line is %d but %d was expected", strings.Count(lines[1], ";"), numberOfSemicolonExpected)
 	}
 
-	if strings.Count(lines[1], "0.02;") != 2 {
-		t.Errorf("The output does not contain the distance as expected. It is: %s", lines[0])
+	if strings.Count(lines[0], "0.02;") != 2 {
+		t.Errorf("The output does not contain the distance as expected. It is: %s", lines[1])
 	}
 
-	if strings.Count(lines[1], "1.00;") != 3 {
-		t.Errorf("The output does not contain the ElevationGain as expected. It is: %s", lines[0])
+	if strings.Count(lines[0], "1.00;") != 3 {
+		t.Errorf("The output does not contain the ElevationGain as expected. It is: %s", lines[1])
 	}
 
-	if strings.Count(lines[1], "0.01;") != 2 {
-		t.Errorf("The output does not contain the UpwardsDistance as expected. It is: %s", lines[0])
+	if strings.Count(lines[0], "0.01;") != 2 {
+		t.Errorf("The output does not contain the UpwardsDistance as expected. It is: %s", lines[1])
 	}
 
-	if strings.Count(lines[1], "2014-08-22T17:19:33Z;") != 1 {
-		t.Errorf("The output does not contain the StartTime as expected. It is: %s", lines[0])
+	if strings.Count(lines[0], "2014-08-22T17:19:33Z;") != 1 {
+		t.Errorf("The output does not contain the StartTime as expected. It is: %s", lines[1])
 	}
 
-	if strings.Count(lines[1], "2014-08-22T17:19:53Z;") != 1 {
-		t.Errorf("The output does not contain the EndTime as expected. It is: %s", lines[0])
+	if strings.Count(lines[0], "2014-08-22T17:19:53Z;") != 1 {
+		t.Errorf("The output does not contain the EndTime as expected. It is: %s", lines[1])
 	}
 
-	if strings.Count(lines[1], "20s;") != 2 {
-		t.Errorf("The output does not contain the MovingTime as expected. It is: %s", lines[0])
+	if strings.Count(lines[0], "20s;") != 2 {
+		t.Errorf("The output does not contain the MovingTime as expected. It is: %s", lines[1])
 	}
 
-	if strings.Count(lines[1], "4.30;") != 3 {
-		t.Errorf("The output does not contain the AvarageSpeed as expected. It is: %s", lines[0])
+	if strings.Count(lines[0], "4.30;") != 3 {
+		t.Errorf("The output does not contain the AvarageSpeed as expected. It is: %s", lines[1])
 	}
 
-	if strings.Count(lines[0], "not valid;") != numberOfNotValideExpected {
-		t.Errorf("The output does not contain the Time values as often as expected. Found it %d times in: %s", strings.Count(lines[1], "not valid;"), lines[1])
+	if strings.Count(lines[1], "not valid;") != numberOfNotValideExpected {
+		t.Errorf("The output does not contain the Time values as often as expected. Found it %d times in: %s", strings.Count(lines[0], "not valid;"), lines[0])
 	}
 
-	if strings.Count(lines[1], "10s") != 2 {
-		t.Errorf("The output does not contain the Time values as often as expected. Found it %d times in: %s", strings.Count(lines[0], "10s;"), lines[1])
+	if strings.Count(lines[0], "10s") != 2 {
+		t.Errorf("The output does not contain the Time values as often as expected. Found it %d times in: %s", strings.Count(lines[1], "10s;"), lines[0])
 	}
 }
 
@@ -728,6 +728,7 @@ func TestOutputIsSorted(t *testing.T) {
 	if err != nil {
 		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
 	}
+	frt.SetSorting(gpsabl.OutputSorting{Column: gpsabl.STARTTIME, Order: gpsabl.ASCENDING})
 	lines := frt.GetLines()
 
 	slpitLineOne := strings.Split(lines[0], ";")
@@ -741,6 +742,55 @@ func TestOutputIsSorted(t *testing.T) {
 
 }
 
+func TestOutputKeepsInputOrder(t *testing.T) {
+	frt := NewCsvOutputFormater(";", false)
+	trackFile1 := getTrackFileWithDifferentTime()
+	err := frt.AddOutPut(trackFile1, "file", false)
+	if err != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+	trackFile2 := getSimpleTrackFileWithTime()
+	err = frt.AddOutPut(trackFile2, "file", false)
+	if err != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+	lines := frt.GetLines()
+
+	slpitLineOne := strings.Split(lines[0], ";")
+	slpitLineTwo := strings.Split(lines[1], ";")
+	if slpitLineOne[1] != "2015-08-22T17:19:33Z" {
+		t.Errorf("The lines are not in the right order")
+	}
+	if slpitLineTwo[1] != "2014-08-22T17:19:33Z" {
+		t.Errorf("The lines are not in the right order")
+	}
+}
+
+func TestOutputIsSortedDescending(t *testing.T) {
+	frt := NewCsvOutputFormater(";", false)
+	trackFile1 := getSimpleTrackFileWithTime()
+	err := frt.AddOutPut(trackFile1, "file", false)
+	if err != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+	trackFile2 := getTrackFileWithDifferentTime()
+	err = frt.AddOutPut(trackFile2, "file", false)
+	if err != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+	frt.SetSorting(gpsabl.OutputSorting{Column: gpsabl.STARTTIME, Order: gpsabl.DESCENDING})
+	lines := frt.GetLines()
+
+	slpitLineOne := strings.Split(lines[0], ";")
+	slpitLineTwo := strings.Split(lines[1], ";")
+	if slpitLineOne[1] != "2015-08-22T17:19:33Z" {
+		t.Errorf("The lines are not in the right order")
+	}
+	if slpitLineTwo[1] != "2014-08-22T17:19:33Z" {
+		t.Errorf("The lines are not in the right order")
+	}
+}
+
 func TestGetStatisticSummaryLinesWithTime(t *testing.T) {
 	frt := NewCsvOutputFormater(";", false)
 	trackFile1 := getTrackFileWithDifferentTime()
@@ -854,6 +904,66 @@ func TestGetOutputLinesSummaryAdditional(t *testing.T) {
 	}
 }
 
+func TestGetOutputLinesWithWaypoints(t *testing.T) {
+	frt := NewCsvOutputFormater(";", true)
+	trackFile := getSimpleTrackFileWithTime()
+	trackFile.Waypoints = []gpsabl.Waypoint{
+		{Name: "Lonely", Elevation: 100.0},
+		{Name: "Top", Elevation: 108.0, TrackValuesValid: true, TrackName: "My Track", DistanceAlongTrack: 1234.5, DistanceFromTrack: 3.5},
+	}
+
+	frt.SetAddWaypoints(true)
+	err := frt.AddOutPut(trackFile, "file", false)
+	if err != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+
+	lines, errOut := frt.GetOutputLines("none")
+	if errOut != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", errOut.Error())
+	}
+	if len(lines) != 6 {
+		t.Fatalf("Got %d lines, but expected %d", len(lines), 6)
+	}
+
+	if lines[2] != "Waypoints:;"+GetNewLine() {
+		t.Errorf("The waypoint separator line is \"%s\"", lines[2])
+	}
+
+	if !strings.HasPrefix(lines[3], "Name;Track;DistanceAlongTrack (km);") {
+		t.Errorf("The waypoint header line is \"%s\"", lines[3])
+	}
+
+	if !strings.HasPrefix(lines[4], "Lonely;not valid;not valid;not valid;") {
+		t.Errorf("The waypoint line without track values is \"%s\"", lines[4])
+	}
+
+	if !strings.HasPrefix(lines[5], "Top;My Track;1.23;3.50;") || !strings.HasSuffix(lines[5], ";108.00;"+GetNewLine()) {
+		t.Errorf("The waypoint line is \"%s\"", lines[5])
+	}
+
+	summaryLines, _ := frt.GetOutputLines("only")
+	if len(summaryLines) != 5 {
+		t.Errorf("Got %d lines, but expected %d", len(summaryLines), 5)
+	}
+}
+
+func TestGetOutputLinesWithoutWaypoints(t *testing.T) {
+	frt := NewCsvOutputFormater(";", false)
+	trackFile := getSimpleTrackFileWithTime()
+	trackFile.Waypoints = []gpsabl.Waypoint{{Name: "Top"}}
+
+	err := frt.AddOutPut(trackFile, "file", false)
+	if err != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+
+	lines, _ := frt.GetOutputLines("none")
+	if len(lines) != 1 {
+		t.Errorf("Got %d lines, but expected %d", len(lines), 1)
+	}
+}
+
 func TestGetOutputLinesSummaryUnValid(t *testing.T) {
 	frt := NewCsvOutputFormater(";", false)
 	trackFile1 := getTrackFileWithDifferentTime()
@@ -1377,3 +1487,53 @@ func getTrackFileWithTimeGaps() gpsabl.TrackFile {
 
 	return file
 }
+
+func TestFormatOutPutWithSensorData(t *testing.T) {
+	formater := NewCsvOutputFormater(";", true)
+	trackFile := getSimpleTrackFile()
+	trackFile.HeartRate = gpsabl.SensorValues{Minimum: 120, Average: 130.5, Maximum: 140, Samples: 3}
+
+	err := formater.AddOutPut(trackFile, "file", false)
+	if err != nil {
+		t.Errorf("Got a error but did not expect one. The error is: %s", err.Error())
+	}
+	ret, _ := formater.GetOutputLines(gpsabl.ADDITIONAL)
+
+	if strings.Contains(ret[0], "AverageHeartRate (bpm);") == false {
+		t.Errorf("The header does not contain the heart rate as expected. It is: %s", ret[0])
+	}
+
+	if strings.HasSuffix(ret[1], ";120.00;130.50;140.00;-;-;-;-;-;-;-;-;"+GetNewLine()) == false {
+		t.Errorf("The output does not contain the sensor values as expected. It is: %s", ret[1])
+	}
+
+	// The sum of sensor values is not valid
+	if strings.HasSuffix(ret[3], ";-;-;-;-;-;-;-;-;-;-;-;"+GetNewLine()) == false {
+		t.Errorf("The sum line does not contain the sensor values as expected. It is: %s", ret[3])
+	}
+
+	if strings.Contains(ret[4], ";120.00;130.50;140.00;") == false {
+		t.Errorf("The average line does not contain the sensor values as expected. It is: %s", ret[4])
+	}
+}
+
+func TestFormatOutPutWithActivity(t *testing.T) {
+	formater := NewCsvOutputFormater(";", true)
+	trackFile := getSimpleTrackFile()
+	trackFile.ActivityType = "Ride"
+	trackFile.Gear = "My Bike"
+
+	err := formater.AddOutPut(trackFile, "file", false)
+	if err != nil {
+		t.Errorf("Got a error but did not expect one. The error is: %s", err.Error())
+	}
+	ret, _ := formater.GetOutputLines(gpsabl.ADDITIONAL)
+
+	if strings.HasSuffix(ret[0], ";ActivityType;Gear;"+GetNewLine()) == false {
+		t.Errorf("The header does not contain the activity columns as expected. It is: %s", ret[0])
+	}
+
+	if strings.HasSuffix(ret[1], ";Ride;My Bike;"+GetNewLine()) == false {
+		t.Errorf("The output does not contain the activity values as expected. It is: %s", ret[1])
+	}
+}
@@ -0,0 +1,103 @@
+package csvtrackbl
+
+import (
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+// ConvertCsvTrack - Convert a csvtrackbl.CsvTrack to a gpsabl.TrackFile. Each track ID gets its own gpsabl.Track named by the ID,
+// and each segment ID within a track its own gpsabl.TrackSegment, in the order the IDs first appear in the file.
+// Points without elevation get the elevation of the point before, see gpsabl.FillMissingElevation
+func ConvertCsvTrack(content CsvTrack, filePath string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+
+	if len(content.Points) <= 0 {
+		return gpsabl.TrackFile{}, newEmptyCsvTrackFileError(filePath)
+	}
+
+	res := gpsabl.NewTrackFile(filePath)
+	for _, group := range groupByTrack(content.Points) {
+		track := gpsabl.Track{}
+		track.Name = group.id
+		for _, segment := range groupBySegment(group.points) {
+			seg, err := convertPoints(segment.points, correction, minimalMovingSpeed, minimalStepHight)
+			if err != nil {
+				return gpsabl.TrackFile{}, err
+			}
+			track.TrackSegments = append(track.TrackSegments, seg)
+		}
+
+		track.NumberOfSegments = len(track.TrackSegments)
+		gpsabl.FillTrackValues(&track)
+		res.Tracks = append(res.Tracks, track)
+	}
+
+	res.NumberOfTracks = len(res.Tracks)
+	gpsabl.FillTrackFileValues(&res)
+
+	return res, nil
+}
+
+// pointGroup - The points with the same track or segment ID
+type pointGroup struct {
+	id     string
+	points []CsvTrackPoint
+}
+
+func groupByTrack(points []CsvTrackPoint) []pointGroup {
+	return groupPoints(points, func(point CsvTrackPoint) string { return point.TrackID })
+}
+
+func groupBySegment(points []CsvTrackPoint) []pointGroup {
+	return groupPoints(points, func(point CsvTrackPoint) string { return point.SegmentID })
+}
+
+// groupPoints - Group the points by the ID, the groups are in the order the IDs first appear
+func groupPoints(points []CsvTrackPoint, getID func(CsvTrackPoint) string) []pointGroup {
+	var ret []pointGroup
+	indexes := make(map[string]int)
+	for _, point := range points {
+		id := getID(point)
+		index, found := indexes[id]
+		if !found {
+			index = len(ret)
+			indexes[id] = index
+			ret = append(ret, pointGroup{id: id})
+		}
+		ret[index].points = append(ret[index].points, point)
+	}
+
+	return ret
+}
+
+func convertPoints(points []CsvTrackPoint, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackSegment, error) {
+	res := gpsabl.TrackSegment{}
+	pointCount := len(points)
+	basic := make([]gpsabl.TrackPoint, pointCount)
+	for i, point := range points {
+		pnt := gpsabl.TrackPoint{}
+		pnt.Latitude = float32(point.Latitude)
+		pnt.Longitude = float32(point.Longitude)
+		pnt.Elevation = point.Elevation
+		pnt.ElevationMissing = !point.ElevationValid
+		pnt.Time = point.Time
+		pnt.TimeValid = point.TimeValid
+		basic[i] = pnt
+	}
+	gpsabl.FillMissingElevation(basic)
+
+	ret := gpsabl.FillDistances(basic)
+
+	err := gpsabl.FillValuesTrackPointArray(ret, correction, minimalMovingSpeed, minimalStepHight)
+	if err != nil {
+		return gpsabl.TrackSegment{}, err
+	}
+
+	res.TrackPoints = ret
+	gpsabl.FillTrackSegmentValues(&res)
+
+	return res, nil
+}
@@ -0,0 +1,93 @@
+package csvtrackbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func TestConvertCsvTrack(t *testing.T) {
+	content, err := ReadCsvTrack(testhelper.GetValidFile("csvtrack", "01.csv"), getValid01Settings())
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	file, convErr := ConvertCsvTrack(content, "my/path.csv", gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	if file.NumberOfTracks != 1 || file.Tracks[0].NumberOfSegments != 1 {
+		t.Errorf("The file has %d tracks with %d segments, but should have 1 track with 1 segment", file.NumberOfTracks, file.Tracks[0].NumberOfSegments)
+	}
+
+	// The file was created out of testdata/valid-gpx/12.gpx, the missing elevations must not change the MinimumAltitude
+	if file.MinimumAltitude != 245 || file.MaximumAltitude != 304 {
+		t.Errorf("The MinimumAltitude and MaximumAltitude are %f and %f, but should be %f and %f", file.MinimumAltitude, file.MaximumAltitude, 245.0, 304.0)
+	}
+
+	if file.StartTime.Format(time.RFC3339) != "2014-08-22T16:48:52Z" || file.EndTime.Format(time.RFC3339) != "2014-08-22T17:19:42Z" {
+		t.Errorf("The StartTime and EndTime are %s and %s, but should be %s and %s", file.StartTime.Format(time.RFC3339), file.EndTime.Format(time.RFC3339), "2014-08-22T16:48:52Z", "2014-08-22T17:19:42Z")
+	}
+
+	pnt := file.Tracks[0].TrackSegments[0].TrackPoints[20]
+	if pnt.ElevationMissing != true || pnt.Elevation != file.Tracks[0].TrackSegments[0].TrackPoints[19].Elevation {
+		t.Errorf("The point without elevation has the Elevation %f and ElevationMissing %t", pnt.Elevation, pnt.ElevationMissing)
+	}
+}
+
+func TestConvertCsvTrackWithTrackAndSegmentColumns(t *testing.T) {
+	content, err := ReadCsvTrack(testhelper.GetValidFile("csvtrack", "02.csv"), getValid02Settings())
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	file, convErr := ConvertCsvTrack(content, "my/path.csv", gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	if file.NumberOfTracks != 2 {
+		t.Fatalf("The NumberOfTracks is %d, but should be %d", file.NumberOfTracks, 2)
+	}
+
+	first := file.Tracks[0]
+	if first.Name != "A" || first.NumberOfSegments != 2 || len(first.TrackSegments[0].TrackPoints) != 3 || len(first.TrackSegments[1].TrackPoints) != 2 {
+		t.Errorf("The first track is named \"%s\" and has %d segments, but should be named \"A\" with 2 segments of 3 and 2 points", first.Name, first.NumberOfSegments)
+	}
+
+	second := file.Tracks[1]
+	if second.Name != "B" || second.NumberOfSegments != 1 || len(second.TrackSegments[0].TrackPoints) != 3 {
+		t.Errorf("The second track is named \"%s\" and has %d segments, but should be named \"B\" with 1 segment of 3 points", second.Name, second.NumberOfSegments)
+	}
+
+	if file.EndTime.Format(time.RFC3339) != "2014-08-22T17:52:05Z" {
+		t.Errorf("The EndTime is %s, but should be %s", file.EndTime.Format(time.RFC3339), "2014-08-22T17:52:05Z")
+	}
+}
+
+func TestGroupPointsKeepsOrder(t *testing.T) {
+	points := []CsvTrackPoint{{TrackID: "2"}, {TrackID: "1"}, {TrackID: "2"}}
+	groups := groupByTrack(points)
+
+	if len(groups) != 2 || groups[0].id != "2" || len(groups[0].points) != 2 || groups[1].id != "1" {
+		t.Errorf("The groups are %v, but should be the IDs 2 and 1", groups)
+	}
+}
+
+func TestConvertEmptyCsvTrack(t *testing.T) {
+	_, convErr := ConvertCsvTrack(CsvTrack{}, "my/path.csv", gpsabl.NO, 0.3, 10.0)
+	switch convErr.(type) {
+	case *EmptyCsvTrackFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *EmptyCsvTrackFileError, got \"%v\"", convErr)
+	}
+}
@@ -0,0 +1,151 @@
+package csvtrackbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"strings"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+const CsvTrackBuffer gpsabl.InputFileType = "CsvTrackBuffer"
+
+// The file extension this Reader can read
+const FileExtension string = ".csv"
+
+// CsvTrackFile - The struct to handle *.csv files with track points, as written by lab instruments or custom loggers.
+// The reader only handles files when the Settings contain a ColumnMapping
+type CsvTrackFile struct {
+	gpsabl.TrackFile
+	input gpsabl.InputFile
+	// Settings - The settings used to read the files. Set them before getting readers with NewReader
+	Settings Settings
+}
+
+// NewCsvTrackFile - Constructor for the CsvTrackFile struct
+func NewCsvTrackFile(filePath string, settings Settings) CsvTrackFile {
+	csvTrack := CsvTrackFile{}
+	csvTrack.FilePath = filePath
+	csvTrack.input = *gpsabl.NewInputFileWithPath(filePath)
+	csvTrack.Settings = settings
+
+	return csvTrack
+}
+
+// NewReader - Get a new reader for csv track files that will read the data in the given gpsabl.InputFile
+// Implement the gpsabl.TrackReader interface for *.csv files
+func (csvTrack *CsvTrackFile) NewReader(data gpsabl.InputFile) gpsabl.TrackReader {
+	newCsvTrack := CsvTrackFile{}
+	newCsvTrack.input = data
+	newCsvTrack.Settings = csvTrack.Settings
+	if data.Type == gpsabl.FilePath {
+		newCsvTrack.FilePath = data.Name
+	}
+
+	return &newCsvTrack
+}
+
+// ReadTracks - Read the *.csv from the input from FilePath or InputFile.Buffer, and return a gpsabl.TrackFile struct that contains all information
+// Implement the gpsabl.TrackReader interface for *.csv files
+func (csvTrack *CsvTrackFile) ReadTracks(correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	var err error
+	var ret gpsabl.TrackFile
+	if csvTrack.input.Type == gpsabl.FilePath {
+		ret, err = ReadCsvTrackFile(csvTrack.FilePath, csvTrack.Settings, correction, minimalMovingSpeed, minimalStepHight)
+	} else if csvTrack.input.Type == CsvTrackBuffer {
+		var buffer []byte
+		buffer, err = csvTrack.input.ReadContent()
+		if err == nil {
+			ret, err = ReadBuffer(buffer, csvTrack.input.Name, csvTrack.Settings, correction, minimalMovingSpeed, minimalStepHight)
+		}
+	} else {
+		err = gpsabl.NewUnKnownInputFileTypeError(csvTrack.input.Name)
+	}
+
+	if err == nil {
+		csvTrack.TrackFile = ret
+	}
+
+	return ret, err
+}
+
+// CheckInputFile - Check if a gpsabl.InputFile can be handled by the CsvTrackFile reader
+// Implement the gpsabl.TrackReader interface for *.csv files
+func (csvTrack *CsvTrackFile) CheckInputFile(input gpsabl.InputFile) bool {
+	if input.Type == CsvTrackBuffer {
+		return true
+	} else if input.Type == gpsabl.FilePath && csvTrack.CheckFile(input.Name) {
+		return true
+	}
+
+	return false
+}
+
+// ReadBuffer - Read the csv track data from a buffer, and return a gpsabl.TrackFile struct that contains all information
+func ReadBuffer(buffer []byte, name string, settings Settings, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	content, readErr := readCsvTrackBuffer(buffer, name, settings)
+	if readErr != nil {
+		return gpsabl.TrackFile{}, readErr
+	}
+	ret, convertError := ConvertCsvTrack(content, name, correction, minimalMovingSpeed, minimalStepHight)
+	if convertError != nil {
+		return gpsabl.TrackFile{}, convertError
+	}
+
+	return ret, nil
+}
+
+// CheckFile - Check if a file can be read by the CsvTrackFile "class". This is only the case for *.csv files when a column mapping is configured
+// Implement the gpsabl.TrackReader interface for *.csv files
+func (csvTrack *CsvTrackFile) CheckFile(path string) bool {
+	if csvTrack.Settings.IsConfigured() && strings.HasSuffix(strings.ToLower(path), FileExtension) {
+		return true
+	}
+
+	return false
+}
+
+// CheckBuffer - Check if a buffer can be read by he CsvTrackFile "class". This is the case if a column mapping is configured and
+// the first line contains the mapped columns
+// Implement the gpsabl.TrackReader interface for *.csv files
+func (csvTrack *CsvTrackFile) CheckBuffer(buffer []byte) bool {
+	return isCsvTrackBuffer(buffer, csvTrack.Settings)
+}
+
+// NewInputFileForBuffer - Get a new InputFile for a buffer containing a csv track files content
+// Implement the gpsabl.TrackReader interface for *.csv files
+func (csvTrack *CsvTrackFile) NewInputFileForBuffer(buffer []byte, name string) *gpsabl.InputFile {
+	file := gpsabl.InputFile{}
+	file.Name = name
+	file.Type = CsvTrackBuffer
+	file.Buffer = buffer
+
+	return &file
+}
+
+// GetValidFileExtensions - Get a list of file extensions this reader can read
+// Implement the gpsabl.TrackReader interface for *.csv files
+func (csvTrack *CsvTrackFile) GetValidFileExtensions() []string {
+
+	extensions := []string{FileExtension}
+
+	return extensions
+}
+
+// ReadCsvTrackFile - Reads a *.csv file with the given settings
+func ReadCsvTrackFile(filePath string, settings Settings, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	content, fileError := ReadCsvTrack(filePath, settings)
+
+	if fileError != nil {
+		return gpsabl.TrackFile{}, fileError
+	}
+
+	ret, convertError := ConvertCsvTrack(content, filePath, correction, minimalMovingSpeed, minimalStepHight)
+	if convertError != nil {
+		return gpsabl.TrackFile{}, convertError
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,169 @@
+package csvtrackbl
+
+import (
+	"fmt"
+	"testing"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+func TestTrackReaderInValidCorrectionParameter(t *testing.T) {
+	csvTrack := NewCsvTrackFile(testhelper.GetValidFile("csvtrack", "01.csv"), getValid01Settings())
+
+	_, err := csvTrack.ReadTracks("asdfg", 0.3, 10.0)
+	if err != nil {
+		switch ty := err.(type) {
+		case *gpsabl.CorrectionParameterNotKnownError:
+			fmt.Println("OK")
+		default:
+			t.Errorf("The Error ReadTracks gave is of the wrong type. The type is %v", ty)
+		}
+	} else {
+		t.Errorf("ReadTracks did not return a error, but was expected")
+	}
+}
+
+func TestReadAllValidCsvTrackDueInterface(t *testing.T) {
+	for name, settings := range map[string]Settings{"01.csv": getValid01Settings(), "02.csv": getValid02Settings()} {
+		csvTrackFile := NewCsvTrackFile(testhelper.GetValidFile("csvtrack", name), settings)
+		iCsvTrack := gpsabl.TrackReader(&csvTrackFile)
+
+		track, err := iCsvTrack.ReadTracks("none", 0.3, 10.0)
+		if err != nil {
+			t.Errorf("Got the error \"%s\" while reading file %s.", err.Error(), testhelper.GetValidFile("csvtrack", name))
+		}
+		if track.Distance <= 0.0 {
+			t.Errorf("The track.Distance is %f but should not be less then %f", track.Distance, 0.0)
+		}
+	}
+}
+
+func TestReadAllInValidCsvTrackDueInterface(t *testing.T) {
+	for _, name := range []string{"01.csv", "02.csv", "03.csv"} {
+		csvTrackFile := NewCsvTrackFile(testhelper.GetInvalidFile("csvtrack", name), getValid01Settings())
+		iCsvTrack := gpsabl.TrackReader(&csvTrackFile)
+
+		_, err := iCsvTrack.ReadTracks("none", 0.3, 10.0)
+		if err == nil {
+			t.Errorf("Got no error while reading file %s.", testhelper.GetInvalidFile("csvtrack", name))
+		}
+	}
+}
+
+func TestNewReaderWithValidFilePath(t *testing.T) {
+	csvTrack := CsvTrackFile{Settings: getValid01Settings()}
+	file := testhelper.GetValidFile("csvtrack", "01.csv")
+	checkRes := csvTrack.CheckFile(file)
+	input := *gpsabl.NewInputFileWithPath(file)
+
+	sut := csvTrack.NewReader(input)
+
+	if checkRes != true {
+		t.Errorf("CsvTrackFile can not read %s", file)
+	}
+
+	if csvTrack.CheckInputFile(input) != true {
+		t.Errorf("CsvTrackFile can not read the input %s", file)
+	}
+
+	trk, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+	if err != nil {
+		t.Errorf("Got error \"%s\" but expect none", err)
+	}
+
+	if trk.FilePath != file {
+		t.Errorf("The trk.FilePath %s is not the given path %s", trk.FilePath, file)
+	}
+}
+
+func TestNewReaderWithValidBuffer(t *testing.T) {
+	csvTrack := CsvTrackFile{Settings: getValid02Settings()}
+	buffer, createErr := testhelper.GetValidFileBuffer("csvtrack", "02.csv")
+	if createErr != nil {
+		t.Fatalf("Got error \"%s\" while creating the input buffer", createErr)
+	}
+	checkRes := csvTrack.CheckBuffer(buffer)
+	input := *csvTrack.NewInputFileForBuffer(buffer, "Buffer 1")
+
+	sut := csvTrack.NewReader(input)
+
+	if checkRes != true {
+		t.Errorf("CsvTrackFile can not read the buffer")
+	}
+
+	if csvTrack.CheckInputFile(input) != true {
+		t.Errorf("CsvTrackFile can not read the input buffer")
+	}
+
+	trk, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+	if err != nil {
+		t.Errorf("Got error \"%s\" but expect none", err)
+	}
+
+	if trk.FilePath != "Buffer 1" || trk.NumberOfTracks != 2 {
+		t.Errorf("The trk.FilePath %s is not the given path %s, or the settings are not used by the reader", trk.FilePath, "Buffer 1")
+	}
+}
+
+func TestNewReaderWithUnknownInputType(t *testing.T) {
+	csvTrack := CsvTrackFile{}
+	input := gpsabl.InputFile{Type: "Unknown", Name: "Buffer 1"}
+	sut := csvTrack.NewReader(input)
+
+	if csvTrack.CheckInputFile(input) != false {
+		t.Errorf("CsvTrackFile can read an input of unknown type")
+	}
+
+	_, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+	switch err.(type) {
+	case *gpsabl.UnKnownInputFileTypeError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *gpsabl.UnKnownInputFileTypeError, got \"%v\"", err)
+	}
+}
+
+func TestCheckBuffer(t *testing.T) {
+	csvTrack := CsvTrackFile{Settings: getValid01Settings()}
+	gpx, _ := testhelper.GetValidGpxBuffer("01.gpx")
+	if csvTrack.CheckBuffer(gpx) != false {
+		t.Errorf("CsvTrackFile can read a gpx buffer")
+	}
+
+	invalid, _ := testhelper.GetInvalidFileBuffer("csvtrack", "01.csv")
+	if csvTrack.CheckBuffer(invalid) != false {
+		t.Errorf("CsvTrackFile can read a buffer without the mapped columns")
+	}
+}
+
+func TestCheckFile(t *testing.T) {
+	csvTrack := CsvTrackFile{}
+
+	if csvTrack.CheckFile("my/path/file.csv") != false {
+		t.Errorf("CsvTrackFile can read *.csv files without a column mapping")
+	}
+
+	csvTrack.Settings = getValid01Settings()
+	if csvTrack.CheckFile("my/path/file.CSV") != true {
+		t.Errorf("CsvTrackFile can not read *.CSV files")
+	}
+
+	if csvTrack.CheckFile("my/path/file.gpx") != false {
+		t.Errorf("CsvTrackFile can read *.gpx files")
+	}
+}
+
+func TestGetValidFileExtensions(t *testing.T) {
+	csvTrack := CsvTrackFile{}
+	extensions := csvTrack.GetValidFileExtensions()
+
+	if len(extensions) != 1 || extensions[0] != FileExtension {
+		t.Errorf("The valid file extensions are %v, but should be [%s]", extensions, FileExtension)
+	}
+}
@@ -0,0 +1,189 @@
+package csvtrackbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CsvTrack - Represents the track points of a csv file
+type CsvTrack struct {
+	Points []CsvTrackPoint
+}
+
+// CsvTrackPoint - Represents one line of a csv file
+type CsvTrackPoint struct {
+	Latitude  float64
+	Longitude float64
+	// Elevation - The elevation in m
+	Elevation float32
+	// ElevationValid - False when the elevation column is not mapped or empty
+	ElevationValid bool
+	Time           time.Time
+	TimeValid      bool
+	// SegmentID - The value of the segment column, empty when not mapped
+	SegmentID string
+	// TrackID - The value of the track column, empty when not mapped
+	TrackID string
+}
+
+// ReadCsvTrack - Read a csv file with the given settings
+func ReadCsvTrack(fileName string, settings Settings) (CsvTrack, error) {
+	fileBuffer, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return CsvTrack{}, err
+	}
+	return readCsvTrackBuffer(fileBuffer, fileName, settings)
+}
+
+func readCsvTrackBuffer(fileBuffer []byte, fileName string, settings Settings) (CsvTrack, error) {
+	reader := newCsvReader(fileBuffer, settings)
+
+	ret := CsvTrack{}
+	var columns columnIndexes
+	for i := 0; ; i++ {
+		record, errRead := reader.Read()
+		if errRead == io.EOF {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if errRead != nil {
+			return CsvTrack{}, newCsvTrackPointError(fileName, line, errRead.Error())
+		}
+		if i == 0 {
+			var missing string
+			columns, missing = settings.getColumnIndexes(trimBOM(record))
+			if missing != "" {
+				return CsvTrack{}, newCsvTrackFileError(fileName, missing)
+			}
+			if settings.HasHeader {
+				continue
+			}
+		}
+		if isEmptyRecord(record) {
+			continue
+		}
+
+		point, reason := parseRecord(record, columns, settings)
+		if reason != "" {
+			return CsvTrack{}, newCsvTrackPointError(fileName, line, reason)
+		}
+		ret.Points = append(ret.Points, point)
+	}
+
+	if len(ret.Points) == 0 {
+		return CsvTrack{}, newEmptyCsvTrackFileError(fileName)
+	}
+
+	return ret, nil
+}
+
+// isCsvTrackBuffer - Check if the first line of the buffer fits to the mapping of the settings
+func isCsvTrackBuffer(fileBuffer []byte, settings Settings) bool {
+	if !settings.IsConfigured() {
+		return false
+	}
+
+	record, err := newCsvReader(fileBuffer, settings).Read()
+	if err != nil {
+		return false
+	}
+	record = trimBOM(record)
+	columns, missing := settings.getColumnIndexes(record)
+	if missing != "" {
+		return false
+	}
+	if settings.HasHeader {
+		return true
+	}
+
+	_, errLat := strconv.ParseFloat(strings.TrimSpace(record[columns.latitude]), 64)
+	_, errLon := strconv.ParseFloat(strings.TrimSpace(record[columns.longitude]), 64)
+	return errLat == nil && errLon == nil
+}
+
+func newCsvReader(fileBuffer []byte, settings Settings) *csv.Reader {
+	reader := csv.NewReader(bytes.NewReader(fileBuffer))
+	reader.Comma = settings.Separator
+	if reader.Comma == 0 {
+		reader.Comma = ','
+	}
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	return reader
+}
+
+// trimBOM - Remove the byte order mark some programs write at the start of the first field
+func trimBOM(record []string) []string {
+	if len(record) > 0 {
+		record[0] = strings.TrimPrefix(record[0], "\ufeff")
+	}
+
+	return record
+}
+
+func isEmptyRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func getField(record []string, index int) string {
+	if index < 0 || index >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[index])
+}
+
+// parseRecord - Parse a line of the file. Return the reason when the line can not be parsed
+func parseRecord(record []string, columns columnIndexes, settings Settings) (CsvTrackPoint, string) {
+	point := CsvTrackPoint{}
+	var err error
+	point.Latitude, err = strconv.ParseFloat(getField(record, columns.latitude), 64)
+	if err != nil || point.Latitude < -90 || point.Latitude > 90 {
+		return CsvTrackPoint{}, fmt.Sprintf("\"%s\" is not a valid latitude", getField(record, columns.latitude))
+	}
+	point.Longitude, err = strconv.ParseFloat(getField(record, columns.longitude), 64)
+	if err != nil || point.Longitude < -180 || point.Longitude > 180 {
+		return CsvTrackPoint{}, fmt.Sprintf("\"%s\" is not a valid longitude", getField(record, columns.longitude))
+	}
+
+	if elevation := getField(record, columns.elevation); elevation != "" {
+		value, errEle := strconv.ParseFloat(elevation, 64)
+		if errEle != nil {
+			return CsvTrackPoint{}, fmt.Sprintf("\"%s\" is not a valid elevation", elevation)
+		}
+		point.Elevation = float32(value * settings.getElevationFactor())
+		point.ElevationValid = true
+	}
+
+	if timeValue := getField(record, columns.time); timeValue != "" {
+		value, errTime := settings.parseTime(timeValue)
+		if errTime != nil {
+			return CsvTrackPoint{}, fmt.Sprintf("\"%s\" does not match the time layout \"%s\"", timeValue, settings.TimeLayout)
+		}
+		point.Time = value
+		point.TimeValid = true
+	}
+
+	point.SegmentID = getField(record, columns.segment)
+	point.TrackID = getField(record, columns.track)
+
+	return point, ""
+}
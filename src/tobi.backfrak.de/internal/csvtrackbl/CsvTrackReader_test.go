@@ -0,0 +1,140 @@
+package csvtrackbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func getValid01Settings() Settings {
+	return NewSettings(ColumnMapping{Latitude: "Lat", Longitude: "Lon", Elevation: "Height", Time: "Timestamp"})
+}
+
+func getValid02Settings() Settings {
+	settings := NewSettings(ColumnMapping{Track: "1", Segment: "2", Time: "3", Latitude: "4", Longitude: "5", Elevation: "6"})
+	settings.Separator = ';'
+	settings.TimeLayout = UnixMilliTimeLayout
+	settings.ElevationUnit = FEET
+	settings.HasHeader = false
+
+	return settings
+}
+
+func TestReadValidCsvTrack01(t *testing.T) {
+	content, err := ReadCsvTrack(testhelper.GetValidFile("csvtrack", "01.csv"), getValid01Settings())
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(content.Points) != 413 {
+		t.Fatalf("The number of Points is %d, but should be %d", len(content.Points), 413)
+	}
+
+	first := content.Points[0]
+	if first.Latitude != 45.273245 || first.Longitude != 13.715185 || first.Elevation != 271 || first.ElevationValid != true {
+		t.Errorf("The first point is %v", first)
+	}
+
+	if first.TimeValid != true || first.Time.Format(time.RFC3339) != "2014-08-22T16:48:52Z" {
+		t.Errorf("The Time is %s, but should be %s", first.Time.Format(time.RFC3339), "2014-08-22T16:48:52Z")
+	}
+
+	if content.Points[10].ElevationValid != false || content.Points[20].ElevationValid != false {
+		t.Errorf("The points with empty elevation have a valid elevation")
+	}
+
+	if first.TrackID != "" || first.SegmentID != "" {
+		t.Errorf("The point has a track or segment ID, but the mapping has no such columns")
+	}
+}
+
+func TestReadValidCsvTrack02(t *testing.T) {
+	content, err := ReadCsvTrack(testhelper.GetValidFile("csvtrack", "02.csv"), getValid02Settings())
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(content.Points) != 8 {
+		t.Fatalf("The number of Points is %d, but should be %d", len(content.Points), 8)
+	}
+
+	first := content.Points[0]
+	if first.TrackID != "A" || first.SegmentID != "1" || content.Points[3].SegmentID != "2" || content.Points[5].TrackID != "B" {
+		t.Errorf("The track and segment IDs are not as expected")
+	}
+
+	if first.Elevation < 270.99 || first.Elevation > 271.01 {
+		t.Errorf("The Elevation is %f, but should be %f", first.Elevation, 271.0)
+	}
+
+	if first.Time.Format(time.RFC3339) != "2014-08-22T16:48:52Z" {
+		t.Errorf("The Time is %s, but should be %s", first.Time.Format(time.RFC3339), "2014-08-22T16:48:52Z")
+	}
+}
+
+func TestReadCsvTrackMissingColumn(t *testing.T) {
+	_, err := ReadCsvTrack(testhelper.GetInvalidFile("csvtrack", "01.csv"), getValid01Settings())
+	switch ty := err.(type) {
+	case *CsvTrackFileError:
+		if ty.Column != "Lat" {
+			t.Errorf("The Column is \"%s\", but should be \"%s\"", ty.Column, "Lat")
+		}
+	default:
+		t.Errorf("Expected a *CsvTrackFileError, got \"%v\"", err)
+	}
+}
+
+func TestReadCsvTrackInvalidTime(t *testing.T) {
+	_, err := ReadCsvTrack(testhelper.GetInvalidFile("csvtrack", "02.csv"), getValid01Settings())
+	switch ty := err.(type) {
+	case *CsvTrackPointError:
+		if ty.Line != 3 {
+			t.Errorf("The Line is %d, but should be %d", ty.Line, 3)
+		}
+	default:
+		t.Errorf("Expected a *CsvTrackPointError, got \"%v\"", err)
+	}
+}
+
+func TestReadCsvTrackWithoutPoints(t *testing.T) {
+	_, err := ReadCsvTrack(testhelper.GetInvalidFile("csvtrack", "03.csv"), getValid01Settings())
+	switch err.(type) {
+	case *EmptyCsvTrackFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *EmptyCsvTrackFileError, got \"%v\"", err)
+	}
+}
+
+func TestReadCsvTrackNotExistingFile(t *testing.T) {
+	_, err := ReadCsvTrack(testhelper.GetValidFile("csvtrack", "not-existing.csv"), getValid01Settings())
+	switch err.(type) {
+	case *os.PathError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *os.PathError, got \"%v\"", err)
+	}
+}
+
+func TestIsCsvTrackBuffer(t *testing.T) {
+	buffer01, _ := testhelper.GetValidFileBuffer("csvtrack", "01.csv")
+	buffer02, _ := testhelper.GetValidFileBuffer("csvtrack", "02.csv")
+	if isCsvTrackBuffer(buffer01, getValid01Settings()) != true || isCsvTrackBuffer(buffer02, getValid02Settings()) != true {
+		t.Errorf("A valid buffer is not detected")
+	}
+
+	if isCsvTrackBuffer(buffer01, getValid02Settings()) != false || isCsvTrackBuffer(buffer02, getValid01Settings()) != false {
+		t.Errorf("A buffer that does not fit the settings is detected")
+	}
+
+	if isCsvTrackBuffer(buffer01, Settings{}) != false {
+		t.Errorf("A buffer is detected without a mapping")
+	}
+}
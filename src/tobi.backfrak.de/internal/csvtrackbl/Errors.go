@@ -0,0 +1,93 @@
+package csvtrackbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import "fmt"
+
+// CsvTrackFileError - Error when a csv file does not contain the columns of the ColumnMapping
+type CsvTrackFileError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+	// Column - The column of the mapping that was not found
+	Column string
+}
+
+func (e *CsvTrackFileError) Error() string { // Implement the Error Interface for the CsvTrackFileError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newCsvTrackFileError - Get a new CsvTrackFileError struct
+func newCsvTrackFileError(fileName string, column string) *CsvTrackFileError {
+	return &CsvTrackFileError{fmt.Sprintf("The file \"%s\" does not contain the column \"%s\" of the csv mapping", fileName, column), fileName, column}
+}
+
+// EmptyCsvTrackFileError - Error when trying to load a csv file that does not contain any track point
+type EmptyCsvTrackFileError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+}
+
+func (e *EmptyCsvTrackFileError) Error() string { // Implement the Error Interface for the EmptyCsvTrackFileError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newEmptyCsvTrackFileError - Get a new EmptyCsvTrackFileError struct
+func newEmptyCsvTrackFileError(fileName string) *EmptyCsvTrackFileError {
+	return &EmptyCsvTrackFileError{fmt.Sprintf("The file \"%s\" does not contain any track point.", fileName), fileName}
+}
+
+// CsvTrackPointError - Error when a line of a csv file can not be parsed with the settings
+type CsvTrackPointError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+	// Line - The number of the line that could not be parsed
+	Line int
+}
+
+func (e *CsvTrackPointError) Error() string { // Implement the Error Interface for the CsvTrackPointError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newCsvTrackPointError - Get a new CsvTrackPointError struct
+func newCsvTrackPointError(fileName string, line int, reason string) *CsvTrackPointError {
+	return &CsvTrackPointError{fmt.Sprintf("The track point in line %d of the file \"%s\" can not be parsed: %s", line, fileName, reason), fileName, line}
+}
+
+// ColumnMappingError - Error when a csv mapping text can not be parsed
+type ColumnMappingError struct {
+	err string
+	// Mapping - The text that could not be parsed
+	Mapping string
+}
+
+func (e *ColumnMappingError) Error() string { // Implement the Error Interface for the ColumnMappingError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// NewColumnMappingError - Get a new ColumnMappingError struct
+func NewColumnMappingError(mapping string, reason string) *ColumnMappingError {
+	return &ColumnMappingError{fmt.Sprintf("The csv mapping \"%s\" is not valid: %s", mapping, reason), mapping}
+}
+
+// SettingNotKnownError - Error when a separator, time layout or unit of the csv settings is not known
+type SettingNotKnownError struct {
+	err string
+	// Setting - The name of the setting
+	Setting string
+	// Value - The value that is not known
+	Value string
+}
+
+func (e *SettingNotKnownError) Error() string { // Implement the Error Interface for the SettingNotKnownError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// NewSettingNotKnownError - Get a new SettingNotKnownError struct
+func NewSettingNotKnownError(setting string, value string, validValues string) *SettingNotKnownError {
+	return &SettingNotKnownError{fmt.Sprintf("The value \"%s\" of the csv setting \"%s\" is not known. Possible values are [%s]", value, setting, validValues), setting, value}
+}
@@ -0,0 +1,69 @@
+package csvtrackbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"strings"
+	"testing"
+)
+
+func TestCsvTrackFileError(t *testing.T) {
+	path := "/some/sample/path"
+	err := newCsvTrackFileError(path, "Height")
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of CsvTrackFileError does not contain the expected Path")
+	}
+
+	if err.File != path || err.Column != "Height" {
+		t.Errorf("The CsvTrackFileError.File or Column does not match the expected value")
+	}
+}
+
+func TestEmptyCsvTrackFileError(t *testing.T) {
+	path := "/some/sample/path"
+	err := newEmptyCsvTrackFileError(path)
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of EmptyCsvTrackFileError does not contain the expected Path")
+	}
+
+	if err.File != path {
+		t.Errorf("The EmptyCsvTrackFileError.File does not match the expected value")
+	}
+}
+
+func TestCsvTrackPointError(t *testing.T) {
+	path := "/some/sample/path"
+	err := newCsvTrackPointError(path, 8, "no number")
+	if strings.Contains(err.Error(), path) == false || strings.Contains(err.Error(), "no number") == false {
+		t.Errorf("The error message of CsvTrackPointError does not contain the expected Path and reason")
+	}
+
+	if err.File != path || err.Line != 8 {
+		t.Errorf("The CsvTrackPointError.File or Line does not match the expected value")
+	}
+}
+
+func TestColumnMappingError(t *testing.T) {
+	mapping := "lat=1"
+	err := NewColumnMappingError(mapping, "no lon")
+	if strings.Contains(err.Error(), mapping) == false {
+		t.Errorf("The error message of ColumnMappingError does not contain the expected mapping")
+	}
+
+	if err.Mapping != mapping {
+		t.Errorf("The ColumnMappingError.Mapping does not match the expected value")
+	}
+}
+
+func TestSettingNotKnownError(t *testing.T) {
+	err := NewSettingNotKnownError("unit", "yard", "m ft")
+	if strings.Contains(err.Error(), "yard") == false || strings.Contains(err.Error(), "unit") == false {
+		t.Errorf("The error message of SettingNotKnownError does not contain the expected setting and value")
+	}
+
+	if err.Setting != "unit" || err.Value != "yard" {
+		t.Errorf("The SettingNotKnownError.Setting or Value does not match the expected value")
+	}
+}
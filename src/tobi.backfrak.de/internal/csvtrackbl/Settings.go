@@ -0,0 +1,263 @@
+package csvtrackbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ElevationUnit - The unit of the elevation column
+type ElevationUnit string
+
+const (
+	// METER - The elevation is given in m
+	METER ElevationUnit = "m"
+	// FEET - The elevation is given in ft
+	FEET ElevationUnit = "ft"
+)
+
+const feetToMeter float64 = 0.3048
+
+// UnixTimeLayout - The time layout for times given as seconds since 1970-01-01 UTC
+const UnixTimeLayout string = "unix"
+
+// UnixMilliTimeLayout - The time layout for times given as milliseconds since 1970-01-01 UTC
+const UnixMilliTimeLayout string = "unixms"
+
+// DefaultTimeLayout - The time layout used when nothing else is configured
+const DefaultTimeLayout string = time.RFC3339
+
+// DefaultSeparator - The separator used when nothing else is configured
+const DefaultSeparator string = ","
+
+// The keys of a mapping text like "lat=Latitude,lon=Longitude,ele=3"
+const (
+	latitudeKey  string = "lat"
+	longitudeKey string = "lon"
+	elevationKey string = "ele"
+	timeKey      string = "time"
+	segmentKey   string = "segment"
+	trackKey     string = "track"
+)
+
+// GetValidElevationUnits - Get the valid values for the ElevationUnit
+func GetValidElevationUnits() []ElevationUnit {
+	return []ElevationUnit{METER, FEET}
+}
+
+// GetValidElevationUnitsString - Get the valid values for the ElevationUnit as one string
+func GetValidElevationUnitsString() string {
+	ret := ""
+	for _, str := range GetValidElevationUnits() {
+		ret = fmt.Sprintf("%s %s", str, ret)
+	}
+
+	return ret
+}
+
+// CheckValidElevationUnit - Check if a string is a valid ElevationUnit
+func CheckValidElevationUnit(given ElevationUnit) bool {
+	for _, str := range GetValidElevationUnits() {
+		if str == given {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetValidMappingKeys - Get the keys that can be used in a mapping text
+func GetValidMappingKeys() []string {
+	return []string{latitudeKey, longitudeKey, elevationKey, timeKey, segmentKey, trackKey}
+}
+
+// ColumnMapping - Tells which column of a csv file contains which value. A column is given by its header name or by its
+// number, starting with 1. Latitude and Longitude are mandatory, all other columns are optional
+type ColumnMapping struct {
+	Latitude  string
+	Longitude string
+	Elevation string
+	Time      string
+	// Segment - The column with a segment ID. A new gpsabl.TrackSegment is created for each ID
+	Segment string
+	// Track - The column with a track ID. A new gpsabl.Track is created for each ID
+	Track string
+}
+
+// IsEmpty - True if no column is mapped
+func (mapping ColumnMapping) IsEmpty() bool {
+	return mapping == ColumnMapping{}
+}
+
+// ParseColumnMapping - Parse a mapping text like "lat=Latitude,lon=Longitude,ele=3,time=Timestamp,segment=Lap,track=Id"
+func ParseColumnMapping(text string) (ColumnMapping, error) {
+	ret := ColumnMapping{}
+	for _, part := range strings.Split(text, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		keyValue := strings.SplitN(part, "=", 2)
+		if len(keyValue) != 2 || strings.TrimSpace(keyValue[1]) == "" {
+			return ColumnMapping{}, NewColumnMappingError(text, fmt.Sprintf("\"%s\" is not like key=column", part))
+		}
+
+		key := strings.ToLower(strings.TrimSpace(keyValue[0]))
+		field := ret.getField(key)
+		if field == nil {
+			return ColumnMapping{}, NewColumnMappingError(text, fmt.Sprintf("the key \"%s\" is not known. Possible keys are %v", key, GetValidMappingKeys()))
+		}
+		if *field != "" {
+			return ColumnMapping{}, NewColumnMappingError(text, fmt.Sprintf("the key \"%s\" is given twice", key))
+		}
+		*field = strings.TrimSpace(keyValue[1])
+	}
+
+	if ret.Latitude == "" || ret.Longitude == "" {
+		return ColumnMapping{}, NewColumnMappingError(text, "the lat and lon columns are mandatory")
+	}
+
+	return ret, nil
+}
+
+func (mapping *ColumnMapping) getField(key string) *string {
+	switch key {
+	case latitudeKey:
+		return &mapping.Latitude
+	case longitudeKey:
+		return &mapping.Longitude
+	case elevationKey:
+		return &mapping.Elevation
+	case timeKey:
+		return &mapping.Time
+	case segmentKey:
+		return &mapping.Segment
+	case trackKey:
+		return &mapping.Track
+	}
+
+	return nil
+}
+
+// ParseSeparator - Get the separator rune of a text. "tab" and "\t" are the tab separator
+func ParseSeparator(text string) (rune, error) {
+	if text == "tab" || text == "\\t" || text == "\t" {
+		return '\t', nil
+	}
+
+	if utf8.RuneCountInString(text) != 1 || text == "\"" || text == "\n" || text == "\r" {
+		return 0, NewSettingNotKnownError("separator", text, "a single character or tab")
+	}
+
+	separator, _ := utf8.DecodeRuneInString(text)
+	return separator, nil
+}
+
+// Settings - The settings to read a csv file with track points
+type Settings struct {
+	Mapping   ColumnMapping
+	Separator rune
+	// TimeLayout - A go time layout like "2006-01-02 15:04:05", or UnixTimeLayout or UnixMilliTimeLayout. Times without zone are UTC
+	TimeLayout    string
+	ElevationUnit ElevationUnit
+	// HasHeader - True if the first line contains the column names, and no values
+	HasHeader bool
+}
+
+// NewSettings - Get Settings with the default separator, time layout and unit, for a file with header line
+func NewSettings(mapping ColumnMapping) Settings {
+	return Settings{Mapping: mapping, Separator: ',', TimeLayout: DefaultTimeLayout, ElevationUnit: METER, HasHeader: true}
+}
+
+// IsConfigured - True if the settings contain a column mapping, so csv files can be read
+func (settings Settings) IsConfigured() bool {
+	return !settings.Mapping.IsEmpty()
+}
+
+// columnIndexes - The index of the mapped columns in a line, -1 for not mapped columns
+type columnIndexes struct {
+	latitude  int
+	longitude int
+	elevation int
+	time      int
+	segment   int
+	track     int
+}
+
+// getColumnIndexes - Resolve the mapped columns with the header fields. A number is used as column number, starting with 1.
+// Return the name of the first column that can not be resolved if there is one
+func (settings Settings) getColumnIndexes(header []string) (columnIndexes, string) {
+	ret := columnIndexes{}
+	mapping := settings.Mapping
+	targets := []*int{&ret.latitude, &ret.longitude, &ret.elevation, &ret.time, &ret.segment, &ret.track}
+	columns := []string{mapping.Latitude, mapping.Longitude, mapping.Elevation, mapping.Time, mapping.Segment, mapping.Track}
+	for i, column := range columns {
+		*targets[i] = -1
+		if column == "" {
+			continue
+		}
+
+		index := getColumnIndex(column, header, settings.HasHeader)
+		if index < 0 {
+			return ret, column
+		}
+		*targets[i] = index
+	}
+
+	return ret, ""
+}
+
+func getColumnIndex(column string, header []string, hasHeader bool) int {
+	if number, err := strconv.Atoi(column); err == nil {
+		if number >= 1 && number <= len(header) {
+			return number - 1
+		}
+		return -1
+	}
+
+	if !hasHeader {
+		return -1
+	}
+
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), column) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// parseTime - Parse a time value with the TimeLayout
+func (settings Settings) parseTime(value string) (time.Time, error) {
+	switch settings.TimeLayout {
+	case UnixTimeLayout, UnixMilliTimeLayout:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if settings.TimeLayout == UnixMilliTimeLayout {
+			number = number / 1000
+		}
+		seconds := int64(number)
+		return time.Unix(seconds, int64((number-float64(seconds))*1e9)).UTC().Round(time.Millisecond), nil
+	case "":
+		return time.Parse(DefaultTimeLayout, value)
+	}
+
+	return time.Parse(settings.TimeLayout, value)
+}
+
+// getElevationFactor - Get the factor that converts the elevation to m
+func (settings Settings) getElevationFactor() float64 {
+	if settings.ElevationUnit == FEET {
+		return feetToMeter
+	}
+
+	return 1
+}
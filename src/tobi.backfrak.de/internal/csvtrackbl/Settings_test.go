@@ -0,0 +1,110 @@
+package csvtrackbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestParseColumnMapping(t *testing.T) {
+	mapping, err := ParseColumnMapping("lat=Latitude, lon=2,ELE=Height,time=Timestamp,segment=Lap,track=Id")
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	expected := ColumnMapping{Latitude: "Latitude", Longitude: "2", Elevation: "Height", Time: "Timestamp", Segment: "Lap", Track: "Id"}
+	if mapping != expected {
+		t.Errorf("The mapping is %v, but should be %v", mapping, expected)
+	}
+
+	if mapping.IsEmpty() != false || (ColumnMapping{}).IsEmpty() != true {
+		t.Errorf("IsEmpty does not work as expected")
+	}
+}
+
+func TestParseColumnMappingInvalid(t *testing.T) {
+	for _, text := range []string{"", "lat=1", "lat=1,lon=2,speed=3", "lat=1,lon=2,lat=3", "lat=1,lon", "lat=1,lon="} {
+		_, err := ParseColumnMapping(text)
+		switch err.(type) {
+		case *ColumnMappingError:
+			fmt.Println("OK")
+		default:
+			t.Errorf("Expected a *ColumnMappingError for \"%s\", got \"%v\"", text, err)
+		}
+	}
+}
+
+func TestParseSeparator(t *testing.T) {
+	for text, expected := range map[string]rune{",": ',', ";": ';', "tab": '\t', "\\t": '\t', "|": '|'} {
+		separator, err := ParseSeparator(text)
+		if err != nil {
+			t.Errorf("Got error \"%s\" but expected none", err)
+		}
+		if separator != expected {
+			t.Errorf("The separator for \"%s\" is %q, but should be %q", text, separator, expected)
+		}
+	}
+
+	for _, text := range []string{"", ";;", "\""} {
+		_, err := ParseSeparator(text)
+		switch err.(type) {
+		case *SettingNotKnownError:
+			fmt.Println("OK")
+		default:
+			t.Errorf("Expected a *SettingNotKnownError for \"%s\", got \"%v\"", text, err)
+		}
+	}
+}
+
+func TestCheckValidElevationUnit(t *testing.T) {
+	if CheckValidElevationUnit(METER) != true || CheckValidElevationUnit(FEET) != true {
+		t.Errorf("A valid ElevationUnit is not valid")
+	}
+
+	if CheckValidElevationUnit(ElevationUnit("yard")) != false {
+		t.Errorf("A invalid ElevationUnit is valid")
+	}
+}
+
+func TestGetColumnIndexes(t *testing.T) {
+	settings := NewSettings(ColumnMapping{Latitude: "lat", Longitude: "3", Elevation: "Height"})
+	columns, missing := settings.getColumnIndexes([]string{"Time", "Lat", "Lon", " height "})
+	if missing != "" {
+		t.Fatalf("The column \"%s\" is missing", missing)
+	}
+
+	if columns.latitude != 1 || columns.longitude != 2 || columns.elevation != 3 || columns.time != -1 {
+		t.Errorf("The column indexes are %v", columns)
+	}
+
+	_, missing = settings.getColumnIndexes([]string{"Time", "Lat", "Lon"})
+	if missing != "Height" {
+		t.Errorf("The missing column is \"%s\", but should be \"%s\"", missing, "Height")
+	}
+
+	settings.HasHeader = false
+	_, missing = settings.getColumnIndexes([]string{"1", "2", "3", "4"})
+	if missing != "lat" {
+		t.Errorf("The missing column is \"%s\", but should be \"%s\"", missing, "lat")
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	settings := NewSettings(ColumnMapping{})
+	expected := "2014-08-22T16:48:52Z"
+	values := map[string]string{"": expected, UnixTimeLayout: "1408726132", UnixMilliTimeLayout: "1408726132000", "02.01.2006 15:04:05": "22.08.2014 16:48:52"}
+	for layout, value := range values {
+		settings.TimeLayout = layout
+		res, err := settings.parseTime(value)
+		if err != nil {
+			t.Errorf("Got error \"%s\" but expected none", err)
+		}
+		if res.Format(time.RFC3339) != expected {
+			t.Errorf("The time for layout \"%s\" is %s, but should be %s", layout, res.Format(time.RFC3339), expected)
+		}
+	}
+}
@@ -0,0 +1,6 @@
+module tobi.backfrak.de/internal/csvtrackbl
+
+require "tobi.backfrak.de/internal/gpsabl" v0.0.0
+replace  "tobi.backfrak.de/internal/gpsabl" v0.0.0 => "../gpsabl"
+require "tobi.backfrak.de/internal/testhelper" v0.0.0
+replace  "tobi.backfrak.de/internal/testhelper" v0.0.0 => "../testhelper"
\ No newline at end of file
@@ -0,0 +1,41 @@
+package exportbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import "fmt"
+
+// ExportFormatError - Error when the activities.csv of an export directory can not be used
+type ExportFormatError struct {
+	err string
+	// File - The path to the activities.csv that caused this error
+	File string
+}
+
+func (e *ExportFormatError) Error() string { // Implement the Error Interface for the ExportFormatError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newExportFormatError - Get a new ExportFormatError struct
+func newExportFormatError(fileName string, reason string) *ExportFormatError {
+	return &ExportFormatError{fmt.Sprintf("The file \"%s\" is not a valid activity list: %s", fileName, reason), fileName}
+}
+
+// ActivityFileError - Error when the track file of an activity can not be found or read
+type ActivityFileError struct {
+	err string
+	// File - The path to the track file that caused this error
+	File string
+	// ActivityID - The ID of the activity the file belongs to
+	ActivityID string
+}
+
+func (e *ActivityFileError) Error() string { // Implement the Error Interface for the ActivityFileError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newActivityFileError - Get a new ActivityFileError struct
+func newActivityFileError(fileName string, activityID string, reason string) *ActivityFileError {
+	return &ActivityFileError{fmt.Sprintf("The file \"%s\" of activity \"%s\" can not be used: %s", fileName, activityID, reason), fileName, activityID}
+}
@@ -0,0 +1,46 @@
+package exportbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportFormatError(t *testing.T) {
+	path := "/some/sample/activities.csv"
+	err := newExportFormatError(path, "no Filename column")
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of ExportFormatError does not contain the expected Path")
+	}
+
+	if strings.Contains(err.Error(), "no Filename column") == false {
+		t.Errorf("The error message of ExportFormatError does not contain the expected reason")
+	}
+
+	if err.File != path {
+		t.Errorf("The ExportFormatError.File does not match the expected value")
+	}
+}
+
+func TestActivityFileError(t *testing.T) {
+	path := "/some/sample/activities/1234.gpx.gz"
+	err := newActivityFileError(path, "1234", "not found")
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of ActivityFileError does not contain the expected Path")
+	}
+
+	if strings.Contains(err.Error(), "1234") == false {
+		t.Errorf("The error message of ActivityFileError does not contain the expected activity ID")
+	}
+
+	if err.File != path {
+		t.Errorf("The ActivityFileError.File does not match the expected value")
+	}
+
+	if err.ActivityID != "1234" {
+		t.Errorf("The ActivityFileError.ActivityID does not match the expected value")
+	}
+}
@@ -0,0 +1,163 @@
+package exportbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+// ActivitiesFileName - The name of the activity list in the root directory of a bulk export
+const ActivitiesFileName string = "activities.csv"
+
+// The column names of the activity list, as used by the Strava bulk export
+const (
+	idColumn          string = "Activity ID"
+	nameColumn        string = "Activity Name"
+	typeColumn        string = "Activity Type"
+	descriptionColumn string = "Activity Description"
+	gearColumn        string = "Activity Gear"
+	fileNameColumn    string = "Filename"
+)
+
+// Activity - One row of the activity list of a bulk export
+type Activity struct {
+	ID          string
+	Name        string
+	Type        string
+	Description string
+	Gear        string
+	// FileName - The path of the track file, relative to the export root directory. Empty for activities without a file
+	FileName string
+}
+
+// HasFile - True if a track file belongs to the activity
+func (activity Activity) HasFile() bool {
+	return activity.FileName != ""
+}
+
+// GetMetadata - Get the gpsabl.TrackMetadata of the activity
+func (activity Activity) GetMetadata() gpsabl.TrackMetadata {
+	return gpsabl.TrackMetadata{
+		Name:         activity.Name,
+		Description:  activity.Description,
+		ActivityType: activity.Type,
+		Gear:         activity.Gear,
+	}
+}
+
+// IsExportDirectory - Check if the path is the root directory of a bulk export, that contains an activities.csv
+func IsExportDirectory(path string) bool {
+	dirInfo, errDir := os.Stat(path)
+	if errDir != nil || !dirInfo.IsDir() {
+		return false
+	}
+
+	fileInfo, errFile := os.Stat(filepath.Join(path, ActivitiesFileName))
+	if errFile != nil || fileInfo.IsDir() {
+		return false
+	}
+
+	return true
+}
+
+// ReadActivities - Read the activities.csv in the root directory of a bulk export
+func ReadActivities(exportDir string) ([]Activity, error) {
+	listPath := filepath.Join(exportDir, ActivitiesFileName)
+	file, errOpen := os.Open(listPath)
+	if errOpen != nil {
+		return nil, errOpen
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+
+	header, errHeader := reader.Read()
+	if errHeader == io.EOF {
+		return nil, newExportFormatError(listPath, "the file is empty")
+	} else if errHeader != nil {
+		return nil, newExportFormatError(listPath, errHeader.Error())
+	}
+
+	columns := getColumnIndexes(header)
+	if _, found := columns[fileNameColumn]; !found {
+		return nil, newExportFormatError(listPath, "there is no \""+fileNameColumn+"\" column")
+	}
+
+	var ret []Activity
+	for {
+		record, errRead := reader.Read()
+		if errRead == io.EOF {
+			break
+		} else if errRead != nil {
+			return nil, newExportFormatError(listPath, errRead.Error())
+		}
+
+		ret = append(ret, Activity{
+			ID:          getColumnValue(record, columns, idColumn),
+			Name:        getColumnValue(record, columns, nameColumn),
+			Type:        getColumnValue(record, columns, typeColumn),
+			Description: getColumnValue(record, columns, descriptionColumn),
+			Gear:        getColumnValue(record, columns, gearColumn),
+			FileName:    getColumnValue(record, columns, fileNameColumn),
+		})
+	}
+
+	return ret, nil
+}
+
+// GetActivityInputFiles - Get the InputFiles of the track file that belongs to an activity. Compressed files are expanded,
+// and the metadata of the activity is attached to all InputFiles
+func GetActivityInputFiles(validReaders []gpsabl.TrackReader, exportDir string, activity Activity) ([]gpsabl.InputFile, error) {
+	path := filepath.Join(exportDir, filepath.FromSlash(activity.FileName))
+	if _, errStat := os.Stat(path); errStat != nil {
+		return nil, newActivityFileError(path, activity.ID, "the file does not exist")
+	}
+
+	res, inputs, errExpand := gpsabl.GetInputFilesFromPath(validReaders, path)
+	if errExpand != nil {
+		return nil, errExpand
+	}
+	if res == false {
+		return nil, newActivityFileError(path, activity.ID, "no reader can handle the file")
+	}
+
+	metadata := activity.GetMetadata()
+	for i := range inputs {
+		inputs[i].Metadata = metadata
+	}
+
+	return inputs, nil
+}
+
+// getColumnIndexes - Map the column names of the header to the column index. The first column with a name wins,
+// since the Strava export contains some column names twice
+func getColumnIndexes(header []string) map[string]int {
+	ret := make(map[string]int)
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		if _, found := ret[name]; !found {
+			ret[name] = i
+		}
+	}
+
+	return ret
+}
+
+func getColumnValue(record []string, columns map[string]int, column string) string {
+	index, found := columns[column]
+	if !found || index >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[index])
+}
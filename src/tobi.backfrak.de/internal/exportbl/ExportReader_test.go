@@ -0,0 +1,166 @@
+package exportbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/gpxbl"
+	"tobi.backfrak.de/internal/tcxbl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func getValidReaders() []gpsabl.TrackReader {
+	gpx := gpxbl.NewGpxFile("")
+	tcx := tcxbl.NewTcxFile("")
+
+	return []gpsabl.TrackReader{&gpx, &tcx}
+}
+
+func TestIsExportDirectory(t *testing.T) {
+	if IsExportDirectory(testhelper.GetValidFile("export", "01")) != true {
+		t.Errorf("The valid export directory is not detected")
+	}
+
+	if IsExportDirectory(filepath.Join(testhelper.GetValidFile("export", "01"), ActivitiesFileName)) != false {
+		t.Errorf("The activities.csv is detected as export directory")
+	}
+
+	if IsExportDirectory(filepath.Join(testhelper.GetValidFile("export", "01"), "activities")) != false {
+		t.Errorf("A directory without activities.csv is detected as export directory")
+	}
+
+	if IsExportDirectory(testhelper.GetValidFile("export", "not-existing")) != false {
+		t.Errorf("A not existing directory is detected as export directory")
+	}
+}
+
+func TestReadActivities(t *testing.T) {
+	activities, err := ReadActivities(testhelper.GetValidFile("export", "01"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(activities) != 3 {
+		t.Fatalf("Got %d activities, but expected %d", len(activities), 3)
+	}
+
+	first := activities[0]
+	if first.ID != "1001" || first.Name != "Morning Ride" || first.Type != "Ride" || first.Gear != "My Bike" {
+		t.Errorf("The first activity is %v", first)
+	}
+
+	if first.Description != "Along the river, then home" {
+		t.Errorf("The Description is \"%s\", but should be \"%s\"", first.Description, "Along the river, then home")
+	}
+
+	if first.FileName != "activities/1001.gpx.gz" || first.HasFile() != true {
+		t.Errorf("The FileName is \"%s\", but should be \"%s\"", first.FileName, "activities/1001.gpx.gz")
+	}
+
+	if activities[1].Gear != "" || activities[1].Description != "" {
+		t.Errorf("The second activity has values that are not in the list: %v", activities[1])
+	}
+
+	if activities[2].HasFile() != false {
+		t.Errorf("The third activity has the file \"%s\", but should have none", activities[2].FileName)
+	}
+}
+
+func TestReadActivitiesWithoutFilenameColumn(t *testing.T) {
+	_, err := ReadActivities(testhelper.GetInvalidFile("export", "02"))
+	switch err.(type) {
+	case *ExportFormatError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *ExportFormatError, got \"%v\"", err)
+	}
+}
+
+func TestReadActivitiesNotExisting(t *testing.T) {
+	_, err := ReadActivities(testhelper.GetInvalidFile("export", "not-existing"))
+	if err == nil {
+		t.Errorf("ReadActivities did not return a error, but was expected")
+	}
+}
+
+func TestGetActivityInputFiles(t *testing.T) {
+	exportDir := testhelper.GetValidFile("export", "01")
+	activities, _ := ReadActivities(exportDir)
+
+	for _, activity := range activities[:2] {
+		inputs, err := GetActivityInputFiles(getValidReaders(), exportDir, activity)
+		if err != nil {
+			t.Fatalf("Got error \"%s\" but expected none", err)
+		}
+
+		if len(inputs) != 1 {
+			t.Fatalf("Got %d input files for %s, but expected %d", len(inputs), activity.FileName, 1)
+		}
+
+		if inputs[0].Metadata != activity.GetMetadata() {
+			t.Errorf("The metadata of the input file is %v, but should be %v", inputs[0].Metadata, activity.GetMetadata())
+		}
+	}
+}
+
+func TestGetActivityInputFilesReadTracks(t *testing.T) {
+	exportDir := testhelper.GetValidFile("export", "01")
+	activities, _ := ReadActivities(exportDir)
+	readers := getValidReaders()
+
+	inputs, err := GetActivityInputFiles(readers, exportDir, activities[0])
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	reader := readers[0].NewReader(inputs[0])
+	file, readErr := reader.ReadTracks("none", 0.3, 10.0)
+	if readErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", readErr)
+	}
+	gpsabl.ApplyTrackMetadata(&file, inputs[0].Metadata)
+
+	if file.Name != "Morning Ride" {
+		t.Errorf("The Name is \"%s\", but should be \"%s\"", file.Name, "Morning Ride")
+	}
+
+	if file.Tracks[0].GetActivityType() != "Ride" || file.Tracks[0].GetGear() != "My Bike" {
+		t.Errorf("The activity of the track is \"%s\" with \"%s\", but should be \"%s\" with \"%s\"",
+			file.Tracks[0].GetActivityType(), file.Tracks[0].GetGear(), "Ride", "My Bike")
+	}
+}
+
+func TestGetActivityInputFilesMissingFile(t *testing.T) {
+	exportDir := testhelper.GetInvalidFile("export", "01")
+	activities, errRead := ReadActivities(exportDir)
+	if errRead != nil {
+		t.Fatalf("Got error \"%s\" but expected none", errRead)
+	}
+
+	_, err := GetActivityInputFiles(getValidReaders(), exportDir, activities[0])
+	switch err.(type) {
+	case *ActivityFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *ActivityFileError, got \"%v\"", err)
+	}
+}
+
+func TestGetActivityInputFilesUnknownType(t *testing.T) {
+	exportDir := testhelper.GetValidFile("export", "01")
+	activity := Activity{ID: "1", FileName: ActivitiesFileName}
+
+	_, err := GetActivityInputFiles(getValidReaders(), exportDir, activity)
+	switch err.(type) {
+	case *ActivityFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *ActivityFileError, got \"%v\"", err)
+	}
+}
@@ -0,0 +1,10 @@
+module tobi.backfrak.de/internal/exportbl
+
+require "tobi.backfrak.de/internal/gpsabl" v0.0.0
+replace  "tobi.backfrak.de/internal/gpsabl" v0.0.0 => "../gpsabl"
+require "tobi.backfrak.de/internal/testhelper" v0.0.0
+replace  "tobi.backfrak.de/internal/testhelper" v0.0.0 => "../testhelper"
+require "tobi.backfrak.de/internal/gpxbl" v0.0.0
+replace  "tobi.backfrak.de/internal/gpxbl" v0.0.0 => "../gpxbl"
+require "tobi.backfrak.de/internal/tcxbl" v0.0.0
+replace  "tobi.backfrak.de/internal/tcxbl" v0.0.0 => "../tcxbl"
\ No newline at end of file
@@ -0,0 +1,57 @@
+package fitbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import "fmt"
+
+// FitFileError - Error when trying to load something that is no fit file
+type FitFileError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+}
+
+func (e *FitFileError) Error() string { // Implement the Error Interface for the FitFileError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newFitFileError - Get a new FitFileError struct
+func newFitFileError(fileName string) *FitFileError {
+	return &FitFileError{fmt.Sprintf("The file \"%s\" is not a fit file", fileName), fileName}
+}
+
+// EmptyFitFileError - Error when trying to load a fit file that does not contain any valid track
+type EmptyFitFileError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+}
+
+func (e *EmptyFitFileError) Error() string { // Implement the Error Interface for the EmptyFitFileError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newEmptyFitFileError - Get a new EmptyFitFileError struct
+func newEmptyFitFileError(fileName string) *EmptyFitFileError {
+	return &EmptyFitFileError{fmt.Sprintf("The file \"%s\" does not contain any valid tracks.", fileName), fileName}
+}
+
+// CorruptFitFileError - Error when a fit file is truncated or its CRC does not match the content
+type CorruptFitFileError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+	// Reason - Tells what is wrong with the file
+	Reason string
+}
+
+func (e *CorruptFitFileError) Error() string { // Implement the Error Interface for the CorruptFitFileError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newCorruptFitFileError - Get a new CorruptFitFileError struct
+func newCorruptFitFileError(fileName string, reason string) *CorruptFitFileError {
+	return &CorruptFitFileError{fmt.Sprintf("The fit file \"%s\" is corrupt: %s", fileName, reason), fileName, reason}
+}
@@ -0,0 +1,52 @@
+package fitbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"strings"
+	"testing"
+)
+
+func TestFitFileErrorStruct(t *testing.T) {
+
+	path := "/some/sample/path"
+	err := newFitFileError(path)
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of FitFileError does not contain the expected Path")
+	}
+
+	if err.File != path {
+		t.Errorf("The FitFileError.File does not match the expected value")
+	}
+}
+
+func TestEmptyFitFileError(t *testing.T) {
+	path := "/some/sample/path"
+	err := newEmptyFitFileError(path)
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of EmptyFitFileError does not contain the expected Path")
+	}
+
+	if err.File != path {
+		t.Errorf("The EmptyFitFileError.File does not match the expected value")
+	}
+}
+
+func TestCorruptFitFileError(t *testing.T) {
+	path := "/some/sample/path"
+	reason := "the file is truncated"
+	err := newCorruptFitFileError(path, reason)
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of CorruptFitFileError does not contain the expected Path")
+	}
+
+	if strings.Contains(err.Error(), reason) == false {
+		t.Errorf("The error message of CorruptFitFileError does not contain the expected reason")
+	}
+
+	if err.File != path {
+		t.Errorf("The CorruptFitFileError.File does not match the expected value")
+	}
+}
@@ -0,0 +1,199 @@
+package fitbl
+
+import (
+	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+// ConvertFit - Convert a fitbl.Fit to a gpsabl.TrackFile. Each session becomes a gpsabl.Track, each lap a gpsabl.TrackSegment
+func ConvertFit(fit Fit, filePath string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+
+	res := gpsabl.NewTrackFile(filePath)
+
+	sessions := getSessions(fit)
+	sessionRecords := splitRecords(getPositionRecords(fit), getSessionStartTimes(sessions))
+	for i, session := range sessions {
+		laps := getSessionLaps(fit, session)
+		track, err := convertSession(session, laps, sessionRecords[i], correction, minimalMovingSpeed, minimalStepHight)
+		if err != nil {
+			return gpsabl.TrackFile{}, err
+		}
+
+		// Add only tracks that contain segments
+		if len(track.TrackSegments) > 0 {
+			res.Tracks = append(res.Tracks, track)
+		}
+	}
+
+	if len(res.Tracks) <= 0 {
+		return gpsabl.TrackFile{}, newEmptyFitFileError(filePath)
+	}
+
+	res.NumberOfTracks = len(res.Tracks)
+	gpsabl.FillTrackFileValues(&res)
+
+	return res, nil
+}
+
+func convertSession(session Session, laps []Lap, records []Record, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.Track, error) {
+	res := gpsabl.Track{}
+	lapRecords := splitRecords(records, getLapStartTimes(laps))
+
+	for i, lap := range laps {
+		seg, err := convertLap(lap, lapRecords[i], correction, minimalMovingSpeed, minimalStepHight)
+		if err != nil {
+			return gpsabl.Track{}, err
+		}
+
+		// Laps without records and without distance are just noise, like the automatic lap at the end of some devices
+		if len(seg.TrackPoints) > 0 || seg.Distance > 0 {
+			res.TrackSegments = append(res.TrackSegments, seg)
+		}
+	}
+
+	res.Name = session.StartTime.Format(time.RFC3339)
+	res.NumberOfSegments = len(res.TrackSegments)
+	gpsabl.FillTrackValues(&res)
+
+	return res, nil
+}
+
+func convertLap(lap Lap, records []Record, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackSegment, error) {
+	res := gpsabl.TrackSegment{}
+
+	// A lap with a single record is a segment with one point, unless the lap message has a distance that tells more
+	if len(records) > 1 || (len(records) > 0 && lap.TotalDistance <= 0) {
+		retArr, err := convertRecords(records, correction, minimalMovingSpeed, minimalStepHight)
+		if err != nil {
+			return gpsabl.TrackSegment{}, err
+		}
+		res.TrackPoints = retArr
+		gpsabl.FillTrackSegmentValues(&res)
+	} else if lap.TotalDistance > 0 {
+		// No usable track points, so the summary is taken from the lap message
+		res.StartTime = lap.StartTime
+		res.Distance = lap.TotalDistance
+		res.HorizontalDistance = lap.TotalDistance
+		res.MovingTime = time.Duration(lap.TotalTimerTime * float64(time.Second))
+		res.EndTime = res.StartTime.Add(time.Duration(lap.TotalElapsedTime * float64(time.Second)))
+		res.TimeDataValid = true
+	}
+
+	return res, nil
+}
+
+func convertRecords(records []Record, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) ([]gpsabl.TrackPoint, error) {
+	pointCount := len(records)
+	basic := make([]gpsabl.TrackPoint, pointCount)
+	for i, record := range records {
+		basic[i] = convertBasicPointValues(record)
+	}
+
+	ret := gpsabl.FillDistances(basic)
+
+	err := gpsabl.FillValuesTrackPointArray(ret, correction, minimalMovingSpeed, minimalStepHight)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func convertBasicPointValues(record Record) gpsabl.TrackPoint {
+	pnt := gpsabl.TrackPoint{}
+	pnt.Latitude = record.Latitude
+	pnt.Longitude = record.Longitude
+	pnt.Elevation = record.Altitude
+	pnt.TimeValid = record.TimeValid
+	pnt.Time = record.Timestamp
+
+	return pnt
+}
+
+// getSessions - Get the sessions of the file. Files without session message are handled as one session containing all records
+func getSessions(fit Fit) []Session {
+	if len(fit.Sessions) > 0 {
+		return fit.Sessions
+	}
+
+	session := Session{}
+	session.StartTime = fit.Records[0].Timestamp
+	session.Timestamp = fit.Records[len(fit.Records)-1].Timestamp
+	session.NumLaps = len(fit.Laps)
+
+	return []Session{session}
+}
+
+// getSessionLaps - Get the laps of a session. Sessions without lap message are handled as one lap
+func getSessionLaps(fit Fit, session Session) []Lap {
+	var laps []Lap
+	if session.NumLaps > 0 && session.FirstLapIndex+session.NumLaps <= len(fit.Laps) {
+		laps = fit.Laps[session.FirstLapIndex : session.FirstLapIndex+session.NumLaps]
+	} else {
+		for _, lap := range fit.Laps {
+			if !lap.StartTime.Before(session.StartTime) && !lap.StartTime.After(session.Timestamp) {
+				laps = append(laps, lap)
+			}
+		}
+	}
+
+	if len(laps) > 0 {
+		return laps
+	}
+
+	lap := Lap{}
+	lap.StartTime = session.StartTime
+	lap.Timestamp = session.Timestamp
+
+	return []Lap{lap}
+}
+
+// getPositionRecords - Get the records of the file that contain a valid position
+func getPositionRecords(fit Fit) []Record {
+	var records []Record
+	for _, record := range fit.Records {
+		if record.PositionValid {
+			records = append(records, record)
+		}
+	}
+
+	return records
+}
+
+func getSessionStartTimes(sessions []Session) []time.Time {
+	ret := []time.Time{}
+	for _, session := range sessions {
+		ret = append(ret, session.StartTime)
+	}
+
+	return ret
+}
+
+func getLapStartTimes(laps []Lap) []time.Time {
+	ret := []time.Time{}
+	for _, lap := range laps {
+		ret = append(ret, lap.StartTime)
+	}
+
+	return ret
+}
+
+// splitRecords - Assign each record to the last session or lap that started before the record was taken
+func splitRecords(records []Record, startTimes []time.Time) [][]Record {
+	ret := make([][]Record, len(startTimes))
+	index := 0
+	for _, record := range records {
+		for record.TimeValid && index < len(startTimes)-1 && !record.Timestamp.Before(startTimes[index+1]) {
+			index++
+		}
+		ret[index] = append(ret[index], record)
+	}
+
+	return ret
+}
@@ -0,0 +1,154 @@
+package fitbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func TestConvertFitSessionsAndLaps(t *testing.T) {
+	fit, err := ReadFit(testhelper.GetValidFile("fit", "02.fit"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	file, convErr := ConvertFit(fit, "my/path.fit", gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	if file.NumberOfTracks != 2 {
+		t.Errorf("The NumberOfTracks is %d, but should be %d", file.NumberOfTracks, 2)
+	}
+
+	if file.FilePath != "my/path.fit" {
+		t.Errorf("The FilePath is %s, but should be %s", file.FilePath, "my/path.fit")
+	}
+
+	for _, track := range file.Tracks {
+		if track.NumberOfSegments != 4 {
+			t.Errorf("The NumberOfSegments of track %s is %d, but should be %d", track.Name, track.NumberOfSegments, 4)
+		}
+	}
+
+	if file.Tracks[1].Name != "2016-06-22T06:07:33Z" {
+		t.Errorf("The Name of the second track is %s, but should be %s", file.Tracks[1].Name, "2016-06-22T06:07:33Z")
+	}
+
+	points := 0
+	for _, track := range file.Tracks {
+		for _, seg := range track.TrackSegments {
+			points += len(seg.TrackPoints)
+		}
+	}
+	if points != len(fit.Records) {
+		t.Errorf("The file contains %d points, but %d records where read", points, len(fit.Records))
+	}
+}
+
+func TestConvertFitWithoutLapsAndSessions(t *testing.T) {
+	fit, err := ReadFit(testhelper.GetValidFile("fit", "03.fit"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	file, convErr := ConvertFit(fit, "my/path.fit", gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	if file.NumberOfTracks != 1 || file.Tracks[0].NumberOfSegments != 1 {
+		t.Errorf("Expected one track with one segment, got %d tracks", file.NumberOfTracks)
+	}
+
+	if len(file.Tracks[0].TrackSegments[0].TrackPoints) != len(fit.Records) {
+		t.Errorf("The segment contains %d points, but should contain %d", len(file.Tracks[0].TrackSegments[0].TrackPoints), len(fit.Records))
+	}
+}
+
+func TestConvertFitLapWithoutRecords(t *testing.T) {
+	start := time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC)
+	fit := Fit{}
+	fit.Records = []Record{Record{Timestamp: start, TimeValid: true}}
+	lap := Lap{}
+	lap.StartTime = start
+	lap.TotalDistance = 1250.0
+	lap.TotalTimerTime = 3000.0
+	lap.TotalElapsedTime = 3100.0
+	fit.Laps = []Lap{lap}
+
+	file, err := ConvertFit(fit, "my/path.fit", gpsabl.NO, 0.3, 10.0)
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if file.Distance != 1250.0 {
+		t.Errorf("The Distance is %f, but should be %f", file.Distance, 1250.0)
+	}
+
+	if file.MovingTime != 3000*time.Second {
+		t.Errorf("The MovingTime is %s, but should be %s", file.MovingTime, 3000*time.Second)
+	}
+
+	if file.EndTime != start.Add(3100*time.Second) {
+		t.Errorf("The EndTime is %s, but should be %s", file.EndTime, start.Add(3100*time.Second))
+	}
+}
+
+func TestConvertFitLapWithOneRecord(t *testing.T) {
+	start := time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC)
+	fit := Fit{}
+	fit.Records = []Record{
+		Record{Timestamp: start, TimeValid: true, Latitude: 50.0, Longitude: 8.0, PositionValid: true},
+		Record{Timestamp: start.Add(time.Second), TimeValid: true, Latitude: 50.001, Longitude: 8.0, PositionValid: true},
+		Record{Timestamp: start.Add(10 * time.Second), TimeValid: true, Latitude: 50.002, Longitude: 8.0, PositionValid: true}}
+	fit.Laps = []Lap{Lap{StartTime: start, Timestamp: start.Add(time.Second)}, Lap{StartTime: start.Add(10 * time.Second), Timestamp: start.Add(10 * time.Second)}}
+
+	file, err := ConvertFit(fit, "my/path.fit", gpsabl.NO, 0.3, 10.0)
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if file.Tracks[0].NumberOfSegments != 2 {
+		t.Fatalf("The NumberOfSegments is %d, but should be %d", file.Tracks[0].NumberOfSegments, 2)
+	}
+
+	if len(file.Tracks[0].TrackSegments[1].TrackPoints) != 1 {
+		t.Errorf("The second segment contains %d points, but should contain %d", len(file.Tracks[0].TrackSegments[1].TrackPoints), 1)
+	}
+}
+
+func TestConvertFitWithoutPositions(t *testing.T) {
+	start := time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC)
+	fit := Fit{}
+	fit.Records = []Record{Record{Timestamp: start, TimeValid: true}, Record{Timestamp: start.Add(time.Second), TimeValid: true}}
+
+	_, err := ConvertFit(fit, "my/path.fit", gpsabl.NO, 0.3, 10.0)
+	switch err.(type) {
+	case *EmptyFitFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *EmptyFitFileError, got \"%v\"", err)
+	}
+}
+
+func TestSplitRecords(t *testing.T) {
+	start := time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC)
+	records := []Record{}
+	for i := 0; i < 10; i++ {
+		records = append(records, Record{Timestamp: start.Add(time.Duration(i) * time.Minute), TimeValid: true})
+	}
+	laps := []Lap{Lap{StartTime: start}, Lap{StartTime: start.Add(4 * time.Minute)}, Lap{StartTime: start.Add(20 * time.Minute)}}
+
+	split := splitRecords(records, getLapStartTimes(laps))
+	if len(split[0]) != 4 || len(split[1]) != 6 || len(split[2]) != 0 {
+		t.Errorf("The records are split into %d, %d, %d, but should be 4, 6, 0", len(split[0]), len(split[1]), len(split[2]))
+	}
+}
@@ -0,0 +1,170 @@
+package fitbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+const FitBuffer gpsabl.InputFileType = "FitBuffer"
+
+// The file extension this Reader can read
+const FileExtension string = ".fit"
+
+// FitFile - The struct to handle *.fit data files
+type FitFile struct {
+	gpsabl.TrackFile
+	input gpsabl.InputFile
+}
+
+// NewFitFile - Constructor for the FitFile struct
+func NewFitFile(filePath string) FitFile {
+	fit := FitFile{}
+	fit.FilePath = filePath
+	fit.input = *gpsabl.NewInputFileWithPath(filePath)
+
+	return fit
+}
+
+// NewReader - Get a new reader for FIT files that will read the data in the given gpsabl.InputFile
+// Implement the gpsabl.TrackReader interface for *.fit files
+func (fit *FitFile) NewReader(data gpsabl.InputFile) gpsabl.TrackReader {
+	newFit := FitFile{}
+	newFit.input = data
+	if data.Type == gpsabl.FilePath {
+		newFit.FilePath = data.Name
+	}
+
+	return &newFit
+}
+
+// ReadTracks - Read the *.fit from the input from FilePath or InputFile.Buffer, and return a gpsabl.TrackFile struct that contains all information
+// Implement the gpsabl.TrackReader interface for *.fit files
+func (fit *FitFile) ReadTracks(correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	var err error
+	var ret gpsabl.TrackFile
+	if fit.input.Type == gpsabl.FilePath {
+		ret, err = ReadFitFile(fit.FilePath, correction, minimalMovingSpeed, minimalStepHight)
+	} else if fit.input.Type == FitBuffer {
+		var buffer []byte
+		buffer, err = fit.input.ReadContent()
+		if err == nil {
+			ret, err = ReadBuffer(buffer, fit.input.Name, correction, minimalMovingSpeed, minimalStepHight)
+		}
+	} else {
+		err = gpsabl.NewUnKnownInputFileTypeError(fit.input.Name)
+	}
+
+	if err == nil {
+		fit.TrackFile = ret
+	}
+
+	return ret, err
+}
+
+// CheckInputFile - Check if a gpsabl.InputFile can be handled by the FitFile reader
+// Implement the gpsabl.TrackReader interface for *.fit files
+func (fit *FitFile) CheckInputFile(input gpsabl.InputFile) bool {
+	if input.Type == FitBuffer {
+		return true
+	} else if input.Type == gpsabl.FilePath && fit.CheckFile(input.Name) {
+		return true
+	}
+
+	return false
+}
+
+// ReadBuffer - Read the fit data from a buffer, and return a gpsabl.TrackFile struct that contains all information
+func ReadBuffer(buffer []byte, name string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	content, readErr := readFITBuffer(buffer, name)
+	if readErr != nil {
+		return gpsabl.TrackFile{}, readErr
+	}
+	ret, convertError := ConvertFit(content, name, correction, minimalMovingSpeed, minimalStepHight)
+	if convertError != nil {
+		return gpsabl.TrackFile{}, convertError
+	}
+
+	return ret, nil
+}
+
+// CheckFile - Check if a file can be read by the FitFile "class"
+// Implement the gpsabl.TrackReader interface for *.fit files
+func (fit *FitFile) CheckFile(path string) bool {
+	if strings.HasSuffix(strings.ToLower(path), FileExtension) == true { // If the file is a *.fit, we can read it
+		return true
+	}
+
+	return false
+}
+
+// CheckBuffer - Check if a buffer can be read by he FitFile "class"
+// Implement the gpsabl.TrackReader interface for *.fit files
+func (fit *FitFile) CheckBuffer(buffer []byte) bool {
+	isFit, _ := checkFitHeader(buffer)
+
+	return isFit
+}
+
+// SniffContent - Check if the start of a file or buffer is a FIT file header
+// Implement the gpsabl.ContentSniffer interface for *.fit files
+func (fit *FitFile) SniffContent(head []byte) (bool, string) {
+	isFit, headerSize := checkFitHeader(head)
+	if !isFit {
+		return false, ""
+	}
+
+	return true, fmt.Sprintf("the %d byte FIT file header with the \".FIT\" signature", headerSize)
+}
+
+// GetDocumentSize - Get the size of the FIT file at the start of the buffer, from the header size, the data size in the header and the CRC
+// Implement the gpsabl.DocumentSizer interface for *.fit files
+func (fit *FitFile) GetDocumentSize(buffer []byte) (int, bool) {
+	isFit, headerSize := checkFitHeader(buffer)
+	if !isFit {
+		return 0, false
+	}
+
+	return headerSize + int(binary.LittleEndian.Uint32(buffer[4:8])) + 2, true
+}
+
+// NewInputFileForBuffer - Get a new InputFile for a buffer containing a fit files content
+// Implement the gpsabl.TrackReader interface for *.fit files
+func (fit *FitFile) NewInputFileForBuffer(buffer []byte, name string) *gpsabl.InputFile {
+	file := gpsabl.InputFile{}
+	file.Name = name
+	file.Type = FitBuffer
+	file.Buffer = buffer
+
+	return &file
+}
+
+// GetValidFileExtensions - Get a list of file extensions this reader can read
+// Implement the gpsabl.TrackReader interface for *.fit files
+func (fit *FitFile) GetValidFileExtensions() []string {
+
+	extensions := []string{FileExtension}
+
+	return extensions
+}
+
+// ReadFitFile - Reads a *.fit file
+func ReadFitFile(filePath string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	fit, fileError := ReadFit(filePath)
+	if fileError != nil {
+		return gpsabl.TrackFile{}, fileError
+	}
+
+	ret, convertError := ConvertFit(fit, filePath, correction, minimalMovingSpeed, minimalStepHight)
+	if convertError != nil {
+		return gpsabl.TrackFile{}, convertError
+	}
+
+	return ret, nil
+}
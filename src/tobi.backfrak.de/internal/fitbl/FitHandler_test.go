@@ -0,0 +1,254 @@
+package fitbl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+func TestTrackReader01(t *testing.T) {
+	fit := NewFitFile(testhelper.GetValidFile("fit", "01.fit"))
+
+	file, err := fit.ReadTracks("none", 0.3, 10.0)
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	// The file was created out of testdata/valid-tcx/01.tcx, so the values should be close to the tcx values
+	if gpsabl.RoundFloat64To2Digits(file.GetDistance()) != 6216.20 {
+		t.Errorf("The Distance is %f, but should be %f", file.GetDistance(), 6216.20)
+	}
+
+	if file.GetMovingTime() != 1103000000000 {
+		t.Errorf("The MovingTime is %d, but should be %d", file.GetMovingTime(), 1103000000000)
+	}
+
+	if file.GetStartTime().Format(time.RFC3339) != "2016-06-05T10:45:59Z" {
+		t.Errorf("The StartTime is %s, but should be %s", file.GetStartTime().Format(time.RFC3339), "2016-06-05T10:45:59Z")
+	}
+
+	if file.NumberOfTracks != 1 {
+		t.Errorf("The NumberOfTracks is %d, but should be %d", file.NumberOfTracks, 1)
+	}
+
+	if file.Tracks[0].NumberOfSegments != 7 {
+		t.Errorf("The NumberOfSegments is %d, but should be %d", file.Tracks[0].NumberOfSegments, 7)
+	}
+}
+
+func TestTrackReaderEmptyTrack(t *testing.T) {
+	fit := NewFitFile(testhelper.GetInvalidFile("fit", "03.fit"))
+
+	_, err := fit.ReadTracks("none", 0.3, 10.0)
+	if err != nil {
+		switch ty := err.(type) {
+		case *EmptyFitFileError:
+			fmt.Println("OK")
+		default:
+			t.Errorf("The Error ReadTracks gave is of the wrong type. The type is %v", ty)
+		}
+	} else {
+		t.Errorf("ReadTracks did not return a error, but was expected")
+	}
+}
+
+func TestTrackReaderInValidCorrectionParameter(t *testing.T) {
+	fit := NewFitFile(testhelper.GetValidFile("fit", "02.fit"))
+
+	_, err := fit.ReadTracks("asdfg", 0.3, 10.0)
+	if err != nil {
+		switch ty := err.(type) {
+		case *gpsabl.CorrectionParameterNotKnownError:
+			fmt.Println("OK")
+		default:
+			t.Errorf("The Error ReadTracks gave is of the wrong type. The type is %v", ty)
+		}
+	} else {
+		t.Errorf("ReadTracks did not return a error, but was expected")
+	}
+}
+
+func TestReadAllValidFitDueInterface(t *testing.T) {
+	files, _ := ioutil.ReadDir(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-fit"))
+
+	for _, file := range files {
+		if strings.HasSuffix(file.Name(), ".fit") {
+			if file.IsDir() == false {
+				fitFile := NewFitFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-fit", file.Name()))
+				iFit := gpsabl.TrackReader(&fitFile)
+
+				track, err := iFit.ReadTracks("none", 0.3, 10.0)
+				if err != nil {
+					t.Errorf("Got the error \"%s\" while reading file %s.", err.Error(), filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-fit", file.Name()))
+
+				}
+				if track.Distance <= 0.0 {
+					t.Errorf("The track.Distance is %f but should not be less then %f", track.Distance, 0.0)
+				}
+			}
+		}
+	}
+}
+
+func TestNewReaderWithValidFilePath(t *testing.T) {
+	fit := FitFile{}
+	file := testhelper.GetValidFile("fit", "01.fit")
+	checkRes := fit.CheckFile(file)
+	input := *gpsabl.NewInputFileWithPath(file)
+
+	sut := fit.NewReader(input)
+
+	if checkRes != true {
+		t.Errorf("FitFile can not read %s", file)
+	}
+
+	if fit.CheckInputFile(input) != true {
+		t.Errorf("FitFile can not read the input %s", file)
+	}
+
+	trk, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+	if err != nil {
+		t.Errorf("Got error \"%s\" but expect none", err)
+	}
+
+	if trk.FilePath != file {
+		t.Errorf("The trk.FilePath %s is not the given path %s", trk.FilePath, file)
+	}
+}
+
+func TestNewReaderWithValidBuffer(t *testing.T) {
+	fit := FitFile{}
+	buffer, createErr := testhelper.GetValidFileBuffer("fit", "01.fit")
+	name := "Buffer 1"
+	if createErr != nil {
+		t.Fatalf("Got error \"%s\" while creating the input buffer", createErr)
+	}
+	checkRes := fit.CheckBuffer(buffer)
+	input := *fit.NewInputFileForBuffer(buffer, name)
+
+	sut := fit.NewReader(input)
+
+	if checkRes != true {
+		t.Errorf("FitFile can not read from buffer")
+	}
+
+	if fit.CheckInputFile(input) != true {
+		t.Errorf("FitFile can not read the input buffer")
+	}
+
+	trk, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+	if err != nil {
+		t.Errorf("Got error \"%s\" but expect none", err)
+	}
+
+	if trk.FilePath != name {
+		t.Errorf("The trk.FilePath %s is not the given path %s", trk.FilePath, name)
+	}
+}
+
+func TestNewReaderWithInValidBuffer(t *testing.T) {
+	fit := FitFile{}
+	buffer, createErr := testhelper.GetInvalidFileBuffer("fit", "01.fit")
+	if createErr != nil {
+		t.Fatalf("Got error \"%s\" while creating the input buffer", createErr)
+	}
+	sut := fit.NewReader(*fit.NewInputFileForBuffer(buffer, "Buffer 1"))
+
+	_, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+	switch err.(type) {
+	case *CorruptFitFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *CorruptFitFileError, got \"%v\"", err)
+	}
+}
+
+func TestNewReaderWithUnknownInputType(t *testing.T) {
+	fit := FitFile{}
+	input := gpsabl.InputFile{Type: "Unknown", Name: "Buffer 1"}
+	sut := fit.NewReader(input)
+
+	if fit.CheckInputFile(input) != false {
+		t.Errorf("FitFile can read an input of unknown type")
+	}
+
+	_, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+	switch err.(type) {
+	case *gpsabl.UnKnownInputFileTypeError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *gpsabl.UnKnownInputFileTypeError, got \"%v\"", err)
+	}
+}
+
+func TestCheckBuffer(t *testing.T) {
+	fit := FitFile{}
+	gpx, _ := testhelper.GetValidGpxBuffer("01.gpx")
+	if fit.CheckBuffer(gpx) != false {
+		t.Errorf("FitFile can read a gpx buffer")
+	}
+
+	if fit.CheckBuffer([]byte{14, 0x20}) != false {
+		t.Errorf("FitFile can read a buffer that is to short")
+	}
+}
+
+func TestCheckFile(t *testing.T) {
+	fit := FitFile{}
+
+	if fit.CheckFile("my/path/file.FIT") != true {
+		t.Errorf("FitFile can not read *.FIT files")
+	}
+
+	if fit.CheckFile("my/path/file.gpx") != false {
+		t.Errorf("FitFile can read *.gpx files")
+	}
+}
+
+func TestGetValidFileExtensions(t *testing.T) {
+	fit := FitFile{}
+	extensions := fit.GetValidFileExtensions()
+
+	if len(extensions) != 1 || extensions[0] != FileExtension {
+		t.Errorf("The valid file extensions are %v, but should be [%s]", extensions, FileExtension)
+	}
+}
+
+func TestGetDocumentSize(t *testing.T) {
+	fit := FitFile{}
+	buffer, _ := testhelper.GetValidFileBuffer("fit", "01.fit")
+	size, res := fit.GetDocumentSize(append(buffer, buffer...))
+	if res != true || size != len(buffer) {
+		t.Errorf("The size of the FIT file is %d, but should be %d", size, len(buffer))
+	}
+
+	gpx, _ := testhelper.GetValidGpxBuffer("01.gpx")
+	if _, res := fit.GetDocumentSize(gpx); res != false {
+		t.Errorf("A gpx buffer has a FIT file size")
+	}
+}
+
+func TestSniffContent(t *testing.T) {
+	fit := FitFile{}
+	buffer, _ := testhelper.GetValidFileBuffer("fit", "01.fit")
+	res, reason := fit.SniffContent(buffer[:20])
+	if res != true || strings.Contains(reason, ".FIT") == false {
+		t.Errorf("The FIT header is not detected. The reason is \"%s\"", reason)
+	}
+
+	gpx, _ := testhelper.GetValidGpxBuffer("01.gpx")
+	if res, _ := fit.SniffContent(gpx); res != false {
+		t.Errorf("A gpx buffer is detected as fit")
+	}
+}
@@ -0,0 +1,459 @@
+package fitbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"time"
+)
+
+// fitEpoch - FIT timestamps count the seconds since 1989-12-31T00:00:00Z
+var fitEpoch = time.Date(1989, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+// Global message numbers of the FIT profile messages this reader is interested in
+const (
+	mesgNumSession uint16 = 18
+	mesgNumLap     uint16 = 19
+	mesgNumRecord  uint16 = 20
+)
+
+// Field definition numbers of the FIT profile fields this reader is interested in
+const (
+	fieldTimestamp        uint8 = 253
+	fieldStartTime        uint8 = 2
+	fieldTotalElapsedTime uint8 = 7
+	fieldTotalTimerTime   uint8 = 8
+	fieldTotalDistance    uint8 = 9
+	fieldSport            uint8 = 5
+	fieldFirstLapIndex    uint8 = 25
+	fieldNumLaps          uint8 = 26
+	fieldPositionLat      uint8 = 0
+	fieldPositionLong     uint8 = 1
+	fieldAltitude         uint8 = 2
+	fieldDistance         uint8 = 5
+	fieldEnhancedAltitude uint8 = 78
+)
+
+// Fit - Represents the content of a FIT activity file
+type Fit struct {
+	Sessions []Session
+	Laps     []Lap
+	Records  []Record
+}
+
+// Session - Represents one session message of a FIT file
+type Session struct {
+	StartTime        time.Time
+	Timestamp        time.Time
+	TotalElapsedTime float64
+	TotalTimerTime   float64
+	TotalDistance    float64
+	Sport            uint8
+	FirstLapIndex    int
+	NumLaps          int
+}
+
+// Lap - Represents one lap message of a FIT file
+type Lap struct {
+	StartTime        time.Time
+	Timestamp        time.Time
+	TotalElapsedTime float64
+	TotalTimerTime   float64
+	TotalDistance    float64
+}
+
+// Record - Represents one record message (a track point) of a FIT file
+type Record struct {
+	Timestamp     time.Time
+	TimeValid     bool
+	Latitude      float32
+	Longitude     float32
+	PositionValid bool
+	Altitude      float32
+	AltitudeValid bool
+	Distance      float64
+}
+
+type fieldDefinition struct {
+	number   uint8
+	size     int
+	baseType uint8
+}
+
+type messageDefinition struct {
+	byteOrder     binary.ByteOrder
+	globalNumber  uint16
+	fields        []fieldDefinition
+	developerSize int
+}
+
+// fieldValues - The raw values of the valid fields of one data message
+type fieldValues map[uint8]uint64
+
+// ReadFit - Read a FIT file
+func ReadFit(fileName string) (Fit, error) {
+	fitFile, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return Fit{}, err
+	}
+	return readFITBuffer(fitFile, fileName)
+}
+
+// checkFitHeader - Check if the buffer starts with a FIT file header. Returns the header size in case it does
+func checkFitHeader(buffer []byte) (bool, int) {
+	if len(buffer) < 12 {
+		return false, 0
+	}
+	headerSize := int(buffer[0])
+	if headerSize != 12 && headerSize != 14 {
+		return false, 0
+	}
+	if string(buffer[8:12]) != ".FIT" {
+		return false, 0
+	}
+
+	return true, headerSize
+}
+
+func readFITBuffer(fileBuffer []byte, fileName string) (Fit, error) {
+	isFit, headerSize := checkFitHeader(fileBuffer)
+	if !isFit {
+		return Fit{}, newFitFileError(fileName)
+	}
+
+	dataSize := int(binary.LittleEndian.Uint32(fileBuffer[4:8]))
+	dataEnd := headerSize + dataSize
+	if len(fileBuffer) < dataEnd+2 {
+		return Fit{}, newCorruptFitFileError(fileName, "the file is truncated")
+	}
+
+	fileCrc := binary.LittleEndian.Uint16(fileBuffer[dataEnd : dataEnd+2])
+	if fileCrc != 0 && fileCrc != getCrc(fileBuffer[:dataEnd]) {
+		return Fit{}, newCorruptFitFileError(fileName, "the CRC does not match the content")
+	}
+
+	fit, err := readFITRecords(fileBuffer[headerSize:dataEnd])
+	if err != nil {
+		return Fit{}, newCorruptFitFileError(fileName, err.Error())
+	}
+
+	if len(fit.Records) <= 0 {
+		return Fit{}, newEmptyFitFileError(fileName)
+	}
+
+	return fit, nil
+}
+
+// readFITRecords - Decode the definition and data messages of the FIT data section
+func readFITRecords(data []byte) (Fit, error) {
+	fit := Fit{}
+	definitions := map[uint8]messageDefinition{}
+	var lastTimestamp uint32
+	pos := 0
+
+	for pos < len(data) {
+		header := data[pos]
+		pos++
+
+		if header&0x80 != 0 { // Compressed timestamp header
+			localNumber := (header >> 5) & 0x03
+			timeOffset := uint32(header & 0x1F)
+			lastTimestamp = lastTimestamp + ((timeOffset - (lastTimestamp & 0x1F)) & 0x1F)
+			def, found := definitions[localNumber]
+			if !found {
+				return Fit{}, fmt.Errorf("data message for undefined local message type %d", localNumber)
+			}
+			values, size, err := readDataMessage(data[pos:], def)
+			if err != nil {
+				return Fit{}, err
+			}
+			pos += size
+			values[fieldTimestamp] = uint64(lastTimestamp)
+			addMessage(&fit, def.globalNumber, values)
+			continue
+		}
+
+		localNumber := header & 0x0F
+		if header&0x40 != 0 { // Definition message
+			def, size, err := readDefinitionMessage(data[pos:], header&0x20 != 0)
+			if err != nil {
+				return Fit{}, err
+			}
+			pos += size
+			definitions[localNumber] = def
+			continue
+		}
+
+		def, found := definitions[localNumber]
+		if !found {
+			return Fit{}, fmt.Errorf("data message for undefined local message type %d", localNumber)
+		}
+		values, size, err := readDataMessage(data[pos:], def)
+		if err != nil {
+			return Fit{}, err
+		}
+		pos += size
+		if timestamp, ok := values[fieldTimestamp]; ok {
+			lastTimestamp = uint32(timestamp)
+		}
+		addMessage(&fit, def.globalNumber, values)
+	}
+	fillMissingStartTimes(&fit)
+
+	return fit, nil
+}
+
+func readDefinitionMessage(data []byte, hasDeveloperData bool) (messageDefinition, int, error) {
+	def := messageDefinition{}
+	if len(data) < 5 {
+		return def, 0, fmt.Errorf("definition message is truncated")
+	}
+
+	if data[1] == 1 {
+		def.byteOrder = binary.BigEndian
+	} else {
+		def.byteOrder = binary.LittleEndian
+	}
+	def.globalNumber = def.byteOrder.Uint16(data[2:4])
+	numFields := int(data[4])
+	pos := 5
+	if len(data) < pos+numFields*3 {
+		return def, 0, fmt.Errorf("definition message is truncated")
+	}
+	for i := 0; i < numFields; i++ {
+		field := fieldDefinition{}
+		field.number = data[pos]
+		field.size = int(data[pos+1])
+		field.baseType = data[pos+2]
+		def.fields = append(def.fields, field)
+		pos += 3
+	}
+
+	if hasDeveloperData {
+		if len(data) < pos+1 {
+			return def, 0, fmt.Errorf("definition message is truncated")
+		}
+		numDevFields := int(data[pos])
+		pos++
+		if len(data) < pos+numDevFields*3 {
+			return def, 0, fmt.Errorf("definition message is truncated")
+		}
+		for i := 0; i < numDevFields; i++ {
+			def.developerSize += int(data[pos+1])
+			pos += 3
+		}
+	}
+
+	return def, pos, nil
+}
+
+func readDataMessage(data []byte, def messageDefinition) (fieldValues, int, error) {
+	values := fieldValues{}
+	pos := 0
+	for _, field := range def.fields {
+		if len(data) < pos+field.size {
+			return nil, 0, fmt.Errorf("data message is truncated")
+		}
+		value, valid := readFieldValue(data[pos:pos+field.size], field, def.byteOrder)
+		if valid {
+			values[field.number] = value
+		}
+		pos += field.size
+	}
+
+	if len(data) < pos+def.developerSize {
+		return nil, 0, fmt.Errorf("data message is truncated")
+	}
+	pos += def.developerSize
+
+	return values, pos, nil
+}
+
+// readFieldValue - Read the raw value of a numeric field. Arrays, strings and invalid values are reported as not valid
+func readFieldValue(data []byte, field fieldDefinition, byteOrder binary.ByteOrder) (uint64, bool) {
+	var value uint64
+	switch field.size {
+	case 1:
+		value = uint64(data[0])
+	case 2:
+		value = uint64(byteOrder.Uint16(data))
+	case 4:
+		value = uint64(byteOrder.Uint32(data))
+	case 8:
+		value = byteOrder.Uint64(data)
+	default:
+		return 0, false
+	}
+
+	switch field.baseType {
+	case 0x00, 0x02, 0x0D: // enum, uint8, byte
+		return value, field.size == 1 && value != 0xFF
+	case 0x01: // sint8
+		return value, field.size == 1 && value != 0x7F
+	case 0x0A, 0x8B, 0x8C, 0x90: // uint8z, uint16z, uint32z, uint64z
+		return value, value != 0
+	case 0x83: // sint16
+		return value, field.size == 2 && value != 0x7FFF
+	case 0x84: // uint16
+		return value, field.size == 2 && value != 0xFFFF
+	case 0x85: // sint32
+		return value, field.size == 4 && value != 0x7FFFFFFF
+	case 0x86: // uint32
+		return value, field.size == 4 && value != 0xFFFFFFFF
+	case 0x8E: // sint64
+		return value, field.size == 8 && value != 0x7FFFFFFFFFFFFFFF
+	case 0x8F: // uint64
+		return value, field.size == 8 && value != 0xFFFFFFFFFFFFFFFF
+	case 0x88: // float32
+		return value, field.size == 4 && value != 0xFFFFFFFF
+	case 0x89: // float64
+		return value, field.size == 8 && value != 0xFFFFFFFFFFFFFFFF
+	}
+
+	return 0, false
+}
+
+func addMessage(fit *Fit, globalNumber uint16, values fieldValues) {
+	switch globalNumber {
+	case mesgNumRecord:
+		fit.Records = append(fit.Records, newRecord(values))
+	case mesgNumLap:
+		fit.Laps = append(fit.Laps, newLap(values))
+	case mesgNumSession:
+		fit.Sessions = append(fit.Sessions, newSession(values))
+	}
+}
+
+func newRecord(values fieldValues) Record {
+	rec := Record{}
+	if timestamp, ok := values[fieldTimestamp]; ok {
+		rec.Timestamp = fitTime(timestamp)
+		rec.TimeValid = true
+	}
+
+	lat, latOk := values[fieldPositionLat]
+	long, longOk := values[fieldPositionLong]
+	if latOk && longOk {
+		rec.Latitude = semicirclesToDegrees(lat)
+		rec.Longitude = semicirclesToDegrees(long)
+		rec.PositionValid = true
+	}
+
+	if altitude, ok := values[fieldEnhancedAltitude]; ok {
+		rec.Altitude = float32(float64(altitude)/5 - 500)
+		rec.AltitudeValid = true
+	} else if altitude, ok := values[fieldAltitude]; ok {
+		rec.Altitude = float32(float64(altitude)/5 - 500)
+		rec.AltitudeValid = true
+	}
+
+	if distance, ok := values[fieldDistance]; ok {
+		rec.Distance = float64(distance) / 100
+	}
+
+	return rec
+}
+
+func newLap(values fieldValues) Lap {
+	lap := Lap{}
+	lap.Timestamp = fitTime(values[fieldTimestamp])
+	lap.StartTime = getStartTime(values)
+	lap.TotalElapsedTime = float64(values[fieldTotalElapsedTime]) / 1000
+	lap.TotalTimerTime = float64(values[fieldTotalTimerTime]) / 1000
+	lap.TotalDistance = float64(values[fieldTotalDistance]) / 100
+
+	return lap
+}
+
+func newSession(values fieldValues) Session {
+	session := Session{}
+	session.Timestamp = fitTime(values[fieldTimestamp])
+	session.StartTime = getStartTime(values)
+	session.TotalElapsedTime = float64(values[fieldTotalElapsedTime]) / 1000
+	session.TotalTimerTime = float64(values[fieldTotalTimerTime]) / 1000
+	session.TotalDistance = float64(values[fieldTotalDistance]) / 100
+	session.Sport = uint8(values[fieldSport])
+	session.FirstLapIndex = int(values[fieldFirstLapIndex])
+	session.NumLaps = int(values[fieldNumLaps])
+
+	return session
+}
+
+// getStartTime - Get the start_time of a lap or session message. The zero time if the message has no start_time
+func getStartTime(values fieldValues) time.Time {
+	if startTime, ok := values[fieldStartTime]; ok {
+		return fitTime(startTime)
+	}
+
+	return time.Time{}
+}
+
+// fillMissingStartTimes - Laps and sessions without start_time start with the first record taken after the end of
+// the previous lap or session. When there is no such record, the start is calculated from the end and the elapsed time
+func fillMissingStartTimes(fit *Fit) {
+	for i := range fit.Laps {
+		if fit.Laps[i].StartTime.IsZero() {
+			previousEnd := time.Time{}
+			if i > 0 {
+				previousEnd = fit.Laps[i-1].Timestamp
+			}
+			fit.Laps[i].StartTime = getFirstRecordTime(fit.Records, previousEnd, fit.Laps[i].Timestamp, fit.Laps[i].TotalElapsedTime)
+		}
+	}
+
+	for i := range fit.Sessions {
+		if fit.Sessions[i].StartTime.IsZero() {
+			previousEnd := time.Time{}
+			if i > 0 {
+				previousEnd = fit.Sessions[i-1].Timestamp
+			}
+			fit.Sessions[i].StartTime = getFirstRecordTime(fit.Records, previousEnd, fit.Sessions[i].Timestamp, fit.Sessions[i].TotalElapsedTime)
+		}
+	}
+}
+
+// getFirstRecordTime - Get the time of the first record taken after previousEnd, or end minus the elapsedTime if there is none
+func getFirstRecordTime(records []Record, previousEnd time.Time, end time.Time, elapsedTime float64) time.Time {
+	for _, record := range records {
+		if record.TimeValid && record.Timestamp.After(previousEnd) {
+			return record.Timestamp
+		}
+	}
+
+	return end.Add(-time.Duration(elapsedTime * float64(time.Second)))
+}
+
+func fitTime(timestamp uint64) time.Time {
+	return fitEpoch.Add(time.Duration(timestamp) * time.Second)
+}
+
+func semicirclesToDegrees(value uint64) float32 {
+	return float32(float64(int32(uint32(value))) * (180.0 / math.Pow(2, 31)))
+}
+
+// crcTable - The nibble table of the FIT CRC-16 algorithm
+var crcTable = [16]uint16{
+	0x0000, 0xCC01, 0xD801, 0x1400, 0xF001, 0x3C00, 0x2800, 0xE401,
+	0xA001, 0x6C00, 0x7800, 0xB401, 0x5000, 0x9C01, 0x8801, 0x4400,
+}
+
+// getCrc - Calculate the FIT CRC-16 of a byte array
+func getCrc(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		tmp := crcTable[crc&0xF]
+		crc = (crc >> 4) & 0x0FFF
+		crc = crc ^ tmp ^ crcTable[b&0xF]
+
+		tmp = crcTable[crc&0xF]
+		crc = (crc >> 4) & 0x0FFF
+		crc = crc ^ tmp ^ crcTable[(b>>4)&0xF]
+	}
+
+	return crc
+}
@@ -0,0 +1,205 @@
+package fitbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func TestReadValidFit01(t *testing.T) {
+	fit, err := ReadFit(testhelper.GetValidFile("fit", "01.fit"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(fit.Sessions) != 1 {
+		t.Errorf("The number of sessions is %d, but should be %d", len(fit.Sessions), 1)
+	}
+
+	if len(fit.Laps) != 7 {
+		t.Errorf("The number of laps is %d, but should be %d", len(fit.Laps), 7)
+	}
+
+	if len(fit.Records) != 336 {
+		t.Errorf("The number of records is %d, but should be %d", len(fit.Records), 336)
+	}
+
+	first := fit.Records[0]
+	if first.Timestamp.Format(time.RFC3339) != "2016-06-05T10:45:59Z" {
+		t.Errorf("The first Timestamp is %s, but should be %s", first.Timestamp.Format(time.RFC3339), "2016-06-05T10:45:59Z")
+	}
+
+	if !first.PositionValid || first.Latitude != 49.516804 || first.Longitude != 11.374258 {
+		t.Errorf("The first position is %f, %f, but should be %f, %f", first.Latitude, first.Longitude, 49.516804, 11.374258)
+	}
+
+	if !first.AltitudeValid || first.Altitude != 349.0 {
+		t.Errorf("The first Altitude is %f, but should be %f", first.Altitude, 349.0)
+	}
+
+	if fit.Laps[0].TotalDistance != 1000.0 {
+		t.Errorf("The TotalDistance of the first lap is %f, but should be %f", fit.Laps[0].TotalDistance, 1000.0)
+	}
+
+	if fit.Sessions[0].NumLaps != 7 {
+		t.Errorf("The NumLaps of the session is %d, but should be %d", fit.Sessions[0].NumLaps, 7)
+	}
+}
+
+func TestReadValidFitWithCompressedTimestamps(t *testing.T) {
+	fit, err := ReadFit(testhelper.GetValidFile("fit", "02.fit"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(fit.Sessions) != 2 {
+		t.Errorf("The number of sessions is %d, but should be %d", len(fit.Sessions), 2)
+	}
+
+	for i := 1; i < len(fit.Records); i++ {
+		if !fit.Records[i].TimeValid {
+			t.Fatalf("The record %d has no valid time", i)
+		}
+		if fit.Records[i].Timestamp.Before(fit.Records[i-1].Timestamp) {
+			t.Fatalf("The record %d was taken before the record %d", i, i-1)
+		}
+		if !fit.Records[i].AltitudeValid {
+			t.Fatalf("The record %d has no valid altitude", i)
+		}
+	}
+}
+
+func TestNewLapWithoutStartTime(t *testing.T) {
+	lap := newLap(fieldValues{fieldTimestamp: 1000})
+	if !lap.StartTime.IsZero() {
+		t.Errorf("The StartTime of a lap without start_time is %s, but should be the zero time", lap.StartTime)
+	}
+
+	session := newSession(fieldValues{fieldTimestamp: 1000, fieldStartTime: 900})
+	if session.StartTime != fitTime(900) {
+		t.Errorf("The StartTime of the session is %s, but should be %s", session.StartTime, fitTime(900))
+	}
+}
+
+func TestFillMissingStartTimes(t *testing.T) {
+	start := time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC)
+	fit := Fit{}
+	fit.Records = []Record{Record{}, Record{Timestamp: start, TimeValid: true}, Record{Timestamp: start.Add(60 * time.Second), TimeValid: true},
+		Record{Timestamp: start.Add(120 * time.Second), TimeValid: true}}
+	fit.Laps = []Lap{Lap{Timestamp: start.Add(60 * time.Second)}, Lap{Timestamp: start.Add(120 * time.Second)},
+		Lap{Timestamp: start.Add(300 * time.Second), TotalElapsedTime: 100}}
+	fit.Sessions = []Session{Session{Timestamp: start.Add(300 * time.Second)}}
+
+	fillMissingStartTimes(&fit)
+
+	expected := []time.Time{start, start.Add(120 * time.Second), start.Add(200 * time.Second)}
+	for i, lap := range fit.Laps {
+		if lap.StartTime != expected[i] {
+			t.Errorf("The StartTime of lap %d is %s, but should be %s", i, lap.StartTime, expected[i])
+		}
+	}
+
+	if fit.Sessions[0].StartTime != start {
+		t.Errorf("The StartTime of the session is %s, but should be %s", fit.Sessions[0].StartTime, start)
+	}
+}
+
+func TestReadInValidFitCrc(t *testing.T) {
+	_, err := ReadFit(testhelper.GetInvalidFile("fit", "01.fit"))
+	switch v := err.(type) {
+	case nil:
+		t.Errorf("No error, when reading an invalid fit file")
+	case *CorruptFitFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *CorruptFitFileError, got a %s", reflect.TypeOf(v))
+	}
+}
+
+func TestReadInValidFitTruncated(t *testing.T) {
+	_, err := ReadFit(testhelper.GetInvalidFile("fit", "02.fit"))
+	switch v := err.(type) {
+	case nil:
+		t.Errorf("No error, when reading an invalid fit file")
+	case *CorruptFitFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *CorruptFitFileError, got a %s", reflect.TypeOf(v))
+	}
+}
+
+func TestReadFitWithoutRecords(t *testing.T) {
+	_, err := ReadFit(testhelper.GetInvalidFile("fit", "03.fit"))
+	switch v := err.(type) {
+	case nil:
+		t.Errorf("No error, when reading an invalid fit file")
+	case *EmptyFitFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *EmptyFitFileError, got a %s", reflect.TypeOf(v))
+	}
+}
+
+func TestReadNoFitBuffer(t *testing.T) {
+	buffer, _ := testhelper.GetValidGpxBuffer("01.gpx")
+	_, err := readFITBuffer(buffer, "Buffer")
+	switch v := err.(type) {
+	case nil:
+		t.Errorf("No error, when reading a gpx file as fit")
+	case *FitFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *FitFileError, got a %s", reflect.TypeOf(v))
+	}
+}
+
+func TestReadNotExistFit(t *testing.T) {
+	_, err := ReadFit(testhelper.GetInvalidFile("fit", "not-exist.fit"))
+	switch v := err.(type) {
+	case nil:
+		t.Errorf("No error, when reading an not existing fit file")
+	case *os.PathError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *os.PathError, got a %s", reflect.TypeOf(v))
+	}
+}
+
+func TestReadAllInValidFit(t *testing.T) {
+	files, _ := ioutil.ReadDir(filepath.Join(testhelper.GetProjectRoot(), "testdata", "invalid-fit"))
+
+	for _, file := range files {
+		if strings.HasSuffix(file.Name(), ".fit") {
+			if file.IsDir() == false {
+				_, err := ReadFit(filepath.Join(testhelper.GetProjectRoot(), "testdata", "invalid-fit", file.Name()))
+				if err == nil {
+					t.Errorf("Got no error while reading file %s.", filepath.Join(testhelper.GetProjectRoot(), "testdata", "invalid-fit", file.Name()))
+				}
+			}
+		}
+	}
+}
+
+func TestGetCrc(t *testing.T) {
+	if getCrc([]byte{}) != 0 {
+		t.Errorf("The CRC of an empty array is %d, but should be 0", getCrc([]byte{}))
+	}
+
+	// The header of a FIT file contains the CRC of the first 12 bytes
+	buffer, _ := testhelper.GetValidFileBuffer("fit", "01.fit")
+	headerCrc := uint16(buffer[12]) | uint16(buffer[13])<<8
+	if getCrc(buffer[:12]) != headerCrc {
+		t.Errorf("The CRC of the header is %d, but should be %d", getCrc(buffer[:12]), headerCrc)
+	}
+}
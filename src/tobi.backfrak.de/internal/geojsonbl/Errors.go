@@ -0,0 +1,55 @@
+package geojsonbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import "fmt"
+
+// GeoJsonFileError - Error when trying to load something that is no GeoJSON FeatureCollection
+type GeoJsonFileError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+}
+
+func (e *GeoJsonFileError) Error() string { // Implement the Error Interface for the GeoJsonFileError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newGeoJsonFileError - Get a new GeoJsonFileError struct
+func newGeoJsonFileError(fileName string) *GeoJsonFileError {
+	return &GeoJsonFileError{fmt.Sprintf("The file \"%s\" is not a GeoJSON file", fileName), fileName}
+}
+
+// EmptyGeoJsonFileError - Error when trying to load a GeoJSON file that does not contain any LineString or MultiLineString
+type EmptyGeoJsonFileError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+}
+
+func (e *EmptyGeoJsonFileError) Error() string { // Implement the Error Interface for the EmptyGeoJsonFileError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newEmptyGeoJsonFileError - Get a new EmptyGeoJsonFileError struct
+func newEmptyGeoJsonFileError(fileName string) *EmptyGeoJsonFileError {
+	return &EmptyGeoJsonFileError{fmt.Sprintf("The file \"%s\" does not contain any valid tracks.", fileName), fileName}
+}
+
+// GeoJsonCoordinateError - Error when a GeoJSON file contains a position that can not be converted to a track point
+type GeoJsonCoordinateError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+}
+
+func (e *GeoJsonCoordinateError) Error() string { // Implement the Error Interface for the GeoJsonCoordinateError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newGeoJsonCoordinateError - Get a new GeoJsonCoordinateError struct
+func newGeoJsonCoordinateError(fileName string, feature int) *GeoJsonCoordinateError {
+	return &GeoJsonCoordinateError{fmt.Sprintf("The feature %d in the file \"%s\" contains a position with less than two values", feature, fileName), fileName}
+}
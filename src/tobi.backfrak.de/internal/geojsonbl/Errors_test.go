@@ -0,0 +1,51 @@
+package geojsonbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeoJsonFileErrorStruct(t *testing.T) {
+
+	path := "/some/sample/path"
+	err := newGeoJsonFileError(path)
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of GeoJsonFileError does not contain the expected Path")
+	}
+
+	if err.File != path {
+		t.Errorf("The GeoJsonFileError.File does not match the expected value")
+	}
+}
+
+func TestEmptyGeoJsonFileError(t *testing.T) {
+	path := "/some/sample/path"
+	err := newEmptyGeoJsonFileError(path)
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of EmptyGeoJsonFileError does not contain the expected Path")
+	}
+
+	if err.File != path {
+		t.Errorf("The EmptyGeoJsonFileError.File does not match the expected value")
+	}
+}
+
+func TestGeoJsonCoordinateError(t *testing.T) {
+	path := "/some/sample/path"
+	err := newGeoJsonCoordinateError(path, 3)
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of GeoJsonCoordinateError does not contain the expected Path")
+	}
+
+	if strings.Contains(err.Error(), "feature 3") == false {
+		t.Errorf("The error message of GeoJsonCoordinateError does not contain the expected feature")
+	}
+
+	if err.File != path {
+		t.Errorf("The GeoJsonCoordinateError.File does not match the expected value")
+	}
+}
@@ -0,0 +1,188 @@
+package geojsonbl
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+// ConvertGeoJson - Convert a geojsonbl.GeoJson to a gpsabl.TrackFile. Each LineString or MultiLineString Feature becomes a gpsabl.Track,
+// each part of a MultiLineString a gpsabl.TrackSegment
+func ConvertGeoJson(geoJson GeoJson, filePath string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+
+	res := gpsabl.NewTrackFile(filePath)
+
+	for i, feature := range geoJson.Features {
+		if feature.Geometry == nil {
+			continue
+		}
+
+		var lines [][][]json.Number
+		var times [][]interface{}
+		switch feature.Geometry.Type {
+		case lineStringType:
+			var line [][]json.Number
+			err := json.Unmarshal(feature.Geometry.Coordinates, &line)
+			if err != nil {
+				return gpsabl.TrackFile{}, err
+			}
+			lines = [][][]json.Number{line}
+			times = [][]interface{}{getInterfaceArray(feature.Properties["coordTimes"])}
+		case multiLineStringType:
+			err := json.Unmarshal(feature.Geometry.Coordinates, &lines)
+			if err != nil {
+				return gpsabl.TrackFile{}, err
+			}
+			for _, lineTimes := range getInterfaceArray(feature.Properties["coordTimes"]) {
+				times = append(times, getInterfaceArray(lineTimes))
+			}
+		default:
+			// Points or Polygons are no tracks
+			continue
+		}
+
+		track, err := convertFeature(feature, lines, times, filePath, i, correction, minimalMovingSpeed, minimalStepHight)
+		if err != nil {
+			return gpsabl.TrackFile{}, err
+		}
+
+		// Add only tracks that contain segments
+		if len(track.TrackSegments) > 0 {
+			res.Tracks = append(res.Tracks, track)
+		}
+	}
+
+	if len(res.Tracks) <= 0 {
+		return gpsabl.TrackFile{}, newEmptyGeoJsonFileError(filePath)
+	}
+
+	res.Name = geoJson.Name
+	res.NumberOfTracks = len(res.Tracks)
+	gpsabl.FillTrackFileValues(&res)
+
+	return res, nil
+}
+
+func convertFeature(feature Feature, lines [][][]json.Number, times [][]interface{}, filePath string, featureIndex int, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.Track, error) {
+	res := gpsabl.Track{}
+
+	for i, line := range lines {
+		var lineTimes []interface{}
+		if i < len(times) {
+			lineTimes = times[i]
+		}
+
+		points, err := convertPositions(line, lineTimes, filePath, featureIndex)
+		if err != nil {
+			return gpsabl.Track{}, err
+		}
+
+		seg, segErr := convertSegment(points, correction, minimalMovingSpeed, minimalStepHight)
+		if segErr != nil {
+			return gpsabl.Track{}, segErr
+		}
+		if len(seg.TrackPoints) > 0 {
+			res.TrackSegments = append(res.TrackSegments, seg)
+		}
+	}
+
+	res.Name = getStringProperty(feature.Properties, "name")
+	res.Description = getStringProperty(feature.Properties, "desc")
+	if res.Description == "" {
+		res.Description = getStringProperty(feature.Properties, "description")
+	}
+	res.NumberOfSegments = len(res.TrackSegments)
+	gpsabl.FillTrackValues(&res)
+
+	return res, nil
+}
+
+func convertSegment(points []gpsabl.TrackPoint, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackSegment, error) {
+	res := gpsabl.TrackSegment{}
+	if len(points) <= 0 {
+		return res, nil
+	}
+
+	ret := gpsabl.FillDistances(points)
+
+	err := gpsabl.FillValuesTrackPointArray(ret, correction, minimalMovingSpeed, minimalStepHight)
+	if err != nil {
+		return gpsabl.TrackSegment{}, err
+	}
+
+	res.TrackPoints = ret
+	gpsabl.FillTrackSegmentValues(&res)
+
+	return res, nil
+}
+
+// convertPositions - Convert GeoJSON positions [lon, lat, (elevation)] to track points. The time of a point is the coordTimes entry with the same index
+func convertPositions(positions [][]json.Number, times []interface{}, filePath string, featureIndex int) ([]gpsabl.TrackPoint, error) {
+	var ret []gpsabl.TrackPoint
+	for i, position := range positions {
+		if len(position) < 2 {
+			return nil, newGeoJsonCoordinateError(filePath, featureIndex)
+		}
+
+		pnt := gpsabl.TrackPoint{}
+		lon, lonErr := strconv.ParseFloat(string(position[0]), 32)
+		lat, latErr := strconv.ParseFloat(string(position[1]), 32)
+		if lonErr != nil || latErr != nil {
+			return nil, newGeoJsonCoordinateError(filePath, featureIndex)
+		}
+		pnt.Longitude = float32(lon)
+		pnt.Latitude = float32(lat)
+
+		if len(position) > 2 {
+			ele, eleErr := strconv.ParseFloat(string(position[2]), 32)
+			if eleErr != nil {
+				return nil, newGeoJsonCoordinateError(filePath, featureIndex)
+			}
+			pnt.Elevation = float32(ele)
+		}
+
+		if i < len(times) {
+			timeStr, ok := times[i].(string)
+			if ok {
+				t, tErr := time.Parse(time.RFC3339, timeStr)
+
+				// In case the time stamp of the track point is not in the specified format, it is not valid
+				if tErr == nil {
+					pnt.Time = t
+					pnt.TimeValid = true
+				}
+			}
+		}
+
+		ret = append(ret, pnt)
+	}
+
+	return ret, nil
+}
+
+// getStringProperty - Get a property of a Feature, if it is a string
+func getStringProperty(properties map[string]interface{}, name string) string {
+	value, ok := properties[name].(string)
+	if !ok {
+		return ""
+	}
+
+	return value
+}
+
+// getInterfaceArray - Get a json array value, or nil if the value is not an array
+func getInterfaceArray(value interface{}) []interface{} {
+	arr, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	return arr
+}
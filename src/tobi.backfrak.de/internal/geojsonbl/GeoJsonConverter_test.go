@@ -0,0 +1,154 @@
+package geojsonbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func TestConvertGeoJsonLineString(t *testing.T) {
+	geoJson, err := ReadGeoJson(testhelper.GetValidFile("geojson", "01.geojson"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	file, convErr := ConvertGeoJson(geoJson, "my/path.geojson", gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	// The file was created out of testdata/valid-gpx/01.gpx, so the values should be the gpx values
+	if file.Distance != 18478.293509238614 {
+		t.Errorf("The Distance is %f, but should be %f", file.Distance, 18478.293509238614)
+	}
+
+	if file.Name != "GeoJSON name" {
+		t.Errorf("The Name is %s, but should be %s", file.Name, "GeoJSON name")
+	}
+
+	if file.Tracks[0].Name != "Track name" || file.Tracks[0].Description != "Created out of valid-gpx/01.gpx" {
+		t.Errorf("The track Name is \"%s\" and the Description \"%s\", but should be \"%s\" and \"%s\"", file.Tracks[0].Name, file.Tracks[0].Description, "Track name", "Created out of valid-gpx/01.gpx")
+	}
+
+	if file.TimeDataValid != false {
+		t.Errorf("The TimeDataValid is true, but the file has no coordTimes")
+	}
+}
+
+func TestConvertGeoJsonMultiLineString(t *testing.T) {
+	geoJson, err := ReadGeoJson(testhelper.GetValidFile("geojson", "02.geojson"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	file, convErr := ConvertGeoJson(geoJson, "my/path.geojson", gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	// The file was created out of testdata/valid-gpx/02.gpx, so the values should be the gpx values
+	if file.Distance != 37823.344979382266 {
+		t.Errorf("The Distance is %f, but should be %f", file.Distance, 37823.344979382266)
+	}
+
+	if file.Tracks[0].NumberOfSegments != 2 {
+		t.Errorf("The NumberOfSegments is %d, but should be %d", file.Tracks[0].NumberOfSegments, 2)
+	}
+
+	if file.StartTime.Format(time.RFC3339) != "2019-08-18T09:11:01Z" {
+		t.Errorf("The StartTime is %s, but should be %s", file.StartTime.Format(time.RFC3339), "2019-08-18T09:11:01Z")
+	}
+
+	if file.MovingTime != 5600*time.Second {
+		t.Errorf("The MovingTime is %s, but should be %s", file.MovingTime, 5600*time.Second)
+	}
+}
+
+func TestConvertGeoJsonMixedFeatures(t *testing.T) {
+	geoJson, err := ReadGeoJson(testhelper.GetValidFile("geojson", "03.json"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	file, convErr := ConvertGeoJson(geoJson, "my/path.json", gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	// The Point and the Feature without geometry are no tracks
+	if file.NumberOfTracks != 2 {
+		t.Errorf("The NumberOfTracks is %d, but should be %d", file.NumberOfTracks, 2)
+	}
+
+	if file.Tracks[0].TimeDataValid != true {
+		t.Errorf("The first track has coordTimes, but no valid time data")
+	}
+
+	if file.Tracks[1].TimeDataValid != false {
+		t.Errorf("The second track has no coordTimes, but valid time data")
+	}
+
+	if file.Tracks[1].MaximumAltitude != 0.0 {
+		t.Errorf("The MaximumAltitude of the second track is %f, but should be %f", file.Tracks[1].MaximumAltitude, 0.0)
+	}
+}
+
+func TestConvertGeoJsonWithoutTracks(t *testing.T) {
+	geoJson, err := ReadGeoJson(testhelper.GetInvalidFile("geojson", "01.geojson"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	_, convErr := ConvertGeoJson(geoJson, "my/path.geojson", gpsabl.NO, 0.3, 10.0)
+	switch convErr.(type) {
+	case *EmptyGeoJsonFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *EmptyGeoJsonFileError, got \"%v\"", convErr)
+	}
+}
+
+func TestConvertGeoJsonWithInvalidPosition(t *testing.T) {
+	geoJson, err := ReadGeoJson(testhelper.GetInvalidFile("geojson", "03.geojson"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	_, convErr := ConvertGeoJson(geoJson, "my/path.geojson", gpsabl.NO, 0.3, 10.0)
+	switch convErr.(type) {
+	case *GeoJsonCoordinateError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *GeoJsonCoordinateError, got \"%v\"", convErr)
+	}
+}
+
+func TestConvertPositions(t *testing.T) {
+	positions := [][]json.Number{{"11.5", "49.25", "310.5"}, {"11.6", "49.3"}, {"11.7", "49.35", "312"}}
+	times := []interface{}{"2025-03-01T10:00:00Z", 12, "yesterday"}
+
+	points, err := convertPositions(positions, times, "my/path.geojson", 0)
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if points[0].Longitude != 11.5 || points[0].Latitude != 49.25 || points[0].Elevation != 310.5 {
+		t.Errorf("The first point is %f, %f, %f, but should be %f, %f, %f", points[0].Longitude, points[0].Latitude, points[0].Elevation, 11.5, 49.25, 310.5)
+	}
+
+	if points[0].TimeValid != true {
+		t.Errorf("The time of the first point is not valid")
+	}
+
+	if points[1].TimeValid != false || points[2].TimeValid != false {
+		t.Errorf("The time of a point without a valid coordTimes entry is valid")
+	}
+}
@@ -0,0 +1,149 @@
+package geojsonbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"strings"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+const GeoJsonBuffer gpsabl.InputFileType = "GeoJsonBuffer"
+
+// The file extension this Reader can read
+const FileExtension string = ".geojson"
+
+// The file extension of plain json files this Reader can read
+const JsonFileExtension string = ".json"
+
+// GeoJsonFile - The struct to handle *.geojson and *.json data files
+type GeoJsonFile struct {
+	gpsabl.TrackFile
+	input gpsabl.InputFile
+}
+
+// NewGeoJsonFile - Constructor for the GeoJsonFile struct
+func NewGeoJsonFile(filePath string) GeoJsonFile {
+	geoJson := GeoJsonFile{}
+	geoJson.FilePath = filePath
+	geoJson.input = *gpsabl.NewInputFileWithPath(filePath)
+
+	return geoJson
+}
+
+// NewReader - Get a new reader for GeoJSON files that will read the data in the given gpsabl.InputFile
+// Implement the gpsabl.TrackReader interface for *.geojson files
+func (geoJson *GeoJsonFile) NewReader(data gpsabl.InputFile) gpsabl.TrackReader {
+	newGeoJson := GeoJsonFile{}
+	newGeoJson.input = data
+	if data.Type == gpsabl.FilePath {
+		newGeoJson.FilePath = data.Name
+	}
+
+	return &newGeoJson
+}
+
+// ReadTracks - Read the *.geojson or *.json from the input from FilePath or InputFile.Buffer, and return a gpsabl.TrackFile struct that contains all information
+// Implement the gpsabl.TrackReader interface for *.geojson files
+func (geoJson *GeoJsonFile) ReadTracks(correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	var err error
+	var ret gpsabl.TrackFile
+	if geoJson.input.Type == gpsabl.FilePath {
+		ret, err = ReadGeoJsonFile(geoJson.FilePath, correction, minimalMovingSpeed, minimalStepHight)
+	} else if geoJson.input.Type == GeoJsonBuffer {
+		var buffer []byte
+		buffer, err = geoJson.input.ReadContent()
+		if err == nil {
+			ret, err = ReadBuffer(buffer, geoJson.input.Name, correction, minimalMovingSpeed, minimalStepHight)
+		}
+	} else {
+		err = gpsabl.NewUnKnownInputFileTypeError(geoJson.input.Name)
+	}
+
+	if err == nil {
+		geoJson.TrackFile = ret
+	}
+
+	return ret, err
+}
+
+// CheckInputFile - Check if a gpsabl.InputFile can be handled by the GeoJsonFile reader
+// Implement the gpsabl.TrackReader interface for *.geojson files
+func (geoJson *GeoJsonFile) CheckInputFile(input gpsabl.InputFile) bool {
+	if input.Type == GeoJsonBuffer {
+		return true
+	} else if input.Type == gpsabl.FilePath && geoJson.CheckFile(input.Name) {
+		return true
+	}
+
+	return false
+}
+
+// ReadBuffer - Read the GeoJSON data from a buffer, and return a gpsabl.TrackFile struct that contains all information
+func ReadBuffer(buffer []byte, name string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	content, readErr := readGeoJSONBuffer(buffer, name)
+	if readErr != nil {
+		return gpsabl.TrackFile{}, readErr
+	}
+	ret, convertError := ConvertGeoJson(content, name, correction, minimalMovingSpeed, minimalStepHight)
+	if convertError != nil {
+		return gpsabl.TrackFile{}, convertError
+	}
+
+	return ret, nil
+}
+
+// CheckFile - Check if a file can be read by the GeoJsonFile "class"
+// Implement the gpsabl.TrackReader interface for *.geojson files
+func (geoJson *GeoJsonFile) CheckFile(path string) bool {
+	lowerPath := strings.ToLower(path)
+	if strings.HasSuffix(lowerPath, FileExtension) || strings.HasSuffix(lowerPath, JsonFileExtension) { // If the file is a *.geojson or *.json, we can read it
+		return true
+	}
+
+	return false
+}
+
+// CheckBuffer - Check if a buffer can be read by he GeoJsonFile "class"
+// Implement the gpsabl.TrackReader interface for *.geojson files
+func (geoJson *GeoJsonFile) CheckBuffer(buffer []byte) bool {
+	return isGeoJSONBuffer(buffer)
+}
+
+// NewInputFileForBuffer - Get a new InputFile for a buffer containing a GeoJSON files content
+// Implement the gpsabl.TrackReader interface for *.geojson files
+func (geoJson *GeoJsonFile) NewInputFileForBuffer(buffer []byte, name string) *gpsabl.InputFile {
+	file := gpsabl.InputFile{}
+	file.Name = name
+	file.Type = GeoJsonBuffer
+	file.Buffer = buffer
+
+	return &file
+}
+
+// GetValidFileExtensions - Get a list of file extensions this reader can read
+// Implement the gpsabl.TrackReader interface for *.geojson files
+func (geoJson *GeoJsonFile) GetValidFileExtensions() []string {
+
+	extensions := []string{FileExtension, JsonFileExtension}
+
+	return extensions
+}
+
+// ReadGeoJsonFile - Reads a *.geojson or *.json file
+func ReadGeoJsonFile(filePath string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	geoJson, fileError := ReadGeoJson(filePath)
+
+	if fileError != nil {
+		return gpsabl.TrackFile{}, fileError
+	}
+
+	ret, convertError := ConvertGeoJson(geoJson, filePath, correction, minimalMovingSpeed, minimalStepHight)
+	if convertError != nil {
+		return gpsabl.TrackFile{}, convertError
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,201 @@
+package geojsonbl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+func TestTrackReaderGeoJson(t *testing.T) {
+	geoJson := NewGeoJsonFile(testhelper.GetValidFile("geojson", "02.geojson"))
+
+	file, err := geoJson.ReadTracks("none", 0.3, 10.0)
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if file.Distance != 37823.344979382266 {
+		t.Errorf("The Distance is %f, but should be %f", file.Distance, 37823.344979382266)
+	}
+
+	if file.FilePath != testhelper.GetValidFile("geojson", "02.geojson") {
+		t.Errorf("The FilePath is %s, but should be %s", file.FilePath, testhelper.GetValidFile("geojson", "02.geojson"))
+	}
+
+	if geoJson.Distance != file.Distance {
+		t.Errorf("The GeoJsonFile.Distance is %f, but should be %f", geoJson.Distance, file.Distance)
+	}
+}
+
+func TestTrackReaderInValidCorrectionParameter(t *testing.T) {
+	geoJson := NewGeoJsonFile(testhelper.GetValidFile("geojson", "02.geojson"))
+
+	_, err := geoJson.ReadTracks("asdfg", 0.3, 10.0)
+	if err != nil {
+		switch ty := err.(type) {
+		case *gpsabl.CorrectionParameterNotKnownError:
+			fmt.Println("OK")
+		default:
+			t.Errorf("The Error ReadTracks gave is of the wrong type. The type is %v", ty)
+		}
+	} else {
+		t.Errorf("ReadTracks did not return a error, but was expected")
+	}
+}
+
+func TestReadAllValidGeoJsonDueInterface(t *testing.T) {
+	files, _ := ioutil.ReadDir(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-geojson"))
+
+	for _, file := range files {
+		geoJsonFile := NewGeoJsonFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-geojson", file.Name()))
+		if geoJsonFile.CheckFile(file.Name()) && file.IsDir() == false {
+			iGeoJson := gpsabl.TrackReader(&geoJsonFile)
+
+			track, err := iGeoJson.ReadTracks("none", 0.3, 10.0)
+			if err != nil {
+				t.Errorf("Got the error \"%s\" while reading file %s.", err.Error(), filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-geojson", file.Name()))
+			}
+			if track.Distance <= 0.0 {
+				t.Errorf("The track.Distance is %f but should not be less then %f", track.Distance, 0.0)
+			}
+		}
+	}
+}
+
+func TestReadAllInValidGeoJsonDueInterface(t *testing.T) {
+	files, _ := ioutil.ReadDir(filepath.Join(testhelper.GetProjectRoot(), "testdata", "invalid-geojson"))
+
+	for _, file := range files {
+		geoJsonFile := NewGeoJsonFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "invalid-geojson", file.Name()))
+		if geoJsonFile.CheckFile(file.Name()) && file.IsDir() == false {
+			iGeoJson := gpsabl.TrackReader(&geoJsonFile)
+
+			_, err := iGeoJson.ReadTracks("none", 0.3, 10.0)
+			if err == nil {
+				t.Errorf("Got no error while reading file %s.", filepath.Join(testhelper.GetProjectRoot(), "testdata", "invalid-geojson", file.Name()))
+			}
+		}
+	}
+}
+
+func TestNewReaderWithValidFilePath(t *testing.T) {
+	geoJson := GeoJsonFile{}
+	file := testhelper.GetValidFile("geojson", "01.geojson")
+	checkRes := geoJson.CheckFile(file)
+	input := *gpsabl.NewInputFileWithPath(file)
+
+	sut := geoJson.NewReader(input)
+
+	if checkRes != true {
+		t.Errorf("GeoJsonFile can not read %s", file)
+	}
+
+	if geoJson.CheckInputFile(input) != true {
+		t.Errorf("GeoJsonFile can not read the input %s", file)
+	}
+
+	trk, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+	if err != nil {
+		t.Errorf("Got error \"%s\" but expect none", err)
+	}
+
+	if trk.FilePath != file {
+		t.Errorf("The trk.FilePath %s is not the given path %s", trk.FilePath, file)
+	}
+}
+
+func TestNewReaderWithValidBuffer(t *testing.T) {
+	for _, name := range []string{"01.geojson", "03.json"} {
+		geoJson := GeoJsonFile{}
+		buffer, createErr := testhelper.GetValidFileBuffer("geojson", name)
+		if createErr != nil {
+			t.Fatalf("Got error \"%s\" while creating the input buffer", createErr)
+		}
+		checkRes := geoJson.CheckBuffer(buffer)
+		input := *geoJson.NewInputFileForBuffer(buffer, "Buffer 1")
+
+		sut := geoJson.NewReader(input)
+
+		if checkRes != true {
+			t.Errorf("GeoJsonFile can not read %s from buffer", name)
+		}
+
+		if geoJson.CheckInputFile(input) != true {
+			t.Errorf("GeoJsonFile can not read the input buffer")
+		}
+
+		trk, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+		if err != nil {
+			t.Errorf("Got error \"%s\" but expect none", err)
+		}
+
+		if trk.FilePath != "Buffer 1" {
+			t.Errorf("The trk.FilePath %s is not the given path %s", trk.FilePath, "Buffer 1")
+		}
+	}
+}
+
+func TestNewReaderWithUnknownInputType(t *testing.T) {
+	geoJson := GeoJsonFile{}
+	input := gpsabl.InputFile{Type: "Unknown", Name: "Buffer 1"}
+	sut := geoJson.NewReader(input)
+
+	if geoJson.CheckInputFile(input) != false {
+		t.Errorf("GeoJsonFile can read an input of unknown type")
+	}
+
+	_, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+	switch err.(type) {
+	case *gpsabl.UnKnownInputFileTypeError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *gpsabl.UnKnownInputFileTypeError, got \"%v\"", err)
+	}
+}
+
+func TestCheckBuffer(t *testing.T) {
+	geoJson := GeoJsonFile{}
+	gpx, _ := testhelper.GetValidGpxBuffer("01.gpx")
+	if geoJson.CheckBuffer(gpx) != false {
+		t.Errorf("GeoJsonFile can read a gpx buffer")
+	}
+
+	other, _ := testhelper.GetInvalidFileBuffer("geojson", "02.json")
+	if geoJson.CheckBuffer(other) != false {
+		t.Errorf("GeoJsonFile can read a json buffer that is not GeoJSON")
+	}
+}
+
+func TestCheckFile(t *testing.T) {
+	geoJson := GeoJsonFile{}
+
+	if geoJson.CheckFile("my/path/file.GEOJSON") != true {
+		t.Errorf("GeoJsonFile can not read *.GEOJSON files")
+	}
+
+	if geoJson.CheckFile("my/path/file.json") != true {
+		t.Errorf("GeoJsonFile can not read *.json files")
+	}
+
+	if geoJson.CheckFile("my/path/file.gpx") != false {
+		t.Errorf("GeoJsonFile can read *.gpx files")
+	}
+}
+
+func TestGetValidFileExtensions(t *testing.T) {
+	geoJson := GeoJsonFile{}
+	extensions := geoJson.GetValidFileExtensions()
+
+	if len(extensions) != 2 || extensions[0] != FileExtension || extensions[1] != JsonFileExtension {
+		t.Errorf("The valid file extensions are %v, but should be [%s %s]", extensions, FileExtension, JsonFileExtension)
+	}
+}
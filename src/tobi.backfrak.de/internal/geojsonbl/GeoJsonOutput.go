@@ -0,0 +1,329 @@
+package geojsonbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+// GeoJsonOutputFormatertype - The gpsabl.OutputFormaterType this formater is responsible for
+const GeoJsonOutputFormatertype gpsabl.OutputFormaterType = "GEOJSON"
+
+// DefaultPrecision - The number of decimal places of the written coordinates, about 0.1 m
+const DefaultPrecision = 6
+
+const pointType = "Point"
+
+// geoJsonOutput - The FeatureCollection that is written. The summary lines are a foreign member of the collection
+type geoJsonOutput struct {
+	Type     string                 `json:"type"`
+	Features []geoJsonOutputFeature `json:"features"`
+	Summary  []gpsabl.OutputLine    `json:",omitempty"`
+}
+
+type geoJsonOutputFeature struct {
+	Type       string                 `json:"type"`
+	Properties interface{}            `json:"properties"`
+	Geometry   *geoJsonOutputGeometry `json:"geometry"`
+}
+
+type geoJsonOutputGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// geoJsonOutputProperties - The properties of a track Feature. The name and the coordTimes are named like the reader expects them
+type geoJsonOutputProperties struct {
+	Name string `json:"name"`
+	gpsabl.ExtendedTrackSummary
+	CoordTimes interface{} `json:"coordTimes,omitempty"`
+}
+
+// GeoJsonOutputFormater - type that writes the output lines as Features of a GeoJSON FeatureCollection
+type GeoJsonOutputFormater struct {
+	// Precision - The number of decimal places of the longitude and latitude. The full precision is written, when negative
+	Precision int
+
+	// CorrectedElevation - Tell if the corrected elevation is written instead of the elevation read from the file
+	CorrectedElevation bool
+
+	// Tell if the waypoints of the TrackFiles should be added as Point Features
+	AddWaypoints bool
+
+	// Sorting - The order the Features are written in. The lines are written in the order they are added by default
+	Sorting gpsabl.OutputSorting
+
+	writtenEntiresCount int
+	lineBuffer          []gpsabl.OutputLine
+	waypointBuffer      []gpsabl.Waypoint
+	mux                 sync.Mutex
+}
+
+// NewGeoJsonOutputFormater - Get a new instance of the GeoJsonOutputFormater
+func NewGeoJsonOutputFormater() *GeoJsonOutputFormater {
+	ret := GeoJsonOutputFormater{}
+	ret.Precision = DefaultPrecision
+	ret.writtenEntiresCount = -1
+	ret.lineBuffer = []gpsabl.OutputLine{}
+	ret.waypointBuffer = []gpsabl.Waypoint{}
+
+	return &ret
+}
+
+// NewOutputFormater -  Get a new gpsabl.OutputFormater of this type
+func (formater *GeoJsonOutputFormater) NewOutputFormater() gpsabl.OutputFormater {
+	ret := NewGeoJsonOutputFormater()
+
+	return gpsabl.OutputFormater(ret)
+}
+
+// GetTextOutputFormater - Get the gpsabl.TextOutputFormater. This is always nil for this formater
+func (formater *GeoJsonOutputFormater) GetTextOutputFormater() gpsabl.TextOutputFormater {
+	return nil
+}
+
+// SetAddWaypoints - Set the value of formater.AddWaypoints
+func (formater *GeoJsonOutputFormater) SetAddWaypoints(value bool) {
+	formater.AddWaypoints = value
+}
+
+// SetSorting - Set the value of formater.Sorting
+func (formater *GeoJsonOutputFormater) SetSorting(sorting gpsabl.OutputSorting) {
+	formater.Sorting = sorting
+}
+
+// AddOutPut - Add the output lines of a TrackFile to the buffer. The lines keep their track points, since they are needed for the geometry
+func (formater *GeoJsonOutputFormater) AddOutPut(trackFile gpsabl.TrackFile, depth gpsabl.DepthArg, filterDuplicate bool) error {
+	var lines []gpsabl.OutputLine
+	linesFromFile, err := gpsabl.GetOutlines(trackFile, depth)
+	if err != nil {
+		return err
+	}
+	if filterDuplicate {
+		for _, line := range linesFromFile {
+			if gpsabl.OutputContainsLineByTimeStamps(lines, line) == false && gpsabl.OutputContainsLineByTimeStamps(formater.lineBuffer, line) == false {
+				lines = append(lines, line)
+			}
+		}
+	} else {
+		lines = linesFromFile
+	}
+
+	formater.mux.Lock()
+	defer formater.mux.Unlock()
+	if formater.AddWaypoints {
+		formater.waypointBuffer = append(formater.waypointBuffer, trackFile.Waypoints...)
+	}
+	formater.lineBuffer = append(formater.lineBuffer, lines...)
+
+	return nil
+}
+
+// WriteOutput - Write the FeatureCollection to the output file
+func (formater *GeoJsonOutputFormater) WriteOutput(outFile *os.File, summary gpsabl.SummaryArg) error {
+	output, errGet := formater.getOutput(summary)
+	if errGet != nil {
+		return errGet
+	}
+
+	entries := len(output.Features) + len(output.Summary)
+	if entries > 0 {
+		file, errConv := json.MarshalIndent(output, "", " ")
+		if errConv != nil {
+			return errConv
+		}
+
+		if _, errWrite := outFile.Write(file); errWrite != nil {
+			return errWrite
+		}
+	}
+
+	formater.writtenEntiresCount = entries
+
+	return nil
+}
+
+// getOutput - Get the FeatureCollection that will be written to the file
+func (formater *GeoJsonOutputFormater) getOutput(summary gpsabl.SummaryArg) (geoJsonOutput, error) {
+	if !gpsabl.CheckValidSummaryArg(string(summary)) {
+		return geoJsonOutput{}, gpsabl.NewSummaryParamaterNotKnown(summary)
+	}
+
+	formater.mux.Lock()
+	defer formater.mux.Unlock()
+	gpsabl.SortOutputLines(formater.lineBuffer, formater.Sorting)
+
+	ret := geoJsonOutput{Type: featureCollectionType, Features: []geoJsonOutputFeature{}}
+	if summary != gpsabl.ONLY {
+		for _, line := range formater.lineBuffer {
+			ret.Features = append(ret.Features, formater.convertLine(line))
+		}
+
+		if formater.AddWaypoints {
+			for _, wpt := range gpsabl.GetSortedWaypoints(formater.waypointBuffer) {
+				ret.Features = append(ret.Features, formater.convertWaypoint(wpt))
+			}
+		}
+	}
+
+	if summary != gpsabl.NONE {
+		ret.Summary = formater.getSummaryEntires()
+	}
+
+	return ret, nil
+}
+
+// CheckOutputFormaterType - Check if this OutputFormater is responsible for the given gpsabl.OutputFormaterType
+func (formater *GeoJsonOutputFormater) CheckOutputFormaterType(formaterType gpsabl.OutputFormaterType) bool {
+	if formaterType == GeoJsonOutputFormatertype {
+		return true
+	}
+
+	return false
+}
+
+// GetOutputFormaterTypes - Get the list of gpsabl.OutputFormaterType this formater can write
+func (formater *GeoJsonOutputFormater) GetOutputFormaterTypes() []gpsabl.OutputFormaterType {
+	return []gpsabl.OutputFormaterType{GeoJsonOutputFormatertype}
+}
+
+// CheckFileExtension - Check if this OutputFormater can write the given output file
+func (formater *GeoJsonOutputFormater) CheckFileExtension(filePath string) bool {
+	if strings.HasSuffix(strings.ToLower(filePath), FileExtension) {
+		return true
+	}
+
+	return false
+}
+
+// GetFileExtensions - Get the list of file extensions this formater can write
+func (formater *GeoJsonOutputFormater) GetFileExtensions() []string {
+	return []string{FileExtension}
+}
+
+// GetOutputTableLineCount - Get the number of Features in the buffer, without the waypoints
+func (formater *GeoJsonOutputFormater) GetOutputTableLineCount() int {
+	return len(formater.lineBuffer)
+}
+
+// Tells the number if output entries that are written to output.
+// * -1: When output was not written yet
+// * 0: Output was written but contains no entries, may because no entry passes the given filter
+// * >0: The number of entries written to the outputs
+func (formater *GeoJsonOutputFormater) GetNumberOfOutputEntries() int {
+	return formater.writtenEntiresCount
+}
+
+// convertLine - Get the Feature of an output line. The geometry is null, when the line has no track points
+func (formater *GeoJsonOutputFormater) convertLine(line gpsabl.OutputLine) geoJsonOutputFeature {
+	properties := geoJsonOutputProperties{Name: line.Name}
+	properties.ExtendedTrackSummary = gpsabl.StripOutlines([]gpsabl.OutputLine{line})[0].Data.(gpsabl.ExtendedTrackSummary)
+
+	var segments []gpsabl.TrackSegment
+	switch data := line.Data.(type) {
+	case gpsabl.TrackFile:
+		for _, track := range data.Tracks {
+			segments = append(segments, track.TrackSegments...)
+		}
+	case gpsabl.Track:
+		segments = data.TrackSegments
+	case gpsabl.TrackSegment:
+		segments = []gpsabl.TrackSegment{data}
+	}
+
+	var coordinates [][][]json.Number
+	var times [][]interface{}
+	for _, segment := range segments {
+		if len(segment.TrackPoints) == 0 {
+			continue
+		}
+		var positions [][]json.Number
+		var segmentTimes []interface{}
+		for _, pnt := range segment.TrackPoints {
+			positions = append(positions, formater.getPosition(pnt))
+			if pnt.TimeValid {
+				segmentTimes = append(segmentTimes, pnt.Time.UTC().Format(time.RFC3339Nano))
+			} else {
+				segmentTimes = append(segmentTimes, nil)
+			}
+		}
+		coordinates = append(coordinates, positions)
+		times = append(times, segmentTimes)
+	}
+
+	ret := geoJsonOutputFeature{Type: featureType}
+	switch len(coordinates) {
+	case 0:
+	case 1:
+		ret.Geometry = &geoJsonOutputGeometry{Type: lineStringType, Coordinates: coordinates[0]}
+		if properties.TimeDataValid {
+			properties.CoordTimes = times[0]
+		}
+	default:
+		ret.Geometry = &geoJsonOutputGeometry{Type: multiLineStringType, Coordinates: coordinates}
+		if properties.TimeDataValid {
+			properties.CoordTimes = times
+		}
+	}
+	ret.Properties = properties
+
+	return ret
+}
+
+// convertWaypoint - Get the Point Feature of a waypoint
+func (formater *GeoJsonOutputFormater) convertWaypoint(wpt gpsabl.Waypoint) geoJsonOutputFeature {
+	position := []json.Number{formater.getCoordinate(wpt.Longitude), formater.getCoordinate(wpt.Latitude), getElevation(wpt.Elevation)}
+
+	return geoJsonOutputFeature{Type: featureType, Properties: wpt, Geometry: &geoJsonOutputGeometry{Type: pointType, Coordinates: position}}
+}
+
+// getPosition - Get the [lon, lat, elevation] position of a track point. The elevation is left out, when the point has none
+func (formater *GeoJsonOutputFormater) getPosition(pnt gpsabl.TrackPoint) []json.Number {
+	ret := []json.Number{formater.getCoordinate(pnt.Longitude), formater.getCoordinate(pnt.Latitude)}
+	if formater.CorrectedElevation {
+		ret = append(ret, getElevation(pnt.CorectedElevation))
+	} else if !pnt.ElevationMissing {
+		ret = append(ret, getElevation(pnt.Elevation))
+	}
+
+	return ret
+}
+
+// getCoordinate - Get the longitude or latitude rounded to the Precision of the formater
+func (formater *GeoJsonOutputFormater) getCoordinate(value float32) json.Number {
+	if formater.Precision < 0 {
+		return json.Number(strconv.FormatFloat(float64(value), 'f', -1, 32))
+	}
+
+	factor := math.Pow(10, float64(formater.Precision))
+	return json.Number(strconv.FormatFloat(math.Round(float64(value)*factor)/factor, 'f', -1, 64))
+}
+
+// getElevation - Get the elevation as json number
+func getElevation(value float32) json.Number {
+	return json.Number(strconv.FormatFloat(float64(value), 'f', -1, 32))
+}
+
+// getSummaryEntires - Get the Sum, Average, Minimum and Maximum lines of the summary
+func (formater *GeoJsonOutputFormater) getSummaryEntires() []gpsabl.OutputLine {
+	ret := []gpsabl.OutputLine{}
+	if len(formater.lineBuffer) > 0 {
+		stats := gpsabl.GetStatisticSummaryData(formater.lineBuffer)
+		ret = append(ret, gpsabl.OutputLine{Name: "Sum", Data: stats.Sum})
+		ret = append(ret, gpsabl.OutputLine{Name: "Average", Data: stats.Average})
+		ret = append(ret, gpsabl.OutputLine{Name: "Minimum", Data: stats.Minimum})
+		ret = append(ret, gpsabl.OutputLine{Name: "Maximum", Data: stats.Maximum})
+	}
+
+	return ret
+}
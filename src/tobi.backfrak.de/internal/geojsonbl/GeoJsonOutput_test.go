@@ -0,0 +1,312 @@
+package geojsonbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func TestGeoJsonOutputFormaterIsOutputFormater(t *testing.T) {
+	var orig GeoJsonOutputFormater
+	sut := orig.NewOutputFormater()
+
+	if sut.CheckFileExtension("my/output.geojson") == false || sut.CheckFileExtension("my/output.GeoJSON") == false {
+		t.Errorf("GeoJsonOutputFormater can not write *.geojson")
+	}
+
+	if sut.CheckFileExtension("my/output.json") == true {
+		t.Errorf("GeoJsonOutputFormater can write *.json")
+	}
+
+	if sut.CheckOutputFormaterType(GeoJsonOutputFormatertype) == false {
+		t.Errorf("GeoJsonOutputFormater can not write %s type", GeoJsonOutputFormatertype)
+	}
+
+	if len(sut.GetOutputFormaterTypes()) != 1 || sut.GetOutputFormaterTypes()[0] != "GEOJSON" {
+		t.Errorf("The OutputFormaterTypes are %v, but should be %v", sut.GetOutputFormaterTypes(), []string{"GEOJSON"})
+	}
+
+	if sut.GetNumberOfOutputEntries() != -1 {
+		t.Errorf("The initial value of GetNumberOfOutputEntries is %d but should be %d", sut.GetNumberOfOutputEntries(), -1)
+	}
+
+	if (sut.(*GeoJsonOutputFormater)).Precision != DefaultPrecision {
+		t.Errorf("The Precision is %d, but should be %d", (sut.(*GeoJsonOutputFormater)).Precision, DefaultPrecision)
+	}
+}
+
+func TestGeoJsonOutputReadAgain(t *testing.T) {
+	input, _ := ReadGeoJsonFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-geojson", "02.geojson"), gpsabl.STEPS, 0.3, 10.0)
+
+	sut := NewGeoJsonOutputFormater()
+	sut.Precision = -1
+	sut.AddOutPut(input, gpsabl.TRACK, false)
+	actual := writeAndReadGeoJson(t, sut, gpsabl.NONE)
+
+	if len(actual.Tracks) != len(input.Tracks) {
+		t.Fatalf("The written file contains %d tracks, but should contain %d", len(actual.Tracks), len(input.Tracks))
+	}
+
+	if len(actual.Tracks[0].TrackSegments) != len(input.Tracks[0].TrackSegments) {
+		t.Errorf("The written track contains %d segments, but should contain %d", len(actual.Tracks[0].TrackSegments), len(input.Tracks[0].TrackSegments))
+	}
+
+	if actual.Distance != input.Distance || actual.ElevationGain != input.ElevationGain {
+		t.Errorf("The Distance and ElevationGain are %f, %f, but should be %f, %f", actual.Distance, actual.ElevationGain, input.Distance, input.ElevationGain)
+	}
+
+	if actual.StartTime != input.StartTime || actual.EndTime != input.EndTime || !actual.TimeDataValid {
+		t.Errorf("The StartTime and EndTime are %s, %s, but should be %s, %s", actual.StartTime, actual.EndTime, input.StartTime, input.EndTime)
+	}
+
+	if actual.Tracks[0].Name != fmt.Sprintf("%s: %s", input.FilePath, input.Tracks[0].Name) {
+		t.Errorf("The track name is \"%s\", but should be the name of the output line", actual.Tracks[0].Name)
+	}
+}
+
+func TestGeoJsonOutputProperties(t *testing.T) {
+	input, _ := ReadGeoJsonFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-geojson", "02.geojson"), gpsabl.STEPS, 0.3, 10.0)
+
+	sut := NewGeoJsonOutputFormater()
+	sut.AddOutPut(input, gpsabl.FILE, false)
+	output := writeAndUnmarshal(t, sut, gpsabl.NONE)
+
+	features := output["features"].([]interface{})
+	if len(features) != 1 {
+		t.Fatalf("The output contains %d Features, but should contain %d", len(features), 1)
+	}
+
+	properties := features[0].(map[string]interface{})["properties"].(map[string]interface{})
+	expected := gpsabl.StripOutlines([]gpsabl.OutputLine{{Name: input.FilePath, Data: input}})[0].Data.(gpsabl.ExtendedTrackSummary)
+	if properties["Distance"].(float64) != expected.Distance {
+		t.Errorf("The Distance property is %f, but should be %f", properties["Distance"], expected.Distance)
+	}
+
+	if properties["name"].(string) != input.FilePath {
+		t.Errorf("The name property is \"%s\", but should be \"%s\"", properties["name"], input.FilePath)
+	}
+
+	if _, ok := properties["MovingTime"]; !ok {
+		t.Errorf("The MovingTime is not a property of the Feature")
+	}
+
+	if _, ok := output["Summary"]; ok {
+		t.Errorf("The output contains a summary, but summary is \"none\"")
+	}
+}
+
+func TestGeoJsonOutputGeometryType(t *testing.T) {
+	track := getOutputTestTrack()
+	file := gpsabl.NewTrackFile("my/file.gpx")
+	file.Tracks = []gpsabl.Track{track}
+	gpsabl.FillTrackFileValues(&file)
+
+	sut := NewGeoJsonOutputFormater()
+	sut.AddOutPut(file, gpsabl.TRACK, false)
+	sut.AddOutPut(file, gpsabl.SEGMENT, false)
+	output, _ := sut.getOutput(gpsabl.NONE)
+
+	expected := []string{multiLineStringType, lineStringType, lineStringType}
+	if len(output.Features) != len(expected) {
+		t.Fatalf("The output contains %d Features, but should contain %d", len(output.Features), len(expected))
+	}
+
+	for i, feature := range output.Features {
+		if feature.Geometry.Type != expected[i] {
+			t.Errorf("The geometry of Feature %d is a %s, but should be a %s", i, feature.Geometry.Type, expected[i])
+		}
+	}
+
+	emptyFile := gpsabl.NewTrackFile("my/empty.gpx")
+	emptyFile.Tracks = []gpsabl.Track{{}}
+	sut = NewGeoJsonOutputFormater()
+	sut.AddOutPut(emptyFile, gpsabl.TRACK, false)
+	output, _ = sut.getOutput(gpsabl.NONE)
+	if output.Features[0].Geometry != nil {
+		t.Errorf("The geometry of a track without points is %+v, but should be null", output.Features[0].Geometry)
+	}
+}
+
+func TestGeoJsonOutputPrecision(t *testing.T) {
+	sut := NewGeoJsonOutputFormater()
+	pnt := gpsabl.TrackPoint{Latitude: 49.4159423, Longitude: 11.0174471, Elevation: 308.5}
+
+	sut.Precision = 2
+	position := sut.getPosition(pnt)
+	if position[0] != "11.02" || position[1] != "49.42" || position[2] != "308.5" {
+		t.Errorf("The position is %v, but should be %v", position, []string{"11.02", "49.42", "308.5"})
+	}
+
+	sut.Precision = -1
+	position = sut.getPosition(pnt)
+	if position[0] != "11.017447" || position[1] != "49.415943" {
+		t.Errorf("The position is %v, but should be %v", position, []string{"11.017447", "49.415943"})
+	}
+
+	pnt.ElevationMissing = true
+	if len(sut.getPosition(pnt)) != 2 {
+		t.Errorf("The position of a point without elevation contains %d values, but should contain %d", len(sut.getPosition(pnt)), 2)
+	}
+
+	pnt.CorectedElevation = 310
+	sut.CorrectedElevation = true
+	position = sut.getPosition(pnt)
+	if len(position) != 3 || position[2] != "310" {
+		t.Errorf("The position is %v, but should contain the corrected elevation", position)
+	}
+}
+
+func TestGeoJsonOutputSummary(t *testing.T) {
+	input, _ := ReadGeoJsonFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-geojson", "01.geojson"), gpsabl.STEPS, 0.3, 10.0)
+
+	sut := NewGeoJsonOutputFormater()
+	sut.AddOutPut(input, gpsabl.TRACK, false)
+	output, _ := sut.getOutput(gpsabl.ADDITIONAL)
+	if len(output.Features) != 1 || len(output.Summary) != 4 {
+		t.Errorf("The output contains %d Features and %d summary lines, but should contain %d and %d", len(output.Features), len(output.Summary), 1, 4)
+	}
+
+	output, _ = sut.getOutput(gpsabl.ONLY)
+	if len(output.Features) != 0 || len(output.Summary) != 4 {
+		t.Errorf("The output contains %d Features and %d summary lines, but should contain %d and %d", len(output.Features), len(output.Summary), 0, 4)
+	}
+
+	content := writeAndUnmarshal(t, sut, gpsabl.ADDITIONAL)
+	if len(content["Summary"].([]interface{})) != 4 {
+		t.Errorf("The Summary member of the written collection contains %d lines, but should contain %d", len(content["Summary"].([]interface{})), 4)
+	}
+
+	if sut.GetNumberOfOutputEntries() != 5 {
+		t.Errorf("The number of written entries is %d, but should be %d", sut.GetNumberOfOutputEntries(), 5)
+	}
+}
+
+func TestGeoJsonOutputWaypoints(t *testing.T) {
+	input, _ := ReadGeoJsonFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-geojson", "01.geojson"), gpsabl.STEPS, 0.3, 10.0)
+	input.Waypoints = []gpsabl.Waypoint{{Name: "Start", Latitude: 49.415942, Longitude: 11.017447}}
+
+	sut := NewGeoJsonOutputFormater()
+	sut.AddOutPut(input, gpsabl.TRACK, false)
+	output, _ := sut.getOutput(gpsabl.NONE)
+	if len(output.Features) != 1 {
+		t.Errorf("The output contains %d Features, but should contain %d", len(output.Features), 1)
+	}
+
+	sut = NewGeoJsonOutputFormater()
+	sut.SetAddWaypoints(true)
+	sut.AddOutPut(input, gpsabl.TRACK, false)
+	output, _ = sut.getOutput(gpsabl.NONE)
+	if len(output.Features) != 2 || output.Features[1].Geometry.Type != pointType {
+		t.Fatalf("The output does not contain the waypoint as Point Feature")
+	}
+
+	// The reader skips the waypoints
+	actual := writeAndReadGeoJson(t, sut, gpsabl.NONE)
+	if len(actual.Tracks) != 1 {
+		t.Errorf("The written file contains %d tracks, but should contain %d", len(actual.Tracks), 1)
+	}
+}
+
+func TestGeoJsonOutputNoTrack(t *testing.T) {
+	sut := NewGeoJsonOutputFormater()
+	if err := sut.WriteOutput(os.Stdout, gpsabl.ADDITIONAL); err != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+
+	if sut.GetNumberOfOutputEntries() != 0 {
+		t.Errorf("The number of written entries is %d, but should be %d", sut.GetNumberOfOutputEntries(), 0)
+	}
+}
+
+func TestGeoJsonOutputNotValidArguments(t *testing.T) {
+	sut := NewGeoJsonOutputFormater()
+	err := sut.WriteOutput(os.Stdout, "abc")
+	switch err.(type) {
+	case *gpsabl.SummaryParamaterNotKnown:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a SummaryParamaterNotKnown, got \"%v\"", err)
+	}
+
+	err = sut.AddOutPut(gpsabl.NewTrackFile("my/file.gpx"), "abc", false)
+	switch err.(type) {
+	case *gpsabl.DepthParameterNotKnownError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a DepthParameterNotKnownError, got \"%v\"", err)
+	}
+}
+
+// getOutputTestTrack - Get a track with two segments of two points
+func getOutputTestTrack() gpsabl.Track {
+	track := gpsabl.Track{Name: "Track"}
+	for i := 0; i < 2; i++ {
+		points := []gpsabl.TrackPoint{
+			{Latitude: 49.4159 + float32(i)*0.01, Longitude: 11.0174, Elevation: 300},
+			{Latitude: 49.4169 + float32(i)*0.01, Longitude: 11.0184, Elevation: 310},
+		}
+		seg, _ := convertSegment(points, gpsabl.NO, 0.3, 10.0)
+		track.TrackSegments = append(track.TrackSegments, seg)
+	}
+	gpsabl.FillTrackValues(&track)
+
+	return track
+}
+
+// writeAndReadGeoJson - Write the output of the formater to a temp file, and read the file again
+func writeAndReadGeoJson(t *testing.T, sut *GeoJsonOutputFormater, summary gpsabl.SummaryArg) gpsabl.TrackFile {
+	path := writeToTempFile(t, sut, summary)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	ret, errRead := ReadGeoJsonFile(path, gpsabl.STEPS, 0.3, 10.0)
+	if errRead != nil {
+		t.Fatalf("Can not read the written file: %s", errRead.Error())
+	}
+
+	return ret
+}
+
+// writeAndUnmarshal - Write the output of the formater to a temp file, and get the json content of the file
+func writeAndUnmarshal(t *testing.T, sut *GeoJsonOutputFormater, summary gpsabl.SummaryArg) map[string]interface{} {
+	path := writeToTempFile(t, sut, summary)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	content, _ := ioutil.ReadFile(path)
+	ret := map[string]interface{}{}
+	if err := json.Unmarshal(content, &ret); err != nil {
+		t.Fatalf("The written file is not valid json: %s", err.Error())
+	}
+
+	if ret["type"] != featureCollectionType {
+		t.Errorf("The written file is a \"%v\", but should be a %s", ret["type"], featureCollectionType)
+	}
+
+	return ret
+}
+
+// writeToTempFile - Write the output of the formater to a new temp file, and get the path of the file
+func writeToTempFile(t *testing.T, sut *GeoJsonOutputFormater, summary gpsabl.SummaryArg) string {
+	dir, errDir := ioutil.TempDir("", "geojsonbl")
+	if errDir != nil {
+		t.Fatalf("Can not create a temp dir: %s", errDir)
+	}
+
+	path := filepath.Join(dir, "out.geojson")
+	out, _ := os.Create(path)
+	defer out.Close()
+	if errWrite := sut.WriteOutput(out, summary); errWrite != nil {
+		t.Fatalf("Got an error but did not expect one. The error is: %s", errWrite.Error())
+	}
+
+	return path
+}
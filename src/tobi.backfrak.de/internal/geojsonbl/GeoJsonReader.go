@@ -0,0 +1,87 @@
+package geojsonbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"encoding/json"
+	"io/ioutil"
+)
+
+// The GeoJSON object types this reader handles
+const (
+	featureCollectionType = "FeatureCollection"
+	featureType           = "Feature"
+	lineStringType        = "LineString"
+	multiLineStringType   = "MultiLineString"
+)
+
+// GeoJson - Represents the content of a GeoJSON file. A single Feature is handled like a FeatureCollection with one Feature
+type GeoJson struct {
+	Type     string    `json:"type"`
+	Name     string    `json:"name"`
+	Features []Feature `json:"features"`
+}
+
+// Feature - Represents one Feature in a GeoJSON file
+type Feature struct {
+	Type       string                 `json:"type"`
+	Properties map[string]interface{} `json:"properties"`
+	Geometry   *Geometry              `json:"geometry"`
+}
+
+// Geometry - Represents the geometry of a Feature. The coordinates are kept raw, since there layout depends on the Type
+type Geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// geoJsonType - Used to read only the type of a json object
+type geoJsonType struct {
+	Type string `json:"type"`
+}
+
+// ReadGeoJson - Read a GeoJSON file
+func ReadGeoJson(fileName string) (GeoJson, error) {
+	fileBuffer, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return GeoJson{}, err
+	}
+	return readGeoJSONBuffer(fileBuffer, fileName)
+}
+
+func readGeoJSONBuffer(fileBuffer []byte, fileName string) (GeoJson, error) {
+	if !isGeoJSONBuffer(fileBuffer) {
+		return GeoJson{}, newGeoJsonFileError(fileName)
+	}
+
+	geoJson := GeoJson{}
+	err := json.Unmarshal(fileBuffer, &geoJson)
+	if err != nil {
+		return GeoJson{}, err
+	}
+
+	if geoJson.Type == featureType {
+		feature := Feature{}
+		err = json.Unmarshal(fileBuffer, &feature)
+		if err != nil {
+			return GeoJson{}, err
+		}
+		geoJson.Features = []Feature{feature}
+	}
+
+	return geoJson, nil
+}
+
+// isGeoJSONBuffer - Tell if a buffer contains a json object with a GeoJSON FeatureCollection or Feature type.
+// Other json, like the output of gpsa, has no such type member
+func isGeoJSONBuffer(buffer []byte) bool {
+	obj := geoJsonType{}
+	err := json.Unmarshal(buffer, &obj)
+	if err != nil {
+		return false
+	}
+
+	return obj.Type == featureCollectionType || obj.Type == featureType
+}
@@ -0,0 +1,98 @@
+package geojsonbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func TestReadValidGeoJson01(t *testing.T) {
+	geoJson, err := ReadGeoJson(testhelper.GetValidFile("geojson", "01.geojson"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if geoJson.Name != "GeoJSON name" {
+		t.Errorf("The Name is %s, but should be %s", geoJson.Name, "GeoJSON name")
+	}
+
+	if len(geoJson.Features) != 1 {
+		t.Fatalf("The number of Features is %d, but should be %d", len(geoJson.Features), 1)
+	}
+
+	if geoJson.Features[0].Geometry.Type != lineStringType {
+		t.Errorf("The Geometry.Type is %s, but should be %s", geoJson.Features[0].Geometry.Type, lineStringType)
+	}
+}
+
+func TestReadGeoJsonFeature(t *testing.T) {
+	buffer := []byte(`{"type": "Feature", "properties": {"name": "One"}, "geometry": {"type": "LineString", "coordinates": [[11.0, 49.0], [11.1, 49.1]]}}`)
+	geoJson, err := readGeoJSONBuffer(buffer, "Buffer")
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(geoJson.Features) != 1 {
+		t.Fatalf("The number of Features is %d, but should be %d", len(geoJson.Features), 1)
+	}
+
+	if geoJson.Features[0].Properties["name"] != "One" {
+		t.Errorf("The name property is %v, but should be %s", geoJson.Features[0].Properties["name"], "One")
+	}
+}
+
+func TestReadNoGeoJson(t *testing.T) {
+	_, err := ReadGeoJson(testhelper.GetInvalidFile("geojson", "02.json"))
+	switch v := err.(type) {
+	case nil:
+		t.Errorf("No error, when reading a json file that is not GeoJSON")
+	case *GeoJsonFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *GeoJsonFileError, got a %s", reflect.TypeOf(v))
+	}
+}
+
+func TestReadNotExistGeoJson(t *testing.T) {
+	_, err := ReadGeoJson(testhelper.GetInvalidFile("geojson", "not-exist.geojson"))
+	switch v := err.(type) {
+	case nil:
+		t.Errorf("No error, when reading an not existing GeoJSON file")
+	case *os.PathError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *os.PathError, got a %s", reflect.TypeOf(v))
+	}
+}
+
+func TestIsGeoJSONBuffer(t *testing.T) {
+	geoJson, _ := testhelper.GetValidFileBuffer("geojson", "03.json")
+	if isGeoJSONBuffer(geoJson) != true {
+		t.Errorf("The GeoJSON buffer is not detected as GeoJSON")
+	}
+
+	other, _ := testhelper.GetInvalidFileBuffer("geojson", "02.json")
+	if isGeoJSONBuffer(other) != false {
+		t.Errorf("A json buffer without GeoJSON type is detected as GeoJSON")
+	}
+
+	if isGeoJSONBuffer([]byte(`{"type": "Point", "coordinates": [11.0, 49.0]}`)) != false {
+		t.Errorf("A GeoJSON Point is detected as FeatureCollection or Feature")
+	}
+
+	if isGeoJSONBuffer([]byte(`[{"type": "FeatureCollection"}]`)) != false {
+		t.Errorf("A json array is detected as GeoJSON")
+	}
+
+	gpx, _ := testhelper.GetValidGpxBuffer("01.gpx")
+	if isGeoJSONBuffer(gpx) != false {
+		t.Errorf("A gpx buffer is detected as GeoJSON")
+	}
+}
@@ -0,0 +1,157 @@
+package gpsabl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// SniffSize - The number of bytes read from the start of a file to detect its format
+const SniffSize int = 64 * 1024
+
+// MaximalContentCheckSize - Files with unknown extension up to this size are read completely, so the readers can check the content
+const MaximalContentCheckSize int64 = 64 * 1024 * 1024
+
+// ContentSniffer - A TrackReader that can tell by the start of a file or buffer if it can read the content.
+// GetInputFileFromPath and GetInputFileFromBuffer prefer a reader that recognizes the content over the file extension
+type ContentSniffer interface {
+	// SniffContent - Check the start of a file or buffer. Return true and the reason if the reader can read the content
+	SniffContent(head []byte) (bool, string)
+}
+
+// XMLRoot - The root element of a xml document
+type XMLRoot struct {
+	// Name - The local name of the root element
+	Name string
+	// Namespace - The namespace of the root element, empty if there is none
+	Namespace string
+}
+
+// GetXMLRoot - Parse the root element of a xml document. The buffer may only contain the start of the document
+// return true and the root element, if the buffer starts with a xml document
+func GetXMLRoot(buffer []byte) (bool, XMLRoot) {
+	decoder := xml.NewDecoder(bytes.NewReader(bytes.TrimPrefix(buffer, []byte("\ufeff"))))
+	decoder.CharsetReader = passThroughCharsetReader
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return false, XMLRoot{}
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			return true, XMLRoot{element.Name.Local, element.Name.Space}
+		case xml.CharData:
+			if len(bytes.TrimSpace(element)) != 0 {
+				return false, XMLRoot{}
+			}
+		}
+	}
+}
+
+// SniffXMLRoot - Check if a buffer starts with a xml document with the given root element. The namespace of the root element
+// must start with one of the namespaces, or be empty
+// return true and the reason if the root element matches
+func SniffXMLRoot(head []byte, name string, namespaces []string) (bool, string) {
+	isXML, root := GetXMLRoot(head)
+	if !isXML || root.Name != name {
+		return false, ""
+	}
+
+	if root.Namespace == "" {
+		return true, fmt.Sprintf("the xml root element <%s> without namespace", root.Name)
+	}
+
+	for _, namespace := range namespaces {
+		if strings.HasPrefix(root.Namespace, namespace) {
+			return true, fmt.Sprintf("the xml root element <%s> in the namespace \"%s\"", root.Name, root.Namespace)
+		}
+	}
+
+	return false, ""
+}
+
+// passThroughCharsetReader - A xml.Decoder CharsetReader that does not convert the input.
+// The encoding does not matter for the structure of a document, the names are ASCII in all known formats
+func passThroughCharsetReader(charset string, input io.Reader) (io.Reader, error) {
+	return input, nil
+}
+
+// sniffBuffer - Get the InputFile of the first reader that recognizes the content of the buffer
+func sniffBuffer(validReaders []TrackReader, buffer []byte, name string) (bool, InputFile) {
+	for _, reader := range validReaders {
+		if sniffer, ok := reader.(ContentSniffer); ok {
+			if res, reason := sniffer.SniffContent(buffer); res == true {
+				input := *reader.NewInputFileForBuffer(buffer, name)
+				input.Detection = reason
+				return true, input
+			}
+		}
+	}
+
+	return false, InputFile{}
+}
+
+// sniffFile - Get the InputFile of the first reader that recognizes the start of the file. The InputFile is given by path,
+// when the reader knows the file extension, otherwise the content is read from the Path of a buffer InputFile when the track is read
+func sniffFile(validReaders []TrackReader, path string, head []byte) (bool, InputFile) {
+	for _, reader := range validReaders {
+		sniffer, ok := reader.(ContentSniffer)
+		if !ok {
+			continue
+		}
+		res, reason := sniffer.SniffContent(head)
+		if res == false {
+			continue
+		}
+
+		var input InputFile
+		if reader.CheckFile(path) == true {
+			input = *NewInputFileWithPath(path)
+		} else {
+			input = *reader.NewInputFileForBuffer(nil, path)
+			input.Path = path
+		}
+		input.Detection = reason
+
+		return true, input
+	}
+
+	return false, InputFile{}
+}
+
+// readFileHead - Read up to size bytes from the start of a file
+func readFileHead(path string, size int) ([]byte, error) {
+	file, errOpen := os.Open(path)
+	if errOpen != nil {
+		return nil, errOpen
+	}
+	defer file.Close()
+
+	head := make([]byte, size)
+	count, errRead := io.ReadFull(file, head)
+	if errRead != nil && errRead != io.ErrUnexpectedEOF && errRead != io.EOF {
+		return nil, errRead
+	}
+
+	return head[:count], nil
+}
+
+// getFileSize - Get the size of a file, -1 if the file does not exist or is a directory
+func getFileSize(path string) int64 {
+	info, errStat := os.Stat(path)
+	if errStat != nil || info.IsDir() {
+		return -1
+	}
+
+	return info.Size()
+}
@@ -0,0 +1,119 @@
+package gpsabl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// snifferMock - A reader that recognizes xml documents with the <abc> root element
+type snifferMock struct {
+	readerMock
+}
+
+func (mok *snifferMock) SniffContent(head []byte) (bool, string) {
+	return SniffXMLRoot(head, "abc", []string{"http://abc.de/"})
+}
+
+func TestGetXMLRoot(t *testing.T) {
+	expected := map[string]XMLRoot{
+		"<abc/>": {"abc", ""},
+		"\ufeff<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?>\n<!-- comment -->\n<gpx xmlns=\"http://www.topografix.com/GPX/1/1\" version=\"1.1\">": {"gpx", "http://www.topografix.com/GPX/1/1"},
+		"  <?xml version=\"1.0\"?><!DOCTYPE kml><kml:kml xmlns:kml=\"http://www.opengis.net/kml/2.2\"><Document>":                                    {"kml", "http://www.opengis.net/kml/2.2"},
+	}
+	for buffer, root := range expected {
+		res, got := GetXMLRoot([]byte(buffer))
+		if res != true || got != root {
+			t.Errorf("The root of \"%s\" is %v, but should be %v", buffer, got, root)
+		}
+	}
+
+	for _, buffer := range []string{"", "abc", "$GPGGA,123519,4807.038,N", "{\"type\": \"FeatureCollection\"}", "text <gpx>"} {
+		if res, _ := GetXMLRoot([]byte(buffer)); res != false {
+			t.Errorf("\"%s\" is detected as xml document", buffer)
+		}
+	}
+}
+
+func TestSniffXMLRoot(t *testing.T) {
+	if res, reason := SniffXMLRoot([]byte("<abc xmlns=\"http://abc.de/1\"/>"), "abc", []string{"http://abc.de/"}); res != true || !strings.Contains(reason, "http://abc.de/1") {
+		t.Errorf("The <abc> root element in the namespace is not detected. The reason is \"%s\"", reason)
+	}
+
+	if res, reason := SniffXMLRoot([]byte("<abc/>"), "abc", []string{"http://abc.de/"}); res != true || !strings.Contains(reason, "<abc>") {
+		t.Errorf("The <abc> root element without namespace is not detected. The reason is \"%s\"", reason)
+	}
+
+	if res, _ := SniffXMLRoot([]byte("<abc xmlns=\"http://other.de/1\"/>"), "abc", []string{"http://abc.de/"}); res != false {
+		t.Errorf("The <abc> root element in a foreign namespace is detected")
+	}
+
+	if res, _ := SniffXMLRoot([]byte("<abcd/>"), "abc", []string{"http://abc.de/"}); res != false {
+		t.Errorf("The <abcd> root element is detected as <abc>")
+	}
+}
+
+func TestGetInputFileFromPathByContent(t *testing.T) {
+	dir, errDir := ioutil.TempDir("", "gpsabl")
+	if errDir != nil {
+		t.Fatalf("Got error \"%s\" while creating a temp dir", errDir)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{"track.xml": "<abc/>", "track.abc": "<abc/>", "noext": "some abc text", "picture.jpg": "no track"}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Got error \"%s\" while creating the file", err)
+		}
+	}
+	readers := []TrackReader{&snifferMock{}}
+
+	res, input := GetInputFileFromPath(readers, filepath.Join(dir, "track.xml"))
+	if res != true || input.Type != InputFileType("abcType") || input.Buffer != nil || !strings.Contains(input.Detection, "root element") {
+		t.Errorf("The misnamed file is not detected by content. Got %v", input)
+	}
+	if content, errRead := input.ReadContent(); errRead != nil || string(content) != "<abc/>" {
+		t.Errorf("The content of the misnamed file is \"%s\", but should be \"<abc/>\"", content)
+	}
+
+	res, input = GetInputFileFromPath(readers, filepath.Join(dir, "track.abc"))
+	if res != true || input.Type != FilePath || !strings.Contains(input.Detection, "root element") {
+		t.Errorf("The file with known extension is not given by path. Got %v", input)
+	}
+
+	res, input = GetInputFileFromPath(readers, filepath.Join(dir, "noext"))
+	if res != true || input.Type != InputFileType("abcType") || !strings.Contains(input.Detection, "content") {
+		t.Errorf("The file without extension is not detected by CheckBuffer. Got %v", input)
+	}
+
+	res, input = GetInputFileFromPath(readers, filepath.Join(dir, "not-existing.abc"))
+	if res != true || input.Type != FilePath || !strings.Contains(input.Detection, "extension \".abc\"") {
+		t.Errorf("The not existing file is not detected by extension. Got %v", input)
+	}
+
+	if res, _ = GetInputFileFromPath(readers, filepath.Join(dir, "picture.jpg")); res != false {
+		t.Errorf("A file of unknown type is detected")
+	}
+}
+
+func TestGetInputFileFromBufferByContent(t *testing.T) {
+	readers := []TrackReader{&readerMock{}, &snifferMock{}}
+
+	// The readerMock would accept the buffer, but the snifferMock recognizes the content
+	res, input := GetInputFileFromBuffer(readers, []byte("<abc>abc</abc>"), "name")
+	if res != true || !strings.Contains(input.Detection, "root element") {
+		t.Errorf("The buffer is not detected by content. Got %v", input)
+	}
+
+	res, input = GetInputFileFromBuffer(readers, []byte("abc"), "name")
+	if res != true || !strings.Contains(input.Detection, "abcType") {
+		t.Errorf("The buffer is not detected by CheckBuffer. Got %v", input)
+	}
+}
@@ -0,0 +1,392 @@
+package gpsabl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CompressionType - a string type to implement the enum pattern
+type CompressionType string
+
+const (
+	// NOCOMPRESSION - The data is not compressed
+	NOCOMPRESSION CompressionType = "none"
+	// GZIP - The data is gzip compressed
+	GZIP CompressionType = "gzip"
+	// BZIP2 - The data is bzip2 compressed
+	BZIP2 CompressionType = "bzip2"
+	// ZIP - The data is a zip archive, that may contain several files
+	ZIP CompressionType = "zip"
+	// TAR - The data is a tar archive, that may contain several files
+	TAR CompressionType = "tar"
+)
+
+// GzipFileExtension - The file extension of gzip compressed files
+const GzipFileExtension string = ".gz"
+
+// Bzip2FileExtension - The file extension of bzip2 compressed files
+const Bzip2FileExtension string = ".bz2"
+
+// ZipFileExtension - The file extension of zip archives
+const ZipFileExtension string = ".zip"
+
+// TarFileExtension - The file extension of tar archives
+const TarFileExtension string = ".tar"
+
+// MaximalArchiveDepth - Compressed data inside compressed data is expanded up to this depth
+const MaximalArchiveDepth int = 3
+
+const gzipMagic string = "\x1f\x8b"
+const bzip2Magic string = "BZh"
+const zipMagic string = "PK\x03\x04"
+const tarMagic string = "ustar"
+const tarMagicOffset int = 257
+
+// GetCompressionTypeFromPath - Get the CompressionType of a file by its extension
+func GetCompressionTypeFromPath(path string) CompressionType {
+	lowerPath := strings.ToLower(path)
+	switch {
+	case strings.HasSuffix(lowerPath, GzipFileExtension):
+		return GZIP
+	case strings.HasSuffix(lowerPath, Bzip2FileExtension):
+		return BZIP2
+	case strings.HasSuffix(lowerPath, ZipFileExtension):
+		return ZIP
+	case strings.HasSuffix(lowerPath, TarFileExtension):
+		return TAR
+	}
+
+	return NOCOMPRESSION
+}
+
+// GetCompressionTypeFromBuffer - Get the CompressionType of a buffer by its magic bytes
+func GetCompressionTypeFromBuffer(buffer []byte) CompressionType {
+	switch {
+	case bytes.HasPrefix(buffer, []byte(gzipMagic)):
+		return GZIP
+	case bytes.HasPrefix(buffer, []byte(bzip2Magic)):
+		return BZIP2
+	case bytes.HasPrefix(buffer, []byte(zipMagic)):
+		return ZIP
+	case len(buffer) >= tarMagicOffset+len(tarMagic) && string(buffer[tarMagicOffset:tarMagicOffset+len(tarMagic)]) == tarMagic:
+		return TAR
+	}
+
+	return NOCOMPRESSION
+}
+
+// GetInputFilesFromPath - Get the InputFiles for a file, that may be compressed. Compressed files are detected by
+// extension or magic bytes. A gzip or bzip2 compressed track file is expanded while its track is read, the reader is chosen
+// by the start of the expanded content or the inner file name. Other compressed files are expanded into buffers.
+// Every track file inside a zip or tar archive gets its own InputFile. Files in the archive no reader supports are skipped.
+// - validReaders    List of valid TrackReader
+// - path            The path to the data file
+// return true and the coresponding InputFiles if a valid reader was found
+// return false and no InputFile if no valid reader was found
+// return an error if the compressed file can not be expanded
+func GetInputFilesFromPath(validReaders []TrackReader, path string) (bool, []InputFile, error) {
+	compression := GetCompressionTypeFromPath(path)
+	if compression == NOCOMPRESSION {
+		compression = getCompressionTypeFromFile(path)
+		// Some track files like *.kmz are zip archives, the reader of the file type will handle them
+		if compression == ZIP && checkFileWithReaders(validReaders, path) {
+			compression = NOCOMPRESSION
+		}
+	}
+
+	if compression == NOCOMPRESSION {
+		res, input := GetInputFileFromPath(validReaders, path)
+		if res == true {
+			return true, []InputFile{input}, nil
+		}
+
+		return false, nil, nil
+	}
+
+	if compression == GZIP || compression == BZIP2 {
+		res, input, errStream := getStreamedInputFile(validReaders, path, compression)
+		if errStream != nil {
+			return false, nil, errStream
+		}
+		if res == true {
+			return true, []InputFile{input}, nil
+		}
+	}
+
+	buffer, errRead := ioutil.ReadFile(path)
+	if errRead != nil {
+		return false, nil, errRead
+	}
+
+	return expandCompressedBuffer(validReaders, buffer, path, compression, 1)
+}
+
+// GetInputFilesFromBuffer - Get the InputFiles for a buffer, that may contain compressed data. Compressed data is detected
+// by magic bytes and expanded the same way as GetInputFilesFromPath does. Not compressed data is handled like GetInputFileFromBuffer does.
+// - validReaders    List of valid TrackReader
+// - buffer          The buffer that contains data
+// - bufferName      Name of the buffer
+// return true and the coresponding InputFiles if a valid reader was found
+// return false and no InputFile if no valid reader was found
+// return an error if the compressed data can not be expanded
+func GetInputFilesFromBuffer(validReaders []TrackReader, buffer []byte, bufferName string) (bool, []InputFile, error) {
+	return getInputFilesFromBuffer(validReaders, buffer, bufferName, 0)
+}
+
+func getInputFilesFromBuffer(validReaders []TrackReader, buffer []byte, name string, depth int) (bool, []InputFile, error) {
+	compression := GetCompressionTypeFromBuffer(buffer)
+	// Some track files like *.kmz are zip archives, the reader of the file type will handle them, unless the name tells it is a *.zip
+	if compression == ZIP && GetCompressionTypeFromPath(name) != ZIP &&
+		(checkFileWithReaders(validReaders, name) || checkBufferWithReaders(validReaders, buffer)) {
+		compression = NOCOMPRESSION
+	}
+
+	if compression == NOCOMPRESSION {
+		if res, input := sniffBuffer(validReaders, buffer, name); res == true {
+			return true, []InputFile{input}, nil
+		}
+
+		for _, reader := range validReaders {
+			if reader.CheckFile(name) == true {
+				input := *reader.NewInputFileForBuffer(buffer, name)
+				input.Detection = fmt.Sprintf("the file extension \"%s\"", filepath.Ext(name))
+				return true, []InputFile{input}, nil
+			}
+		}
+
+		res, input := GetInputFileFromBuffer(validReaders, buffer, name)
+		if res == true {
+			return true, []InputFile{input}, nil
+		}
+
+		return false, nil, nil
+	}
+
+	if depth >= MaximalArchiveDepth {
+		return false, nil, NewDecompressionError(name, "Too many nested compressed files")
+	}
+
+	return expandCompressedBuffer(validReaders, buffer, name, compression, depth+1)
+}
+
+func expandCompressedBuffer(validReaders []TrackReader, buffer []byte, name string, compression CompressionType, depth int) (bool, []InputFile, error) {
+	switch compression {
+	case GZIP:
+		reader, errOpen := gzip.NewReader(bytes.NewReader(buffer))
+		if errOpen != nil {
+			return false, nil, NewDecompressionError(name, errOpen.Error())
+		}
+		defer reader.Close()
+		content, errRead := ioutil.ReadAll(reader)
+		if errRead != nil {
+			return false, nil, NewDecompressionError(name, errRead.Error())
+		}
+
+		return getInputFilesFromBuffer(validReaders, content, getInnerName(name, GzipFileExtension, reader.Header.Name), depth)
+	case BZIP2:
+		content, errRead := ioutil.ReadAll(bzip2.NewReader(bytes.NewReader(buffer)))
+		if errRead != nil {
+			return false, nil, NewDecompressionError(name, errRead.Error())
+		}
+
+		return getInputFilesFromBuffer(validReaders, content, getInnerName(name, Bzip2FileExtension, ""), depth)
+	case ZIP:
+		return expandZipBuffer(validReaders, buffer, name, depth)
+	case TAR:
+		return expandTarBuffer(validReaders, buffer, name, name, depth)
+	}
+
+	return false, nil, NewDecompressionError(name, "Unknown compression")
+}
+
+func expandZipBuffer(validReaders []TrackReader, buffer []byte, name string, depth int) (bool, []InputFile, error) {
+	archive, errOpen := zip.NewReader(bytes.NewReader(buffer), int64(len(buffer)))
+	if errOpen != nil {
+		return false, nil, NewDecompressionError(name, errOpen.Error())
+	}
+
+	var ret []InputFile
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		content, errRead := readZipFile(file)
+		if errRead != nil {
+			return false, nil, NewDecompressionError(name, errRead.Error())
+		}
+
+		res, inputs, errExpand := getInputFilesFromBuffer(validReaders, content, filepath.Join(name, file.Name), depth)
+		if errExpand != nil {
+			return false, nil, errExpand
+		}
+		if res == true {
+			ret = append(ret, inputs...)
+		}
+	}
+
+	return len(ret) > 0, ret, nil
+}
+
+// expandTarBuffer - Get the InputFiles of the files in a tar archive. The name of a file is joined to the entryPrefix
+func expandTarBuffer(validReaders []TrackReader, buffer []byte, name string, entryPrefix string, depth int) (bool, []InputFile, error) {
+	archive := tar.NewReader(bytes.NewReader(buffer))
+
+	var ret []InputFile
+	for {
+		header, errNext := archive.Next()
+		if errNext == io.EOF {
+			break
+		}
+		if errNext != nil {
+			return false, nil, NewDecompressionError(name, errNext.Error())
+		}
+
+		if !header.FileInfo().Mode().IsRegular() {
+			continue
+		}
+
+		content, errRead := ioutil.ReadAll(archive)
+		if errRead != nil {
+			return false, nil, NewDecompressionError(name, errRead.Error())
+		}
+
+		res, inputs, errExpand := getInputFilesFromBuffer(validReaders, content, filepath.Join(entryPrefix, header.Name), depth)
+		if errExpand != nil {
+			return false, nil, errExpand
+		}
+		if res == true {
+			ret = append(ret, inputs...)
+		}
+	}
+
+	return len(ret) > 0, ret, nil
+}
+
+// getStreamedInputFile - Get the InputFile of a gzip or bzip2 compressed file, that is expanded while its track is read.
+// Only the start of the expanded content is read here. Return false, when the content is compressed again or
+// the reader can only be found by checking the whole content
+func getStreamedInputFile(validReaders []TrackReader, path string, compression CompressionType) (bool, InputFile, error) {
+	input := InputFile{Name: path, Path: path, Compression: compression}
+	content, errOpen := input.OpenContent()
+	if errOpen != nil {
+		return false, InputFile{}, errOpen
+	}
+	defer content.Close()
+
+	// A truncated stream fails here, when its end is in the head
+	head, errRead := ioutil.ReadAll(io.LimitReader(content, int64(SniffSize)))
+	if errRead != nil {
+		return false, InputFile{}, NewDecompressionError(path, errRead.Error())
+	}
+	if GetCompressionTypeFromBuffer(head) != NOCOMPRESSION {
+		return false, InputFile{}, nil
+	}
+
+	innerName := getInnerName(path, Bzip2FileExtension, "")
+	if gzipReader, ok := content.(*expandingReader).Reader.(*gzip.Reader); ok {
+		innerName = getInnerName(path, GzipFileExtension, gzipReader.Header.Name)
+	}
+
+	for _, reader := range validReaders {
+		if sniffer, ok := reader.(ContentSniffer); ok {
+			if res, reason := sniffer.SniffContent(head); res == true {
+				return true, newStreamedInputFile(reader, innerName, path, compression, reason), nil
+			}
+		}
+	}
+
+	for _, reader := range validReaders {
+		if reader.CheckFile(innerName) == true {
+			reason := fmt.Sprintf("the file extension \"%s\"", filepath.Ext(innerName))
+			return true, newStreamedInputFile(reader, innerName, path, compression, reason), nil
+		}
+	}
+
+	return false, InputFile{}, nil
+}
+
+// newStreamedInputFile - Get a buffer InputFile of the reader, that is read from the compressed file at the path
+func newStreamedInputFile(reader TrackReader, name string, path string, compression CompressionType, detection string) InputFile {
+	input := *reader.NewInputFileForBuffer(nil, name)
+	input.Path = path
+	input.Compression = compression
+	input.Detection = detection
+
+	return input
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, errOpen := file.Open()
+	if errOpen != nil {
+		return nil, errOpen
+	}
+	defer reader.Close()
+
+	return ioutil.ReadAll(reader)
+}
+
+// getInnerName - Get the name of the compressed file. This is the name without the compression extension,
+// or the original name stored in the compressed data, when the name has no compression extension
+func getInnerName(name string, extension string, originalName string) string {
+	if strings.HasSuffix(strings.ToLower(name), extension) {
+		return name[:len(name)-len(extension)]
+	}
+
+	if originalName != "" {
+		return filepath.Join(name, originalName)
+	}
+
+	return name
+}
+
+// getCompressionTypeFromFile - Get the CompressionType of a file by its magic bytes. NOCOMPRESSION when the file can not be read
+func getCompressionTypeFromFile(path string) CompressionType {
+	file, errOpen := os.Open(path)
+	if errOpen != nil {
+		return NOCOMPRESSION
+	}
+	defer file.Close()
+
+	magic := make([]byte, tarMagicOffset+len(tarMagic))
+	count, errRead := io.ReadFull(file, magic)
+	if errRead != nil && errRead != io.ErrUnexpectedEOF {
+		return NOCOMPRESSION
+	}
+
+	return GetCompressionTypeFromBuffer(magic[:count])
+}
+
+func checkFileWithReaders(validReaders []TrackReader, path string) bool {
+	for _, reader := range validReaders {
+		if reader.CheckFile(path) == true {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkBufferWithReaders(validReaders []TrackReader, buffer []byte) bool {
+	for _, reader := range validReaders {
+		if reader.CheckBuffer(buffer) == true {
+			return true
+		}
+	}
+
+	return false
+}
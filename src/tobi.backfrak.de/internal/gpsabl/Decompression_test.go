@@ -0,0 +1,322 @@
+package gpsabl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGetCompressionTypeFromPath(t *testing.T) {
+	expected := map[string]CompressionType{
+		"my/file.gpx.gz":  GZIP,
+		"my/file.TCX.GZ":  GZIP,
+		"my/file.fit.bz2": BZIP2,
+		"my/export.zip":   ZIP,
+		"my/tracks.tar":   TAR,
+		"my/file.gpx":     NOCOMPRESSION,
+		"my/file.kmz":     NOCOMPRESSION,
+	}
+
+	for path, compression := range expected {
+		if GetCompressionTypeFromPath(path) != compression {
+			t.Errorf("The CompressionType of %s is %s, but should be %s", path, GetCompressionTypeFromPath(path), compression)
+		}
+	}
+}
+
+func TestGetCompressionTypeFromBuffer(t *testing.T) {
+	if GetCompressionTypeFromBuffer(getGzipBuffer(t, []byte("abc"), "")) != GZIP {
+		t.Errorf("The gzip buffer is not detected")
+	}
+
+	if GetCompressionTypeFromBuffer([]byte("BZh91AY&SY")) != BZIP2 {
+		t.Errorf("The bzip2 buffer is not detected")
+	}
+
+	if GetCompressionTypeFromBuffer(getZipBuffer(t, map[string][]byte{"a.abc": []byte("abc")})) != ZIP {
+		t.Errorf("The zip buffer is not detected")
+	}
+
+	if GetCompressionTypeFromBuffer(getTarBuffer(t, []string{"a.abc"}, [][]byte{[]byte("abc")})) != TAR {
+		t.Errorf("The tar buffer is not detected")
+	}
+
+	if GetCompressionTypeFromBuffer([]byte("<?xml")) != NOCOMPRESSION {
+		t.Errorf("The xml buffer is detected as compressed")
+	}
+
+	if GetCompressionTypeFromBuffer([]byte{}) != NOCOMPRESSION {
+		t.Errorf("The empty buffer is detected as compressed")
+	}
+}
+
+func TestGetInputFilesFromBufferGzip(t *testing.T) {
+	readers := []TrackReader{&readerMock{}}
+
+	res, inputs, err := GetInputFilesFromBuffer(readers, getGzipBuffer(t, []byte("xyzabc"), "track.abc"), "Buffer 1")
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if res != true || len(inputs) != 1 {
+		t.Fatalf("Got %d InputFiles, but expected %d", len(inputs), 1)
+	}
+
+	if inputs[0].Name != filepath.Join("Buffer 1", "track.abc") {
+		t.Errorf("The InputFile.Name is \"%s\", but should be \"%s\"", inputs[0].Name, filepath.Join("Buffer 1", "track.abc"))
+	}
+
+	if string(inputs[0].Buffer) != "xyzabc" || inputs[0].Type != InputFileType("abcType") {
+		t.Errorf("The InputFile does not contain the expanded buffer")
+	}
+}
+
+func TestGetInputFilesFromBufferNotCompressed(t *testing.T) {
+	readers := []TrackReader{&readerMock{}}
+
+	res, inputs, err := GetInputFilesFromBuffer(readers, []byte("xyzabc"), "Buffer 1")
+	if err != nil || res != true || len(inputs) != 1 {
+		t.Fatalf("Can not get the InputFile of a not compressed buffer")
+	}
+
+	if inputs[0].Name != "Buffer 1" {
+		t.Errorf("The InputFile.Name is \"%s\", but should be \"%s\"", inputs[0].Name, "Buffer 1")
+	}
+
+	res, _, _ = GetInputFilesFromBuffer(readers, []byte("xyz"), "Buffer 1")
+	if res == true {
+		t.Errorf("Got an InputFile for a buffer the reader can not read")
+	}
+}
+
+func TestGetInputFilesFromBufferZip(t *testing.T) {
+	readers := []TrackReader{&readerMock{}}
+	archive := getZipBuffer(t, map[string][]byte{
+		"a.abc":            []byte("first abc"),
+		"dir/b.abc.gz":     getGzipBuffer(t, []byte("second abc"), ""),
+		"readme.txt":       []byte("nothing to read"),
+		"dir/inner.zip":    getZipBuffer(t, map[string][]byte{"c.abc": []byte("third abc")}),
+		"dir/not/a/track/": nil,
+	})
+
+	res, inputs, err := GetInputFilesFromBuffer(readers, archive, "export.zip")
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if res != true || len(inputs) != 3 {
+		t.Fatalf("Got %d InputFiles, but expected %d", len(inputs), 3)
+	}
+
+	names := map[string]bool{}
+	for _, input := range inputs {
+		names[input.Name] = true
+	}
+	for _, name := range []string{"a.abc", filepath.Join("dir", "b.abc"), filepath.Join("dir", "inner.zip", "c.abc")} {
+		if !names[filepath.Join("export.zip", name)] {
+			t.Errorf("The InputFile for %s is missing in %v", name, names)
+		}
+	}
+}
+
+func TestGetInputFilesFromBufferZipWithoutTracks(t *testing.T) {
+	readers := []TrackReader{&readerMock{}}
+	archive := getZipBuffer(t, map[string][]byte{"readme.txt": []byte("nothing to read")})
+
+	res, inputs, err := GetInputFilesFromBuffer(readers, archive, "export.zip")
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if res == true || len(inputs) != 0 {
+		t.Errorf("Got %d InputFiles, but expected none", len(inputs))
+	}
+}
+
+func TestGetInputFilesFromBufferTar(t *testing.T) {
+	readers := []TrackReader{&readerMock{}}
+	archive := getTarBuffer(t, []string{"a.abc", "dir/", "dir/b.abc", "readme.txt"},
+		[][]byte{[]byte("first abc"), nil, []byte("second abc"), []byte("nothing to read")})
+
+	res, inputs, err := GetInputFilesFromBuffer(readers, getGzipBuffer(t, archive, ""), "tracks.tar.gz")
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if res != true || len(inputs) != 2 {
+		t.Fatalf("Got %d InputFiles, but expected %d", len(inputs), 2)
+	}
+
+	if inputs[0].Name != filepath.Join("tracks.tar", "a.abc") || inputs[1].Name != filepath.Join("tracks.tar", "dir", "b.abc") {
+		t.Errorf("The InputFiles are named \"%s\" and \"%s\"", inputs[0].Name, inputs[1].Name)
+	}
+
+	if string(inputs[1].Buffer) != "second abc" {
+		t.Errorf("The InputFile does not contain the file content, but \"%s\"", string(inputs[1].Buffer))
+	}
+}
+
+func TestGetInputFilesFromBufferTooDeep(t *testing.T) {
+	readers := []TrackReader{&readerMock{}}
+	buffer := []byte("xyzabc")
+	for i := 0; i <= MaximalArchiveDepth; i++ {
+		buffer = getGzipBuffer(t, buffer, "")
+	}
+
+	_, _, err := GetInputFilesFromBuffer(readers, buffer, "Buffer 1")
+	switch err.(type) {
+	case *DecompressionError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *DecompressionError, got \"%v\"", err)
+	}
+}
+
+func TestGetInputFilesFromBufferBrokenGzip(t *testing.T) {
+	readers := []TrackReader{&readerMock{}}
+	buffer := getGzipBuffer(t, bytes.Repeat([]byte("xyzabc"), 1000), "")
+
+	_, _, err := GetInputFilesFromBuffer(readers, buffer[:len(buffer)/2], "Buffer 1")
+	switch err.(type) {
+	case *DecompressionError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *DecompressionError, got \"%v\"", err)
+	}
+}
+
+func TestGetInputFilesFromPath(t *testing.T) {
+	readers := []TrackReader{&readerMock{}}
+	dir, errDir := ioutil.TempDir("", "gpsabl")
+	if errDir != nil {
+		t.Fatalf("Can not create a temp dir: %s", errDir)
+	}
+	defer os.RemoveAll(dir)
+
+	compressed := filepath.Join(dir, "track.abc.gz")
+	ioutil.WriteFile(compressed, getGzipBuffer(t, []byte("xyzabc"), ""), 0600)
+	res, inputs, err := GetInputFilesFromPath(readers, compressed)
+	if err != nil || res != true || len(inputs) != 1 {
+		t.Fatalf("Can not get the InputFile of %s", compressed)
+	}
+
+	if inputs[0].Name != filepath.Join(dir, "track.abc") || inputs[0].Type != InputFileType("abcType") {
+		t.Errorf("The InputFile is not as expected: %s %s", inputs[0].Name, inputs[0].Type)
+	}
+
+	// The compressed file is expanded while it is read
+	if inputs[0].Buffer != nil || inputs[0].Path != compressed || inputs[0].Compression != GZIP {
+		t.Errorf("The InputFile of %s is expanded into a buffer", compressed)
+	}
+	if content, errRead := inputs[0].ReadContent(); errRead != nil || string(content) != "xyzabc" {
+		t.Errorf("The expanded content is \"%s\", but should be \"xyzabc\"", content)
+	}
+
+	// Detected by the magic bytes
+	noExtension := filepath.Join(dir, "track")
+	ioutil.WriteFile(noExtension, getGzipBuffer(t, []byte("xyzabc"), "original.abc"), 0600)
+	res, inputs, err = GetInputFilesFromPath(readers, noExtension)
+	if err != nil || res != true || len(inputs) != 1 {
+		t.Fatalf("Can not get the InputFile of %s", noExtension)
+	}
+
+	if inputs[0].Name != filepath.Join(noExtension, "original.abc") {
+		t.Errorf("The InputFile.Name is \"%s\", but should be \"%s\"", inputs[0].Name, filepath.Join(noExtension, "original.abc"))
+	}
+
+	// Compressed data in a compressed file is expanded into a buffer
+	nested := filepath.Join(dir, "nested.abc.gz.gz")
+	ioutil.WriteFile(nested, getGzipBuffer(t, getGzipBuffer(t, []byte("xyzabc"), ""), ""), 0600)
+	res, inputs, err = GetInputFilesFromPath(readers, nested)
+	if err != nil || res != true || len(inputs) != 1 {
+		t.Fatalf("Can not get the InputFile of %s", nested)
+	}
+
+	if string(inputs[0].Buffer) != "xyzabc" || inputs[0].Name != filepath.Join(dir, "nested.abc") {
+		t.Errorf("The InputFile of %s does not contain the expanded buffer", nested)
+	}
+
+	// Not compressed files are read by path
+	plain := filepath.Join(dir, "plain.abc")
+	ioutil.WriteFile(plain, []byte("xyzabc"), 0600)
+	res, inputs, err = GetInputFilesFromPath(readers, plain)
+	if err != nil || res != true || len(inputs) != 1 || inputs[0].Type != FilePath {
+		t.Fatalf("Can not get the InputFile of %s", plain)
+	}
+
+	// A zip archive with a known extension is handled by the reader
+	kmzLike := filepath.Join(dir, "archive.abc")
+	ioutil.WriteFile(kmzLike, getZipBuffer(t, map[string][]byte{"doc.abc": []byte("abc")}), 0600)
+	res, inputs, err = GetInputFilesFromPath(readers, kmzLike)
+	if err != nil || res != true || len(inputs) != 1 || inputs[0].Type != FilePath {
+		t.Fatalf("The InputFile of %s is not read by path", kmzLike)
+	}
+
+	res, _, err = GetInputFilesFromPath(readers, filepath.Join(dir, "not", "existing.gpx"))
+	if res == true || err != nil {
+		t.Errorf("Got an InputFile for a not existing file with unknown extension")
+	}
+
+	_, _, err = GetInputFilesFromPath(readers, filepath.Join(dir, "not", "existing.abc.gz"))
+	if err == nil {
+		t.Errorf("Got no error for a not existing compressed file")
+	}
+}
+
+func getGzipBuffer(t *testing.T, content []byte, originalName string) []byte {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	writer.Name = originalName
+	if _, err := writer.Write(content); err != nil {
+		t.Fatalf("Can not write gzip data: %s", err)
+	}
+	writer.Close()
+
+	return buffer.Bytes()
+}
+
+func getZipBuffer(t *testing.T, files map[string][]byte) []byte {
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for name, content := range files {
+		file, err := writer.Create(name)
+		if err != nil {
+			t.Fatalf("Can not create zip entry: %s", err)
+		}
+		file.Write(content)
+	}
+	writer.Close()
+
+	return buffer.Bytes()
+}
+
+// getTarBuffer - Get a tar archive with the given files. Names ending with "/" are directories
+func getTarBuffer(t *testing.T, names []string, contents [][]byte) []byte {
+	var buffer bytes.Buffer
+	writer := tar.NewWriter(&buffer)
+	for i, name := range names {
+		header := tar.Header{Name: name, Mode: 0600, Size: int64(len(contents[i])), Typeflag: tar.TypeReg}
+		if strings.HasSuffix(name, "/") {
+			header.Typeflag = tar.TypeDir
+		}
+		if err := writer.WriteHeader(&header); err != nil {
+			t.Fatalf("Can not write tar header: %s", err)
+		}
+		writer.Write(contents[i])
+	}
+	writer.Close()
+
+	return buffer.Bytes()
+}
@@ -113,3 +113,119 @@ func (e *TimeFormatNotKnown) Error() string { // Implement the Error Interface f
 func NewTimeFormatNotKnown(givenValue TimeFormat) *TimeFormatNotKnown {
 	return &TimeFormatNotKnown{fmt.Sprintf("The given -summary \"%s\" is not known.", givenValue), givenValue}
 }
+
+// DecompressionError - Error when a compressed input can not be expanded
+type DecompressionError struct {
+	err string
+	// Name - The path or buffer name of the compressed input
+	Name string
+}
+
+func (e *DecompressionError) Error() string { // Implement the Error Interface for the DecompressionError struct
+	return fmt.Sprintf("%s", e.err)
+}
+
+// NewDecompressionError - Get a new DecompressionError struct
+func NewDecompressionError(name string, reason string) *DecompressionError {
+	return &DecompressionError{fmt.Sprintf("Can not decompress \"%s\": %s", name, reason), name}
+}
+
+// PathPatternError - Error when a glob pattern for input files is not valid
+type PathPatternError struct {
+	err string
+	// Pattern - The pattern that is not valid
+	Pattern string
+}
+
+func (e *PathPatternError) Error() string { // Implement the Error Interface for the PathPatternError struct
+	return fmt.Sprintf("%s", e.err)
+}
+
+// NewPathPatternError - Get a new PathPatternError struct
+func NewPathPatternError(pattern string) *PathPatternError {
+	return &PathPatternError{fmt.Sprintf("The file pattern \"%s\" is not valid.", pattern), pattern}
+}
+
+// StreamDocumentError - Error when a document of a multi document input stream can not be read
+type StreamDocumentError struct {
+	err string
+	// Name - The name of the document in the stream
+	Name string
+}
+
+func (e *StreamDocumentError) Error() string { // Implement the Error Interface for the StreamDocumentError struct
+	return fmt.Sprintf("%s", e.err)
+}
+
+// NewStreamDocumentError - Get a new StreamDocumentError struct
+func NewStreamDocumentError(name string) *StreamDocumentError {
+	return &StreamDocumentError{fmt.Sprintf("The format of \"%s\" in the input stream is not known.", name), name}
+}
+
+// StreamDocumentSizeError - Error when a document of the input stream is shorter than the size given in its header
+type StreamDocumentSizeError struct {
+	err string
+	// Name - The name of the document in the stream
+	Name string
+	// Size - The size of the document given in its header
+	Size int
+	// Length - The number of bytes left in the stream
+	Length int
+}
+
+func (e *StreamDocumentSizeError) Error() string { // Implement the Error Interface for the StreamDocumentSizeError struct
+	return fmt.Sprintf("%s", e.err)
+}
+
+// NewStreamDocumentSizeError - Get a new StreamDocumentSizeError struct
+func NewStreamDocumentSizeError(name string, size int, length int) *StreamDocumentSizeError {
+	return &StreamDocumentSizeError{fmt.Sprintf("The \"%s\" in the input stream has a size of %d bytes, but only %d bytes are left in the stream.", name, size, length), name, size, length}
+}
+
+// SortColumnNotKnownError - Error when the given -sort-by column is not known
+type SortColumnNotKnownError struct {
+	err string
+	// GivenValue - The column that is not known
+	GivenValue SortColumn
+}
+
+func (e *SortColumnNotKnownError) Error() string { // Implement the Error Interface for the SortColumnNotKnownError struct
+	return fmt.Sprintf("%s", e.err)
+}
+
+// NewSortColumnNotKnownError - Get a new SortColumnNotKnownError struct
+func NewSortColumnNotKnownError(givenValue SortColumn) *SortColumnNotKnownError {
+	return &SortColumnNotKnownError{fmt.Sprintf("The given -sort-by \"%s\" is not known. Valid values are: %s", givenValue, GetValidSortColumnsString()), givenValue}
+}
+
+// SortOrderNotKnownError - Error when the given -sort-order is not known
+type SortOrderNotKnownError struct {
+	err string
+	// GivenValue - The order that is not known
+	GivenValue SortOrder
+}
+
+func (e *SortOrderNotKnownError) Error() string { // Implement the Error Interface for the SortOrderNotKnownError struct
+	return fmt.Sprintf("%s", e.err)
+}
+
+// NewSortOrderNotKnownError - Get a new SortOrderNotKnownError struct
+func NewSortOrderNotKnownError(givenValue SortOrder) *SortOrderNotKnownError {
+	return &SortOrderNotKnownError{fmt.Sprintf("The given -sort-order \"%s\" is not known. Valid values are: %s", givenValue, GetValidSortOrdersString()), givenValue}
+}
+
+// TrackFileCacheError - Error when the cache directory can not be used
+type TrackFileCacheError struct {
+	err string
+	// Directory - The cache directory
+	Directory string
+}
+
+func (e *TrackFileCacheError) Error() string { // Implement the Error Interface for the TrackFileCacheError struct
+	return fmt.Sprintf("%s", e.err)
+}
+
+// NewTrackFileCacheError - Get a new TrackFileCacheError struct
+func NewTrackFileCacheError(directory string, reason string) *TrackFileCacheError {
+	return &TrackFileCacheError{fmt.Sprintf("Can not use the cache directory \"%s\": %s", directory, reason), directory}
+}
@@ -98,3 +98,81 @@ func TestNewTimeFormatNotKnown(t *testing.T) {
 		t.Errorf("The error message of DepthParameterNotKnownError does not contain the expected GivenValue")
 	}
 }
+
+func TestNewPathPatternError(t *testing.T) {
+	val := "data/[*.gpx"
+	err := NewPathPatternError(val)
+
+	if err.Pattern != val {
+		t.Errorf("The Pattern was %s, but %s was expected", err.Pattern, val)
+	}
+
+	if strings.Contains(err.Error(), val) == false {
+		t.Errorf("The error message of PathPatternError does not contain the expected Pattern")
+	}
+}
+
+func TestNewStreamDocumentError(t *testing.T) {
+	val := "Input stream buffer 2"
+	err := NewStreamDocumentError(val)
+
+	if err.Name != val {
+		t.Errorf("The Name was %s, but %s was expected", err.Name, val)
+	}
+
+	if strings.Contains(err.Error(), val) == false {
+		t.Errorf("The error message of StreamDocumentError does not contain the expected Name")
+	}
+}
+
+func TestNewStreamDocumentSizeError(t *testing.T) {
+	val := "Input stream buffer 2"
+	err := NewStreamDocumentSizeError(val, 1024, 100)
+
+	if err.Name != val || err.Size != 1024 || err.Length != 100 {
+		t.Errorf("The Name, Size and Length were %s, %d and %d, but %s, %d and %d were expected", err.Name, err.Size, err.Length, val, 1024, 100)
+	}
+
+	if strings.Contains(err.Error(), val) == false || strings.Contains(err.Error(), "1024") == false {
+		t.Errorf("The error message of StreamDocumentSizeError does not contain the expected Name and Size")
+	}
+}
+
+func TestNewSortColumnNotKnownError(t *testing.T) {
+	val := "Speed"
+	err := NewSortColumnNotKnownError(SortColumn(val))
+
+	if err.GivenValue != SortColumn(val) {
+		t.Errorf("The GivenValue was %s, but %s was expected", err.GivenValue, val)
+	}
+
+	if strings.Contains(err.Error(), val) == false {
+		t.Errorf("The error message of SortColumnNotKnownError does not contain the expected GivenValue")
+	}
+}
+
+func TestNewSortOrderNotKnownError(t *testing.T) {
+	val := "up"
+	err := NewSortOrderNotKnownError(SortOrder(val))
+
+	if err.GivenValue != SortOrder(val) {
+		t.Errorf("The GivenValue was %s, but %s was expected", err.GivenValue, val)
+	}
+
+	if strings.Contains(err.Error(), val) == false {
+		t.Errorf("The error message of SortOrderNotKnownError does not contain the expected GivenValue")
+	}
+}
+
+func TestNewTrackFileCacheError(t *testing.T) {
+	val := "/my/cache"
+	err := NewTrackFileCacheError(val, "permission denied")
+
+	if err.Directory != val {
+		t.Errorf("The Directory was %s, but %s was expected", err.Directory, val)
+	}
+
+	if strings.Contains(err.Error(), val) == false || strings.Contains(err.Error(), "permission denied") == false {
+		t.Errorf("The error message of TrackFileCacheError does not contain the expected Directory and reason")
+	}
+}
@@ -0,0 +1,255 @@
+package gpsabl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// globMetaChars - A input argument that contains one of this chars is handled as glob pattern
+const globMetaChars string = "*?["
+
+// recursiveWildcard - The pattern segment that matches any number of directories
+const recursiveWildcard string = "**"
+
+// InputPathOptions - Tells how directories and glob patterns given as input are expanded
+type InputPathOptions struct {
+	// MaximalDepth - The number of sub directory levels walked below a given directory. 0 means only the files in the directory, a negative value means no limit
+	MaximalDepth int
+	// Include - Glob patterns, a found file must match one of them when given. A pattern without "/" is matched against the file name,
+	// other patterns against the path relative to the walked directory
+	Include []string
+	// Exclude - Glob patterns, found files and directories that match one of them are skipped. Matched like the Include patterns
+	Exclude []string
+}
+
+// SkippedPath - A directory that was found while expanding an input argument, but was not walked
+type SkippedPath struct {
+	Path   string
+	Reason string
+}
+
+// pathWalker - Walks a directory tree and collects the files
+type pathWalker struct {
+	options InputPathOptions
+	root    string
+	// pattern - The glob pattern segments the found files must match, nil when all files are collected
+	pattern  []string
+	maxDepth int
+	files    []string
+	skipped  []SkippedPath
+}
+
+// IsPathPattern - Check if an input argument is a glob pattern
+func IsPathPattern(arg string) bool {
+	return strings.ContainsAny(arg, globMetaChars)
+}
+
+// CheckPathPattern - Check if a glob pattern is valid. "**" matches any number of directories
+func CheckPathPattern(pattern string) error {
+	for _, segment := range splitPath(pattern) {
+		if _, err := path.Match(segment, ""); err != nil {
+			return NewPathPatternError(pattern)
+		}
+	}
+
+	return nil
+}
+
+// MatchPathPattern - Check if a path matches a glob pattern. "**" matches any number of directories, the other segments are
+// matched like path.Match does
+func MatchPathPattern(pattern string, filePath string) bool {
+	return matchSegments(splitPath(pattern), splitPath(filePath))
+}
+
+// ExpandInputPath - Get the files for an input argument.
+// - A file is returned as it is, even if it does not exist
+// - A directory is walked recursively up to the options.MaximalDepth
+// - A glob pattern is matched against the files below the directory the pattern starts with. A pattern without "**" walks only as deep as the pattern
+// Symbolic links are followed, links that point to a directory that is already walked (a symlink loop) are skipped.
+// The files of directories are filtered by the Include and Exclude patterns of the options.
+// return the found files sorted by name, and the directories that were not walked with the reason
+// return an error if the pattern is not valid, or the directory the pattern starts with can not be read
+func ExpandInputPath(arg string, options InputPathOptions) ([]string, []SkippedPath, error) {
+	if !IsPathPattern(arg) {
+		info, errStat := os.Stat(arg)
+		if errStat != nil || !info.IsDir() {
+			return []string{arg}, nil, nil
+		}
+		walker := pathWalker{options: options, root: arg, maxDepth: options.MaximalDepth}
+		errWalk := walker.walk(arg, 0, map[string]bool{})
+
+		return walker.files, walker.skipped, errWalk
+	}
+
+	if err := CheckPathPattern(arg); err != nil {
+		return nil, nil, err
+	}
+
+	root, pattern := splitPattern(arg)
+	walker := pathWalker{options: options, root: root, pattern: pattern, maxDepth: len(pattern) - 1}
+	for _, segment := range pattern {
+		if segment == recursiveWildcard {
+			walker.maxDepth = options.MaximalDepth
+		}
+	}
+	info, errStat := os.Stat(root)
+	if errStat != nil {
+		return nil, nil, errStat
+	}
+	if !info.IsDir() {
+		return nil, nil, nil
+	}
+	errWalk := walker.walk(root, 0, map[string]bool{})
+
+	return walker.files, walker.skipped, errWalk
+}
+
+// walk - Collect the files of the directory dir, that is level levels below the root. ancestors contains the real paths of the directories above
+func (walker *pathWalker) walk(dir string, level int, ancestors map[string]bool) error {
+	realDir, errEval := filepath.EvalSymlinks(dir)
+	if errEval != nil {
+		realDir = dir
+	}
+	if ancestors[realDir] {
+		walker.skipped = append(walker.skipped, SkippedPath{dir, fmt.Sprintf("The symbolic link points to \"%s\", that contains the link", realDir)})
+		return nil
+	}
+	ancestors[realDir] = true
+	defer delete(ancestors, realDir)
+
+	entries, errRead := ioutil.ReadDir(dir)
+	if errRead != nil {
+		if level == 0 {
+			return errRead
+		}
+		walker.skipped = append(walker.skipped, SkippedPath{dir, errRead.Error()})
+		return nil
+	}
+
+	for _, entry := range entries {
+		entryPath := filepath.Join(dir, entry.Name())
+		relativePath := walker.getRelativePath(entryPath)
+		if walker.isExcluded(relativePath) {
+			continue
+		}
+
+		isDir := entry.IsDir()
+		if entry.Mode()&os.ModeSymlink != 0 {
+			info, errStat := os.Stat(entryPath)
+			if errStat != nil {
+				walker.skipped = append(walker.skipped, SkippedPath{entryPath, errStat.Error()})
+				continue
+			}
+			isDir = info.IsDir()
+		}
+
+		if isDir {
+			if walker.maxDepth < 0 || level < walker.maxDepth {
+				if err := walker.walk(entryPath, level+1, ancestors); err != nil {
+					return err
+				}
+			}
+		} else if walker.isIncluded(relativePath) {
+			walker.files = append(walker.files, entryPath)
+		}
+	}
+
+	return nil
+}
+
+func (walker *pathWalker) getRelativePath(entryPath string) string {
+	relativePath, errRel := filepath.Rel(walker.root, entryPath)
+	if errRel != nil {
+		return entryPath
+	}
+
+	return relativePath
+}
+
+func (walker *pathWalker) isExcluded(relativePath string) bool {
+	return matchAnyPattern(walker.options.Exclude, relativePath)
+}
+
+func (walker *pathWalker) isIncluded(relativePath string) bool {
+	if walker.pattern != nil && !matchSegments(walker.pattern, splitPath(relativePath)) {
+		return false
+	}
+
+	return len(walker.options.Include) == 0 || matchAnyPattern(walker.options.Include, relativePath)
+}
+
+// matchAnyPattern - Check if the path matches one of the patterns. A pattern without "/" is matched against the last element of the path
+func matchAnyPattern(patterns []string, relativePath string) bool {
+	for _, pattern := range patterns {
+		if !strings.Contains(filepath.ToSlash(pattern), "/") {
+			if matched, _ := path.Match(pattern, filepath.Base(relativePath)); matched {
+				return true
+			}
+		} else if MatchPathPattern(pattern, relativePath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitPattern - Split a glob pattern into the directory without glob meta chars it starts with, and the segments of the rest
+func splitPattern(pattern string) (string, []string) {
+	segments := splitPath(pattern)
+	rootSegments := 0
+	for rootSegments < len(segments)-1 && !IsPathPattern(segments[rootSegments]) {
+		rootSegments++
+	}
+
+	root := strings.Join(segments[:rootSegments], "/")
+	if strings.HasPrefix(filepath.ToSlash(pattern), "/") {
+		root = "/" + root
+	}
+	if root == "" {
+		root = "."
+	}
+
+	return filepath.FromSlash(root), segments[rootSegments:]
+}
+
+func splitPath(filePath string) []string {
+	var ret []string
+	for _, segment := range strings.Split(filepath.ToSlash(filepath.Clean(filePath)), "/") {
+		if segment != "" && segment != "." {
+			ret = append(ret, segment)
+		}
+	}
+
+	return ret
+}
+
+func matchSegments(pattern []string, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == recursiveWildcard {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+	matched, err := path.Match(pattern[0], segments[0])
+
+	return err == nil && matched && matchSegments(pattern[1:], segments[1:])
+}
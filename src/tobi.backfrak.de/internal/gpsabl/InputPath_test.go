@@ -0,0 +1,181 @@
+package gpsabl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// createInputTree - Create a directory tree with track files in a temp dir, and return the path of the tree
+func createInputTree(t *testing.T) string {
+	dir, errDir := ioutil.TempDir("", "gpsabl")
+	if errDir != nil {
+		t.Fatalf("Got error \"%s\" while creating a temp dir", errDir)
+	}
+
+	for _, name := range []string{"01.gpx", "notes.txt", "2020/02.gpx", "2020/03.tcx", "2020/05/04.gpx", "2020/05/06/07.gpx", "skip/08.gpx"} {
+		filePath := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			t.Fatalf("Got error \"%s\" while creating the tree", err)
+		}
+		if err := ioutil.WriteFile(filePath, []byte(name), 0644); err != nil {
+			t.Fatalf("Got error \"%s\" while creating the tree", err)
+		}
+	}
+
+	return dir
+}
+
+func getRelativePaths(root string, files []string) []string {
+	var ret []string
+	for _, file := range files {
+		rel, _ := filepath.Rel(root, file)
+		ret = append(ret, filepath.ToSlash(rel))
+	}
+
+	return ret
+}
+
+func TestExpandInputPathFile(t *testing.T) {
+	for _, arg := range []string{"my/not/existing.gpx", "./InputPath.go"} {
+		files, skipped, err := ExpandInputPath(arg, InputPathOptions{MaximalDepth: -1})
+		if err != nil {
+			t.Errorf("Got error \"%s\" but expected none", err)
+		}
+		if len(files) != 1 || files[0] != arg || len(skipped) != 0 {
+			t.Errorf("The files are %v, but should be [%s]", files, arg)
+		}
+	}
+}
+
+func TestExpandInputPathDirectory(t *testing.T) {
+	dir := createInputTree(t)
+	defer os.RemoveAll(dir)
+
+	expected := map[int]string{
+		-1: "01.gpx 2020/02.gpx 2020/03.tcx 2020/05/04.gpx 2020/05/06/07.gpx notes.txt skip/08.gpx",
+		0:  "01.gpx notes.txt",
+		1:  "01.gpx 2020/02.gpx 2020/03.tcx notes.txt skip/08.gpx",
+	}
+	for depth, expectedFiles := range expected {
+		files, _, err := ExpandInputPath(dir, InputPathOptions{MaximalDepth: depth})
+		if err != nil {
+			t.Fatalf("Got error \"%s\" but expected none", err)
+		}
+		if strings.Join(getRelativePaths(dir, files), " ") != expectedFiles {
+			t.Errorf("The files for depth %d are %v, but should be %s", depth, getRelativePaths(dir, files), expectedFiles)
+		}
+	}
+}
+
+func TestExpandInputPathIncludeExclude(t *testing.T) {
+	dir := createInputTree(t)
+	defer os.RemoveAll(dir)
+
+	options := InputPathOptions{MaximalDepth: -1, Include: []string{"*.gpx"}, Exclude: []string{"skip", "2020/05/**/07.gpx"}}
+	files, _, err := ExpandInputPath(dir, options)
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	expectedFiles := "01.gpx 2020/02.gpx 2020/05/04.gpx"
+	if strings.Join(getRelativePaths(dir, files), " ") != expectedFiles {
+		t.Errorf("The files are %v, but should be %s", getRelativePaths(dir, files), expectedFiles)
+	}
+}
+
+func TestExpandInputPathPattern(t *testing.T) {
+	dir := createInputTree(t)
+	defer os.RemoveAll(dir)
+
+	expected := map[string]string{
+		"*.gpx":         "01.gpx",
+		"*/*.gpx":       "2020/02.gpx skip/08.gpx",
+		"**/*.gpx":      "01.gpx 2020/02.gpx 2020/05/04.gpx 2020/05/06/07.gpx skip/08.gpx",
+		"2020/**/0?.*":  "2020/02.gpx 2020/03.tcx 2020/05/04.gpx 2020/05/06/07.gpx",
+		"2020/**/06/*":  "2020/05/06/07.gpx",
+		"2020/[a-z]*/*": "",
+	}
+	for pattern, expectedFiles := range expected {
+		files, _, err := ExpandInputPath(filepath.Join(dir, pattern), InputPathOptions{MaximalDepth: -1})
+		if err != nil {
+			t.Fatalf("Got error \"%s\" but expected none", err)
+		}
+		if strings.Join(getRelativePaths(dir, files), " ") != expectedFiles {
+			t.Errorf("The files for %s are %v, but should be %s", pattern, getRelativePaths(dir, files), expectedFiles)
+		}
+	}
+}
+
+func TestExpandInputPathInvalidPattern(t *testing.T) {
+	_, _, err := ExpandInputPath("data/[*.gpx", InputPathOptions{MaximalDepth: -1})
+	switch err.(type) {
+	case *PathPatternError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *PathPatternError, got \"%v\"", err)
+	}
+
+	_, _, err = ExpandInputPath("my/not/existing/dir/*.gpx", InputPathOptions{MaximalDepth: -1})
+	switch err.(type) {
+	case *os.PathError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *os.PathError, got \"%v\"", err)
+	}
+}
+
+func TestExpandInputPathSymlinkLoop(t *testing.T) {
+	dir := createInputTree(t)
+	defer os.RemoveAll(dir)
+
+	if err := os.Symlink(filepath.Join(dir, "2020"), filepath.Join(dir, "2020", "05", "loop")); err != nil {
+		t.Skipf("Can not create a symbolic link: %s", err)
+	}
+	if err := os.Symlink(filepath.Join(dir, "skip"), filepath.Join(dir, "link")); err != nil {
+		t.Skipf("Can not create a symbolic link: %s", err)
+	}
+
+	files, skipped, err := ExpandInputPath(dir, InputPathOptions{MaximalDepth: -1, Include: []string{"*.gpx"}})
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	expectedFiles := "01.gpx 2020/02.gpx 2020/05/04.gpx 2020/05/06/07.gpx link/08.gpx skip/08.gpx"
+	if strings.Join(getRelativePaths(dir, files), " ") != expectedFiles {
+		t.Errorf("The files are %v, but should be %s", getRelativePaths(dir, files), expectedFiles)
+	}
+
+	if len(skipped) != 1 || skipped[0].Path != filepath.Join(dir, "2020", "05", "loop") {
+		t.Errorf("The skipped paths are %v, but should be the loop", skipped)
+	}
+}
+
+func TestMatchPathPattern(t *testing.T) {
+	expected := map[string]bool{
+		"**/*.gpx|a/b/c.gpx": true,
+		"**/*.gpx|c.gpx":     true,
+		"a/**|a/b/c.gpx":     true,
+		"a/*.gpx|a/b/c.gpx":  false,
+		"a/**/c.gpx|a/c.gpx": true,
+		"b/**/c.gpx|a/c.gpx": false,
+	}
+	for test, result := range expected {
+		parts := strings.Split(test, "|")
+		if MatchPathPattern(parts[0], parts[1]) != result {
+			t.Errorf("MatchPathPattern(%s, %s) is %t, but should be %t", parts[0], parts[1], !result, result)
+		}
+	}
+
+	if CheckPathPattern("a/[b") == nil || CheckPathPattern("a/**/[bc]*.gpx") != nil {
+		t.Errorf("CheckPathPattern does not work as expected")
+	}
+}
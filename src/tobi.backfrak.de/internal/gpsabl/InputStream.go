@@ -0,0 +1,275 @@
+package gpsabl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// StreamFraming - a string type to implement the enum pattern. Tells how the documents of an input stream are separated
+type StreamFraming string
+
+const (
+	// COMPRESSEDSTREAM - The stream is compressed data or a zip archive
+	COMPRESSEDSTREAM StreamFraming = "compressed"
+	// TARSTREAM - The stream is a tar archive, every file in it keeps its name
+	TARSTREAM StreamFraming = "tar"
+	// NULSEPARATEDSTREAM - The documents in the stream are separated by NUL bytes
+	NULSEPARATEDSTREAM StreamFraming = "NUL separated"
+	// CHAINEDSTREAM - The stream contains chained binary files, like *.fit files, that are split by the size in their header
+	CHAINEDSTREAM StreamFraming = "chained"
+	// CONCATENATEDSTREAM - The stream contains concatenated xml or json documents, with or without xml declaration
+	CONCATENATEDSTREAM StreamFraming = "concatenated"
+	// SINGLESTREAM - The whole stream is one document
+	SINGLESTREAM StreamFraming = "single document"
+)
+
+// DocumentSizer - A TrackReader of a binary format, that tells the size of a document in its header. The documents of an input
+// stream are split by this size, so chained files are read one by one and the NUL bytes in them do not split them
+type DocumentSizer interface {
+	// GetDocumentSize - Get the size of the document at the start of the buffer. Return false if the buffer does not start with a document of the format
+	GetDocumentSize(buffer []byte) (int, bool)
+}
+
+// InputStreamName - The name of the input stream in error messages
+const InputStreamName string = "Input stream"
+
+// GetStreamBufferName - Get the name of the document with the given index (starting with 1) in the input stream
+func GetStreamBufferName(index int) string {
+	return fmt.Sprintf("Input stream buffer %d", index)
+}
+
+// GetInputFilesFromStream - Get the InputFiles for the content of an input stream. The stream may be
+// compressed data, a tar archive, chained binary files, NUL separated documents, concatenated xml and json documents or a single document.
+// The files of a tar archive keep their names, all other documents are named by GetStreamBufferName
+// - validReaders    List of valid TrackReader
+// - buffer          The content of the stream
+// return true, the coresponding InputFiles and the StreamFraming if a valid reader was found for the documents in the stream
+// return false and no InputFile if no document in the stream can be read, the stream may contain a list of files then
+// return an error if the compressed data can not be expanded, or a document in a multi document stream can not be read
+func GetInputFilesFromStream(validReaders []TrackReader, buffer []byte) (bool, []InputFile, StreamFraming, error) {
+	switch GetCompressionTypeFromBuffer(buffer) {
+	case NOCOMPRESSION:
+	case TAR:
+		res, inputs, err := expandTarBuffer(validReaders, buffer, InputStreamName, "", 1)
+		return res, inputs, TARSTREAM, err
+	default:
+		res, inputs, err := GetInputFilesFromBuffer(validReaders, buffer, GetStreamBufferName(1))
+		return res, inputs, COMPRESSEDSTREAM, err
+	}
+
+	if _, sized := getDocumentSize(validReaders, buffer); sized {
+		documents, errSplit := splitDocuments(validReaders, buffer)
+		if errSplit != nil {
+			return false, nil, CHAINEDSTREAM, errSplit
+		}
+		if len(documents) > 1 {
+			res, inputs, err := getInputFilesFromDocuments(validReaders, documents)
+			return res, inputs, CHAINEDSTREAM, err
+		}
+	} else if bytes.IndexByte(buffer, 0) >= 0 && !startsWithBinaryDocument(validReaders, buffer) {
+		documents, errSplit := splitDocuments(validReaders, buffer)
+		if errSplit != nil {
+			return false, nil, NULSEPARATEDSTREAM, errSplit
+		}
+		res, inputs, err := getInputFilesFromDocuments(validReaders, documents)
+		return res, inputs, NULSEPARATEDSTREAM, err
+	}
+
+	if documents, ok := splitConcatenatedDocuments(buffer); ok && len(documents) > 1 {
+		res, inputs, err := getInputFilesFromDocuments(validReaders, documents)
+		return res, inputs, CONCATENATEDSTREAM, err
+	}
+
+	res, input := GetInputFileFromBuffer(validReaders, buffer, GetStreamBufferName(1))
+	if res == true {
+		return true, []InputFile{input}, SINGLESTREAM, nil
+	}
+
+	return false, nil, SINGLESTREAM, nil
+}
+
+// getInputFilesFromDocuments - Get the InputFiles for the documents of a stream. Empty documents are ignored
+// return false if no document can be read, and an error if only some documents can be read
+func getInputFilesFromDocuments(validReaders []TrackReader, documents [][]byte) (bool, []InputFile, error) {
+	var ret []InputFile
+	var unknown []string
+	index := 0
+	for _, document := range documents {
+		if len(bytes.TrimSpace(document)) == 0 {
+			continue
+		}
+		index++
+
+		name := GetStreamBufferName(index)
+		res, inputs, err := GetInputFilesFromBuffer(validReaders, document, name)
+		if err != nil {
+			return false, nil, err
+		}
+		if res == true {
+			ret = append(ret, inputs...)
+		} else {
+			unknown = append(unknown, name)
+		}
+	}
+
+	if len(ret) == 0 {
+		return false, nil, nil
+	}
+
+	if len(unknown) != 0 {
+		return false, nil, NewStreamDocumentError(unknown[0])
+	}
+
+	return true, ret, nil
+}
+
+// getDocumentSize - Get the size of the document at the start of the buffer from the first DocumentSizer that knows its format
+func getDocumentSize(validReaders []TrackReader, buffer []byte) (int, bool) {
+	for _, reader := range validReaders {
+		if sizer, ok := reader.(DocumentSizer); ok {
+			if size, res := sizer.GetDocumentSize(buffer); res == true {
+				return size, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// startsWithBinaryDocument - Tell if the buffer starts with a binary document, that contains NUL bytes. This is the case
+// when a reader recognizes the buffer, but not the part of the buffer in front of the first NUL byte
+func startsWithBinaryDocument(validReaders []TrackReader, buffer []byte) bool {
+	if res, _ := sniffBuffer(validReaders, buffer[:bytes.IndexByte(buffer, 0)], ""); res == true {
+		return false
+	}
+	res, _ := sniffBuffer(validReaders, buffer, "")
+
+	return res
+}
+
+// splitDocuments - Split a buffer of chained binary files and NUL separated documents. A document with the size in its header
+// is split by its size, and may be followed by a NUL byte or the next document. All other documents end at the next NUL byte
+// return an error, if a document is shorter than the size in its header
+func splitDocuments(validReaders []TrackReader, buffer []byte) ([][]byte, error) {
+	var documents [][]byte
+	rest := buffer
+	for len(rest) > 0 {
+		if size, sized := getDocumentSize(validReaders, rest); sized {
+			if size > len(rest) {
+				return nil, NewStreamDocumentSizeError(GetStreamBufferName(countDocuments(documents)+1), size, len(rest))
+			}
+			documents = append(documents, rest[:size])
+			rest = bytes.TrimPrefix(rest[size:], []byte{0})
+			continue
+		}
+
+		end := bytes.IndexByte(rest, 0)
+		if end < 0 {
+			documents = append(documents, rest)
+			break
+		}
+		documents = append(documents, rest[:end])
+		rest = rest[end+1:]
+	}
+
+	return documents, nil
+}
+
+// countDocuments - Count the documents, that are not empty. These are named by getInputFilesFromDocuments
+func countDocuments(documents [][]byte) int {
+	ret := 0
+	for _, document := range documents {
+		if len(bytes.TrimSpace(document)) != 0 {
+			ret++
+		}
+	}
+
+	return ret
+}
+
+// splitConcatenatedDocuments - Split a buffer of concatenated xml and json documents. A document that is neither xml nor json
+// takes the rest of the buffer
+// return false if a xml or json document in the buffer is not complete
+func splitConcatenatedDocuments(buffer []byte) ([][]byte, bool) {
+	var documents [][]byte
+	rest := buffer
+	for {
+		rest = bytes.TrimLeft(rest, " \t\r\n\ufeff")
+		if len(rest) == 0 {
+			return documents, true
+		}
+
+		end := len(rest)
+		hasContent := true
+		ok := true
+		switch rest[0] {
+		case '<':
+			end, hasContent, ok = getXMLDocumentEnd(rest)
+		case '{', '[':
+			end, ok = getJSONDocumentEnd(rest)
+		}
+		if !ok {
+			return nil, false
+		}
+
+		// Comments after the root element of a xml document are no own document
+		if hasContent {
+			documents = append(documents, rest[:end])
+		}
+		rest = rest[end:]
+	}
+}
+
+// getXMLDocumentEnd - Get the offset behind the root element of the xml document at the start of the buffer
+// return false as second value, if the buffer starts with comments, declarations or whitespace only, the offset is behind them then
+// return false as third value, if the buffer does not start with a complete xml document
+func getXMLDocumentEnd(buffer []byte) (int, bool, bool) {
+	decoder := xml.NewDecoder(bytes.NewReader(buffer))
+	decoder.CharsetReader = passThroughCharsetReader
+
+	depth := 0
+	for {
+		start := decoder.InputOffset()
+		token, err := decoder.RawToken()
+		if err == io.EOF && depth == 0 {
+			return len(buffer), false, true
+		}
+		if err != nil {
+			return 0, false, false
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+			if depth == 0 {
+				return int(decoder.InputOffset()), true, true
+			}
+		case xml.CharData:
+			if depth == 0 && len(bytes.TrimSpace(element)) != 0 {
+				return int(start), false, true
+			}
+		}
+	}
+}
+
+// getJSONDocumentEnd - Get the offset behind the json document at the start of the buffer
+// return false if the buffer does not start with a complete json document
+func getJSONDocumentEnd(buffer []byte) (int, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(buffer))
+	var document json.RawMessage
+	if err := decoder.Decode(&document); err != nil {
+		return 0, false
+	}
+
+	return int(decoder.InputOffset()), true
+}
@@ -0,0 +1,227 @@
+package gpsabl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+// binarySnifferMock - A reader that recognizes a binary format with NUL bytes in its header
+type binarySnifferMock struct {
+	readerMock
+}
+
+func (mok *binarySnifferMock) SniffContent(head []byte) (bool, string) {
+	return bytes.HasPrefix(head, []byte("BIN\x00")), "BIN header"
+}
+
+// sizedSnifferMock - A reader of a binary format with the size of the document after the "SIZE" signature
+type sizedSnifferMock struct {
+	readerMock
+}
+
+func (mok *sizedSnifferMock) SniffContent(head []byte) (bool, string) {
+	return bytes.HasPrefix(head, []byte("SIZE")), "SIZE header"
+}
+
+func (mok *sizedSnifferMock) GetDocumentSize(buffer []byte) (int, bool) {
+	if len(buffer) < 5 || !bytes.HasPrefix(buffer, []byte("SIZE")) {
+		return 0, false
+	}
+
+	return int(buffer[4]), true
+}
+
+func TestGetInputFilesFromStreamConcatenated(t *testing.T) {
+	readers := []TrackReader{&snifferMock{}}
+	documents := []string{"<?xml version=\"1.0\"?>\n<abc>1</abc>", "<abc xmlns=\"http://abc.de/\"><x/>2</abc>", "{\"abc\": [3]}", "[\"abc\"]"}
+	stream := fmt.Sprintf("%s\n%s<!-- end -->\n%s%s\n", documents[0], documents[1], documents[2], documents[3])
+
+	res, inputs, framing, err := GetInputFilesFromStream(readers, []byte(stream))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if res != true || framing != CONCATENATEDSTREAM {
+		t.Errorf("The stream is read as %s stream, but should be %s", framing, CONCATENATEDSTREAM)
+	}
+
+	if len(inputs) != len(documents) {
+		t.Fatalf("Got %d InputFiles, but expected %d", len(inputs), len(documents))
+	}
+
+	for i, document := range documents {
+		if string(inputs[i].Buffer) != document {
+			t.Errorf("The document %d is \"%s\", but should be \"%s\"", i+1, string(inputs[i].Buffer), document)
+		}
+
+		if inputs[i].Name != GetStreamBufferName(i+1) {
+			t.Errorf("The document %d is named \"%s\", but should be \"%s\"", i+1, inputs[i].Name, GetStreamBufferName(i+1))
+		}
+	}
+}
+
+func TestGetInputFilesFromStreamNulSeparated(t *testing.T) {
+	readers := []TrackReader{&snifferMock{}}
+
+	res, inputs, framing, err := GetInputFilesFromStream(readers, []byte("abc 1\x00\n<abc>2</abc>\x00"))
+	if err != nil || res != true || framing != NULSEPARATEDSTREAM {
+		t.Fatalf("The stream is not read as %s stream", NULSEPARATEDSTREAM)
+	}
+
+	if len(inputs) != 2 {
+		t.Fatalf("Got %d InputFiles, but expected %d", len(inputs), 2)
+	}
+
+	if string(inputs[0].Buffer) != "abc 1" || inputs[1].Name != GetStreamBufferName(2) {
+		t.Errorf("The InputFiles are not as expected: %v", inputs)
+	}
+
+	// A document at the start of the stream does not hide the documents after the NUL
+	res, inputs, framing, _ = GetInputFilesFromStream(readers, []byte("<abc>1</abc>\x00<abc>2</abc>"))
+	if res != true || framing != NULSEPARATEDSTREAM || len(inputs) != 2 {
+		t.Errorf("The stream is read as %s stream with %d InputFiles", framing, len(inputs))
+	}
+
+	// A part that can not be read is an error, the part is not dropped
+	_, _, _, err = GetInputFilesFromStream(readers, []byte("<abc>1</abc>\x00xyz"))
+	if err == nil {
+		t.Errorf("No error when a part of the NUL separated stream can not be read")
+	}
+
+	// Binary documents may contain NUL bytes
+	res, inputs, framing, _ = GetInputFilesFromStream([]TrackReader{&binarySnifferMock{}}, []byte("BIN\x00\x01\x02\x00xyz"))
+	if res != true || framing != SINGLESTREAM || len(inputs) != 1 {
+		t.Errorf("The binary stream is read as %s stream", framing)
+	}
+
+	// A NUL separated file list is no document stream
+	res, _, _, err = GetInputFilesFromStream(readers, []byte("my/file.gpx\x00my/file.tcx\x00"))
+	if res == true || err != nil {
+		t.Errorf("The NUL separated file list is read as document stream")
+	}
+}
+
+func TestGetInputFilesFromStreamChained(t *testing.T) {
+	readers := []TrackReader{&snifferMock{}, &sizedSnifferMock{}}
+	first := "SIZE\x07\x00\x00"
+	second := "SIZE\x06\x00"
+
+	res, inputs, framing, err := GetInputFilesFromStream(readers, []byte(first+second))
+	if err != nil || res != true || framing != CHAINEDSTREAM {
+		t.Fatalf("The stream is not read as %s stream", CHAINEDSTREAM)
+	}
+	if len(inputs) != 2 || string(inputs[0].Buffer) != first || string(inputs[1].Buffer) != second {
+		t.Errorf("The InputFiles are not split by the size in the header: %v", inputs)
+	}
+
+	// Sized documents are not split at their NUL bytes, when they follow NUL separated documents
+	res, inputs, framing, err = GetInputFilesFromStream(readers, []byte("<abc>1</abc>\x00"+first+"\x00<abc>2</abc>"))
+	if err != nil || res != true || framing != NULSEPARATEDSTREAM {
+		t.Fatalf("The stream is not read as %s stream", NULSEPARATEDSTREAM)
+	}
+	if len(inputs) != 3 || string(inputs[1].Buffer) != first {
+		t.Errorf("The sized document is split at its NUL bytes: %v", inputs)
+	}
+
+	// A single sized document is a single document stream
+	res, inputs, framing, _ = GetInputFilesFromStream(readers, []byte(first))
+	if res != true || framing != SINGLESTREAM || len(inputs) != 1 {
+		t.Errorf("The single sized document is read as %s stream", framing)
+	}
+
+	// Data after the sized documents, that can not be read, is an error
+	_, _, _, err = GetInputFilesFromStream(readers, []byte(first+"xyz"))
+	if err == nil {
+		t.Errorf("No error when data after the sized document can not be read")
+	}
+
+	// A truncated document is an error
+	_, _, _, err = GetInputFilesFromStream(readers, []byte(first+second[:5]))
+	switch err.(type) {
+	case *StreamDocumentSizeError:
+		if err.(*StreamDocumentSizeError).Name != GetStreamBufferName(2) {
+			t.Errorf("The StreamDocumentSizeError.Name is %s, but should be %s", err.(*StreamDocumentSizeError).Name, GetStreamBufferName(2))
+		}
+	default:
+		t.Errorf("Expected a *StreamDocumentSizeError, got \"%v\"", err)
+	}
+}
+
+func TestGetInputFilesFromStreamUnknownDocument(t *testing.T) {
+	readers := []TrackReader{&snifferMock{}}
+
+	_, _, _, err := GetInputFilesFromStream(readers, []byte("abc\x00xyz"))
+	switch err.(type) {
+	case *StreamDocumentError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *StreamDocumentError, got \"%v\"", err)
+	}
+
+	_, _, _, err = GetInputFilesFromStream(readers, []byte("<abc/><xyz/>"))
+	switch err.(type) {
+	case *StreamDocumentError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *StreamDocumentError, got \"%v\"", err)
+	}
+}
+
+func TestGetInputFilesFromStreamTar(t *testing.T) {
+	readers := []TrackReader{&readerMock{}}
+	archive := getTarBuffer(t, []string{"tracks/a.abc", "b.abc", "readme.txt"},
+		[][]byte{[]byte("first abc"), []byte("second abc"), []byte("nothing to read")})
+
+	res, inputs, framing, err := GetInputFilesFromStream(readers, archive)
+	if err != nil || res != true || framing != TARSTREAM {
+		t.Fatalf("The stream is not read as %s stream", TARSTREAM)
+	}
+
+	if len(inputs) != 2 {
+		t.Fatalf("Got %d InputFiles, but expected %d", len(inputs), 2)
+	}
+
+	if inputs[0].Name != filepath.Join("tracks", "a.abc") || inputs[1].Name != "b.abc" {
+		t.Errorf("The InputFiles are named \"%s\" and \"%s\"", inputs[0].Name, inputs[1].Name)
+	}
+
+	// The archive ends in the content of b.abc
+	_, _, _, err = GetInputFilesFromStream(readers, archive[:1540])
+	switch err.(type) {
+	case *DecompressionError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *DecompressionError, got \"%v\"", err)
+	}
+}
+
+func TestGetInputFilesFromStreamSingle(t *testing.T) {
+	readers := []TrackReader{&readerMock{}}
+
+	res, inputs, framing, err := GetInputFilesFromStream(readers, getGzipBuffer(t, []byte("xyzabc"), ""))
+	if err != nil || res != true || framing != COMPRESSEDSTREAM || len(inputs) != 1 {
+		t.Errorf("The stream is not read as %s stream", COMPRESSEDSTREAM)
+	}
+
+	// Not complete xml is no concatenated stream
+	res, inputs, framing, err = GetInputFilesFromStream(readers, []byte("<abc>1</abc><abc>"))
+	if err != nil || res != true || framing != SINGLESTREAM || len(inputs) != 1 {
+		t.Errorf("The stream is not read as %s stream", SINGLESTREAM)
+	}
+
+	if inputs[0].Name != GetStreamBufferName(1) {
+		t.Errorf("The InputFile is named \"%s\", but should be \"%s\"", inputs[0].Name, GetStreamBufferName(1))
+	}
+
+	res, _, _, err = GetInputFilesFromStream(readers, []byte("xyz"))
+	if res == true || err != nil {
+		t.Errorf("Got an InputFile for a stream the reader can not read")
+	}
+}
@@ -62,6 +62,9 @@ type TrackDataArrays struct {
 	AverageSpeeds       []float64
 	UpwardsSpeeds       []float64
 	DownwardsSpeeds     []float64
+	HeartRates          []SensorValues
+	Cadences            []SensorValues
+	Powers              []SensorValues
 }
 
 // TrackStatisticSummaryData - Contains statistic data from a bunch of tracks
@@ -100,6 +103,10 @@ func GetTrackDataArrays(lines []OutputLine) TrackDataArrays {
 		ret.MaximumAltitudes = append(ret.MaximumAltitudes, float64(info.GetMaximumAltitude()))
 		ret.UpwardsDistances = append(ret.UpwardsDistances, info.GetUpwardsDistance())
 		ret.DownwardsDistances = append(ret.DownwardsDistances, info.GetDownwardsDistance())
+		sensor := info.GetSensorSummary()
+		ret.HeartRates = append(ret.HeartRates, sensor.HeartRate)
+		ret.Cadences = append(ret.Cadences, sensor.Cadence)
+		ret.Powers = append(ret.Powers, sensor.Power)
 		if ret.AllTimeDataValid {
 			ret.Durations = append(ret.Durations, info.GetEndTime().Sub(info.GetStartTime()))
 			ret.StartTimes = append(ret.StartTimes, info.GetStartTime())
@@ -165,6 +172,11 @@ func GetStatisticSummaryData(lines []OutputLine) TrackStatisticSummaryData {
 	ret.Maximum.MinimumAltitude = float32(maxFloat64Array(arrays.MinimumAltitudes))
 	ret.Maximum.MaximumAltitude = float32(maxFloat64Array(arrays.MaximumAltitudes))
 
+	// The sum of sensor values makes no sense, so only minimum, average and maximum are set
+	ret.Minimum.HeartRate, ret.Average.HeartRate, ret.Maximum.HeartRate = getSensorValuesStatistic(arrays.HeartRates)
+	ret.Minimum.Cadence, ret.Average.Cadence, ret.Maximum.Cadence = getSensorValuesStatistic(arrays.Cadences)
+	ret.Minimum.Power, ret.Average.Power, ret.Maximum.Power = getSensorValuesStatistic(arrays.Powers)
+
 	ret.Maximum.TimeDataValid = ret.AllTimeDataValid
 	ret.Minimum.TimeDataValid = ret.AllTimeDataValid
 	ret.Average.TimeDataValid = ret.AllTimeDataValid
@@ -238,6 +250,32 @@ func OutputContainsLineByTimeStamps(output []OutputLine, newLine OutputLine) boo
 	return false
 }
 
+// getSensorValuesStatistic - Get the minimum, average and maximum of the sensor values.
+// Values without samples are ignored, so the result is not valid, when no line has sensor data
+func getSensorValuesStatistic(data []SensorValues) (SensorValues, SensorValues, SensorValues) {
+	var minimums, averages, maximums []float64
+	samples := 0
+	for _, value := range data {
+		if value.Valid() {
+			minimums = append(minimums, value.Minimum)
+			averages = append(averages, value.Average)
+			maximums = append(maximums, value.Maximum)
+			samples += value.Samples
+		}
+	}
+
+	if samples <= 0 {
+		return SensorValues{}, SensorValues{}, SensorValues{}
+	}
+
+	count := float64(len(averages))
+	min := SensorValues{Minimum: minFloat64Array(minimums), Average: minFloat64Array(averages), Maximum: minFloat64Array(maximums), Samples: samples}
+	avr := SensorValues{Minimum: sumFloat64Array(minimums) / count, Average: sumFloat64Array(averages) / count, Maximum: sumFloat64Array(maximums) / count, Samples: samples}
+	max := SensorValues{Minimum: maxFloat64Array(minimums), Average: maxFloat64Array(averages), Maximum: maxFloat64Array(maximums), Samples: samples}
+
+	return min, avr, max
+}
+
 func averageDuration(sum time.Duration, count int) time.Duration {
 	timeSumNanoSec := int64(sum)
 	avrDurationNanoSec := timeSumNanoSec / int64(count)
@@ -504,3 +504,35 @@ func getTrackFileTwoTracks() TrackFile {
 
 	return trackFile
 }
+
+func TestGetStatisticSummaryDataSensorValues(t *testing.T) {
+	first := TrackSummary{}
+	first.HeartRate = SensorValues{Minimum: 90, Average: 120, Maximum: 150, Samples: 10}
+	second := TrackSummary{}
+	second.HeartRate = SensorValues{Minimum: 100, Average: 140, Maximum: 170, Samples: 20}
+	third := TrackSummary{}
+	lineBuffer := []OutputLine{*NewOutputLine("1", first), *NewOutputLine("2", second), *NewOutputLine("3", third)}
+
+	summaries := GetStatisticSummaryData(lineBuffer)
+
+	if summaries.Minimum.HeartRate.Minimum != 90 || summaries.Minimum.HeartRate.Average != 120 || summaries.Minimum.HeartRate.Maximum != 150 {
+		t.Errorf("The minimum HeartRate is %v, but {90 120 150} is expected", summaries.Minimum.HeartRate)
+	}
+
+	// Lines without heart rate do not count for the average
+	if summaries.Average.HeartRate.Minimum != 95 || summaries.Average.HeartRate.Average != 130 || summaries.Average.HeartRate.Maximum != 160 {
+		t.Errorf("The average HeartRate is %v, but {95 130 160} is expected", summaries.Average.HeartRate)
+	}
+
+	if summaries.Maximum.HeartRate.Minimum != 100 || summaries.Maximum.HeartRate.Average != 140 || summaries.Maximum.HeartRate.Maximum != 170 {
+		t.Errorf("The maximum HeartRate is %v, but {100 140 170} is expected", summaries.Maximum.HeartRate)
+	}
+
+	if summaries.Sum.HeartRate.Valid() {
+		t.Errorf("The sum of the HeartRate is valid, but should not")
+	}
+
+	if summaries.Average.Cadence.Valid() {
+		t.Errorf("The average Cadence is valid, but no line has a cadence")
+	}
+}
@@ -149,3 +149,9 @@ func (formater *formaterMock) GetNumberOfOutputEntries() int {
 func (formater *formaterMock) GetOutputTableLineCount() int {
 	return len(formater.lineBuffer)
 }
+
+func (formater *formaterMock) SetAddWaypoints(value bool) {
+}
+
+func (formater *formaterMock) SetSorting(sorting OutputSorting) {
+}
@@ -25,6 +25,27 @@ func GetOutlines(trackFile TrackFile, depth DepthArg) ([]OutputLine, error) {
 	return ret, nil
 }
 
+// GetSensorHeaders - Get the column headers of the sensor values, in the order the OutputFormater write them
+func GetSensorHeaders() []string {
+	return []string{
+		"MinimumHeartRate (bpm)", "AverageHeartRate (bpm)", "MaximumHeartRate (bpm)",
+		"MinimumCadence (rpm)", "AverageCadence (rpm)", "MaximumCadence (rpm)",
+		"MinimumPower (W)", "AveragePower (W)", "MaximumPower (W)",
+	}
+}
+
+// GetActivityHeaders - Get the column headers of the activity values, in the order the OutputFormater write them
+func GetActivityHeaders() []string {
+	return []string{"ActivityType", "Gear"}
+}
+
+// GetWaypointHeaders - Get the column headers of the waypoint list, in the order the OutputFormater write them
+func GetWaypointHeaders() []string {
+	return []string{
+		"Name", "Track", "DistanceAlongTrack (km)", "DistanceFromTrack (m)", "Latitude", "Longitude", "Elevation (m)",
+	}
+}
+
 // StripOutlines - Get the input outlines stripped of from inner data, so serialization will work fine
 func StripOutlines(lines []OutputLine) []OutputLine {
 	ret := []OutputLine{}
@@ -41,6 +62,9 @@ func StripOutlines(lines []OutputLine) []OutputLine {
 		data.AltitudeRange = float64(line.Data.GetAltitudeRange())
 		data.UpwardsDistance = line.Data.GetUpwardsDistance()
 		data.DownwardsDistance = line.Data.GetDownwardsDistance()
+		data.SensorSummary = line.Data.GetSensorSummary()
+		data.ActivityType = line.Data.GetActivityType()
+		data.Gear = line.Data.GetGear()
 
 		data.TimeDataValid = line.Data.GetTimeDataValid()
 		if data.TimeDataValid {
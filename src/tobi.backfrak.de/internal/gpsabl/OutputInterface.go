@@ -141,6 +141,12 @@ type OutputFormater interface {
 	// * 0: Output was written but contains no entries, may because no entry passes the given filter
 	// * >0: The number of entries written to the outputs
 	GetNumberOfOutputEntries() int
+
+	// Set if the waypoints of the added TrackFiles should be listed in the output
+	SetAddWaypoints(value bool)
+
+	// Set the order the lines of the output table are written in. See SortOutputLines
+	SetSorting(sorting OutputSorting)
 }
 
 // TextOutputFormater - Interface for classes that can format a track output into a text style file format like csv
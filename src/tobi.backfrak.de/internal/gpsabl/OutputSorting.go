@@ -0,0 +1,326 @@
+package gpsabl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// SortColumn - "Enum" Type that represents the summary columns the output lines can be sorted by
+type SortColumn string
+
+// SortOrder - "Enum" Type that represents the directions the output lines can be sorted in
+type SortOrder string
+
+const (
+	// INPUTORDER - Do not sort, the lines are written in the order of the input files
+	INPUTORDER SortColumn = ""
+	// NAME - Sort by the name of the line
+	NAME SortColumn = "Name"
+	// STARTTIME - Sort by the start time
+	STARTTIME SortColumn = "StartTime"
+	// ENDTIME - Sort by the end time
+	ENDTIME SortColumn = "EndTime"
+	// TRACKTIME - Sort by the time between start and end
+	TRACKTIME SortColumn = "TrackTime"
+	// DISTANCE - Sort by the distance
+	DISTANCE SortColumn = "Distance"
+	// HORIZONTALDISTANCE - Sort by the horizontal distance
+	HORIZONTALDISTANCE SortColumn = "HorizontalDistance"
+	// ALTITUDERANGE - Sort by the altitude range
+	ALTITUDERANGE SortColumn = "AltitudeRange"
+	// MINIMUMALTITUDE - Sort by the minimum altitude
+	MINIMUMALTITUDE SortColumn = "MinimumAltitude"
+	// MAXIMUMALTITUDE - Sort by the maximum altitude
+	MAXIMUMALTITUDE SortColumn = "MaximumAltitude"
+	// ELEVATIONGAIN - Sort by the elevation gain
+	ELEVATIONGAIN SortColumn = "ElevationGain"
+	// ELEVATIONLOSE - Sort by the elevation lose
+	ELEVATIONLOSE SortColumn = "ElevationLose"
+	// UPWARDSDISTANCE - Sort by the distance traveled upwards
+	UPWARDSDISTANCE SortColumn = "UpwardsDistance"
+	// DOWNWARDSDISTANCE - Sort by the distance traveled downwards
+	DOWNWARDSDISTANCE SortColumn = "DownwardsDistance"
+	// MOVINGTIME - Sort by the moving time
+	MOVINGTIME SortColumn = "MovingTime"
+	// UPWARDSTIME - Sort by the time traveled upwards
+	UPWARDSTIME SortColumn = "UpwardsTime"
+	// DOWNWARDSTIME - Sort by the time traveled downwards
+	DOWNWARDSTIME SortColumn = "DownwardsTime"
+	// AVERAGESPEED - Sort by the average speed
+	AVERAGESPEED SortColumn = "AverageSpeed"
+	// UPWARDSSPEED - Sort by the average speed upwards
+	UPWARDSSPEED SortColumn = "UpwardsSpeed"
+	// DOWNWARDSSPEED - Sort by the average speed downwards
+	DOWNWARDSSPEED SortColumn = "DownwardsSpeed"
+	// MINIMUMHEARTRATE - Sort by the minimum heart rate
+	MINIMUMHEARTRATE SortColumn = "MinimumHeartRate"
+	// AVERAGEHEARTRATE - Sort by the average heart rate
+	AVERAGEHEARTRATE SortColumn = "AverageHeartRate"
+	// MAXIMUMHEARTRATE - Sort by the maximum heart rate
+	MAXIMUMHEARTRATE SortColumn = "MaximumHeartRate"
+	// MINIMUMCADENCE - Sort by the minimum cadence
+	MINIMUMCADENCE SortColumn = "MinimumCadence"
+	// AVERAGECADENCE - Sort by the average cadence
+	AVERAGECADENCE SortColumn = "AverageCadence"
+	// MAXIMUMCADENCE - Sort by the maximum cadence
+	MAXIMUMCADENCE SortColumn = "MaximumCadence"
+	// MINIMUMPOWER - Sort by the minimum power
+	MINIMUMPOWER SortColumn = "MinimumPower"
+	// AVERAGEPOWER - Sort by the average power
+	AVERAGEPOWER SortColumn = "AveragePower"
+	// MAXIMUMPOWER - Sort by the maximum power
+	MAXIMUMPOWER SortColumn = "MaximumPower"
+	// ACTIVITYTYPE - Sort by the activity type
+	ACTIVITYTYPE SortColumn = "ActivityType"
+	// GEAR - Sort by the gear
+	GEAR SortColumn = "Gear"
+)
+
+const (
+	// ASCENDING - The smallest value first
+	ASCENDING SortOrder = "asc"
+	// DESCENDING - The biggest value first
+	DESCENDING SortOrder = "desc"
+)
+
+// GetValidSortColumns - Get the columns the output can be sorted by, in the order of the csv output
+func GetValidSortColumns() []SortColumn {
+	return []SortColumn{
+		NAME, STARTTIME, ENDTIME, TRACKTIME, DISTANCE, HORIZONTALDISTANCE, ALTITUDERANGE, MINIMUMALTITUDE, MAXIMUMALTITUDE,
+		ELEVATIONGAIN, ELEVATIONLOSE, UPWARDSDISTANCE, DOWNWARDSDISTANCE, MOVINGTIME, UPWARDSTIME, DOWNWARDSTIME,
+		AVERAGESPEED, UPWARDSSPEED, DOWNWARDSSPEED,
+		MINIMUMHEARTRATE, AVERAGEHEARTRATE, MAXIMUMHEARTRATE, MINIMUMCADENCE, AVERAGECADENCE, MAXIMUMCADENCE,
+		MINIMUMPOWER, AVERAGEPOWER, MAXIMUMPOWER,
+		ACTIVITYTYPE, GEAR,
+	}
+}
+
+// GetValidSortColumnsString - Get a string that contains all valid SortColumn values
+func GetValidSortColumnsString() string {
+	var columns []string
+	for _, column := range GetValidSortColumns() {
+		columns = append(columns, string(column))
+	}
+
+	return strings.Join(columns, " ")
+}
+
+// GetValidNumberColumns - Get the columns that contain numbers, all valid SortColumn values without the Name and the text columns
+func GetValidNumberColumns() []SortColumn {
+	ret := []SortColumn{}
+	for _, column := range GetValidSortColumns() {
+		switch column {
+		case NAME, ACTIVITYTYPE, GEAR:
+		default:
+			ret = append(ret, column)
+		}
+	}
+
+	return ret
+}
+
+// GetValidSortOrders - Get the valid SortOrder values
+func GetValidSortOrders() []SortOrder {
+	return []SortOrder{ASCENDING, DESCENDING}
+}
+
+// GetValidSortOrdersString - Get a string that contains all valid SortOrder values
+func GetValidSortOrdersString() string {
+	return fmt.Sprintf("%s %s", ASCENDING, DESCENDING)
+}
+
+// OutputSorting - Tells in which order the OutputFormater write the lines of the output
+type OutputSorting struct {
+	// Column - The column the lines are sorted by. INPUTORDER keeps the order of the input files
+	Column SortColumn
+	// Order - Tells if the lines are sorted ascending or descending
+	Order SortOrder
+}
+
+// NewOutputSorting - Get the OutputSorting for the given column and order. The column is not case sensitive
+// and may be empty to keep the input order, an empty order sorts ascending. Returns an error if the column or the order is not known
+func NewOutputSorting(column string, order string) (OutputSorting, error) {
+	ret := OutputSorting{INPUTORDER, ASCENDING}
+	if strings.TrimSpace(column) != "" {
+		found := false
+		for _, valid := range GetValidSortColumns() {
+			if strings.EqualFold(string(valid), strings.TrimSpace(column)) {
+				ret.Column = valid
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return OutputSorting{}, NewSortColumnNotKnownError(SortColumn(column))
+		}
+	}
+
+	switch SortOrder(strings.ToLower(strings.TrimSpace(order))) {
+	case ASCENDING, "":
+		ret.Order = ASCENDING
+	case DESCENDING:
+		ret.Order = DESCENDING
+	default:
+		return OutputSorting{}, NewSortOrderNotKnownError(SortOrder(order))
+	}
+
+	return ret, nil
+}
+
+// SortOutputLines - Sort the lines by the column of the sorting. The sort is stable, so lines with the same value keep
+// the order they were added in. Lines without a valid value, like the StartTime of a track without time data, are always
+// sorted behind the lines with a valid value. Nothing is done when the sorting keeps the input order
+func SortOutputLines(lines []OutputLine, sorting OutputSorting) {
+	if sorting.Column == INPUTORDER {
+		return
+	}
+
+	values := make([]sortValue, len(lines))
+	for i, line := range lines {
+		values[i] = getSortValue(line, sorting.Column)
+		if math.IsNaN(values[i].number) {
+			values[i].valid = false
+		}
+	}
+
+	sort.Stable(outputLineSorter{lines, values, sorting.Order == DESCENDING})
+}
+
+// GetNumberValue - Get the value of a line in a column that contains numbers, like it is used for sorting. Times are given as
+// seconds. The value is not valid, when the line has no valid value in the column or the column contains no numbers
+func GetNumberValue(line OutputLine, column SortColumn) (float64, bool) {
+	switch column {
+	case INPUTORDER, NAME, ACTIVITYTYPE, GEAR:
+		return 0, false
+	}
+
+	value := getSortValue(line, column)
+	if !value.valid || math.IsNaN(value.number) {
+		return 0, false
+	}
+
+	return value.number, true
+}
+
+// sortValue - The value of an output line in the sorted column. Text columns use the text, all others the number
+type sortValue struct {
+	number float64
+	text   string
+	valid  bool
+}
+
+// outputLineSorter - Implements sort.Interface to sort the lines together with their values
+type outputLineSorter struct {
+	lines      []OutputLine
+	values     []sortValue
+	descending bool
+}
+
+func (sorter outputLineSorter) Len() int {
+	return len(sorter.lines)
+}
+
+func (sorter outputLineSorter) Swap(i, j int) {
+	sorter.lines[i], sorter.lines[j] = sorter.lines[j], sorter.lines[i]
+	sorter.values[i], sorter.values[j] = sorter.values[j], sorter.values[i]
+}
+
+func (sorter outputLineSorter) Less(i, j int) bool {
+	a := sorter.values[i]
+	b := sorter.values[j]
+	if a.valid != b.valid {
+		return a.valid
+	}
+
+	if !a.valid {
+		return false
+	}
+
+	if sorter.descending {
+		a, b = b, a
+	}
+
+	if a.text != b.text {
+		return a.text < b.text
+	}
+
+	return a.number < b.number
+}
+
+func getSortValue(line OutputLine, column SortColumn) sortValue {
+	data := line.Data
+	sensor := data.GetSensorSummary()
+	timeValid := data.GetTimeDataValid()
+	switch column {
+	case NAME:
+		return sortValue{text: line.Name, valid: true}
+	case STARTTIME:
+		return sortValue{number: float64(data.GetStartTime().UnixNano()) / 1e9, valid: timeValid}
+	case ENDTIME:
+		return sortValue{number: float64(data.GetEndTime().UnixNano()) / 1e9, valid: timeValid}
+	case TRACKTIME:
+		return sortValue{number: data.GetEndTime().Sub(data.GetStartTime()).Seconds(), valid: timeValid}
+	case DISTANCE:
+		return sortValue{number: data.GetDistance(), valid: true}
+	case HORIZONTALDISTANCE:
+		return sortValue{number: data.GetHorizontalDistance(), valid: true}
+	case ALTITUDERANGE:
+		return sortValue{number: float64(data.GetAltitudeRange()), valid: true}
+	case MINIMUMALTITUDE:
+		return sortValue{number: float64(data.GetMinimumAltitude()), valid: true}
+	case MAXIMUMALTITUDE:
+		return sortValue{number: float64(data.GetMaximumAltitude()), valid: true}
+	case ELEVATIONGAIN:
+		return sortValue{number: float64(data.GetElevationGain()), valid: true}
+	case ELEVATIONLOSE:
+		return sortValue{number: float64(data.GetElevationLose()), valid: true}
+	case UPWARDSDISTANCE:
+		return sortValue{number: data.GetUpwardsDistance(), valid: true}
+	case DOWNWARDSDISTANCE:
+		return sortValue{number: data.GetDownwardsDistance(), valid: true}
+	case MOVINGTIME:
+		return sortValue{number: data.GetMovingTime().Seconds(), valid: timeValid}
+	case UPWARDSTIME:
+		return sortValue{number: data.GetUpwardsTime().Seconds(), valid: timeValid}
+	case DOWNWARDSTIME:
+		return sortValue{number: data.GetDownwardsTime().Seconds(), valid: timeValid}
+	case AVERAGESPEED:
+		return sortValue{number: data.GetAvarageSpeed(), valid: timeValid}
+	case UPWARDSSPEED:
+		return sortValue{number: data.GetUpwardsSpeed(), valid: timeValid}
+	case DOWNWARDSSPEED:
+		return sortValue{number: data.GetDownwardsSpeed(), valid: timeValid}
+	case MINIMUMHEARTRATE:
+		return sortValue{number: sensor.HeartRate.Minimum, valid: sensor.HeartRate.Valid()}
+	case AVERAGEHEARTRATE:
+		return sortValue{number: sensor.HeartRate.Average, valid: sensor.HeartRate.Valid()}
+	case MAXIMUMHEARTRATE:
+		return sortValue{number: sensor.HeartRate.Maximum, valid: sensor.HeartRate.Valid()}
+	case MINIMUMCADENCE:
+		return sortValue{number: sensor.Cadence.Minimum, valid: sensor.Cadence.Valid()}
+	case AVERAGECADENCE:
+		return sortValue{number: sensor.Cadence.Average, valid: sensor.Cadence.Valid()}
+	case MAXIMUMCADENCE:
+		return sortValue{number: sensor.Cadence.Maximum, valid: sensor.Cadence.Valid()}
+	case MINIMUMPOWER:
+		return sortValue{number: sensor.Power.Minimum, valid: sensor.Power.Valid()}
+	case AVERAGEPOWER:
+		return sortValue{number: sensor.Power.Average, valid: sensor.Power.Valid()}
+	case MAXIMUMPOWER:
+		return sortValue{number: sensor.Power.Maximum, valid: sensor.Power.Valid()}
+	case ACTIVITYTYPE:
+		return sortValue{text: data.GetActivityType(), valid: data.GetActivityType() != ""}
+	case GEAR:
+		return sortValue{text: data.GetGear(), valid: data.GetGear() != ""}
+	}
+
+	return sortValue{}
+}
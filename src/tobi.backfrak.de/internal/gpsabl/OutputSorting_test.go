@@ -0,0 +1,167 @@
+package gpsabl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewOutputSorting(t *testing.T) {
+	sorting, err := NewOutputSorting("elevationgain", "DESC")
+	if err != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+	if sorting.Column != ELEVATIONGAIN {
+		t.Errorf("The Column is %s, but should be %s", sorting.Column, ELEVATIONGAIN)
+	}
+	if sorting.Order != DESCENDING {
+		t.Errorf("The Order is %s, but should be %s", sorting.Order, DESCENDING)
+	}
+
+	sorting, err = NewOutputSorting("", "")
+	if err != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+	if sorting.Column != INPUTORDER {
+		t.Errorf("The Column is %s, but should be %s", sorting.Column, INPUTORDER)
+	}
+	if sorting.Order != ASCENDING {
+		t.Errorf("The Order is %s, but should be %s", sorting.Order, ASCENDING)
+	}
+}
+
+func TestNewOutputSortingAllColumns(t *testing.T) {
+	for _, column := range GetValidSortColumns() {
+		sorting, err := NewOutputSorting(string(column), string(ASCENDING))
+		if err != nil {
+			t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+		}
+		if sorting.Column != column {
+			t.Errorf("The Column is %s, but should be %s", sorting.Column, column)
+		}
+		if !strings.Contains(GetValidSortColumnsString(), string(column)) {
+			t.Errorf("The column %s is not in the valid columns string", column)
+		}
+	}
+}
+
+func TestNewOutputSortingNotValid(t *testing.T) {
+	_, err := NewOutputSorting("Speed", "asc")
+	switch err.(type) {
+	case *SortColumnNotKnownError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a SortColumnNotKnownError, got a %T", err)
+	}
+
+	_, err = NewOutputSorting("Distance", "up")
+	switch err.(type) {
+	case *SortOrderNotKnownError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a SortOrderNotKnownError, got a %T", err)
+	}
+}
+
+func TestSortOutputLinesInputOrder(t *testing.T) {
+	lines := getSortTestLines()
+	SortOutputLines(lines, OutputSorting{INPUTORDER, DESCENDING})
+
+	checkLineNames(t, lines, []string{"c", "a", "b", "d"})
+}
+
+func TestSortOutputLinesByDistance(t *testing.T) {
+	lines := getSortTestLines()
+	SortOutputLines(lines, OutputSorting{DISTANCE, ASCENDING})
+	checkLineNames(t, lines, []string{"a", "b", "d", "c"})
+
+	SortOutputLines(lines, OutputSorting{DISTANCE, DESCENDING})
+	checkLineNames(t, lines, []string{"c", "b", "d", "a"})
+}
+
+func TestSortOutputLinesByName(t *testing.T) {
+	lines := getSortTestLines()
+	SortOutputLines(lines, OutputSorting{NAME, DESCENDING})
+
+	checkLineNames(t, lines, []string{"d", "c", "b", "a"})
+}
+
+func TestSortOutputLinesNotValidValuesLast(t *testing.T) {
+	lines := getSortTestLines()
+	SortOutputLines(lines, OutputSorting{STARTTIME, ASCENDING})
+	checkLineNames(t, lines, []string{"b", "c", "a", "d"})
+
+	lines = getSortTestLines()
+	SortOutputLines(lines, OutputSorting{STARTTIME, DESCENDING})
+	checkLineNames(t, lines, []string{"c", "b", "a", "d"})
+
+	lines = getSortTestLines()
+	SortOutputLines(lines, OutputSorting{GEAR, ASCENDING})
+	checkLineNames(t, lines, []string{"a", "c", "b", "d"})
+}
+
+func TestGetNumberValue(t *testing.T) {
+	lines := getSortTestLines()
+
+	value, valid := GetNumberValue(lines[0], DISTANCE)
+	if !valid || value != 30 {
+		t.Errorf("The Distance value is %f, %t, but should be %f, %t", value, valid, 30.0, true)
+	}
+
+	if _, valid := GetNumberValue(lines[1], STARTTIME); valid {
+		t.Errorf("The StartTime of a line without time data is valid")
+	}
+
+	for _, column := range []SortColumn{INPUTORDER, NAME, GEAR} {
+		if _, valid := GetNumberValue(lines[0], column); valid {
+			t.Errorf("The %s value is a valid number", column)
+		}
+	}
+
+	if len(GetValidNumberColumns()) != len(GetValidSortColumns())-3 {
+		t.Errorf("There are %d number columns, but should be %d", len(GetValidNumberColumns()), len(GetValidSortColumns())-3)
+	}
+}
+
+// getSortTestLines - Get lines in the order c, a, b, d. a and d have no time values, b and d have the same distance
+func getSortTestLines() []OutputLine {
+	a := ExtendedTrackSummary{}
+	a.Distance = 10
+	a.Gear = "Bike"
+	b := ExtendedTrackSummary{}
+	b.Distance = 20
+	b.TimeDataValid = true
+	b.StartTime = getSortTestTime("2014-08-22T17:19:33Z")
+	c := ExtendedTrackSummary{}
+	c.Distance = 30
+	c.TimeDataValid = true
+	c.StartTime = getSortTestTime("2015-08-22T17:19:33Z")
+	c.Gear = "Car"
+	d := ExtendedTrackSummary{}
+	d.Distance = 20
+
+	return []OutputLine{*NewOutputLine("c", c), *NewOutputLine("a", a), *NewOutputLine("b", b), *NewOutputLine("d", d)}
+}
+
+func getSortTestTime(value string) time.Time {
+	ret, _ := time.Parse(time.RFC3339, value)
+
+	return ret
+}
+
+func checkLineNames(t *testing.T, lines []OutputLine, expected []string) {
+	var names []string
+	for _, line := range lines {
+		names = append(names, line.Name)
+	}
+
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("The lines are in the order %s, but should be %s", strings.Join(names, ","), strings.Join(expected, ","))
+	}
+}
@@ -5,7 +5,14 @@ package gpsabl
 // by a BSD-style license that can be found in the
 // LICENSE file.
 
-// GetInputFileFromBuffer - Get a new InputFile if there is a reader that supports the data in the buffer
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+)
+
+// GetInputFileFromBuffer - Get a new InputFile if there is a reader that supports the data in the buffer.
+// A reader that recognizes the content as ContentSniffer is preferred, the InputFile.Detection tells the reason
 // - validReaders    List of valid TrackReader
 // - buffer          The buffer that contains data
 // - bufferName      Name of the buffer
@@ -14,9 +21,14 @@ package gpsabl
 func GetInputFileFromBuffer(validReaders []TrackReader, buffer []byte, bufferName string) (bool, InputFile) {
 	var retVal InputFile
 
+	if res, input := sniffBuffer(validReaders, buffer, bufferName); res == true {
+		return true, input
+	}
+
 	for _, reader := range validReaders {
 		if reader.CheckBuffer(buffer) == true {
 			retVal = *reader.NewInputFileForBuffer(buffer, bufferName)
+			retVal.Detection = fmt.Sprintf("the content, that can be read as %s", retVal.Type)
 			return true, retVal
 		}
 	}
@@ -24,7 +36,10 @@ func GetInputFileFromBuffer(validReaders []TrackReader, buffer []byte, bufferNam
 	return false, retVal
 }
 
-// GetInputFileFromPath - Get a new InputFile if there is a reader that supports the file extencion of the given file
+// GetInputFileFromPath - Get a new InputFile if there is a reader that supports the given file.
+// The start of the file is checked first, so a reader that recognizes the content as ContentSniffer is preferred.
+// Then the file extension is checked. A file with unknown extension is read into a buffer, when a reader supports its content.
+// The InputFile.Detection tells the reason
 // - validReaders    List of valid TrackReader
 // - path            The path to the data file
 // return true and the coresponding InputFile if a valid reader was found
@@ -32,13 +47,29 @@ func GetInputFileFromBuffer(validReaders []TrackReader, buffer []byte, bufferNam
 func GetInputFileFromPath(validReaders []TrackReader, path string) (bool, InputFile) {
 	var retVal InputFile
 
+	head, errHead := readFileHead(path, SniffSize)
+	if errHead == nil {
+		if res, input := sniffFile(validReaders, path, head); res == true {
+			return true, input
+		}
+	}
+
 	for _, reader := range validReaders {
 		if reader.CheckFile(path) == true {
 			retVal = *NewInputFileWithPath(path)
+			retVal.Detection = fmt.Sprintf("the file extension \"%s\"", filepath.Ext(path))
 			return true, retVal
 		}
 	}
 
+	size := getFileSize(path)
+	if errHead == nil && size >= 0 && size <= MaximalContentCheckSize {
+		buffer, errRead := ioutil.ReadFile(path)
+		if errRead == nil {
+			return GetInputFileFromBuffer(validReaders, buffer, path)
+		}
+	}
+
 	return false, retVal
 }
 
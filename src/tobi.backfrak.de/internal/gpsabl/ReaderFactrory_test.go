@@ -99,6 +99,9 @@ func (mok *readerMock) NewReader(data InputFile) TrackReader {
 
 func (mok *readerMock) CheckBuffer(buffer []byte) bool {
 	for i, _ := range buffer {
+		if i+3 > len(buffer) {
+			break
+		}
 		section := buffer[i : i+3]
 		if string(section) == "abc" {
 			return true
@@ -25,6 +25,52 @@ type TrackSummary struct {
 	MovingTime         time.Duration
 	UpwardsTime        time.Duration
 	DownwardsTime      time.Duration
+	SensorSummary
+	// ActivityType - The type of the activity like "Ride" or "Run", when known from the metadata of the track file. See TrackMetadata
+	ActivityType string `json:",omitempty"`
+	// Gear - The gear used for the activity, when known from the metadata of the track file. See TrackMetadata
+	Gear string `json:",omitempty"`
+}
+
+// SensorValues - The minimum, average and maximum of a sensor value like the heart rate
+type SensorValues struct {
+	Minimum float64
+	Average float64
+	Maximum float64
+	// Samples - The number of track points the values are calculated from
+	Samples int
+}
+
+// Valid - True if the values are calculated from at least one track point
+func (val SensorValues) Valid() bool {
+	return val.Samples > 0
+}
+
+// SensorSummary - The struct to store the statistic data of the sensor values recorded with a track
+type SensorSummary struct {
+	HeartRate SensorValues
+	Cadence   SensorValues
+	Power     SensorValues
+}
+
+// SetSensorSummary - Set the sensor values of a TrackSummary (Implement the TrackSummarySetter )
+func (sum *TrackSummary) SetSensorSummary(sensor SensorSummary) {
+	sum.SensorSummary = sensor
+}
+
+// GetSensorSummary - Implement the TrackSummaryProvider interface for TrackSummary
+func (sum TrackSummary) GetSensorSummary() SensorSummary {
+	return sum.SensorSummary
+}
+
+// GetActivityType - Implement the TrackSummaryProvider interface for TrackSummary
+func (sum TrackSummary) GetActivityType() string {
+	return sum.ActivityType
+}
+
+// GetGear - Implement the TrackSummaryProvider interface for TrackSummary
+func (sum TrackSummary) GetGear() string {
+	return sum.Gear
 }
 
 // SetValues - Set the Values of a TrackSummary (Implement the TrackSummaryProvider )
@@ -170,6 +216,8 @@ type TrackFile struct {
 	Description    string
 	NumberOfTracks int
 	Tracks         []Track
+	// Waypoints - The waypoints found in the file. See FillWaypointValues
+	Waypoints []Waypoint
 }
 
 // NewTrackFile - Constructor for the TrackFile struct
@@ -186,6 +234,8 @@ type Track struct {
 	Name             string
 	Description      string
 	NumberOfSegments int
+	// IsRoute - True if the track is a planned route, like the <rte> elements of a GPX file
+	IsRoute bool
 
 	TrackSegments []TrackSegment
 }
@@ -194,6 +244,12 @@ type Track struct {
 type TrackSegment struct {
 	TrackSummary
 	TrackPoints []TrackPoint
+	// Calories - The energy in kcal a device calculated for the segment, like the calories of a TCX lap
+	Calories int
+	// Intensity - The intensity a device recorded for the segment, like "Active" or "Resting" in a TCX lap
+	Intensity string
+	// TriggerMethod - What started the segment, like "Manual" or "Distance" in a TCX lap
+	TriggerMethod string
 }
 
 // TrackPoint - the struct to handle track point info in gpsa
@@ -223,6 +279,49 @@ type TrackPoint struct {
 	AvarageSpeed             float64
 	SpeedBefore              float64
 	SpeedNext                float64
+	HeartRate                int
+	HeartRateValid           bool
+	Cadence                  int
+	CadenceValid             bool
+	Power                    int
+	PowerValid               bool
+	Temperature              float32
+	TemperatureValid         bool
+	// RecordedSpeed - The speed in m/s the device recorded with the point. SpeedBefore and SpeedNext are calculated from the positions
+	RecordedSpeed      float64
+	RecordedSpeedValid bool
+	// ElevationMissing - True when the point has no elevation data, like the "-999" altitude of OziExplorer files. See FillMissingElevation
+	ElevationMissing bool
+}
+
+// GetActivityType - Implement the TrackSummaryProvider interface for TrackPoint. A point has no activity type
+func (pnt TrackPoint) GetActivityType() string {
+	return ""
+}
+
+// GetGear - Implement the TrackSummaryProvider interface for TrackPoint. A point has no gear
+func (pnt TrackPoint) GetGear() string {
+	return ""
+}
+
+// GetSensorSummary - Implement the TrackSummaryProvider interface for TrackPoint
+func (pnt TrackPoint) GetSensorSummary() SensorSummary {
+	ret := SensorSummary{}
+	if pnt.HeartRateValid {
+		ret.HeartRate = getPointSensorValues(pnt.HeartRate)
+	}
+	if pnt.CadenceValid {
+		ret.Cadence = getPointSensorValues(pnt.Cadence)
+	}
+	if pnt.PowerValid {
+		ret.Power = getPointSensorValues(pnt.Power)
+	}
+
+	return ret
+}
+
+func getPointSensorValues(value int) SensorValues {
+	return SensorValues{Minimum: float64(value), Average: float64(value), Maximum: float64(value), Samples: 1}
 }
 
 // GetDistance - Implement the TrackSummaryProvider interface for TrackPoint
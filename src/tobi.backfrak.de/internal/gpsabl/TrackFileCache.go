@@ -0,0 +1,196 @@
+package gpsabl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+import (
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CacheFileExtension - The file extension of the entries in the cache directory
+const CacheFileExtension string = ".gpsa-cache"
+
+// cacheFormatVersion - Part of every cache key. Change it when the TrackFile struct or the way it is filled changes,
+// so entries written by older versions are not used
+const cacheFormatVersion string = "gpsa-track-file-cache-1"
+
+// TrackFileCache - An on disk cache for the TrackFile summaries read from input files. An entry is stored in its own file,
+// named by the key. The entries do not contain the track points, see StripTrackPoints. Safe for use by several go routines
+type TrackFileCache struct {
+	// Directory - The directory the entries are stored in
+	Directory string
+
+	hits   int
+	misses int
+	mux    sync.Mutex
+}
+
+// NewTrackFileCache - Get a new TrackFileCache that stores the entries in the given directory. The directory is created if needed
+func NewTrackFileCache(directory string) (*TrackFileCache, error) {
+	if errCreate := os.MkdirAll(directory, 0700); errCreate != nil {
+		return nil, NewTrackFileCacheError(directory, errCreate.Error())
+	}
+
+	ret := TrackFileCache{}
+	ret.Directory = directory
+
+	return &ret, nil
+}
+
+// GetDefaultCacheDirectory - Get the directory the cache is stored in by default. This is "gpsa" in the users cache directory,
+// or in the temp directory when the users cache directory is not known
+func GetDefaultCacheDirectory() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "gpsa-cache")
+	}
+
+	return filepath.Join(dir, "gpsa")
+}
+
+// GetTrackFileCacheKey - Get the key of a cache entry from the content of an input file and all options, that change the
+// TrackFile read from the content
+func GetTrackFileCacheKey(content io.Reader, correction CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64, options []string) (string, error) {
+	hash := sha256.New()
+	if _, errRead := io.Copy(hash, content); errRead != nil {
+		return "", errRead
+	}
+
+	values := []string{
+		cacheFormatVersion,
+		string(correction),
+		strconv.FormatFloat(minimalMovingSpeed, 'g', -1, 64),
+		strconv.FormatFloat(minimalStepHight, 'g', -1, 64),
+	}
+	for _, value := range append(values, options...) {
+		// The values are separated by a byte that is not part of a text, so "a", "bc" and "ab", "c" give different keys
+		fmt.Fprintf(hash, "\x00%s", value)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Get - Get the TrackFile stored with the key. Returns false, when the cache contains no entry or an entry that can not be read
+func (cache *TrackFileCache) Get(key string) (TrackFile, bool) {
+	file, found := cache.readEntry(key)
+
+	cache.mux.Lock()
+	defer cache.mux.Unlock()
+	if found {
+		cache.hits++
+	} else {
+		cache.misses++
+	}
+
+	return file, found
+}
+
+// Put - Store the TrackFile with the key. The track points are not stored
+func (cache *TrackFileCache) Put(key string, file TrackFile) error {
+	// Write to a temp file first, so a other process never reads a half written entry
+	tmpFile, errCreate := ioutil.TempFile(cache.Directory, key+"-*.tmp")
+	if errCreate != nil {
+		return NewTrackFileCacheError(cache.Directory, errCreate.Error())
+	}
+	defer os.Remove(tmpFile.Name())
+
+	errEncode := gob.NewEncoder(tmpFile).Encode(StripTrackPoints(file))
+	errClose := tmpFile.Close()
+	if errEncode != nil {
+		return NewTrackFileCacheError(cache.Directory, errEncode.Error())
+	}
+	if errClose != nil {
+		return NewTrackFileCacheError(cache.Directory, errClose.Error())
+	}
+
+	if errRename := os.Rename(tmpFile.Name(), cache.getEntryPath(key)); errRename != nil {
+		return NewTrackFileCacheError(cache.Directory, errRename.Error())
+	}
+
+	return nil
+}
+
+// Clear - Remove all entries from the cache directory. Other files in the directory are kept
+func (cache *TrackFileCache) Clear() (int, error) {
+	files, errRead := ioutil.ReadDir(cache.Directory)
+	if errRead != nil {
+		return 0, NewTrackFileCacheError(cache.Directory, errRead.Error())
+	}
+
+	removed := 0
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), CacheFileExtension) {
+			continue
+		}
+
+		if errRemove := os.Remove(filepath.Join(cache.Directory, file.Name())); errRemove != nil {
+			return removed, NewTrackFileCacheError(cache.Directory, errRemove.Error())
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+// GetHits - Get the number of Get calls that found an entry
+func (cache *TrackFileCache) GetHits() int {
+	cache.mux.Lock()
+	defer cache.mux.Unlock()
+
+	return cache.hits
+}
+
+// GetMisses - Get the number of Get calls that found no entry
+func (cache *TrackFileCache) GetMisses() int {
+	cache.mux.Lock()
+	defer cache.mux.Unlock()
+
+	return cache.misses
+}
+
+// StripTrackPoints - Get a copy of the TrackFile without the track points. All summary values are kept
+func StripTrackPoints(file TrackFile) TrackFile {
+	ret := file
+	ret.Tracks = make([]Track, len(file.Tracks))
+	for i, track := range file.Tracks {
+		ret.Tracks[i] = track
+		ret.Tracks[i].TrackSegments = make([]TrackSegment, len(track.TrackSegments))
+		for j, segment := range track.TrackSegments {
+			ret.Tracks[i].TrackSegments[j] = segment
+			ret.Tracks[i].TrackSegments[j].TrackPoints = nil
+		}
+	}
+
+	return ret
+}
+
+func (cache *TrackFileCache) readEntry(key string) (TrackFile, bool) {
+	entry, errOpen := os.Open(cache.getEntryPath(key))
+	if errOpen != nil {
+		return TrackFile{}, false
+	}
+	defer entry.Close()
+
+	file := TrackFile{}
+	if errDecode := gob.NewDecoder(entry).Decode(&file); errDecode != nil {
+		return TrackFile{}, false
+	}
+
+	return file, true
+}
+
+func (cache *TrackFileCache) getEntryPath(key string) string {
+	return filepath.Join(cache.Directory, key+CacheFileExtension)
+}
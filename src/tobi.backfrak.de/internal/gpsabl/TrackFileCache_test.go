@@ -0,0 +1,171 @@
+package gpsabl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGetTrackFileCacheKey(t *testing.T) {
+	content := []byte("<gpx></gpx>")
+	key, err := GetTrackFileCacheKey(bytes.NewReader(content), LINEAR, 0.3, 10.0, []string{"a", "bc"})
+	if err != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+	if len(key) != 64 {
+		t.Errorf("The key \"%s\" has %d characters, but should have %d", key, len(key), 64)
+	}
+
+	same, _ := GetTrackFileCacheKey(bytes.NewReader(content), LINEAR, 0.3, 10.0, []string{"a", "bc"})
+	if same != key {
+		t.Errorf("The key for the same input is %s, but should be %s", same, key)
+	}
+
+	others := map[string]string{}
+	others["content"], _ = GetTrackFileCacheKey(bytes.NewReader([]byte("<gpx/>")), LINEAR, 0.3, 10.0, []string{"a", "bc"})
+	others["correction"], _ = GetTrackFileCacheKey(bytes.NewReader(content), STEPS, 0.3, 10.0, []string{"a", "bc"})
+	others["minimalMovingSpeed"], _ = GetTrackFileCacheKey(bytes.NewReader(content), LINEAR, 0.5, 10.0, []string{"a", "bc"})
+	others["minimalStepHight"], _ = GetTrackFileCacheKey(bytes.NewReader(content), LINEAR, 0.3, 5.0, []string{"a", "bc"})
+	others["options"], _ = GetTrackFileCacheKey(bytes.NewReader(content), LINEAR, 0.3, 10.0, []string{"ab", "c"})
+	for name, other := range others {
+		if other == key {
+			t.Errorf("The key does not change, when the %s changes", name)
+		}
+	}
+}
+
+func TestTrackFileCachePutAndGet(t *testing.T) {
+	dir, errDir := ioutil.TempDir("", "gpsabl")
+	if errDir != nil {
+		t.Fatalf("Can not create a temp dir: %s", errDir)
+	}
+	defer os.RemoveAll(dir)
+
+	cache, errNew := NewTrackFileCache(filepath.Join(dir, "cache"))
+	if errNew != nil {
+		t.Fatalf("Got an error but did not expect one. The error is: %s", errNew.Error())
+	}
+
+	if _, found := cache.Get("abc"); found {
+		t.Errorf("Found an entry in an empty cache")
+	}
+
+	file := getTrackFileWithMultipleTracks()
+	file.Waypoints = []Waypoint{{Name: "Point", Latitude: 1.0, Longitude: 2.0}}
+	if errPut := cache.Put("abc", file); errPut != nil {
+		t.Fatalf("Got an error but did not expect one. The error is: %s", errPut.Error())
+	}
+
+	cached, found := cache.Get("abc")
+	if !found {
+		t.Fatalf("The entry was not found after it was stored")
+	}
+
+	if fmt.Sprintf("%+v", cached) != fmt.Sprintf("%+v", StripTrackPoints(file)) {
+		t.Errorf("The cached TrackFile is not the same as the stored one without track points")
+	}
+
+	if cache.GetHits() != 1 {
+		t.Errorf("The number of hits is %d, but should be %d", cache.GetHits(), 1)
+	}
+	if cache.GetMisses() != 1 {
+		t.Errorf("The number of misses is %d, but should be %d", cache.GetMisses(), 1)
+	}
+}
+
+func TestTrackFileCacheNotValidEntry(t *testing.T) {
+	dir, errDir := ioutil.TempDir("", "gpsabl")
+	if errDir != nil {
+		t.Fatalf("Can not create a temp dir: %s", errDir)
+	}
+	defer os.RemoveAll(dir)
+
+	cache, _ := NewTrackFileCache(dir)
+	ioutil.WriteFile(filepath.Join(dir, "abc"+CacheFileExtension), []byte("not a cache entry"), 0600)
+	if _, found := cache.Get("abc"); found {
+		t.Errorf("Found an entry, that can not be read")
+	}
+}
+
+func TestTrackFileCacheClear(t *testing.T) {
+	dir, errDir := ioutil.TempDir("", "gpsabl")
+	if errDir != nil {
+		t.Fatalf("Can not create a temp dir: %s", errDir)
+	}
+	defer os.RemoveAll(dir)
+
+	cache, _ := NewTrackFileCache(dir)
+	cache.Put("abc", getSimpleTrackFile())
+	cache.Put("def", getSimpleTrackFile())
+	other := filepath.Join(dir, "notes.txt")
+	ioutil.WriteFile(other, []byte("keep me"), 0600)
+
+	removed, errClear := cache.Clear()
+	if errClear != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", errClear.Error())
+	}
+	if removed != 2 {
+		t.Errorf("%d entries were removed, but %d should", removed, 2)
+	}
+
+	if _, found := cache.Get("abc"); found {
+		t.Errorf("Found an entry after the cache was cleared")
+	}
+	if _, errStat := os.Stat(other); errStat != nil {
+		t.Errorf("The file %s, that is not a cache entry, was removed", other)
+	}
+}
+
+func TestNewTrackFileCacheNotValidDirectory(t *testing.T) {
+	dir, errDir := ioutil.TempDir("", "gpsabl")
+	if errDir != nil {
+		t.Fatalf("Can not create a temp dir: %s", errDir)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "file")
+	ioutil.WriteFile(file, []byte("abc"), 0600)
+	_, err := NewTrackFileCache(file)
+	switch err.(type) {
+	case *TrackFileCacheError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *TrackFileCacheError, got \"%v\"", err)
+	}
+}
+
+func TestStripTrackPoints(t *testing.T) {
+	file := getTrackFileWithMultipleTracks()
+	stripped := StripTrackPoints(file)
+
+	for _, track := range stripped.Tracks {
+		for _, segment := range track.TrackSegments {
+			if len(segment.TrackPoints) != 0 {
+				t.Errorf("The segment contains %d points, but should contain none", len(segment.TrackPoints))
+			}
+		}
+	}
+
+	if len(file.Tracks[0].TrackSegments[0].TrackPoints) == 0 {
+		t.Errorf("The points of the input TrackFile were removed")
+	}
+
+	if stripped.GetDistance() != file.GetDistance() || stripped.Tracks[0].GetDistance() != file.Tracks[0].GetDistance() {
+		t.Errorf("The summary values are not kept")
+	}
+}
+
+func TestGetDefaultCacheDirectory(t *testing.T) {
+	if !strings.Contains(GetDefaultCacheDirectory(), "gpsa") {
+		t.Errorf("The default cache directory %s is not a gpsa directory", GetDefaultCacheDirectory())
+	}
+}
@@ -39,9 +39,61 @@ func FillDistancesTrackPoint(basePoint *TrackPoint, beforePoint TrackPoint, next
 
 }
 
+// FillDistances - Get a copy of the points, with the Number and the distance values of each point set. The distances are
+// calculated from the points as given, so the values of Elevation, Latitude and Longitude had to be set to all points before!
+func FillDistances(pnts []TrackPoint) []TrackPoint {
+	pointCount := len(pnts)
+	ret := make([]TrackPoint, pointCount)
+	for i := range pnts {
+		pnt := pnts[i]
+		pnt.Number = i
+		before := TrackPoint{}
+		next := TrackPoint{}
+		if i > 0 {
+			before = pnts[i-1]
+		}
+		if i < pointCount-1 {
+			next = pnts[i+1]
+		}
+		FillDistancesTrackPoint(&pnt, before, next)
+		ret[i] = pnt
+	}
+
+	return ret
+}
+
+// FillMissingElevation - Set the Elevation of points with ElevationMissing to the elevation of the point before, or of the
+// first point with elevation for points at the start. So missing elevations add no distance or elevation gain and do not change
+// the minimum and maximum altitude. Must be called before FillDistances
+func FillMissingElevation(pnts []TrackPoint) {
+	firstKnown := -1
+	for i := range pnts {
+		if !pnts[i].ElevationMissing {
+			firstKnown = i
+			break
+		}
+	}
+
+	if firstKnown < 0 { // No point has elevation data
+		for i := range pnts {
+			pnts[i].Elevation = 0
+		}
+		return
+	}
+
+	elevation := pnts[firstKnown].Elevation
+	for i := range pnts {
+		if pnts[i].ElevationMissing {
+			pnts[i].Elevation = elevation
+		} else {
+			elevation = pnts[i].Elevation
+		}
+	}
+}
+
 // FillValuesTrackPointArray - Fills all the values of all in points in the array, but not distances and basic info
 // like Elevation, Latitude and Longitude and Time (including TimeValid)
-// You may use FillDistancesTrackPoint to get the distance values
+// You may use FillDistances to get the distance values
 // The Array must be soreted by the points Number!
 func FillValuesTrackPointArray(pnts []TrackPoint, correction CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) error {
 
@@ -341,6 +393,37 @@ func fillTrackSummaryValues(target TrackSummarySetter, input []TrackSummaryProvi
 	}
 	target.SetValues(dist, horizontalDist, minimumAltitude, maximumAltitude, elevationGain, elevationLose, upwardsDistance, downwardsDistance,
 		timeDataValid, startTime, endTime, movingTime, upwardsTime, downwarsTime)
+	target.SetSensorSummary(getSensorSummary(input))
+}
+
+// getSensorSummary - Combine the sensor values of all inputs. The average is weighted by the number of samples
+func getSensorSummary(input []TrackSummaryProvider) SensorSummary {
+	ret := SensorSummary{}
+	for _, sum := range input {
+		sensor := sum.GetSensorSummary()
+		ret.HeartRate = combineSensorValues(ret.HeartRate, sensor.HeartRate)
+		ret.Cadence = combineSensorValues(ret.Cadence, sensor.Cadence)
+		ret.Power = combineSensorValues(ret.Power, sensor.Power)
+	}
+
+	return ret
+}
+
+func combineSensorValues(first SensorValues, second SensorValues) SensorValues {
+	if !second.Valid() {
+		return first
+	}
+	if !first.Valid() {
+		return second
+	}
+
+	ret := SensorValues{}
+	ret.Samples = first.Samples + second.Samples
+	ret.Minimum = math.Min(first.Minimum, second.Minimum)
+	ret.Maximum = math.Max(first.Maximum, second.Maximum)
+	ret.Average = (first.Average*float64(first.Samples) + second.Average*float64(second.Samples)) / float64(ret.Samples)
+
+	return ret
 }
 
 func getCorrectedElevationLinear(basePoint TrackPoint, beforePoint TrackPoint, nextPoint TrackPoint) float32 {
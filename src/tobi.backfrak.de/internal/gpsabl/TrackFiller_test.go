@@ -133,6 +133,39 @@ func TestFillDistancesThreePointsBeforeAfter(t *testing.T) {
 	}
 }
 
+func TestFillDistancesArray(t *testing.T) {
+	pnts := []TrackPoint{getTrackPoint(50.11484790, 8.684885500, 109.0), getTrackPoint(50.11495750, 8.684874770, 108.0), getTrackPoint(50.11484790, 8.684885500, 109.0)}
+	expected := make([]TrackPoint, len(pnts))
+	copy(expected, pnts)
+	FillDistancesTrackPoint(&expected[0], TrackPoint{}, pnts[1])
+	FillDistancesTrackPoint(&expected[1], pnts[0], pnts[2])
+	FillDistancesTrackPoint(&expected[2], pnts[1], TrackPoint{})
+
+	ret := FillDistances(pnts)
+	if len(ret) != len(pnts) {
+		t.Fatalf("The array contains %d points, but should contain %d", len(ret), len(pnts))
+	}
+
+	for i := range ret {
+		if ret[i].Number != i {
+			t.Errorf("The Number of point %d is %d, but should be %d", i, ret[i].Number, i)
+		}
+
+		expected[i].Number = i
+		if ret[i] != expected[i] {
+			t.Errorf("The point %d is %+v, but should be %+v", i, ret[i], expected[i])
+		}
+	}
+
+	if pnts[1].DistanceBefore != 0 || pnts[1].DistanceNext != 0 {
+		t.Errorf("The distances of the given points changed during FillDistances")
+	}
+
+	if len(FillDistances(nil)) != 0 {
+		t.Errorf("FillDistances returns points for an empty array")
+	}
+}
+
 func TestFillDistancesTwoPointBefore(t *testing.T) {
 	pnt1 := getTrackPoint(50.11484790, 8.684885500, 109.0)
 	pnt2 := getTrackPoint(50.11495750, 8.684874770, 108.0)
@@ -674,3 +707,77 @@ func TestHorizontalDistance(t *testing.T) {
 		t.Errorf("The HorizontalDistance is %f but expect %f", file.GetHorizontalDistance(), 47.60253421320727)
 	}
 }
+
+func TestFillSensorSummaryValues(t *testing.T) {
+	seg := getSimpleTrackSegment()
+	seg.TrackPoints[0].HeartRate = 100
+	seg.TrackPoints[0].HeartRateValid = true
+	seg.TrackPoints[1].HeartRate = 120
+	seg.TrackPoints[1].HeartRateValid = true
+	seg.TrackPoints[1].Power = 200
+	seg.TrackPoints[1].PowerValid = true
+	FillTrackSegmentValues(&seg)
+
+	if seg.HeartRate.Minimum != 100 || seg.HeartRate.Average != 110 || seg.HeartRate.Maximum != 120 || seg.HeartRate.Samples != 2 {
+		t.Errorf("The segments HeartRate is %v but {100 110 120 2} is expected", seg.HeartRate)
+	}
+
+	if seg.Power.Average != 200 || seg.Power.Samples != 1 {
+		t.Errorf("The segments Power is %v but {200 200 200 1} is expected", seg.Power)
+	}
+
+	if seg.Cadence.Valid() {
+		t.Errorf("The segments Cadence is valid, but no point has a cadence")
+	}
+
+	seg2 := getSimpleTrackSegment()
+	seg2.TrackPoints[0].HeartRate = 158
+	seg2.TrackPoints[0].HeartRateValid = true
+	FillTrackSegmentValues(&seg2)
+
+	track := Track{}
+	track.TrackSegments = []TrackSegment{seg, seg2}
+	FillTrackValues(&track)
+
+	// The average is weighted by the number of points with a heart rate
+	if track.HeartRate.Minimum != 100 || track.HeartRate.Average != 126.0 || track.HeartRate.Maximum != 158 || track.HeartRate.Samples != 3 {
+		t.Errorf("The tracks HeartRate is %v but {100 126 158 3} is expected", track.HeartRate)
+	}
+}
+
+func TestFillMissingElevation(t *testing.T) {
+	pnts := []TrackPoint{
+		{Elevation: -999, ElevationMissing: true},
+		{Elevation: 100},
+		{Elevation: -999, ElevationMissing: true},
+		{Elevation: 110},
+	}
+
+	FillMissingElevation(pnts)
+
+	expected := []float32{100, 100, 100, 110}
+	for i, pnt := range pnts {
+		if pnt.Elevation != expected[i] {
+			t.Errorf("The Elevation of point %d is %f, but should be %f", i, pnt.Elevation, expected[i])
+		}
+	}
+
+	if pnts[0].ElevationMissing != true || pnts[1].ElevationMissing != false {
+		t.Errorf("FillMissingElevation changed the ElevationMissing values")
+	}
+}
+
+func TestFillMissingElevationNoElevation(t *testing.T) {
+	pnts := []TrackPoint{
+		{Elevation: -999, ElevationMissing: true},
+		{Elevation: -999, ElevationMissing: true},
+	}
+
+	FillMissingElevation(pnts)
+
+	for i, pnt := range pnts {
+		if pnt.Elevation != 0 {
+			t.Errorf("The Elevation of point %d is %f, but should be %f", i, pnt.Elevation, 0.0)
+		}
+	}
+}
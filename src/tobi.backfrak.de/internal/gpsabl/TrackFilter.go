@@ -5,7 +5,10 @@ package gpsabl
 // by a BSD-style license that can be found in the
 // LICENSE file.
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // TrackFilter - An Interface for all 'classes' used to filter tracks from the output
 type TrackFilter interface {
@@ -83,6 +86,54 @@ func (filter *MaxStartTimeFilter) GetFilterText() string {
 	return filter.myFilterText
 }
 
+// ActivityTypeFilter - An implementation of TrackFilter to filter tracks by there activity type.
+// All Tracks with one of the given activity types pass the filter. The activity types are compared case insensitive
+type ActivityTypeFilter struct {
+	ActivityTypes []string
+	myFilterText  string
+}
+
+func (filter *ActivityTypeFilter) Filter(track TrackSummary) bool {
+	return filterTextListContains(filter.ActivityTypes, track.ActivityType)
+}
+
+// NewActivityTypeFilter - Get a new instance of the ActivityTypeFilter. The `filterText` is a comma separated list of activity types
+func NewActivityTypeFilter(filterText string) ActivityTypeFilter {
+	ret := ActivityTypeFilter{}
+	ret.myFilterText = filterText
+	ret.ActivityTypes = filterTextToList(filterText)
+
+	return ret
+}
+
+func (filter *ActivityTypeFilter) GetFilterText() string {
+	return filter.myFilterText
+}
+
+// GearFilter - An implementation of TrackFilter to filter tracks by the used gear.
+// All Tracks with one of the given gears pass the filter. The gears are compared case insensitive
+type GearFilter struct {
+	Gears        []string
+	myFilterText string
+}
+
+func (filter *GearFilter) Filter(track TrackSummary) bool {
+	return filterTextListContains(filter.Gears, track.Gear)
+}
+
+// NewGearFilter - Get a new instance of the GearFilter. The `filterText` is a comma separated list of gears
+func NewGearFilter(filterText string) GearFilter {
+	ret := GearFilter{}
+	ret.myFilterText = filterText
+	ret.Gears = filterTextToList(filterText)
+
+	return ret
+}
+
+func (filter *GearFilter) GetFilterText() string {
+	return filter.myFilterText
+}
+
 // FilterTracks - Filter a list of tracks by applying a list of filters
 // returns a list that contains all tests that passed all the filters
 func FilterTracks(tracks []Track, filters []TrackFilter) []Track {
@@ -102,6 +153,9 @@ func FilterTrackFile(file TrackFile, filters []TrackFilter) TrackFile {
 	file.Tracks = FilterTracks(file.Tracks, filters)
 	FillTrackFileValues(&file)
 	file.NumberOfTracks = len(file.Tracks)
+	// The waypoints may be closer to other tracks now, the copy keeps the input file unchanged
+	file.Waypoints = append([]Waypoint(nil), file.Waypoints...)
+	FillWaypointValues(&file)
 
 	return file
 }
@@ -139,3 +193,25 @@ func filterTextToTime(filterText string) (time.Time, error) {
 
 	return ret, err
 }
+
+func filterTextToList(filterText string) []string {
+	ret := []string{}
+	for _, value := range strings.Split(filterText, ",") {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			ret = append(ret, value)
+		}
+	}
+
+	return ret
+}
+
+func filterTextListContains(list []string, value string) bool {
+	for _, entry := range list {
+		if strings.EqualFold(entry, strings.TrimSpace(value)) {
+			return true
+		}
+	}
+
+	return false
+}
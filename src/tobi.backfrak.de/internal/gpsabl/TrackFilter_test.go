@@ -432,3 +432,47 @@ func printTracks(tracks []Track) string {
 
 	return ret
 }
+
+func TestActivityTypeFilter(t *testing.T) {
+	filterString := "Ride, e-bike ride,"
+	sut := NewActivityTypeFilter(filterString)
+
+	if sut.GetFilterText() != filterString {
+		t.Errorf("The ActivityTypeFilter.GetFilterText() returns \"%s\" but should return \"%s\"", sut.GetFilterText(), filterString)
+	}
+
+	if len(sut.ActivityTypes) != 2 {
+		t.Errorf("The ActivityTypeFilter contains %d activity types, but %d are expected", len(sut.ActivityTypes), 2)
+	}
+
+	if !sut.Filter(TrackSummary{ActivityType: "ride"}) || !sut.Filter(TrackSummary{ActivityType: "E-Bike Ride"}) {
+		t.Errorf("A track with a matching activity type does not pass the filter")
+	}
+
+	if sut.Filter(TrackSummary{ActivityType: "Run"}) || sut.Filter(TrackSummary{}) {
+		t.Errorf("A track without a matching activity type passes the filter")
+	}
+}
+
+func TestGearFilter(t *testing.T) {
+	sut := NewGearFilter("Canyon Endurace")
+
+	if !sut.Filter(TrackSummary{Gear: "canyon endurace"}) {
+		t.Errorf("A track with a matching gear does not pass the filter")
+	}
+
+	if sut.Filter(TrackSummary{Gear: "Canyon"}) || sut.Filter(TrackSummary{}) {
+		t.Errorf("A track without a matching gear passes the filter")
+	}
+}
+
+func TestFilterTrackFileByActivityType(t *testing.T) {
+	file := getTrackFileWithMultipleTracks()
+	file.Tracks[1].ActivityType = "Run"
+	filter := NewActivityTypeFilter("Run")
+
+	filteredFile := FilterTrackFile(file, []TrackFilter{&filter})
+	if len(filteredFile.Tracks) != 1 {
+		t.Errorf("The filteredFile contains %d tracks, but %d are expected", len(filteredFile.Tracks), 1)
+	}
+}
@@ -0,0 +1,49 @@
+package gpsabl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+// TrackMetadata - Information about a track file, that is not stored in the file itself, like the activity data of a bulk export
+type TrackMetadata struct {
+	Name         string
+	Description  string
+	ActivityType string
+	Gear         string
+}
+
+// IsEmpty - True if the TrackMetadata contains no value
+func (metadata TrackMetadata) IsEmpty() bool {
+	return metadata == TrackMetadata{}
+}
+
+// ApplyTrackMetadata - Set the values of the TrackMetadata to the TrackFile. The ActivityType and Gear are set to all tracks
+// and segments as well, so filters and output lines of all depths can use them. Empty values of the TrackMetadata are ignored
+func ApplyTrackMetadata(file *TrackFile, metadata TrackMetadata) {
+	if metadata.Name != "" {
+		file.Name = metadata.Name
+	}
+
+	if metadata.Description != "" {
+		file.Description = metadata.Description
+	}
+
+	applyActivity(&file.TrackSummary, metadata)
+	for i := range file.Tracks {
+		applyActivity(&file.Tracks[i].TrackSummary, metadata)
+		for j := range file.Tracks[i].TrackSegments {
+			applyActivity(&file.Tracks[i].TrackSegments[j].TrackSummary, metadata)
+		}
+	}
+}
+
+func applyActivity(sum *TrackSummary, metadata TrackMetadata) {
+	if metadata.ActivityType != "" {
+		sum.ActivityType = metadata.ActivityType
+	}
+
+	if metadata.Gear != "" {
+		sum.Gear = metadata.Gear
+	}
+}
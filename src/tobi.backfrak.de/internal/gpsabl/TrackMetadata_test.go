@@ -0,0 +1,50 @@
+package gpsabl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+import "testing"
+
+func TestApplyTrackMetadata(t *testing.T) {
+	file := getTrackFileWithMultipleTracks()
+	file.Name = "Old name"
+	file.Description = "Old description"
+	metadata := TrackMetadata{Name: "Morning Ride", ActivityType: "Ride", Gear: "Canyon Endurace"}
+
+	if metadata.IsEmpty() {
+		t.Errorf("The TrackMetadata is empty, but values are set")
+	}
+
+	ApplyTrackMetadata(&file, metadata)
+
+	if file.Name != "Morning Ride" {
+		t.Errorf("The file.Name is \"%s\", but should be \"%s\"", file.Name, "Morning Ride")
+	}
+
+	if file.Description != "Old description" {
+		t.Errorf("The file.Description is \"%s\", but the empty metadata value should not change it", file.Description)
+	}
+
+	if file.GetActivityType() != "Ride" || file.GetGear() != "Canyon Endurace" {
+		t.Errorf("The activity of the file is \"%s\" with \"%s\"", file.GetActivityType(), file.GetGear())
+	}
+
+	for _, track := range file.Tracks {
+		if track.ActivityType != "Ride" || track.Gear != "Canyon Endurace" {
+			t.Errorf("The activity of the track is \"%s\" with \"%s\"", track.ActivityType, track.Gear)
+		}
+		for _, seg := range track.TrackSegments {
+			if seg.ActivityType != "Ride" {
+				t.Errorf("The ActivityType of the segment is \"%s\", but should be \"%s\"", seg.ActivityType, "Ride")
+			}
+		}
+	}
+}
+
+func TestTrackMetadataIsEmpty(t *testing.T) {
+	if !(TrackMetadata{}).IsEmpty() {
+		t.Errorf("A new TrackMetadata is not empty")
+	}
+}
@@ -45,6 +45,9 @@ type TrackSummaryProvider interface {
 	GetAvarageSpeed() float64
 	GetUpwardsSpeed() float64
 	GetDownwardsSpeed() float64
+	GetSensorSummary() SensorSummary
+	GetActivityType() string
+	GetGear() string
 }
 
 // TrackSummarySetter - Interface for classes that can set track summary data
@@ -63,4 +66,5 @@ type TrackSummarySetter interface {
 		movingTime time.Duration,
 		upwardsTime time.Duration,
 		downwards time.Duration)
+	SetSensorSummary(sensor SensorSummary)
 }
@@ -229,3 +229,20 @@ func TestTrackPointIsTrackSummary(t *testing.T) {
 	}
 
 }
+
+func TestTrackPointSensorSummary(t *testing.T) {
+	pnt := TrackPoint{HeartRate: 140, HeartRateValid: true, Cadence: 0, CadenceValid: true, Power: 250}
+	sum := TrackSummaryProvider(pnt).GetSensorSummary()
+
+	if sum.HeartRate.Minimum != 140 || sum.HeartRate.Average != 140 || sum.HeartRate.Maximum != 140 || sum.HeartRate.Samples != 1 {
+		t.Errorf("The HeartRate is %v but {140 140 140 1} is expected", sum.HeartRate)
+	}
+
+	if !sum.Cadence.Valid() {
+		t.Errorf("The Cadence is not valid, but a cadence of 0 was recorded")
+	}
+
+	if sum.Power.Valid() {
+		t.Errorf("The Power is valid, but PowerValid was not set")
+	}
+}
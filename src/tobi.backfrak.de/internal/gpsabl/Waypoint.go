@@ -0,0 +1,78 @@
+package gpsabl
+
+import (
+	"sort"
+	"time"
+)
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+// Waypoint - the struct to handle waypoint info in gpsa, like the <wpt> elements of a GPX file
+type Waypoint struct {
+	Name        string
+	Description string
+	Latitude    float32
+	Longitude   float32
+	Elevation   float32
+	Time        time.Time
+	TimeValid   bool
+
+	// TrackValuesValid - False when the TrackFile contains no track point, so the values below are not set
+	TrackValuesValid bool
+	// TrackName - The name of the output line of the track the waypoint is closest to
+	TrackName string
+	// DistanceAlongTrack - The distance in m from the start of the track to the track point closest to the waypoint
+	DistanceAlongTrack float64
+	// DistanceFromTrack - The distance in m between the waypoint and the closest track point
+	DistanceFromTrack float64
+}
+
+// FillWaypointValues - Find the closest track point for all waypoints of the file and set the track values of the waypoints.
+// All Track values has to be set before. See FillTrackFileValues
+func FillWaypointValues(file *TrackFile) {
+	for i := range file.Waypoints {
+		fillWaypointTrackValues(&file.Waypoints[i], *file)
+	}
+}
+
+// GetSortedWaypoints - Get a copy of the waypoints, sorted by TrackName and DistanceAlongTrack
+func GetSortedWaypoints(waypoints []Waypoint) []Waypoint {
+	ret := make([]Waypoint, len(waypoints))
+	copy(ret, waypoints)
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].TrackName != ret[j].TrackName {
+			return ret[i].TrackName < ret[j].TrackName
+		}
+
+		return ret[i].DistanceAlongTrack < ret[j].DistanceAlongTrack
+	})
+
+	return ret
+}
+
+func fillWaypointTrackValues(waypoint *Waypoint, file TrackFile) {
+	waypoint.TrackValuesValid = false
+	waypoint.TrackName = ""
+	waypoint.DistanceAlongTrack = 0
+	waypoint.DistanceFromTrack = 0
+
+	wptPoint := TrackPoint{Latitude: waypoint.Latitude, Longitude: waypoint.Longitude}
+	for t, track := range file.Tracks {
+		distanceAlongTrack := 0.0
+		for _, seg := range track.TrackSegments {
+			for _, pnt := range seg.TrackPoints {
+				distanceAlongTrack += pnt.DistanceBefore
+				distance := HaversineDistance(wptPoint, pnt)
+				if !waypoint.TrackValuesValid || distance < waypoint.DistanceFromTrack {
+					waypoint.TrackValuesValid = true
+					waypoint.TrackName = getLineNameFromTrack(track, file, t)
+					waypoint.DistanceAlongTrack = distanceAlongTrack
+					waypoint.DistanceFromTrack = distance
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package gpsabl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+import "testing"
+
+func TestFillWaypointValues(t *testing.T) {
+	file := getSimpleTrackFile()
+	file.Tracks[0].Name = "My Track"
+	pnt := file.Tracks[0].TrackSegments[0].TrackPoints[1]
+	file.Waypoints = []Waypoint{
+		{Name: "Top", Latitude: pnt.Latitude, Longitude: pnt.Longitude},
+		{Name: "Start", Latitude: 50.11484790, Longitude: 8.684885500},
+	}
+
+	FillWaypointValues(&file)
+
+	top := file.Waypoints[0]
+	if !top.TrackValuesValid {
+		t.Fatalf("The TrackValuesValid is false, but should be true")
+	}
+
+	if top.TrackName != "/mys/track/file: My Track" {
+		t.Errorf("The TrackName is \"%s\", but should be \"%s\"", top.TrackName, "/mys/track/file: My Track")
+	}
+
+	if top.DistanceAlongTrack != pnt.DistanceBefore {
+		t.Errorf("The DistanceAlongTrack is %f, but should be %f", top.DistanceAlongTrack, pnt.DistanceBefore)
+	}
+
+	if top.DistanceFromTrack != 0.0 {
+		t.Errorf("The DistanceFromTrack is %f, but should be %f", top.DistanceFromTrack, 0.0)
+	}
+
+	// The start point is passed twice, the first pass is used
+	start := file.Waypoints[1]
+	if start.DistanceAlongTrack != 0.0 {
+		t.Errorf("The DistanceAlongTrack is %f, but should be %f", start.DistanceAlongTrack, 0.0)
+	}
+}
+
+func TestFillWaypointValuesNoTrackPoints(t *testing.T) {
+	file := NewTrackFile("/mys/track/file")
+	file.Waypoints = []Waypoint{{Name: "Lonely", Latitude: 50.1, Longitude: 8.6}}
+
+	FillWaypointValues(&file)
+
+	if file.Waypoints[0].TrackValuesValid {
+		t.Errorf("The TrackValuesValid is true, but should be false")
+	}
+}
+
+func TestGetSortedWaypoints(t *testing.T) {
+	waypoints := []Waypoint{
+		{Name: "B2", TrackName: "B", DistanceAlongTrack: 200},
+		{Name: "A1", TrackName: "A", DistanceAlongTrack: 500},
+		{Name: "B1", TrackName: "B", DistanceAlongTrack: 100},
+	}
+
+	sorted := GetSortedWaypoints(waypoints)
+
+	if sorted[0].Name != "A1" || sorted[1].Name != "B1" || sorted[2].Name != "B2" {
+		t.Errorf("The waypoints are not sorted as expected: %v", sorted)
+	}
+
+	if waypoints[0].Name != "B2" {
+		t.Errorf("The input of GetSortedWaypoints was changed")
+	}
+}
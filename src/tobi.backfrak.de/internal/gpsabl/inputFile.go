@@ -1,5 +1,16 @@
 package gpsabl
 
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
 // InputFileType - a string type to implement the enum pattern
 type InputFileType string
 
@@ -17,8 +28,17 @@ type InputFile struct {
 	Type InputFileType
 	// Name - The Files path in case of Type=FilePath, the name of the buffer in other cases
 	Name string
-	// Buffer - nil in case of Type=FilePath, the files content in other cases
+	// Buffer - nil in case of Type=FilePath, the files content in other cases. nil as well, when the content is read from the Path
 	Buffer []byte
+	// Path - The file the content is read from, when the Buffer is nil and the Type is not FilePath. Like a gpx file with an unknown
+	// extension or a compressed file, so the content is not held in memory before it is read
+	Path string
+	// Compression - The compression of the file at the Path. The content is expanded while it is read
+	Compression CompressionType
+	// Metadata - Information about the file, that is not stored in the file itself. See ApplyTrackMetadata
+	Metadata TrackMetadata
+	// Detection - Tells how the format of the file was detected, like by file extension or by content
+	Detection string
 }
 
 // NewInputFileWithPath - Get a new inputFile struct from a file path
@@ -31,6 +51,79 @@ func NewInputFileWithPath(filePath string) *InputFile {
 	return &file
 }
 
+// GetContentHash - Get the sha256 hash of the files content as hex string. The file is read from disk, when the Buffer is nil
+func (file InputFile) GetContentHash() (string, error) {
+	content, errOpen := file.OpenContent()
+	if errOpen != nil {
+		return "", errOpen
+	}
+	defer content.Close()
+
+	hash := sha256.New()
+	if _, errRead := io.Copy(hash, content); errRead != nil {
+		return "", errRead
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// OpenContent - Open the content of the file. This is the Buffer, or the file at the Path or Name when the Buffer is nil.
+// A compressed file is expanded while it is read. The returned reader must be closed
+func (file InputFile) OpenContent() (io.ReadCloser, error) {
+	if file.Buffer != nil {
+		return ioutil.NopCloser(bytes.NewReader(file.Buffer)), nil
+	}
+
+	path := file.Name
+	if file.Path != "" {
+		path = file.Path
+	}
+	osFile, errOpen := os.Open(path)
+	if errOpen != nil {
+		return nil, errOpen
+	}
+
+	switch file.Compression {
+	case GZIP:
+		reader, errGzip := gzip.NewReader(osFile)
+		if errGzip != nil {
+			osFile.Close()
+			return nil, NewDecompressionError(path, errGzip.Error())
+		}
+		return &expandingReader{reader, osFile}, nil
+	case BZIP2:
+		return &expandingReader{bzip2.NewReader(osFile), osFile}, nil
+	}
+
+	return osFile, nil
+}
+
+// ReadContent - Read the content of the file, like OpenContent does, into a buffer
+func (file InputFile) ReadContent() ([]byte, error) {
+	if file.Buffer != nil {
+		return file.Buffer, nil
+	}
+
+	content, errOpen := file.OpenContent()
+	if errOpen != nil {
+		return nil, errOpen
+	}
+	defer content.Close()
+
+	return ioutil.ReadAll(content)
+}
+
+// expandingReader - Reads the expanded content of a compressed file, and closes the file when it is closed
+type expandingReader struct {
+	io.Reader
+	file *os.File
+}
+
+// Close - Close the compressed file
+func (reader *expandingReader) Close() error {
+	return reader.file.Close()
+}
+
 // Check if this inputFile has a valid inputFileType as Type
 func (file InputFile) InputFileTypeValid() bool {
 	return inputFileTypeValid(file.Type)
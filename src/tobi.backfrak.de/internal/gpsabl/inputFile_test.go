@@ -1,6 +1,11 @@
 package gpsabl
 
-import "testing"
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 func TestNewInputFileWithPath(t *testing.T) {
 	path := "my/test/path"
@@ -31,3 +36,79 @@ func TestInputFileTypeValid(t *testing.T) {
 		t.Errorf("The inputFileTypeValid tells that  %s is a valid type", ft)
 	}
 }
+
+func TestGetContentHashBuffer(t *testing.T) {
+	sut := InputFile{Name: "os.Stdin", Buffer: []byte("abc")}
+	hash, err := sut.GetContentHash()
+	if err != nil {
+		t.Errorf("Got the error \"%s\", but expected none", err.Error())
+	}
+
+	expected := "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	if hash != expected {
+		t.Errorf("The hash is %s, but %s is expected", hash, expected)
+	}
+}
+
+func TestGetContentHashFile(t *testing.T) {
+	dir, errDir := ioutil.TempDir("", "gpsabl")
+	if errDir != nil {
+		t.Fatalf("Can not create a temp dir: %s", errDir)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "track.gpx")
+	if err := ioutil.WriteFile(path, []byte("abc"), 0600); err != nil {
+		t.Fatalf("Can not write the test file: %s", err.Error())
+	}
+
+	fileHash, errFile := NewInputFileWithPath(path).GetContentHash()
+	if errFile != nil {
+		t.Errorf("Got the error \"%s\", but expected none", errFile.Error())
+	}
+
+	bufferHash, _ := InputFile{Name: path, Buffer: []byte("abc")}.GetContentHash()
+	if fileHash != bufferHash {
+		t.Errorf("The hash of the file is %s, but the hash of the same content in a buffer is %s", fileHash, bufferHash)
+	}
+}
+
+func TestReadContentCompressed(t *testing.T) {
+	dir, errDir := ioutil.TempDir("", "gpsabl")
+	if errDir != nil {
+		t.Fatalf("Can not create a temp dir: %s", errDir)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "track.gpx.gz")
+	if err := ioutil.WriteFile(path, getGzipBuffer(t, []byte("abc"), ""), 0600); err != nil {
+		t.Fatalf("Can not write the test file: %s", err.Error())
+	}
+
+	input := InputFile{Type: InputFileType("abcType"), Name: filepath.Join(dir, "track.gpx"), Path: path, Compression: GZIP}
+	content, errRead := input.ReadContent()
+	if errRead != nil {
+		t.Fatalf("Got the error \"%s\", but expected none", errRead.Error())
+	}
+	if string(content) != "abc" {
+		t.Errorf("The content is \"%s\", but should be \"abc\"", content)
+	}
+
+	fileHash, _ := input.GetContentHash()
+	bufferHash, _ := InputFile{Name: path, Buffer: []byte("abc")}.GetContentHash()
+	if fileHash != bufferHash {
+		t.Errorf("The hash of the compressed file is %s, but the hash of the same content in a buffer is %s", fileHash, bufferHash)
+	}
+
+	input.Compression = BZIP2
+	if _, errRead = input.ReadContent(); errRead == nil {
+		t.Errorf("Got no error while reading a gzip file as bzip2")
+	}
+}
+
+func TestGetContentHashFileNotExist(t *testing.T) {
+	_, err := NewInputFileWithPath(filepath.Join("not", "exist", "track.gpx")).GetContentHash()
+	if err == nil {
+		t.Errorf("Got no error for a file that does not exist")
+	}
+}
@@ -14,6 +14,8 @@ type Gpx struct {
 	Name        string `xml:"name"`
 	Description string `xml:"desc"`
 	Tracks      []Trk  `xml:"trk"`
+	Routes      []Rte  `xml:"rte"`
+	Waypoints   []Wpt  `xml:"wpt"`
 }
 
 // Trk - Represents the content of a GPX track
@@ -24,6 +26,21 @@ type Trk struct {
 	TrackSegments []Trkseg `xml:"trkseg"`
 }
 
+// Rte - Represents the content of a GPX route, a planned track
+type Rte struct {
+	Name        string  `xml:"name"`
+	Number      int     `xml:"number"`
+	Description string  `xml:"desc"`
+	RoutePoints []Trkpt `xml:"rtept"`
+}
+
+// Wpt - Represents a GPX waypoint
+type Wpt struct {
+	Trkpt
+	Name        string `xml:"name"`
+	Description string `xml:"desc"`
+}
+
 // Trkseg - Represents a track segment, basically an array of Trkpt
 type Trkseg struct {
 	TrackPoints []Trkpt `xml:"trkpt"`
@@ -35,9 +52,36 @@ type Trkpt struct {
 	Latitude  float32 `xml:"lat,attr"`
 	Longitude float32 `xml:"lon,attr"`
 	Time      string  `xml:"time"`
+	// Extensions - The sensor data some devices record with each point
+	Extensions TrkptExtensions `xml:"extensions"`
+}
+
+// TrkptExtensions - Represents the extensions of a track point, the known namespaces are read
+type TrkptExtensions struct {
+	TrackPointExtensionV1 TrackPointExtension `xml:"http://www.garmin.com/xmlschemas/TrackPointExtension/v1 TrackPointExtension"`
+	TrackPointExtensionV2 TrackPointExtension `xml:"http://www.garmin.com/xmlschemas/TrackPointExtension/v2 TrackPointExtension"`
+	PowerExtension        PowerExtension      `xml:"http://www.garmin.com/xmlschemas/PowerExtension/v1 PowerExtension"`
+	// Power - Some applications like Strava write the power as a plain element
+	Power              string `xml:"power"`
+	ClueTrustHeartRate string `xml:"http://www.cluetrust.com/XML/GPXDATA/1/0 hr"`
+	ClueTrustCadence   string `xml:"http://www.cluetrust.com/XML/GPXDATA/1/0 cadence"`
+	ClueTrustTemp      string `xml:"http://www.cluetrust.com/XML/GPXDATA/1/0 temp"`
+}
+
+// TrackPointExtension - Represents the Garmin TrackPointExtension in version 1 or 2
+type TrackPointExtension struct {
+	AirTemperature   string `xml:"atemp"`
+	WaterTemperature string `xml:"wtemp"`
+	HeartRate        string `xml:"hr"`
+	Cadence          string `xml:"cad"`
+}
+
+// PowerExtension - Represents the Garmin PowerExtension
+type PowerExtension struct {
+	PowerInWatts string `xml:"PowerInWatts"`
 }
 
-// ReadGPX - Read a GPX file
+// ReadGPX - Read a GPX file into memory. ReadGpxFile reads and converts a file as stream
 func ReadGPX(fileName string) (Gpx, error) {
 	xmlfile, err := ioutil.ReadFile(fileName)
 	if err != nil {
@@ -50,7 +94,7 @@ func readGPXBuffer(fileBuffer []byte, fileName string) (Gpx, error) {
 	gpx := Gpx{}
 	err := xml.Unmarshal([]byte(fileBuffer), &gpx)
 
-	if len(gpx.Tracks) > 0 || err != nil {
+	if len(gpx.Tracks) > 0 || len(gpx.Routes) > 0 || err != nil {
 		return gpx, err
 	}
 	return gpx, newGpxFileError(fileName)
@@ -101,8 +101,8 @@ func TestReadAllValidGPX(t *testing.T) {
 					t.Errorf("Got the following error while reading file %s: %s", filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-gpx", file.Name()), err.Error())
 					return
 				}
-				if len(gpx.Tracks) < 1 {
-					t.Errorf("The can not find tracks in %s.", filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-gpx", file.Name()))
+				if len(gpx.Tracks) < 1 && len(gpx.Routes) < 1 {
+					t.Errorf("The can not find tracks or routes in %s.", filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-gpx", file.Name()))
 				}
 			}
 		}
@@ -150,3 +150,26 @@ func TestReadValidSimpleGPX(t *testing.T) {
 }
 
 
+
+func TestReadValidRouteGPX(t *testing.T) {
+	gpx, err := ReadGPX(testhelper.GetValidGPX("20.gpx"))
+	if err != nil {
+		t.Fatalf("Got the error \"%s\" but expected none", err.Error())
+	}
+
+	if len(gpx.Tracks) != 0 {
+		t.Errorf("The number of tracks is %d, but should be %d", len(gpx.Tracks), 0)
+	}
+
+	if len(gpx.Routes) != 1 || len(gpx.Routes[0].RoutePoints) != 20 {
+		t.Errorf("The file does not contain one route with 20 points")
+	}
+
+	if len(gpx.Waypoints) != 2 {
+		t.Fatalf("The number of waypoints is %d, but should be %d", len(gpx.Waypoints), 2)
+	}
+
+	if gpx.Waypoints[1].Name != "Viewpoint" || gpx.Waypoints[1].Elevation != 266 {
+		t.Errorf("The waypoint values are not read as expected: %v", gpx.Waypoints[1])
+	}
+}
@@ -0,0 +1,201 @@
+package gpxbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+import (
+	"encoding/xml"
+	"io"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+// gpxDecoder - Reads a GPX document token by token. Tracks are converted segment by segment, so only the points
+// of one segment or route are held in their xml representation. The result is the same as ConvertGPXFile gives for the
+// whole document
+type gpxDecoder struct {
+	decoder            *xml.Decoder
+	correction         gpsabl.CorrectionParameter
+	minimalMovingSpeed float64
+	minimalStepHight   float64
+	// dropTrackPoints - Remove the points of a segment or route, once its values are calculated
+	dropTrackPoints bool
+	// trackError - The first error converting a track. The document is still read, the xml errors are reported first
+	trackError error
+	// routeError - The first error converting a route. Errors of tracks are reported first
+	routeError error
+}
+
+// decodeGPX - Read a GPX document from the reader, and return a gpsabl.TrackFile struct that contains all information.
+// When dropTrackPoints is set, the tracks and routes contain no points, but the values calculated from them
+func decodeGPX(reader io.Reader, filePath string, dropTrackPoints bool, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	d := gpxDecoder{xml.NewDecoder(reader), correction, minimalMovingSpeed, minimalStepHight, dropTrackPoints, nil, nil}
+
+	return d.decodeFile(filePath)
+}
+
+func (d *gpxDecoder) decodeFile(filePath string) (gpsabl.TrackFile, error) {
+	// Like xml.Unmarshal, the name of the root element is not checked
+	if errRoot := d.skipToRootElement(); errRoot != nil {
+		return gpsabl.TrackFile{}, errRoot
+	}
+
+	header := Gpx{}
+	var tracks []gpsabl.Track
+	var routes []gpsabl.Track
+	trackCount := 0
+	routeCount := 0
+	for {
+		token, errToken := d.decoder.Token()
+		if errToken != nil {
+			return gpsabl.TrackFile{}, errToken
+		}
+
+		if _, ok := token.(xml.EndElement); ok {
+			break
+		}
+
+		element, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		var errDecode error
+		switch element.Name.Local {
+		case "name":
+			errDecode = d.decoder.DecodeElement(&header.Name, &element)
+		case "desc":
+			errDecode = d.decoder.DecodeElement(&header.Description, &element)
+		case "wpt":
+			wpt := Wpt{}
+			errDecode = d.decoder.DecodeElement(&wpt, &element)
+			header.Waypoints = append(header.Waypoints, wpt)
+		case "trk":
+			var track gpsabl.Track
+			var segmentCount int
+			track, segmentCount, errDecode = d.decodeTrk()
+			trackCount++
+			// Add only tracks that contain segments
+			if errDecode == nil && d.trackError == nil && segmentCount > 0 {
+				tracks = append(tracks, track)
+			}
+		case "rte":
+			rte := Rte{}
+			errDecode = d.decoder.DecodeElement(&rte, &element)
+			routeCount++
+			// Add only routes that contain points
+			if errDecode == nil && d.routeError == nil && len(rte.RoutePoints) > 0 {
+				route, errConvert := ConvertRte(rte, d.correction, d.minimalMovingSpeed, d.minimalStepHight)
+				if errConvert != nil {
+					d.routeError = errConvert
+				} else {
+					if d.dropTrackPoints {
+						for i := range route.TrackSegments {
+							route.TrackSegments[i].TrackPoints = nil
+						}
+					}
+					routes = append(routes, route)
+				}
+			}
+		default:
+			errDecode = d.decoder.Skip()
+		}
+
+		if errDecode != nil {
+			return gpsabl.TrackFile{}, errDecode
+		}
+	}
+
+	if trackCount == 0 && routeCount == 0 {
+		return gpsabl.TrackFile{}, newGpxFileError(filePath)
+	}
+
+	if d.trackError != nil {
+		return gpsabl.TrackFile{}, d.trackError
+	}
+
+	if d.routeError != nil {
+		return gpsabl.TrackFile{}, d.routeError
+	}
+
+	return newTrackFile(append(tracks, routes...), header, filePath)
+}
+
+// decodeTrk - Read the <trk> element the decoder is in, and convert the segments of the track when they are read
+// return the track and the number of segments, including empty segments
+func (d *gpxDecoder) decodeTrk() (gpsabl.Track, int, error) {
+	res := gpsabl.Track{}
+	segmentCount := 0
+	for {
+		token, errToken := d.decoder.Token()
+		if errToken != nil {
+			return res, segmentCount, errToken
+		}
+
+		if _, ok := token.(xml.EndElement); ok {
+			break
+		}
+
+		element, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		var errDecode error
+		switch element.Name.Local {
+		case "name":
+			errDecode = d.decoder.DecodeElement(&res.Name, &element)
+		case "desc":
+			errDecode = d.decoder.DecodeElement(&res.Description, &element)
+		case "number":
+			// The number is not used, but a number that is not valid makes the file not valid
+			var number int
+			errDecode = d.decoder.DecodeElement(&number, &element)
+		case "trkseg":
+			seg := Trkseg{}
+			errDecode = d.decoder.DecodeElement(&seg, &element)
+			segmentCount++
+			// Add only segments, that contain points
+			if errDecode == nil && d.trackError == nil && len(seg.TrackPoints) > 0 {
+				segment, errConvert := convertSegment(seg, d.correction, d.minimalMovingSpeed, d.minimalStepHight)
+				if errConvert != nil {
+					d.trackError = errConvert
+				} else {
+					if d.dropTrackPoints {
+						segment.TrackPoints = nil
+					}
+					res.TrackSegments = append(res.TrackSegments, segment)
+				}
+			}
+		default:
+			errDecode = d.decoder.Skip()
+		}
+
+		if errDecode != nil {
+			return res, segmentCount, errDecode
+		}
+	}
+
+	res.NumberOfSegments = segmentCount
+	if d.trackError == nil {
+		gpsabl.FillTrackValues(&res)
+	}
+
+	return res, segmentCount, nil
+}
+
+// skipToRootElement - Read the tokens up to the start of the root element
+func (d *gpxDecoder) skipToRootElement() error {
+	for {
+		token, err := d.decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		if _, ok := token.(xml.StartElement); ok {
+			return nil
+		}
+	}
+}
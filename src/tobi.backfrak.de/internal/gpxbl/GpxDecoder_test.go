@@ -0,0 +1,110 @@
+package gpxbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func TestDecodeGPXAllFilesLikeUnmarshal(t *testing.T) {
+	for _, dir := range []string{"valid-gpx", "invalid-gpx"} {
+		files, _ := ioutil.ReadDir(filepath.Join(testhelper.GetProjectRoot(), "testdata", dir))
+		for _, file := range files {
+			path := filepath.Join(testhelper.GetProjectRoot(), "testdata", dir, file.Name())
+			buffer, errRead := ioutil.ReadFile(path)
+			if errRead != nil {
+				t.Fatal(errRead)
+			}
+
+			for _, correction := range gpsabl.GetValidCorrectionParameters() {
+				checkDecodeGPXLikeUnmarshal(t, buffer, path, correction)
+			}
+		}
+	}
+}
+
+func TestDecodeGPXLikeUnmarshal(t *testing.T) {
+	documents := map[string]string{
+		"route before track": `<gpx><rte><rtept lat="1" lon="1"/><rtept lat="1.1" lon="1"/></rte><name>File</name>
+			<trk><name>Track</name><number>2</number><trkseg><trkpt lat="1" lon="1"><ele>10</ele></trkpt><trkpt lat="1.1" lon="1"><ele>20</ele></trkpt></trkseg>
+			<trkseg/><trkseg><trkpt lat="1.2" lon="1"/></trkseg></trk><wpt lat="1" lon="1"><name>Point</name></wpt></gpx>`,
+		"empty segments":         `<gpx><trk><trkseg/></trk><trk></trk></gpx>`,
+		"no track":               `<?xml version="1.0"?><!-- comment --><gpx><wpt lat="1" lon="1"/></gpx>`,
+		"empty route":            `<gpx><rte></rte></gpx>`,
+		"not valid number":       `<gpx><trk><number>two</number><trkseg><trkpt lat="1" lon="1"/></trkseg></trk></gpx>`,
+		"not valid point":        `<gpx><trk><trkseg><trkpt lat="north" lon="1"/></trkseg></trk></gpx>`,
+		"not complete":           `<gpx><trk><trkseg><trkpt lat="1" lon="1"/>`,
+		"empty":                  ``,
+		"content after the root": `<gpx><rte><rtept lat="1" lon="1"/></rte></gpx><gpx><trk>`,
+	}
+
+	for name, document := range documents {
+		t.Run(name, func(t *testing.T) {
+			checkDecodeGPXLikeUnmarshal(t, []byte(document), name, gpsabl.LINEAR)
+		})
+	}
+
+	// A correction that is not valid fails the track conversion
+	checkDecodeGPXLikeUnmarshal(t, []byte(documents["route before track"]), "not valid correction", gpsabl.CorrectionParameter("abc"))
+}
+
+func TestDecodeGPXDropTrackPoints(t *testing.T) {
+	files, _ := ioutil.ReadDir(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-gpx"))
+	for _, file := range files {
+		path := filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-gpx", file.Name())
+		buffer, errRead := ioutil.ReadFile(path)
+		if errRead != nil {
+			t.Fatal(errRead)
+		}
+
+		expected, errExpected := ReadBuffer(buffer, path, gpsabl.LINEAR, 0.3, 10.0)
+		actual, err := decodeGPX(bytes.NewReader(buffer), path, true, gpsabl.LINEAR, 0.3, 10.0)
+		if err != nil || errExpected != nil {
+			t.Fatalf("Got the errors \"%v\" and \"%v\" for %s, but expected none", err, errExpected, path)
+		}
+
+		for _, track := range actual.Tracks {
+			for _, segment := range track.TrackSegments {
+				if segment.TrackPoints != nil {
+					t.Errorf("The segment of %s contains %d points, but should contain none", path, len(segment.TrackPoints))
+				}
+			}
+		}
+
+		// The waypoints get no track values without the points
+		expected.Waypoints = nil
+		actual.Waypoints = nil
+		if fmt.Sprintf("%+v", actual) != fmt.Sprintf("%+v", gpsabl.StripTrackPoints(expected)) {
+			t.Errorf("The values of %s without track points are not the same as with track points", path)
+		}
+	}
+}
+
+// checkDecodeGPXLikeUnmarshal - Check the streamed document gives the same gpsabl.TrackFile or error as the unmarshaled document
+func checkDecodeGPXLikeUnmarshal(t *testing.T, buffer []byte, name string, correction gpsabl.CorrectionParameter) {
+	expected, expectedErr := readGPXBuffer(buffer, name)
+	var expectedFile gpsabl.TrackFile
+	if expectedErr == nil {
+		expectedFile, expectedErr = ConvertGPXFile(expected, name, correction, 0.3, 10.0)
+	}
+
+	actual, err := ReadBuffer(buffer, name, correction, 0.3, 10.0)
+	if fmt.Sprintf("%T %v", err, err) != fmt.Sprintf("%T %v", expectedErr, expectedErr) {
+		t.Errorf("Got the error \"%v\" for %s with %s correction, but \"%v\" was expected", err, name, correction, expectedErr)
+	}
+
+	// Compare the printed values, some values like the VerticalDistanceNext of the last point are NaN, that is never equal
+	if fmt.Sprintf("%+v", actual) != fmt.Sprintf("%+v", expectedFile) {
+		t.Errorf("The streamed TrackFile of %s with %s correction is not the same as the unmarshaled one", name, correction)
+	}
+}
@@ -6,6 +6,9 @@ package gpxbl
 // LICENSE file.
 
 import (
+	"bytes"
+	"io"
+	"os"
 	"strings"
 
 	"tobi.backfrak.de/internal/gpsabl"
@@ -17,7 +20,10 @@ const FileExtension string = ".gpx"
 // GpxFile - The struct to handle *.gpx data files
 type GpxFile struct {
 	gpsabl.TrackFile
-	input gpsabl.InputFile
+	// DropTrackPoints - Remove the points of each segment, once its values are calculated. Set this when the points are
+	// not needed, so a file does not stay in memory with all its points. The waypoints get no track values then
+	DropTrackPoints bool
+	input           gpsabl.InputFile
 }
 
 // NewGpxFile - Constructor for the GpxFile struct
@@ -30,10 +36,12 @@ func NewGpxFile(filePath string) GpxFile {
 }
 
 // NewReader - Get a new reader for GPX files that will read the data in the given gpsabl.InputFile
+// The new reader uses the DropTrackPoints of this reader
 // Implement the gpsabl.TrackReader interface for *.gpx files
 func (gpx *GpxFile) NewReader(data gpsabl.InputFile) gpsabl.TrackReader {
 	newGpx := GpxFile{}
 	newGpx.input = data
+	newGpx.DropTrackPoints = gpx.DropTrackPoints
 	if data.Type == gpsabl.FilePath {
 		newGpx.FilePath = data.Name
 	}
@@ -47,9 +55,14 @@ func (gpx *GpxFile) ReadTracks(correction gpsabl.CorrectionParameter, minimalMov
 	var err error
 	var ret gpsabl.TrackFile
 	if gpx.input.Type == gpsabl.FilePath {
-		ret, err = ReadGpxFile(gpx.FilePath, correction, minimalMovingSpeed, minimalStepHight)
+		ret, err = readGpxFile(gpx.FilePath, gpx.DropTrackPoints, correction, minimalMovingSpeed, minimalStepHight)
 	} else if gpx.input.Type == GpxBuffer {
-		ret, err = ReadBuffer(gpx.input.Buffer, gpx.input.Name, correction, minimalMovingSpeed, minimalStepHight)
+		var content io.ReadCloser
+		content, err = gpx.input.OpenContent()
+		if err == nil {
+			defer content.Close()
+			ret, err = decodeGPX(content, gpx.input.Name, gpx.DropTrackPoints, correction, minimalMovingSpeed, minimalStepHight)
+		}
 	} else {
 		err = gpsabl.NewUnKnownInputFileTypeError(gpx.input.Name)
 	}
@@ -63,16 +76,7 @@ func (gpx *GpxFile) ReadTracks(correction gpsabl.CorrectionParameter, minimalMov
 // ReadBuffer - Read the *.gpx data from a buffer, and return a gpsabl.TrackFile struct that contains all information
 // When using this method, the FilePath property may contain any string
 func ReadBuffer(buffer []byte, name string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
-	content, readErr := readGPXBuffer(buffer, name)
-	if readErr != nil {
-		return gpsabl.TrackFile{}, readErr
-	}
-	ret, convertError := ConvertGPXFile(content, name, correction, minimalMovingSpeed, minimalStepHight)
-	if convertError != nil {
-		return gpsabl.TrackFile{}, convertError
-	}
-
-	return ret, nil
+	return decodeGPX(bytes.NewReader(buffer), name, false, correction, minimalMovingSpeed, minimalStepHight)
 }
 
 // CheckFile - Check if a file can be read by the GpxFile "class"
@@ -85,16 +89,18 @@ func (gpx *GpxFile) CheckFile(path string) bool {
 	return false
 }
 
-// CheckBuffer - Check if a buffer can be read by he GpxFile "class"
+// CheckBuffer - Check if a buffer can be read by he GpxFile "class". This is the case if the xml root element is <gpx>
 // Implement the gpsabl.TrackReader interface for *.gpx files
 func (gpx *GpxFile) CheckBuffer(buffer []byte) bool {
-	for i, _ := range buffer {
-		section := buffer[i : i+4]
-		if string(section) == "<gpx" {
-			return true
-		}
-	}
-	return false
+	res, _ := gpx.SniffContent(buffer)
+
+	return res
+}
+
+// SniffContent - Check if the start of a file or buffer is a xml document with the <gpx> root element of GPX 1.0 or 1.1
+// Implement the gpsabl.ContentSniffer interface for *.gpx files
+func (gpx *GpxFile) SniffContent(head []byte) (bool, string) {
+	return gpsabl.SniffXMLRoot(head, "gpx", []string{"http://www.topografix.com/GPX/"})
 }
 
 // NewInputFileForBuffer - Get a new gpsabl.InputFile for a buffer containing a gpx files content
@@ -129,17 +135,17 @@ func (gpx *GpxFile) CheckInputFile(input gpsabl.InputFile) bool {
 	return false
 }
 
-// ReadGpxFile - Reads a *.gpx file
+// ReadGpxFile - Reads a *.gpx file. The file is decoded as stream, and the tracks are converted segment by segment
 func ReadGpxFile(filePath string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
-	gpx, fileError := ReadGPX(filePath)
+	return readGpxFile(filePath, false, correction, minimalMovingSpeed, minimalStepHight)
+}
 
+func readGpxFile(filePath string, dropTrackPoints bool, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	file, fileError := os.Open(filePath)
 	if fileError != nil {
 		return gpsabl.TrackFile{}, fileError
 	}
-	ret, convertError := ConvertGPXFile(gpx, filePath, correction, minimalMovingSpeed, minimalStepHight)
-	if convertError != nil {
-		return gpsabl.TrackFile{}, convertError
-	}
+	defer file.Close()
 
-	return ret, nil
+	return decodeGPX(file, filePath, dropTrackPoints, correction, minimalMovingSpeed, minimalStepHight)
 }
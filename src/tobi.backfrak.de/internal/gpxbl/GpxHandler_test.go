@@ -866,3 +866,181 @@ func TestGetValidFileExtensions(t *testing.T) {
 	}
 
 }
+
+func TestTrackReaderSensorDataTrackPointExtensionV1(t *testing.T) {
+	gpx := NewGpxFile(testhelper.GetValidGPX("17.gpx"))
+	file, err := gpx.ReadTracks("none", 0.3, 10.0)
+	if err != nil {
+		t.Fatalf("Something wrong when reading a valid gpx file: %s", err.Error())
+	}
+
+	checkSensorValues(file.HeartRate, 100, 109.5, 119, 20, "HeartRate", t)
+	checkSensorValues(file.Cadence, 80, 82, 84, 20, "Cadence", t)
+	checkSensorValues(file.Power, 200, 209.5, 220, 20, "Power", t)
+
+	pnt := file.Tracks[0].TrackSegments[0].TrackPoints[1]
+	if !pnt.TemperatureValid || pnt.Temperature != 21.1 {
+		t.Errorf("The Temperature is %f, but should be %f", pnt.Temperature, 21.1)
+	}
+}
+
+func TestTrackReaderSensorDataTrackPointExtensionV2(t *testing.T) {
+	gpx := NewGpxFile(testhelper.GetValidGPX("18.gpx"))
+	file, err := gpx.ReadTracks("none", 0.3, 10.0)
+	if err != nil {
+		t.Fatalf("Something wrong when reading a valid gpx file: %s", err.Error())
+	}
+
+	// The first 5 points have no heart rate
+	checkSensorValues(file.HeartRate, 145, 152, 159, 15, "HeartRate", t)
+	checkSensorValues(file.Cadence, 90, 90, 90, 20, "Cadence", t)
+	checkSensorValues(file.Power, 150, 159.5, 169, 20, "Power", t)
+
+	pnt := file.Tracks[0].TrackSegments[0].TrackPoints[0]
+	if pnt.HeartRateValid {
+		t.Errorf("The HeartRateValid is true, but false is expected")
+	}
+	if !pnt.TemperatureValid || pnt.Temperature != 18.5 {
+		t.Errorf("The Temperature is %f, but should be %f", pnt.Temperature, 18.5)
+	}
+}
+
+func TestTrackReaderSensorDataClueTrust(t *testing.T) {
+	gpx := NewGpxFile(testhelper.GetValidGPX("19.gpx"))
+	file, err := gpx.ReadTracks("none", 0.3, 10.0)
+	if err != nil {
+		t.Fatalf("Something wrong when reading a valid gpx file: %s", err.Error())
+	}
+
+	checkSensorValues(file.HeartRate, 120, 139, 158, 20, "HeartRate", t)
+	checkSensorValues(file.Cadence, 70, 70, 70, 10, "Cadence", t)
+	if file.Power.Valid() {
+		t.Errorf("The Power is valid, but the file contains no power data")
+	}
+
+	if file.Tracks[0].HeartRate != file.HeartRate {
+		t.Errorf("The tracks HeartRate %v is not the files HeartRate %v", file.Tracks[0].HeartRate, file.HeartRate)
+	}
+}
+
+func TestTrackReaderNoSensorData(t *testing.T) {
+	gpx := NewGpxFile(testhelper.GetValidGPX("01.gpx"))
+	file, err := gpx.ReadTracks("none", 0.3, 10.0)
+	if err != nil {
+		t.Fatalf("Something wrong when reading a valid gpx file: %s", err.Error())
+	}
+
+	if file.HeartRate.Valid() || file.Cadence.Valid() || file.Power.Valid() {
+		t.Errorf("The file has valid sensor data, but the gpx contains none")
+	}
+}
+
+func checkSensorValues(values gpsabl.SensorValues, min, avr, max float64, samples int, name string, t *testing.T) {
+	if values.Minimum != min {
+		t.Errorf("The %s Minimum is %f, but should be %f", name, values.Minimum, min)
+	}
+	if values.Average != avr {
+		t.Errorf("The %s Average is %f, but should be %f", name, values.Average, avr)
+	}
+	if values.Maximum != max {
+		t.Errorf("The %s Maximum is %f, but should be %f", name, values.Maximum, max)
+	}
+	if values.Samples != samples {
+		t.Errorf("The %s Samples is %d, but should be %d", name, values.Samples, samples)
+	}
+}
+
+func TestTrackReaderRouteOnly(t *testing.T) {
+	gpx := NewGpxFile(testhelper.GetValidGPX("20.gpx"))
+	file, err := gpx.ReadTracks("none", 0.3, 10.0)
+	if err != nil {
+		t.Fatalf("Something wrong when reading a valid gpx file: %s", err.Error())
+	}
+
+	if file.NumberOfTracks != 1 || !file.Tracks[0].IsRoute {
+		t.Errorf("The file does not contain one route")
+	}
+
+	if file.Tracks[0].Name != "Planned route" {
+		t.Errorf("The route name is \"%s\", but should be \"%s\"", file.Tracks[0].Name, "Planned route")
+	}
+
+	if file.ElevationGain != 15 {
+		t.Errorf("The ElevationGain is %f, but should be %f", file.ElevationGain, 15.0)
+	}
+
+	if file.TimeDataValid {
+		t.Errorf("The TimeDataValid is true, but a route has no time data")
+	}
+}
+
+func TestTrackReaderWaypoints(t *testing.T) {
+	gpx := NewGpxFile(testhelper.GetValidGPX("21.gpx"))
+	file, err := gpx.ReadTracks("none", 0.3, 10.0)
+	if err != nil {
+		t.Fatalf("Something wrong when reading a valid gpx file: %s", err.Error())
+	}
+
+	if file.NumberOfTracks != 2 || file.Tracks[0].IsRoute || !file.Tracks[1].IsRoute {
+		t.Errorf("The file does not contain one track followed by one route")
+	}
+
+	if len(file.Waypoints) != 2 {
+		t.Fatalf("The number of waypoints is %d, but should be %d", len(file.Waypoints), 2)
+	}
+
+	wpt := file.Waypoints[1]
+	if !wpt.TimeValid || !wpt.TrackValuesValid {
+		t.Errorf("The waypoint has no valid time or track values")
+	}
+
+	if wpt.TrackName != testhelper.GetValidGPX("21.gpx")+": Recorded track" {
+		t.Errorf("The waypoint TrackName is \"%s\", but should be the recorded track", wpt.TrackName)
+	}
+
+	if !gpsabl.CompareFloat64With4Digits(wpt.DistanceAlongTrack, 120.0740) {
+		t.Errorf("The DistanceAlongTrack is %f, but should be %f", wpt.DistanceAlongTrack, 120.0740)
+	}
+
+	if wpt.DistanceFromTrack > 10 {
+		t.Errorf("The DistanceFromTrack is %f, but should be less than %f", wpt.DistanceFromTrack, 10.0)
+	}
+}
+
+func TestSniffContent(t *testing.T) {
+	gpx := GpxFile{}
+	buffer, _ := testhelper.GetValidGpxBuffer("02.gpx")
+	res, reason := gpx.SniffContent(buffer)
+	if res != true || strings.Contains(reason, "http://www.topografix.com/GPX/1/1") == false {
+		t.Errorf("The GPX 1.1 root element is not detected. The reason is \"%s\"", reason)
+	}
+
+	// The check must not be fooled by a <gpx text inside a other document
+	tcx := []byte("<TrainingCenterDatabase><Notes>Converted from <gpx></Notes></TrainingCenterDatabase>")
+	if gpx.CheckBuffer(tcx) != false {
+		t.Errorf("GpxFile can read a tcx buffer that contains a <gpx text")
+	}
+
+	if res, _ := gpx.SniffContent([]byte("<gpx xmlns=\"http://www.other.com/GPX\"/>")); res != false {
+		t.Errorf("A <gpx> root element in a foreign namespace is detected")
+	}
+}
+
+func TestGpxFileNewReaderKeepsDropTrackPoints(t *testing.T) {
+	gpx := NewGpxFile("")
+	gpx.DropTrackPoints = true
+	reader := gpx.NewReader(*gpsabl.NewInputFileWithPath(testhelper.GetValidGPX("01.gpx")))
+
+	file, err := reader.ReadTracks(gpsabl.LINEAR, 0.3, 10.0)
+	if err != nil {
+		t.Fatalf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+
+	if file.Tracks[0].TrackSegments[0].TrackPoints != nil {
+		t.Errorf("The track points were not dropped")
+	}
+
+	if file.Distance == 0 {
+		t.Errorf("The Distance is 0, but should be calculated from the dropped points")
+	}
+}
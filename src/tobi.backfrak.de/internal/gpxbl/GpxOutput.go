@@ -0,0 +1,406 @@
+package gpxbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+import (
+	"encoding/xml"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+// GpxOutputFormatertype - The gpsabl.OutputFormaterType this formater is responsible for
+const GpxOutputFormatertype gpsabl.OutputFormaterType = "GPX"
+
+// gpxCreator - The creator attribute of the written gpx files
+const gpxCreator = "gpsa"
+
+// gpxOutput - The root element of a written GPX 1.1 file. The reader structs are not used to write, because
+// they contain no namespaces and no optional elements
+type gpxOutput struct {
+	XMLName        xml.Name         `xml:"gpx"`
+	Version        string           `xml:"version,attr"`
+	Creator        string           `xml:"creator,attr"`
+	Namespace      string           `xml:"xmlns,attr"`
+	XsiNamespace   string           `xml:"xmlns:xsi,attr"`
+	GpxTpxNamepace string           `xml:"xmlns:gpxtpx,attr"`
+	PwrNamespace   string           `xml:"xmlns:pwr,attr"`
+	SchemaLocation string           `xml:"xsi:schemaLocation,attr"`
+	Metadata       *gpxOutputMeta   `xml:"metadata,omitempty"`
+	Waypoints      []gpxOutputPoint `xml:"wpt"`
+	Routes         []gpxOutputRte   `xml:"rte"`
+	Tracks         []gpxOutputTrk   `xml:"trk"`
+}
+
+type gpxOutputMeta struct {
+	Name        string `xml:"name,omitempty"`
+	Description string `xml:"desc,omitempty"`
+}
+
+type gpxOutputRte struct {
+	Name        string           `xml:"name,omitempty"`
+	Description string           `xml:"desc,omitempty"`
+	RoutePoints []gpxOutputPoint `xml:"rtept"`
+}
+
+type gpxOutputTrk struct {
+	Name          string              `xml:"name,omitempty"`
+	Description   string              `xml:"desc,omitempty"`
+	TrackSegments []gpxOutputTrackSeg `xml:"trkseg"`
+}
+
+type gpxOutputTrackSeg struct {
+	TrackPoints []gpxOutputPoint `xml:"trkpt"`
+}
+
+// gpxOutputPoint - A <trkpt>, <rtept> or <wpt>. The values are strings, so values that are not known are not written
+type gpxOutputPoint struct {
+	Latitude    string                   `xml:"lat,attr"`
+	Longitude   string                   `xml:"lon,attr"`
+	Elevation   string                   `xml:"ele,omitempty"`
+	Time        string                   `xml:"time,omitempty"`
+	Name        string                   `xml:"name,omitempty"`
+	Description string                   `xml:"desc,omitempty"`
+	Extensions  *gpxOutputPointExtension `xml:"extensions,omitempty"`
+}
+
+// gpxOutputPointExtension - The sensor values of a point, in the Garmin TrackPointExtension and PowerExtension the reader understands.
+// The TrackPointExtension has no power value, and GPX 1.1 allows only elements of other namespaces in <extensions>
+type gpxOutputPointExtension struct {
+	TrackPointExtension *gpxOutputTrackPointExtension `xml:"gpxtpx:TrackPointExtension,omitempty"`
+	PowerExtension      *gpxOutputPowerExtension      `xml:"pwr:PowerExtension,omitempty"`
+}
+
+type gpxOutputTrackPointExtension struct {
+	AirTemperature string `xml:"gpxtpx:atemp,omitempty"`
+	HeartRate      string `xml:"gpxtpx:hr,omitempty"`
+	Cadence        string `xml:"gpxtpx:cad,omitempty"`
+}
+
+type gpxOutputPowerExtension struct {
+	PowerInWatts string `xml:"pwr:PowerInWatts"`
+}
+
+// GpxOutputFormater - type that writes the tracks of the TrackFiles as GPX 1.1 file. Each output line becomes a <trk>, so
+// with "file" depth the tracks of a file are joined, with "segment" depth each segment is written as own <trk>.
+// Routes are written as <rte> with "track" depth. The waypoints of the TrackFiles are always written
+type GpxOutputFormater struct {
+	// CorrectedElevation - Write the corrected elevation of the track points, instead of the elevation read from the input
+	CorrectedElevation bool
+
+	// Tell if the waypoints of the TrackFiles should be listed. Not used, the waypoints are part of the gpx data and always written
+	AddWaypoints bool
+
+	// Sorting - The order the tracks are written in. The tracks are written in the order they are added by default
+	Sorting gpsabl.OutputSorting
+
+	writtenEntiresCount int
+	lineBuffer          []gpsabl.OutputLine
+	waypointBuffer      []gpsabl.Waypoint
+	fileCount           int
+	metadata            gpxOutputMeta
+	mux                 sync.Mutex
+}
+
+// NewGpxOutputFormater - Get a new GpxOutputFormater
+func NewGpxOutputFormater() *GpxOutputFormater {
+	ret := GpxOutputFormater{}
+	ret.writtenEntiresCount = -1
+	ret.lineBuffer = []gpsabl.OutputLine{}
+	ret.waypointBuffer = []gpsabl.Waypoint{}
+
+	return &ret
+}
+
+// NewOutputFormater -  Get a new gpsabl.OutputFormater of this type
+func (formater *GpxOutputFormater) NewOutputFormater() gpsabl.OutputFormater {
+	ret := NewGpxOutputFormater()
+
+	return gpsabl.OutputFormater(ret)
+}
+
+// GetTextOutputFormater - Get the gpsabl.TextOutputFormater. This is always nil for this formater
+func (formater *GpxOutputFormater) GetTextOutputFormater() gpsabl.TextOutputFormater {
+	return nil
+}
+
+// SetAddWaypoints - Set the value of formater.AddWaypoints
+func (formater *GpxOutputFormater) SetAddWaypoints(value bool) {
+	formater.AddWaypoints = value
+}
+
+// SetSorting - Set the value of formater.Sorting
+func (formater *GpxOutputFormater) SetSorting(sorting gpsabl.OutputSorting) {
+	formater.Sorting = sorting
+}
+
+// AddOutPut - Add the tracks and waypoints of a TrackFile to the internal buffer, so they can be written out later
+func (formater *GpxOutputFormater) AddOutPut(trackFile gpsabl.TrackFile, depth gpsabl.DepthArg, filterDuplicate bool) error {
+	var lines []gpsabl.OutputLine
+	linesFromFile, err := gpsabl.GetOutlines(trackFile, depth)
+	if err != nil {
+		return err
+	}
+	if filterDuplicate {
+		for _, line := range linesFromFile {
+			if gpsabl.OutputContainsLineByTimeStamps(lines, line) == false && gpsabl.OutputContainsLineByTimeStamps(formater.lineBuffer, line) == false {
+				lines = append(lines, line)
+			}
+		}
+	} else {
+		lines = linesFromFile
+	}
+
+	formater.mux.Lock()
+	defer formater.mux.Unlock()
+	formater.fileCount++
+	if formater.fileCount == 1 {
+		formater.metadata = gpxOutputMeta{Name: trackFile.Name, Description: trackFile.Description}
+	}
+	formater.waypointBuffer = append(formater.waypointBuffer, trackFile.Waypoints...)
+	formater.lineBuffer = append(formater.lineBuffer, lines...)
+
+	return nil
+}
+
+// WriteOutput - Write the gpx document to the output file. The summary is not part of a gpx file, so only the value is checked
+func (formater *GpxOutputFormater) WriteOutput(outFile *os.File, summary gpsabl.SummaryArg) error {
+	output, errGet := formater.getOutput(summary)
+	if errGet != nil {
+		return errGet
+	}
+
+	entries := len(output.Tracks) + len(output.Routes)
+	if entries > 0 {
+		if _, errWrite := outFile.WriteString(xml.Header); errWrite != nil {
+			return errWrite
+		}
+
+		encoder := xml.NewEncoder(outFile)
+		encoder.Indent("", "  ")
+		if errEncode := encoder.Encode(output); errEncode != nil {
+			return errEncode
+		}
+
+		if _, errWrite := outFile.WriteString("\n"); errWrite != nil {
+			return errWrite
+		}
+	}
+
+	formater.writtenEntiresCount = entries
+
+	return nil
+}
+
+// getOutput - Get the gpx document that will be written to the file
+func (formater *GpxOutputFormater) getOutput(summary gpsabl.SummaryArg) (gpxOutput, error) {
+	switch summary {
+	case gpsabl.NONE, gpsabl.ONLY, gpsabl.ADDITIONAL:
+	default:
+		return gpxOutput{}, gpsabl.NewSummaryParamaterNotKnown(summary)
+	}
+
+	formater.mux.Lock()
+	defer formater.mux.Unlock()
+	gpsabl.SortOutputLines(formater.lineBuffer, formater.Sorting)
+
+	ret := gpxOutput{}
+	ret.Version = "1.1"
+	ret.Creator = gpxCreator
+	ret.Namespace = "http://www.topografix.com/GPX/1/1"
+	ret.XsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+	ret.GpxTpxNamepace = "http://www.garmin.com/xmlschemas/TrackPointExtension/v1"
+	ret.PwrNamespace = "http://www.garmin.com/xmlschemas/PowerExtension/v1"
+	ret.SchemaLocation = "http://www.topografix.com/GPX/1/1 http://www.topografix.com/GPX/1/1/gpx.xsd"
+
+	// The name and description of the file are only known, when the tracks of one file are written
+	if formater.fileCount == 1 && (formater.metadata.Name != "" || formater.metadata.Description != "") {
+		meta := formater.metadata
+		ret.Metadata = &meta
+	}
+
+	for _, wpt := range formater.waypointBuffer {
+		ret.Waypoints = append(ret.Waypoints, formater.convertWaypoint(wpt))
+	}
+
+	for _, line := range formater.lineBuffer {
+		if track, ok := line.Data.(gpsabl.Track); ok && track.IsRoute {
+			ret.Routes = append(ret.Routes, formater.convertRoute(track, line.Name))
+			continue
+		}
+
+		// Like the reader, skip tracks that contain no points
+		trk := formater.convertLine(line)
+		if len(trk.TrackSegments) > 0 {
+			ret.Tracks = append(ret.Tracks, trk)
+		}
+	}
+
+	return ret, nil
+}
+
+// CheckOutputFormaterType - Check if this OutputFormater is responsible for the given gpsabl.OutputFormaterType
+func (formater *GpxOutputFormater) CheckOutputFormaterType(formaterType gpsabl.OutputFormaterType) bool {
+	if formaterType == GpxOutputFormatertype {
+		return true
+	}
+
+	return false
+}
+
+// GetOutputFormaterTypes - Get the list of gpsabl.OutputFormaterType this formater can write
+func (formater *GpxOutputFormater) GetOutputFormaterTypes() []gpsabl.OutputFormaterType {
+	return []gpsabl.OutputFormaterType{GpxOutputFormatertype}
+}
+
+// CheckFileExtension - Check if this OutputFormater can write the given output file
+func (formater *GpxOutputFormater) CheckFileExtension(filePath string) bool {
+	if strings.HasSuffix(strings.ToLower(filePath), FileExtension) {
+		return true
+	}
+
+	return false
+}
+
+// GetFileExtensions - Get the list of file extensions this formater can write
+func (formater *GpxOutputFormater) GetFileExtensions() []string {
+	return []string{FileExtension}
+}
+
+// GetOutputTableLineCount - Get the number of tracks in the buffer
+func (formater *GpxOutputFormater) GetOutputTableLineCount() int {
+	return len(formater.lineBuffer)
+}
+
+// Tells the number if output entries that are written to output.
+// * -1: When output was not written yet
+// * 0: Output was written but contains no entries, may because no entry passes the given filter
+// * >0: The number of entries written to the outputs
+func (formater *GpxOutputFormater) GetNumberOfOutputEntries() int {
+	return formater.writtenEntiresCount
+}
+
+// convertLine - Get the <trk> of an output line. The line data is a gpsabl.TrackFile, gpsabl.Track or gpsabl.TrackSegment depending on the depth
+func (formater *GpxOutputFormater) convertLine(line gpsabl.OutputLine) gpxOutputTrk {
+	ret := gpxOutputTrk{}
+	ret.Name = line.Name
+	var segments []gpsabl.TrackSegment
+	switch data := line.Data.(type) {
+	case gpsabl.TrackFile:
+		if data.Name != "" {
+			ret.Name = data.Name
+		}
+		ret.Description = data.Description
+		for _, track := range data.Tracks {
+			segments = append(segments, track.TrackSegments...)
+		}
+	case gpsabl.Track:
+		if data.Name != "" {
+			ret.Name = data.Name
+		}
+		ret.Description = data.Description
+		segments = data.TrackSegments
+	case gpsabl.TrackSegment:
+		segments = []gpsabl.TrackSegment{data}
+	}
+
+	for _, segment := range segments {
+		if len(segment.TrackPoints) == 0 {
+			continue
+		}
+		seg := gpxOutputTrackSeg{}
+		for _, pnt := range segment.TrackPoints {
+			seg.TrackPoints = append(seg.TrackPoints, formater.convertTrackPoint(pnt))
+		}
+		ret.TrackSegments = append(ret.TrackSegments, seg)
+	}
+
+	return ret
+}
+
+// convertRoute - Get the <rte> of a track, that was read from a route
+func (formater *GpxOutputFormater) convertRoute(track gpsabl.Track, lineName string) gpxOutputRte {
+	ret := gpxOutputRte{}
+	ret.Name = track.Name
+	if ret.Name == "" {
+		ret.Name = lineName
+	}
+	ret.Description = track.Description
+	for _, segment := range track.TrackSegments {
+		for _, pnt := range segment.TrackPoints {
+			ret.RoutePoints = append(ret.RoutePoints, formater.convertTrackPoint(pnt))
+		}
+	}
+
+	return ret
+}
+
+func (formater *GpxOutputFormater) convertTrackPoint(pnt gpsabl.TrackPoint) gpxOutputPoint {
+	ret := gpxOutputPoint{}
+	ret.Latitude = formatCoordinate(pnt.Latitude)
+	ret.Longitude = formatCoordinate(pnt.Longitude)
+	if !pnt.ElevationMissing {
+		if formater.CorrectedElevation {
+			ret.Elevation = formatCoordinate(pnt.CorectedElevation)
+		} else {
+			ret.Elevation = formatCoordinate(pnt.Elevation)
+		}
+	}
+	if pnt.TimeValid {
+		ret.Time = formatTime(pnt.Time)
+	}
+
+	extension := gpxOutputTrackPointExtension{}
+	if pnt.TemperatureValid {
+		extension.AirTemperature = formatCoordinate(pnt.Temperature)
+	}
+	if pnt.HeartRateValid {
+		extension.HeartRate = strconv.Itoa(pnt.HeartRate)
+	}
+	if pnt.CadenceValid {
+		extension.Cadence = strconv.Itoa(pnt.Cadence)
+	}
+
+	pointExtension := gpxOutputPointExtension{}
+	if extension != (gpxOutputTrackPointExtension{}) {
+		pointExtension.TrackPointExtension = &extension
+	}
+	if pnt.PowerValid {
+		pointExtension.PowerExtension = &gpxOutputPowerExtension{strconv.Itoa(pnt.Power)}
+	}
+	if pointExtension != (gpxOutputPointExtension{}) {
+		ret.Extensions = &pointExtension
+	}
+
+	return ret
+}
+
+func (formater *GpxOutputFormater) convertWaypoint(wpt gpsabl.Waypoint) gpxOutputPoint {
+	ret := gpxOutputPoint{}
+	ret.Latitude = formatCoordinate(wpt.Latitude)
+	ret.Longitude = formatCoordinate(wpt.Longitude)
+	ret.Elevation = formatCoordinate(wpt.Elevation)
+	if wpt.TimeValid {
+		ret.Time = formatTime(wpt.Time)
+	}
+	ret.Name = wpt.Name
+	ret.Description = wpt.Description
+
+	return ret
+}
+
+// formatCoordinate - Format the value with as many digits as needed to read the same float32 value again
+func formatCoordinate(value float32) string {
+	return strconv.FormatFloat(float64(value), 'f', -1, 32)
+}
+
+func formatTime(value time.Time) string {
+	return value.UTC().Format(time.RFC3339Nano)
+}
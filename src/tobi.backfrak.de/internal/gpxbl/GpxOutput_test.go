@@ -0,0 +1,336 @@
+package gpxbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func TestGpxOutputFormaterIsOutputFormater(t *testing.T) {
+	var orig GpxOutputFormater
+	sut := orig.NewOutputFormater()
+
+	if sut.CheckFileExtension("my/output.gpx") == false || sut.CheckFileExtension("my/output.GPX") == false {
+		t.Errorf("GpxOutputFormater can not write *.gpx")
+	}
+
+	if sut.CheckFileExtension("my/output.json") == true {
+		t.Errorf("GpxOutputFormater can write *.json")
+	}
+
+	if sut.CheckOutputFormaterType(GpxOutputFormatertype) == false {
+		t.Errorf("GpxOutputFormater can not write %s type", GpxOutputFormatertype)
+	}
+
+	if len(sut.GetFileExtensions()) != 1 || sut.GetFileExtensions()[0] != ".gpx" {
+		t.Errorf("The file extensions are %v, but should be %v", sut.GetFileExtensions(), []string{".gpx"})
+	}
+
+	if len(sut.GetOutputFormaterTypes()) != 1 || sut.GetOutputFormaterTypes()[0] != "GPX" {
+		t.Errorf("The OutputFormaterTypes are %v, but should be %v", sut.GetOutputFormaterTypes(), []string{"GPX"})
+	}
+
+	if sut.GetNumberOfOutputEntries() != -1 {
+		t.Errorf("The initial value of GetNumberOfOutputEntries is %d but should be %d", sut.GetNumberOfOutputEntries(), -1)
+	}
+
+	if sut.GetTextOutputFormater() != nil {
+		t.Errorf("The GpxOutputFormater is a TextOutputFormater")
+	}
+}
+
+func TestGpxOutputAllValidGPXReadAgain(t *testing.T) {
+	files, _ := ioutil.ReadDir(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-gpx"))
+	for _, file := range files {
+		path := filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-gpx", file.Name())
+		expected, errRead := ReadGpxFile(path, gpsabl.STEPS, 0.3, 10.0)
+		if errRead != nil {
+			t.Fatalf("Can not read %s: %s", path, errRead.Error())
+		}
+
+		sut := NewGpxOutputFormater()
+		sut.AddOutPut(expected, gpsabl.TRACK, false)
+		actual := writeAndReadGpx(t, sut, gpsabl.STEPS)
+
+		// Tracks without points are not written
+		var expectedTracks []gpsabl.Track
+		for _, track := range expected.Tracks {
+			if len(track.TrackSegments) > 0 {
+				expectedTracks = append(expectedTracks, track)
+			}
+		}
+
+		if len(actual.Tracks) != len(expectedTracks) {
+			t.Errorf("The written %s contains %d tracks, but should contain %d", file.Name(), len(actual.Tracks), len(expectedTracks))
+			continue
+		}
+
+		if len(actual.Waypoints) != len(expected.Waypoints) {
+			t.Errorf("The written %s contains %d waypoints, but should contain %d", file.Name(), len(actual.Waypoints), len(expected.Waypoints))
+		}
+
+		for i, track := range actual.Tracks {
+			// The summary values are calculated from the same points, so they are the same
+			if fmt.Sprintf("%+v", track.TrackSummary) != fmt.Sprintf("%+v", expectedTracks[i].TrackSummary) {
+				t.Errorf("The values of track %d of the written %s are not the same as the values of the input", i, file.Name())
+			}
+
+			if expectedTracks[i].Name != "" && track.Name != expectedTracks[i].Name {
+				t.Errorf("The name of track %d of the written %s is \"%s\", but should be \"%s\"", i, file.Name(), track.Name, expectedTracks[i].Name)
+			}
+
+			if track.Description != expectedTracks[i].Description {
+				t.Errorf("The description of track %d of the written %s is \"%s\", but should be \"%s\"", i, file.Name(), track.Description, expectedTracks[i].Description)
+			}
+
+			if track.IsRoute != expectedTracks[i].IsRoute {
+				t.Errorf("The track %d of the written %s is a route %t, but should be %t", i, file.Name(), track.IsRoute, expectedTracks[i].IsRoute)
+			}
+		}
+	}
+}
+
+func TestGpxOutputCorrectedElevation(t *testing.T) {
+	input, _ := ReadGpxFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-gpx", "02.gpx"), gpsabl.STEPS, 0.3, 10.0)
+
+	sut := NewGpxOutputFormater()
+	sut.CorrectedElevation = true
+	sut.AddOutPut(input, gpsabl.TRACK, false)
+	actual := writeAndReadGpx(t, sut, gpsabl.NO)
+
+	expectedPoints := input.Tracks[0].TrackSegments[0].TrackPoints
+	actualPoints := actual.Tracks[0].TrackSegments[0].TrackPoints
+	if len(actualPoints) != len(expectedPoints) {
+		t.Fatalf("The written track contains %d points, but should contain %d", len(actualPoints), len(expectedPoints))
+	}
+
+	for i, pnt := range actualPoints {
+		if pnt.Elevation != expectedPoints[i].CorectedElevation {
+			t.Fatalf("The elevation of point %d is %f, but should be the corrected elevation %f", i, pnt.Elevation, expectedPoints[i].CorectedElevation)
+		}
+	}
+
+	if actual.ElevationGain != input.ElevationGain {
+		t.Errorf("The ElevationGain of the corrected track is %f, but should be %f", actual.ElevationGain, input.ElevationGain)
+	}
+}
+
+func TestGpxOutputDepth(t *testing.T) {
+	input, _ := ReadGpxFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-gpx", "03.gpx"), gpsabl.STEPS, 0.3, 10.0)
+	segments := 0
+	for _, track := range input.Tracks {
+		segments = segments + len(track.TrackSegments)
+	}
+
+	expected := map[gpsabl.DepthArg]int{gpsabl.FILE: 1, gpsabl.TRACK: len(input.Tracks), gpsabl.SEGMENT: segments}
+	for depth, count := range expected {
+		sut := NewGpxOutputFormater()
+		sut.AddOutPut(input, depth, false)
+		output, _ := sut.getOutput(gpsabl.NONE)
+		if len(output.Tracks) != count {
+			t.Errorf("The output with %s depth contains %d tracks, but should contain %d", depth, len(output.Tracks), count)
+		}
+	}
+
+	sut := NewGpxOutputFormater()
+	err := sut.AddOutPut(input, "abc", false)
+	switch err.(type) {
+	case *gpsabl.DepthParameterNotKnownError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a DepthParameterNotKnownError, got \"%v\"", err)
+	}
+}
+
+func TestGpxOutputSensorValues(t *testing.T) {
+	input, _ := ReadGpxFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-gpx", "01.gpx"), gpsabl.STEPS, 0.3, 10.0)
+	points := input.Tracks[0].TrackSegments[0].TrackPoints
+	points[0].HeartRate, points[0].HeartRateValid = 120, true
+	points[0].Cadence, points[0].CadenceValid = 85, true
+	points[0].Power, points[0].PowerValid = 230, true
+	points[0].Temperature, points[0].TemperatureValid = 21.5, true
+
+	sut := NewGpxOutputFormater()
+	sut.AddOutPut(input, gpsabl.TRACK, false)
+	actual := writeAndReadGpx(t, sut, gpsabl.STEPS)
+
+	pnt := actual.Tracks[0].TrackSegments[0].TrackPoints[0]
+	if pnt.HeartRate != 120 || pnt.Cadence != 85 || pnt.Power != 230 || pnt.Temperature != 21.5 {
+		t.Errorf("The sensor values of the point are %d, %d, %d, %f, but should be %d, %d, %d, %f", pnt.HeartRate, pnt.Cadence, pnt.Power, pnt.Temperature, 120, 85, 230, 21.5)
+	}
+
+	if actual.Tracks[0].TrackSegments[0].TrackPoints[1].HeartRateValid {
+		t.Errorf("The heart rate of a point without heart rate is valid")
+	}
+}
+
+func TestGpxOutputExtensionNamespaces(t *testing.T) {
+	input, _ := ReadGpxFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-gpx", "01.gpx"), gpsabl.STEPS, 0.3, 10.0)
+	points := input.Tracks[0].TrackSegments[0].TrackPoints
+	points[0].HeartRate, points[0].HeartRateValid = 120, true
+	points[0].Power, points[0].PowerValid = 230, true
+
+	dir, errDir := ioutil.TempDir("", "gpxbl")
+	if errDir != nil {
+		t.Fatalf("Can not create a temp dir: %s", errDir)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "out.gpx")
+	out, _ := os.Create(path)
+	sut := NewGpxOutputFormater()
+	sut.AddOutPut(input, gpsabl.TRACK, false)
+	if errWrite := sut.WriteOutput(out, gpsabl.NONE); errWrite != nil {
+		t.Fatalf("Got an error but did not expect one. The error is: %s", errWrite.Error())
+	}
+	out.Close()
+
+	// GPX 1.1 allows only elements of other namespaces than the GPX one in <extensions>
+	file, _ := os.Open(path)
+	defer file.Close()
+	decoder := xml.NewDecoder(file)
+	depth := 0
+	extensionsDepth := 0
+	namespaces := map[string]string{}
+	for {
+		token, errToken := decoder.Token()
+		if errToken != nil {
+			break
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			depth++
+			if extensionsDepth > 0 {
+				namespaces[element.Name.Local] = element.Name.Space
+			} else if element.Name.Local == "extensions" {
+				extensionsDepth = depth
+			}
+		case xml.EndElement:
+			if depth == extensionsDepth {
+				extensionsDepth = 0
+			}
+			depth--
+		}
+	}
+
+	expected := map[string]string{
+		"TrackPointExtension": "http://www.garmin.com/xmlschemas/TrackPointExtension/v1",
+		"hr":                  "http://www.garmin.com/xmlschemas/TrackPointExtension/v1",
+		"PowerExtension":      "http://www.garmin.com/xmlschemas/PowerExtension/v1",
+		"PowerInWatts":        "http://www.garmin.com/xmlschemas/PowerExtension/v1",
+	}
+	if len(namespaces) != len(expected) {
+		t.Errorf("The extensions contain the elements %v, but should contain %v", namespaces, expected)
+	}
+	for name, namespace := range expected {
+		if namespaces[name] != namespace {
+			t.Errorf("The namespace of <%s> is \"%s\", but should be \"%s\"", name, namespaces[name], namespace)
+		}
+	}
+}
+
+func TestGpxOutputMetadata(t *testing.T) {
+	input, _ := ReadGpxFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-gpx", "01.gpx"), gpsabl.STEPS, 0.3, 10.0)
+	input.Name = "My tour"
+	input.Description = "Along the river"
+
+	sut := NewGpxOutputFormater()
+	sut.AddOutPut(input, gpsabl.TRACK, false)
+	output, _ := sut.getOutput(gpsabl.NONE)
+	if output.Metadata == nil || output.Metadata.Name != input.Name || output.Metadata.Description != input.Description {
+		t.Errorf("The metadata is %+v, but should contain the name and description of the file", output.Metadata)
+	}
+
+	sut.AddOutPut(input, gpsabl.TRACK, false)
+	output, _ = sut.getOutput(gpsabl.NONE)
+	if output.Metadata != nil {
+		t.Errorf("The metadata is %+v, but should be empty for more than one file", output.Metadata)
+	}
+}
+
+func TestGpxOutputSortedAndDuplicateFilter(t *testing.T) {
+	first, _ := ReadGpxFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-gpx", "02.gpx"), gpsabl.STEPS, 0.3, 10.0)
+	second, _ := ReadGpxFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-gpx", "11.gpx"), gpsabl.STEPS, 0.3, 10.0)
+
+	sut := NewGpxOutputFormater()
+	sut.SetSorting(gpsabl.OutputSorting{Column: gpsabl.DISTANCE, Order: gpsabl.DESCENDING})
+	sut.AddOutPut(first, gpsabl.TRACK, true)
+	sut.AddOutPut(second, gpsabl.TRACK, true)
+	sut.AddOutPut(first, gpsabl.TRACK, true)
+	output, _ := sut.getOutput(gpsabl.NONE)
+
+	if len(output.Tracks) != 2 {
+		t.Fatalf("The output contains %d tracks, but should contain %d", len(output.Tracks), 2)
+	}
+
+	longest := first.Tracks[0]
+	if second.Distance > first.Distance {
+		longest = second.Tracks[0]
+	}
+	if output.Tracks[0].Name != longest.Name {
+		t.Errorf("The first track is \"%s\", but should be the longest track \"%s\"", output.Tracks[0].Name, longest.Name)
+	}
+}
+
+func TestGpxOutputNoTrack(t *testing.T) {
+	sut := NewGpxOutputFormater()
+	if err := sut.WriteOutput(os.Stdout, gpsabl.NONE); err != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+
+	if sut.GetNumberOfOutputEntries() != 0 {
+		t.Errorf("The number of written entries is %d, but should be %d", sut.GetNumberOfOutputEntries(), 0)
+	}
+}
+
+func TestGpxOutputSummaryNotValid(t *testing.T) {
+	sut := NewGpxOutputFormater()
+	err := sut.WriteOutput(os.Stdout, "abc")
+	switch err.(type) {
+	case *gpsabl.SummaryParamaterNotKnown:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a SummaryParamaterNotKnown, got \"%v\"", err)
+	}
+}
+
+// writeAndReadGpx - Write the output of the formater to a temp file, and read the file again
+func writeAndReadGpx(t *testing.T, sut *GpxOutputFormater, correction gpsabl.CorrectionParameter) gpsabl.TrackFile {
+	dir, errDir := ioutil.TempDir("", "gpxbl")
+	if errDir != nil {
+		t.Fatalf("Can not create a temp dir: %s", errDir)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "out.gpx")
+	out, _ := os.Create(path)
+	if errWrite := sut.WriteOutput(out, gpsabl.NONE); errWrite != nil {
+		t.Fatalf("Got an error but did not expect one. The error is: %s", errWrite.Error())
+	}
+	out.Close()
+
+	content, _ := ioutil.ReadFile(path)
+	if !strings.Contains(string(content), "xmlns=\"http://www.topografix.com/GPX/1/1\"") || !strings.Contains(string(content), "version=\"1.1\"") {
+		t.Errorf("The written file is not a GPX 1.1 file")
+	}
+
+	ret, errRead := ReadGpxFile(path, correction, 0.3, 10.0)
+	if errRead != nil {
+		t.Fatalf("Can not read the written file: %s", errRead.Error())
+	}
+
+	return ret
+}
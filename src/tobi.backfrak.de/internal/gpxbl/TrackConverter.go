@@ -6,7 +6,8 @@ package gpxbl
 // LICENSE file.
 
 import (
-	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"tobi.backfrak.de/internal/gpsabl"
@@ -31,9 +32,22 @@ func ConvertTrk(track Trk, correction gpsabl.CorrectionParameter, minimalMovingS
 	return res, err
 }
 
+// ConvertRte - Convert a gpxbl.Rte to a gpsabl.Track with one segment, that is marked as route
+func ConvertRte(route Rte, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.Track, error) {
+	track := Trk{}
+	track.Name = route.Name
+	track.Number = route.Number
+	track.Description = route.Description
+	track.TrackSegments = []Trkseg{{TrackPoints: route.RoutePoints}}
+
+	res, err := ConvertTrk(track, correction, minimalMovingSpeed, minimalStepHight)
+	res.IsRoute = true
+
+	return res, err
+}
+
 // ConvertGPXFile - Convert a gpxbl.Gpx to a gpsabl.TrackFile
 func ConvertGPXFile(gpx Gpx, filePath string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
-	ret := gpsabl.TrackFile{}
 	var tracks []gpsabl.Track
 	for _, trk := range gpx.Tracks {
 
@@ -41,13 +55,32 @@ func ConvertGPXFile(gpx Gpx, filePath string, correction gpsabl.CorrectionParame
 		if len(trk.TrackSegments) > 0 {
 			track, convertError := ConvertTrk(trk, correction, minimalMovingSpeed, minimalStepHight)
 			if convertError != nil {
-				return ret, convertError
+				return gpsabl.TrackFile{}, convertError
 			}
 			tracks = append(tracks, track)
 		}
 
 	}
 
+	for _, rte := range gpx.Routes {
+
+		// Add only routes that contain points
+		if len(rte.RoutePoints) > 0 {
+			track, convertError := ConvertRte(rte, correction, minimalMovingSpeed, minimalStepHight)
+			if convertError != nil {
+				return gpsabl.TrackFile{}, convertError
+			}
+			tracks = append(tracks, track)
+		}
+	}
+
+	return newTrackFile(tracks, gpx, filePath)
+}
+
+// newTrackFile - Get the gpsabl.TrackFile with the converted tracks, and the name, description and waypoints of the gpx
+func newTrackFile(tracks []gpsabl.Track, gpx Gpx, filePath string) (gpsabl.TrackFile, error) {
+	ret := gpsabl.TrackFile{}
+
 	// If no valid tracks found in the file, a error is returned
 	if len(tracks) > 0 {
 		ret.Tracks = tracks
@@ -57,6 +90,8 @@ func ConvertGPXFile(gpx Gpx, filePath string, correction gpsabl.CorrectionParame
 		ret.FilePath = filePath
 
 		gpsabl.FillTrackFileValues(&ret)
+		ret.Waypoints = convertWaypoints(gpx.Waypoints)
+		gpsabl.FillWaypointValues(&ret)
 	} else {
 		return ret, newEmptyGpxFileError(filePath)
 	}
@@ -64,20 +99,35 @@ func ConvertGPXFile(gpx Gpx, filePath string, correction gpsabl.CorrectionParame
 	return ret, nil
 }
 
+func convertWaypoints(waypoints []Wpt) []gpsabl.Waypoint {
+	var ret []gpsabl.Waypoint
+	for _, wpt := range waypoints {
+		pnt := convertBasicPointValues(wpt.Latitude, wpt.Longitude, wpt.Elevation, wpt.Time)
+		waypoint := gpsabl.Waypoint{}
+		waypoint.Name = wpt.Name
+		waypoint.Description = wpt.Description
+		waypoint.Latitude = pnt.Latitude
+		waypoint.Longitude = pnt.Longitude
+		waypoint.Elevation = pnt.Elevation
+		waypoint.Time = pnt.Time
+		waypoint.TimeValid = pnt.TimeValid
+		ret = append(ret, waypoint)
+	}
+
+	return ret
+}
+
 func convertSegments(segments []Trkseg, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) ([]gpsabl.TrackSegment, error) {
 	var ret []gpsabl.TrackSegment
-	var err error
 	for _, seg := range segments {
 
 		// Add only segments, that contain points
 		if len(seg.TrackPoints) > 0 {
-			segment := gpsabl.TrackSegment{}
-			segment.TrackPoints, err = convertPoints(seg.TrackPoints, correction, minimalMovingSpeed, minimalStepHight)
+			segment, err := convertSegment(seg, correction, minimalMovingSpeed, minimalStepHight)
 			if err != nil {
 				return nil, err
 			}
 
-			gpsabl.FillTrackSegmentValues(&segment)
 			ret = append(ret, segment)
 		}
 	}
@@ -85,70 +135,39 @@ func convertSegments(segments []Trkseg, correction gpsabl.CorrectionParameter, m
 	return ret, nil
 }
 
-func convertPoints(points []Trkpt, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) ([]gpsabl.TrackPoint, error) {
-	var ret []gpsabl.TrackPoint
-
-	pointCount := len(points)
-	if pointCount > 10 { // I think it makes only sense to use go routines for tracks with more then 10 points
-		c := make(chan gpsabl.TrackPoint, pointCount)
-		pointCounter := 0
-		for i, point := range points {
-			go goConvertPointDistance(point, i, &points, pointCount, c)
-
-		}
-		for pnt := range c {
-			ret = append(ret, pnt)
-			pointCounter++
-			if pointCounter == pointCount {
-				close(c)
-			}
-		}
-	} else {
-		for i, point := range points {
-			pnt := convertPointDistance(point, i, &points, pointCount)
-			ret = append(ret, pnt)
-		}
-	}
-
-	sort.Slice(ret, func(i, j int) bool {
-		return ret[i].Number < ret[j].Number
-	})
-
-	err := gpsabl.FillValuesTrackPointArray(ret, correction, minimalMovingSpeed, minimalStepHight)
+func convertSegment(seg Trkseg, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackSegment, error) {
+	segment := gpsabl.TrackSegment{}
+	var err error
+	segment.TrackPoints, err = convertPoints(seg.TrackPoints, correction, minimalMovingSpeed, minimalStepHight)
 	if err != nil {
-		return nil, err
+		return segment, err
 	}
 
-	return ret, nil
+	gpsabl.FillTrackSegmentValues(&segment)
+
+	return segment, nil
 }
 
-func convertPointDistance(point Trkpt, i int, pnts *[]Trkpt, pointCount int) gpsabl.TrackPoint {
-	pnt := convertBasicPointValues(point.Latitude, point.Longitude, point.Elevation, point.Time)
-	pnt.Number = i
-	points := *pnts
+func convertPoints(points []Trkpt, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) ([]gpsabl.TrackPoint, error) {
+	pointCount := len(points)
 
-	if i == 0 && pointCount > 1 {
-		pntNext := convertBasicPointValues(points[i+1].Latitude, points[i+1].Longitude, points[i+1].Elevation, points[i+1].Time)
-		gpsabl.FillDistancesTrackPoint(&pnt, gpsabl.TrackPoint{}, pntNext)
+	// The distances are calculated from the basic values of the points before and after
+	basic := make([]gpsabl.TrackPoint, pointCount)
+	for i, point := range points {
+		basic[i] = convertBasicPointValues(point.Latitude, point.Longitude, point.Elevation, point.Time)
 	}
 
-	if i > 0 && i < pointCount-1 {
-		pntNext := convertBasicPointValues(points[i+1].Latitude, points[i+1].Longitude, points[i+1].Elevation, points[i+1].Time)
-		pntBefore := convertBasicPointValues(points[i-1].Latitude, points[i-1].Longitude, points[i-1].Elevation, points[i-1].Time)
-		gpsabl.FillDistancesTrackPoint(&pnt, pntBefore, pntNext)
+	ret := gpsabl.FillDistances(basic)
+	for i := range ret {
+		convertSensorValues(&ret[i], points[i].Extensions)
 	}
 
-	if i == pointCount-1 && pointCount > 1 {
-		pntBefore := convertBasicPointValues(points[i-1].Latitude, points[i-1].Longitude, points[i-1].Elevation, points[i-1].Time)
-		gpsabl.FillDistancesTrackPoint(&pnt, pntBefore, gpsabl.TrackPoint{})
+	err := gpsabl.FillValuesTrackPointArray(ret, correction, minimalMovingSpeed, minimalStepHight)
+	if err != nil {
+		return nil, err
 	}
 
-	return pnt
-}
-
-func goConvertPointDistance(point Trkpt, i int, pnts *[]Trkpt, pointCount int, c chan gpsabl.TrackPoint) {
-
-	c <- convertPointDistance(point, i, pnts, pointCount)
+	return ret, nil
 }
 
 func convertBasicPointValues(latitude, longitude, elevation float32, timeStamp string) gpsabl.TrackPoint {
@@ -175,3 +194,37 @@ func convertBasicPointValues(latitude, longitude, elevation float32, timeStamp s
 
 	return pnt
 }
+
+// convertSensorValues - Set the sensor values of the point, out of the first extension that contains the value
+func convertSensorValues(pnt *gpsabl.TrackPoint, ext TrkptExtensions) {
+	pnt.HeartRate, pnt.HeartRateValid = getFirstIntValue(ext.TrackPointExtensionV2.HeartRate, ext.TrackPointExtensionV1.HeartRate, ext.ClueTrustHeartRate)
+	pnt.Cadence, pnt.CadenceValid = getFirstIntValue(ext.TrackPointExtensionV2.Cadence, ext.TrackPointExtensionV1.Cadence, ext.ClueTrustCadence)
+	pnt.Power, pnt.PowerValid = getFirstIntValue(ext.PowerExtension.PowerInWatts, ext.Power)
+	pnt.Temperature, pnt.TemperatureValid = getFirstFloatValue(ext.TrackPointExtensionV2.AirTemperature, ext.TrackPointExtensionV1.AirTemperature,
+		ext.TrackPointExtensionV2.WaterTemperature, ext.TrackPointExtensionV1.WaterTemperature, ext.ClueTrustTemp)
+}
+
+func getFirstIntValue(values ...string) (int, bool) {
+	value, valid := getFirstFloatValue(values...)
+	if !valid {
+		return 0, false
+	}
+
+	return int(value + 0.5), true
+}
+
+func getFirstFloatValue(values ...string) (float32, bool) {
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		ret, err := strconv.ParseFloat(value, 32)
+		if err == nil {
+			return float32(ret), true
+		}
+	}
+
+	return 0, false
+}
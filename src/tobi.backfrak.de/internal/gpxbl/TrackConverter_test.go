@@ -6,8 +6,12 @@ package gpxbl
 // LICENSE file.
 
 import (
+	"path/filepath"
 	"testing"
 	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
 )
 
 func getTrk() Trk {
@@ -225,3 +229,80 @@ func TestConvertTrkBasicInfo(t *testing.T) {
 		}
 	}
 }
+
+func TestConvertSensorValues(t *testing.T) {
+	ext := TrkptExtensions{}
+	ext.TrackPointExtensionV1.HeartRate = "120"
+	ext.TrackPointExtensionV2.HeartRate = " 121 "
+	ext.TrackPointExtensionV1.Cadence = "no number"
+	ext.ClueTrustCadence = "85"
+	ext.Power = "250.6"
+	ext.TrackPointExtensionV1.WaterTemperature = "12.5"
+
+	pnt := gpsabl.TrackPoint{}
+	convertSensorValues(&pnt, ext)
+
+	if !pnt.HeartRateValid || pnt.HeartRate != 121 {
+		t.Errorf("The HeartRate is %d, but should be %d", pnt.HeartRate, 121)
+	}
+
+	if !pnt.CadenceValid || pnt.Cadence != 85 {
+		t.Errorf("The Cadence is %d, but should be %d", pnt.Cadence, 85)
+	}
+
+	if !pnt.PowerValid || pnt.Power != 251 {
+		t.Errorf("The Power is %d, but should be %d", pnt.Power, 251)
+	}
+
+	if !pnt.TemperatureValid || pnt.Temperature != 12.5 {
+		t.Errorf("The Temperature is %f, but should be %f", pnt.Temperature, 12.5)
+	}
+}
+
+func TestConvertSensorValuesEmpty(t *testing.T) {
+	pnt := gpsabl.TrackPoint{}
+	convertSensorValues(&pnt, TrkptExtensions{})
+
+	if pnt.HeartRateValid || pnt.CadenceValid || pnt.PowerValid || pnt.TemperatureValid {
+		t.Errorf("A sensor value is valid, but the extensions are empty")
+	}
+}
+
+func BenchmarkConvertPoints(b *testing.B) {
+	var segments []Trkseg
+	files, _ := filepath.Glob(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-gpx", "*.gpx"))
+	for _, file := range files {
+		gpx, err := ReadGPX(file)
+		if err != nil {
+			continue
+		}
+		for _, trk := range gpx.Tracks {
+			segments = append(segments, trk.TrackSegments...)
+		}
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, seg := range segments {
+			if len(seg.TrackPoints) == 0 {
+				continue
+			}
+			if _, err := convertPoints(seg.TrackPoints, gpsabl.STEPS, 0.3, 10.0); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkReadGpxFile(b *testing.B) {
+	files, _ := filepath.Glob(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-gpx", "*.gpx"))
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, file := range files {
+			if _, err := ReadGpxFile(file, gpsabl.STEPS, 0.3, 10.0); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package htmlbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+// MaximalProfilePoints - The maximal number of points of an elevation profile. The points of longer tracks are thinned out, so the report stays small
+const MaximalProfilePoints = 500
+
+// The size of the svg drawing of an elevation profile. The profile line is drawn in the plot area, the labels left and below of it
+const (
+	profileWidth      = 640.0
+	profileHeight     = 180.0
+	profilePlotLeft   = 60.0
+	profilePlotRight  = 630.0
+	profilePlotTop    = 10.0
+	profilePlotBottom = 155.0
+)
+
+// ProfilePoint - A point of the elevation over distance profile of an output line
+type ProfilePoint struct {
+	// Distance - The distance from the start of the line in [m]
+	Distance float64
+	// Elevation - The corrected elevation in [m], the elevation the ElevationGain and ElevationLose are calculated from
+	Elevation float64
+}
+
+// GetElevationProfile - Get the elevation over distance profile of a gpsabl.TrackFile, gpsabl.Track or gpsabl.TrackSegment. The segments
+// are joined like in the ElevationOverDistance.csv. Other gpsabl.TrackSummaryProvider, like the summary values read from the cache, have no profile
+func GetElevationProfile(info gpsabl.TrackSummaryProvider) []ProfilePoint {
+	var segments []gpsabl.TrackSegment
+	switch data := info.(type) {
+	case gpsabl.TrackFile:
+		for _, track := range data.Tracks {
+			segments = append(segments, track.TrackSegments...)
+		}
+	case gpsabl.Track:
+		segments = data.TrackSegments
+	case gpsabl.TrackSegment:
+		segments = []gpsabl.TrackSegment{data}
+	}
+
+	points := []ProfilePoint{}
+	startDistance := 0.0
+	for _, segment := range segments {
+		for _, pnt := range segment.TrackPoints {
+			points = append(points, ProfilePoint{Distance: startDistance + pnt.DistanceToThisPoint, Elevation: float64(pnt.CorectedElevation)})
+		}
+
+		if len(segment.TrackPoints) > 0 {
+			startDistance = startDistance + segment.TrackPoints[len(segment.TrackPoints)-1].DistanceToThisPoint
+		}
+	}
+
+	return thinOutProfile(points, MaximalProfilePoints)
+}
+
+// thinOutProfile - Get maximal maxPoints points of the profile, evenly spread over the profile. The first and the last point are always kept
+func thinOutProfile(points []ProfilePoint, maxPoints int) []ProfilePoint {
+	if len(points) <= maxPoints || maxPoints < 2 {
+		return points
+	}
+
+	ret := make([]ProfilePoint, 0, maxPoints)
+	step := float64(len(points)-1) / float64(maxPoints-1)
+	for i := 0; i < maxPoints; i++ {
+		ret = append(ret, points[int(math.Round(float64(i)*step))])
+	}
+
+	return ret
+}
+
+// GetProfileSVG - Get the inline svg drawing of an elevation profile. Empty when the profile has less than two points
+func GetProfileSVG(profile []ProfilePoint) string {
+	if len(profile) < 2 {
+		return ""
+	}
+
+	minElevation, maxElevation := profile[0].Elevation, profile[0].Elevation
+	for _, pnt := range profile {
+		minElevation = math.Min(minElevation, pnt.Elevation)
+		maxElevation = math.Max(maxElevation, pnt.Elevation)
+	}
+	distance := profile[len(profile)-1].Distance
+
+	// A flat or a standing still profile is drawn as line at the bottom or the left of the plot area
+	elevationRange := maxElevation - minElevation
+	if elevationRange <= 0 {
+		elevationRange = 1
+	}
+	if distance <= 0 {
+		distance = 1
+	}
+
+	coordinates := []string{}
+	for _, pnt := range profile {
+		x := profilePlotLeft + pnt.Distance/distance*(profilePlotRight-profilePlotLeft)
+		y := profilePlotBottom - (pnt.Elevation-minElevation)/elevationRange*(profilePlotBottom-profilePlotTop)
+		coordinates = append(coordinates, fmt.Sprintf("%.1f,%.1f", x, y))
+	}
+	line := strings.Join(coordinates, " ")
+	area := fmt.Sprintf("%.1f,%.1f %s %.1f,%.1f", profilePlotLeft, profilePlotBottom, line, profilePlotRight, profilePlotBottom)
+
+	ret := fmt.Sprintf("<svg class=\"profile\" viewBox=\"0 0 %.0f %.0f\" role=\"img\" xmlns=\"http://www.w3.org/2000/svg\">%s", profileWidth, profileHeight, newLine)
+	ret = fmt.Sprintf("%s<polygon class=\"area\" points=\"%s\"/>%s", ret, area, newLine)
+	ret = fmt.Sprintf("%s<polyline class=\"line\" points=\"%s\"/>%s", ret, line, newLine)
+	ret = fmt.Sprintf("%s<line class=\"axis\" x1=\"%.1f\" y1=\"%.1f\" x2=\"%.1f\" y2=\"%.1f\"/>%s", ret, profilePlotLeft, profilePlotTop, profilePlotLeft, profilePlotBottom, newLine)
+	ret = fmt.Sprintf("%s<line class=\"axis\" x1=\"%.1f\" y1=\"%.1f\" x2=\"%.1f\" y2=\"%.1f\"/>%s", ret, profilePlotLeft, profilePlotBottom, profilePlotRight, profilePlotBottom, newLine)
+	ret = fmt.Sprintf("%s<text x=\"%.1f\" y=\"%.1f\" text-anchor=\"end\">%.0f m</text>%s", ret, profilePlotLeft-5, profilePlotTop+10, maxElevation, newLine)
+	ret = fmt.Sprintf("%s<text x=\"%.1f\" y=\"%.1f\" text-anchor=\"end\">%.0f m</text>%s", ret, profilePlotLeft-5, profilePlotBottom, minElevation, newLine)
+	ret = fmt.Sprintf("%s<text x=\"%.1f\" y=\"%.1f\">0 km</text>%s", ret, profilePlotLeft, profileHeight-5, newLine)
+	ret = fmt.Sprintf("%s<text x=\"%.1f\" y=\"%.1f\" text-anchor=\"end\">%.2f km</text>%s", ret, profilePlotRight, profileHeight-5, gpsabl.RoundFloat64To2Digits(profile[len(profile)-1].Distance/1000), newLine)
+	ret = fmt.Sprintf("%s</svg>%s", ret, newLine)
+
+	return ret
+}
@@ -0,0 +1,115 @@
+package htmlbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"strings"
+	"testing"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+func TestGetElevationProfileTrackFile(t *testing.T) {
+	file := getTrackFileTwoTracks()
+	profile := GetElevationProfile(file)
+
+	if len(profile) != 6 {
+		t.Fatalf("The profile has %d points, but should have %d", len(profile), 6)
+	}
+
+	for i := 1; i < len(profile); i++ {
+		if profile[i].Distance < profile[i-1].Distance {
+			t.Errorf("The distance of point %d is %f, and smaller than the distance of the point before %f", i, profile[i].Distance, profile[i-1].Distance)
+		}
+	}
+
+	if gpsabl.RoundFloat64To2Digits(profile[5].Distance) != gpsabl.RoundFloat64To2Digits(file.Distance) {
+		t.Errorf("The distance of the last point is %f, but should be %f", profile[5].Distance, file.Distance)
+	}
+
+	if profile[1].Elevation != float64(file.Tracks[0].TrackSegments[0].TrackPoints[1].CorectedElevation) {
+		t.Errorf("The elevation of the point is %f, but should be %f", profile[1].Elevation, file.Tracks[0].TrackSegments[0].TrackPoints[1].CorectedElevation)
+	}
+}
+
+func TestGetElevationProfileTrackAndSegment(t *testing.T) {
+	file := getTrackFileTwoTracks()
+
+	if len(GetElevationProfile(file.Tracks[0])) != 3 {
+		t.Errorf("The profile of the track has %d points, but should have %d", len(GetElevationProfile(file.Tracks[0])), 3)
+	}
+
+	if len(GetElevationProfile(file.Tracks[0].TrackSegments[0])) != 3 {
+		t.Errorf("The profile of the segment has %d points, but should have %d", len(GetElevationProfile(file.Tracks[0].TrackSegments[0])), 3)
+	}
+}
+
+func TestGetElevationProfileNoTrackPoints(t *testing.T) {
+	if len(GetElevationProfile(gpsabl.ExtendedTrackSummary{})) != 0 {
+		t.Errorf("A summary without track points has a profile")
+	}
+
+	if len(GetElevationProfile(gpsabl.StripTrackPoints(getSimpleTrackFile()))) != 0 {
+		t.Errorf("A TrackFile without track points has a profile")
+	}
+}
+
+func TestThinOutProfile(t *testing.T) {
+	points := []ProfilePoint{}
+	for i := 0; i < 1001; i++ {
+		points = append(points, ProfilePoint{Distance: float64(i), Elevation: float64(i % 7)})
+	}
+
+	thinned := thinOutProfile(points, MaximalProfilePoints)
+	if len(thinned) != MaximalProfilePoints {
+		t.Errorf("The thinned out profile has %d points, but should have %d", len(thinned), MaximalProfilePoints)
+	}
+
+	if thinned[0] != points[0] || thinned[len(thinned)-1] != points[len(points)-1] {
+		t.Errorf("The first or the last point of the profile is not kept")
+	}
+
+	if len(thinOutProfile(points[:10], MaximalProfilePoints)) != 10 {
+		t.Errorf("A short profile is thinned out")
+	}
+}
+
+func TestGetProfileSVG(t *testing.T) {
+	svg := GetProfileSVG(GetElevationProfile(getSimpleTrackFile()))
+
+	if !strings.HasPrefix(svg, "<svg class=\"profile\"") || !strings.Contains(svg, "</svg>") {
+		t.Errorf("The profile is not a svg drawing: %s", svg)
+	}
+
+	if strings.Count(svg, "<polyline") != 1 {
+		t.Errorf("The svg contains %d lines, but should contain %d", strings.Count(svg, "<polyline"), 1)
+	}
+
+	if strings.Contains(svg, "NaN") || strings.Contains(svg, "Inf") {
+		t.Errorf("The svg contains values that are not valid: %s", svg)
+	}
+}
+
+func TestGetProfileSVGFlatProfile(t *testing.T) {
+	svg := GetProfileSVG([]ProfilePoint{{Distance: 0, Elevation: 100}, {Distance: 0, Elevation: 100}})
+
+	if svg == "" {
+		t.Errorf("Got no svg for a flat profile")
+	}
+
+	if strings.Contains(svg, "NaN") || strings.Contains(svg, "Inf") {
+		t.Errorf("The svg contains values that are not valid: %s", svg)
+	}
+}
+
+func TestGetProfileSVGTooShort(t *testing.T) {
+	if GetProfileSVG([]ProfilePoint{{Distance: 0, Elevation: 100}}) != "" {
+		t.Errorf("Got a svg for a profile with one point")
+	}
+
+	if GetProfileSVG(nil) != "" {
+		t.Errorf("Got a svg for an empty profile")
+	}
+}
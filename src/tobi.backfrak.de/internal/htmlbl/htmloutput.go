@@ -0,0 +1,595 @@
+package htmlbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"html"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+// NotValidValue - The value set when values are not valid
+const NotValidValue = "not valid"
+
+// HTMLOutputFormatertype - The gpsabl.OutputFormaterType this formater is responsible for
+const HTMLOutputFormatertype gpsabl.OutputFormaterType = "HTML"
+
+// FileExtension - The file extension of the files this formater writes
+const FileExtension = ".html"
+
+const newLine = "\n"
+
+// The index of the columns in a table row, that are not filled for all summary rows
+const (
+	startTimeColumn       = 1
+	endTimeColumn         = 2
+	altitudeRangeColumn   = 6
+	minimumAltitudeColumn = 7
+	maximumAltitudeColumn = 8
+	averageSpeedColumn    = 16
+	upwardsSpeedColumn    = 17
+	downwardsSpeedColumn  = 18
+)
+
+// profiledSummary - The summary values of an output line together with its elevation profile. Only the profile of the
+// track points is kept, so the report of a big archive does not hold all points in memory
+type profiledSummary struct {
+	gpsabl.ExtendedTrackSummary
+	Profile []ProfilePoint
+}
+
+// tableCell - A cell of a html table. The Value is the key the column is sorted by, empty when the cell has no value
+type tableCell struct {
+	Text  string
+	Value string
+}
+
+// HTMLOutputFormater - type that formats TrackSummary into a self contained html report, with sortable tables and elevation profiles.
+// The report contains all styles and scripts, so it can be read offline or send by mail
+type HTMLOutputFormater struct {
+	// Tell if the waypoints of the TrackFiles should be added to the output
+	AddWaypoints bool
+
+	// Sorting - The order the lines of the track table are written in. The lines are written in the order they are added by default
+	Sorting gpsabl.OutputSorting
+
+	Title            string
+	TrackListText    string
+	SummaryText      string
+	WaypointListText string
+	ProfileListText  string
+
+	writtenEntiresCount int
+	entriesToWriteCount int
+	lineBuffer          []gpsabl.OutputLine
+	waypointBuffer      []gpsabl.Waypoint
+	mux                 sync.Mutex
+}
+
+// NewHTMLOutputFormater - Get a new HTMLOutputFormater
+func NewHTMLOutputFormater() *HTMLOutputFormater {
+	ret := HTMLOutputFormater{}
+	ret.writtenEntiresCount = -1
+	ret.entriesToWriteCount = 0
+	ret.lineBuffer = []gpsabl.OutputLine{}
+	ret.waypointBuffer = []gpsabl.Waypoint{}
+	ret.Title = "Track report"
+	ret.TrackListText = "List of Tracks"
+	ret.SummaryText = "Summary"
+	ret.WaypointListText = "List of Waypoints"
+	ret.ProfileListText = "Elevation profiles"
+
+	return &ret
+}
+
+// NewOutputFormater -  Get a new gpsabl.OutputFormater of this type
+func (formater *HTMLOutputFormater) NewOutputFormater() gpsabl.OutputFormater {
+	ret := NewHTMLOutputFormater()
+
+	return gpsabl.OutputFormater(ret)
+}
+
+// GetTextOutputFormater - Get the gpsabl.TextOutputFormater. This is always nil for this formater
+func (formater *HTMLOutputFormater) GetTextOutputFormater() gpsabl.TextOutputFormater {
+	return nil
+}
+
+// SetAddWaypoints - Set the value of formater.AddWaypoints
+func (formater *HTMLOutputFormater) SetAddWaypoints(value bool) {
+	formater.AddWaypoints = value
+}
+
+// SetSorting - Set the value of formater.Sorting
+func (formater *HTMLOutputFormater) SetSorting(sorting gpsabl.OutputSorting) {
+	formater.Sorting = sorting
+}
+
+// AddOutPut - Add the output values and the elevation profiles of a TrackFile to the internal buffer, so it can be written out later
+func (formater *HTMLOutputFormater) AddOutPut(trackFile gpsabl.TrackFile, depth gpsabl.DepthArg, filterDuplicate bool) error {
+	var lines []gpsabl.OutputLine
+	linesFromFile, err := gpsabl.GetOutlines(trackFile, depth)
+	if err != nil {
+		return err
+	}
+
+	for _, line := range linesFromFile {
+		if filterDuplicate && (gpsabl.OutputContainsLineByTimeStamps(lines, line) || gpsabl.OutputContainsLineByTimeStamps(formater.lineBuffer, line)) {
+			continue
+		}
+
+		lines = append(lines, getProfiledLine(line))
+	}
+
+	if formater.AddWaypoints && len(trackFile.Waypoints) > 0 {
+		formater.mux.Lock()
+		formater.waypointBuffer = append(formater.waypointBuffer, trackFile.Waypoints...)
+		formater.mux.Unlock()
+	}
+
+	if len(lines) > 0 {
+		formater.mux.Lock()
+		defer formater.mux.Unlock()
+		formater.lineBuffer = append(formater.lineBuffer, lines...)
+	}
+
+	return nil
+}
+
+// WriteOutput - Write the output to a given file handle object. Make sure the file exists before you call this method!
+func (formater *HTMLOutputFormater) WriteOutput(outFile *os.File, summary gpsabl.SummaryArg) error {
+	lines, getErr := formater.GetOutputLines(summary)
+	if getErr != nil {
+		return getErr
+	}
+
+	if formater.entriesToWriteCount == 0 {
+		formater.writtenEntiresCount = formater.entriesToWriteCount
+		return nil
+	}
+
+	for _, line := range lines {
+		_, errWrite := outFile.WriteString(line)
+		if errWrite != nil {
+			return errWrite
+		}
+	}
+
+	formater.writtenEntiresCount = formater.entriesToWriteCount
+	return nil
+}
+
+// GetOutputLines - Get all lines of the html document. Empty when there is no track to write
+func (formater *HTMLOutputFormater) GetOutputLines(summary gpsabl.SummaryArg) ([]string, error) {
+	formater.mux.Lock()
+	defer formater.mux.Unlock()
+
+	var body []string
+	switch summary {
+	case gpsabl.NONE:
+		body = append(body, formater.getTrackTableLines()...)
+		body = append(body, formater.getWaypointLines()...)
+		body = append(body, formater.getProfileLines()...)
+		formater.entriesToWriteCount = len(formater.lineBuffer)
+	case gpsabl.ONLY:
+		body = append(body, formater.getSummaryTableLines()...)
+		formater.entriesToWriteCount = len(formater.getSummaryRows())
+	case gpsabl.ADDITIONAL:
+		body = append(body, formater.getTrackTableLines()...)
+		body = append(body, formater.getWaypointLines()...)
+		body = append(body, formater.getSummaryTableLines()...)
+		body = append(body, formater.getProfileLines()...)
+		formater.entriesToWriteCount = len(formater.lineBuffer)
+	default:
+		return nil, gpsabl.NewSummaryParamaterNotKnown(summary)
+	}
+
+	if formater.entriesToWriteCount == 0 {
+		return []string{}, nil
+	}
+
+	ret := formater.getDocumentHeadLines()
+	ret = append(ret, body...)
+	ret = append(ret, getDocumentEndLines()...)
+
+	return ret, nil
+}
+
+// CheckOutputFormaterType - Check if this OutputFormater is responsible for the given gpsabl.OutputFormaterType
+func (formater *HTMLOutputFormater) CheckOutputFormaterType(formaterType gpsabl.OutputFormaterType) bool {
+	if formaterType == HTMLOutputFormatertype {
+		return true
+	}
+
+	return false
+}
+
+// GetFileExtensions - Get the list of file extensions this formater can write
+func (formater *HTMLOutputFormater) GetFileExtensions() []string {
+	return []string{FileExtension}
+}
+
+// GetOutputFormaterTypes - Get the list of gpsabl.OutputFormaterType this formater can write
+func (formater *HTMLOutputFormater) GetOutputFormaterTypes() []gpsabl.OutputFormaterType {
+	return []gpsabl.OutputFormaterType{HTMLOutputFormatertype}
+}
+
+// CheckFileExtension - Check if this OutputFormater can write the given output file
+func (formater *HTMLOutputFormater) CheckFileExtension(filePath string) bool {
+	if strings.HasSuffix(strings.ToLower(filePath), FileExtension) {
+		return true
+	}
+
+	return false
+}
+
+// GetOutputTableLineCount - Get the number of output lines in the normal output table
+func (formater *HTMLOutputFormater) GetOutputTableLineCount() int {
+	return len(formater.lineBuffer)
+}
+
+// Tells the number if output entries already written to output.
+// * -1: When output was not written yet
+// * 0: Output was written but contains no entries, may because no entry passes the given filter
+// * >0: The number of entries written to the outputs
+func (formater *HTMLOutputFormater) GetNumberOfOutputEntries() int {
+	return formater.writtenEntiresCount
+}
+
+// getProfiledLine - Get the OutputLine with the summary values and the elevation profile of the line
+func getProfiledLine(line gpsabl.OutputLine) gpsabl.OutputLine {
+	stripped := gpsabl.StripOutlines([]gpsabl.OutputLine{line})[0]
+
+	data := profiledSummary{}
+	data.ExtendedTrackSummary = stripped.Data.(gpsabl.ExtendedTrackSummary)
+	data.Profile = GetElevationProfile(line.Data)
+
+	return *gpsabl.NewOutputLine(line.Name, data)
+}
+
+// getSummary - Get the summary values of a line in the buffer
+func getSummary(line gpsabl.OutputLine) gpsabl.ExtendedTrackSummary {
+	if data, ok := line.Data.(profiledSummary); ok {
+		return data.ExtendedTrackSummary
+	}
+
+	return gpsabl.StripOutlines([]gpsabl.OutputLine{line})[0].Data.(gpsabl.ExtendedTrackSummary)
+}
+
+// getProfile - Get the elevation profile of a line in the buffer
+func getProfile(line gpsabl.OutputLine) []ProfilePoint {
+	if data, ok := line.Data.(profiledSummary); ok {
+		return data.Profile
+	}
+
+	return nil
+}
+
+// getTrackTableLines - Get the sortable table of the lines in the buffer
+func (formater *HTMLOutputFormater) getTrackTableLines() []string {
+	if len(formater.lineBuffer) == 0 {
+		return []string{}
+	}
+
+	gpsabl.SortOutputLines(formater.lineBuffer, formater.Sorting)
+	rows := [][]tableCell{}
+	links := []string{}
+	for i, line := range formater.lineBuffer {
+		summary := getSummary(line)
+		rows = append(rows, getCells(line.Name, summary, summary.TimeDataValid))
+		link := ""
+		if len(getProfile(line)) >= 2 {
+			link = getProfileID(i)
+		}
+		links = append(links, link)
+	}
+
+	ret := []string{fmt.Sprintf("<h2>%s</h2>%s", html.EscapeString(formater.TrackListText), newLine)}
+	ret = append(ret, getTableLines("tracks", true, getHeaders(), rows, links)...)
+
+	return ret
+}
+
+// getSummaryTableLines - Get the table of the statistic summary values of the lines in the buffer
+func (formater *HTMLOutputFormater) getSummaryTableLines() []string {
+	rows := formater.getSummaryRows()
+	if len(rows) == 0 {
+		return []string{}
+	}
+
+	ret := []string{fmt.Sprintf("<h2>%s</h2>%s", html.EscapeString(formater.SummaryText), newLine)}
+	ret = append(ret, getTableLines("summary", false, getHeaders(), rows, nil)...)
+
+	return ret
+}
+
+// getSummaryRows - Get the Sum, Average, Minimum and Maximum rows of the summary table. The columns that have no meaning
+// for a row, like the StartTime of the Sum, are written as "-"
+func (formater *HTMLOutputFormater) getSummaryRows() [][]tableCell {
+	if len(formater.lineBuffer) == 0 {
+		return [][]tableCell{}
+	}
+
+	stats := gpsabl.GetStatisticSummaryData(formater.lineBuffer)
+	sum := getCells("Sum", stats.Sum, stats.AllTimeDataValid)
+	setNotApplicable(sum, startTimeColumn, endTimeColumn, altitudeRangeColumn, minimumAltitudeColumn, maximumAltitudeColumn,
+		averageSpeedColumn, upwardsSpeedColumn, downwardsSpeedColumn)
+	for i := range gpsabl.GetSensorHeaders() {
+		setNotApplicable(sum, downwardsSpeedColumn+1+i)
+	}
+	average := getCells("Average", stats.Average, stats.AllTimeDataValid)
+	setNotApplicable(average, startTimeColumn, endTimeColumn, minimumAltitudeColumn, maximumAltitudeColumn)
+
+	return [][]tableCell{
+		sum,
+		average,
+		getCells("Minimum", stats.Minimum, stats.AllTimeDataValid),
+		getCells("Maximum", stats.Maximum, stats.AllTimeDataValid),
+	}
+}
+
+// getWaypointLines - Get the waypoint table that is written after the track table. Empty if formater.AddWaypoints is false or no waypoint was added
+func (formater *HTMLOutputFormater) getWaypointLines() []string {
+	if !formater.AddWaypoints || len(formater.waypointBuffer) == 0 {
+		return []string{}
+	}
+
+	rows := [][]tableCell{}
+	for _, wpt := range gpsabl.GetSortedWaypoints(formater.waypointBuffer) {
+		trackName := tableCell{Text: NotValidValue}
+		distanceAlongTrack := tableCell{Text: NotValidValue}
+		distanceFromTrack := tableCell{Text: NotValidValue}
+		if wpt.TrackValuesValid {
+			trackName = textCell(wpt.TrackName)
+			distanceAlongTrack = floatCell(wpt.DistanceAlongTrack / 1000)
+			distanceFromTrack = floatCell(wpt.DistanceFromTrack)
+		}
+
+		rows = append(rows, []tableCell{
+			textCell(wpt.Name),
+			trackName,
+			distanceAlongTrack,
+			distanceFromTrack,
+			{Text: fmt.Sprintf("%f", wpt.Latitude), Value: fmt.Sprintf("%f", wpt.Latitude)},
+			{Text: fmt.Sprintf("%f", wpt.Longitude), Value: fmt.Sprintf("%f", wpt.Longitude)},
+			floatCell(float64(wpt.Elevation)),
+		})
+	}
+
+	ret := []string{fmt.Sprintf("<h2>%s</h2>%s", html.EscapeString(formater.WaypointListText), newLine)}
+	ret = append(ret, getTableLines("waypoints", true, gpsabl.GetWaypointHeaders(), rows, nil)...)
+
+	return ret
+}
+
+// getProfileLines - Get the elevation profiles of the lines in the buffer, in the order of the track table
+func (formater *HTMLOutputFormater) getProfileLines() []string {
+	ret := []string{}
+	for i, line := range formater.lineBuffer {
+		svg := GetProfileSVG(getProfile(line))
+		if svg == "" {
+			continue
+		}
+
+		ret = append(ret, fmt.Sprintf("<figure id=\"%s\">%s", getProfileID(i), newLine))
+		ret = append(ret, fmt.Sprintf("<figcaption>%s</figcaption>%s", html.EscapeString(line.Name), newLine))
+		ret = append(ret, svg)
+		ret = append(ret, fmt.Sprintf("</figure>%s", newLine))
+	}
+
+	if len(ret) == 0 {
+		return ret
+	}
+
+	return append([]string{fmt.Sprintf("<h2>%s</h2>%s", html.EscapeString(formater.ProfileListText), newLine)}, ret...)
+}
+
+// getDocumentHeadLines - Get the start of the html document up to the begin of the body
+func (formater *HTMLOutputFormater) getDocumentHeadLines() []string {
+	return []string{
+		fmt.Sprintf("<!DOCTYPE html>%s", newLine),
+		fmt.Sprintf("<html lang=\"en\">%s", newLine),
+		fmt.Sprintf("<head>%s", newLine),
+		fmt.Sprintf("<meta charset=\"utf-8\">%s", newLine),
+		fmt.Sprintf("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">%s", newLine),
+		fmt.Sprintf("<meta name=\"generator\" content=\"gpsa\">%s", newLine),
+		fmt.Sprintf("<title>%s</title>%s", html.EscapeString(formater.Title), newLine),
+		fmt.Sprintf("<style>%s%s</style>%s", newLine, documentStyle, newLine),
+		fmt.Sprintf("</head>%s", newLine),
+		fmt.Sprintf("<body>%s", newLine),
+		fmt.Sprintf("<h1>%s</h1>%s", html.EscapeString(formater.Title), newLine),
+	}
+}
+
+// getDocumentEndLines - Get the end of the html document, with the script that sorts the tables
+func getDocumentEndLines() []string {
+	return []string{
+		fmt.Sprintf("<script>%s%s</script>%s", newLine, documentScript, newLine),
+		fmt.Sprintf("</body>%s", newLine),
+		fmt.Sprintf("</html>%s", newLine),
+	}
+}
+
+// getTableLines - Get the lines of a html table. The cells of the first column link to the anchor given in links, when not empty
+func getTableLines(id string, sortable bool, headers []string, rows [][]tableCell, links []string) []string {
+	class := ""
+	if sortable {
+		class = " class=\"sortable\""
+	}
+
+	ret := []string{fmt.Sprintf("<div class=\"table\"><table id=\"%s\"%s>%s", id, class, newLine)}
+	header := "<thead><tr>"
+	for _, name := range headers {
+		header = fmt.Sprintf("%s<th>%s</th>", header, html.EscapeString(name))
+	}
+	ret = append(ret, fmt.Sprintf("%s</tr></thead>%s", header, newLine))
+
+	ret = append(ret, fmt.Sprintf("<tbody>%s", newLine))
+	for i, row := range rows {
+		line := "<tr>"
+		for j, cell := range row {
+			text := html.EscapeString(cell.Text)
+			if j == 0 && i < len(links) && links[i] != "" {
+				text = fmt.Sprintf("<a href=\"#%s\">%s</a>", links[i], text)
+			}
+			line = fmt.Sprintf("%s<td data-value=\"%s\">%s</td>", line, html.EscapeString(cell.Value), text)
+		}
+		ret = append(ret, fmt.Sprintf("%s</tr>%s", line, newLine))
+	}
+	ret = append(ret, fmt.Sprintf("</tbody>%s", newLine))
+	ret = append(ret, fmt.Sprintf("</table></div>%s", newLine))
+
+	return ret
+}
+
+// getHeaders - Get the column headers of the track and the summary table
+func getHeaders() []string {
+	ret := []string{
+		"Name", "StartTime", "EndTime", "TrackTime", "Distance (km)", "HorizontalDistance (km)",
+		"AltitudeRange (m)", "MinimumAltitude (m)", "MaximumAltitude (m)", "ElevationGain (m)", "ElevationLose (m)",
+		"UpwardsDistance (km)", "DownwardsDistance (km)", "MovingTime", "UpwardsTime", "DownwardsTime",
+		"AverageSpeed (km/h)", "UpwardsSpeed (km/h)", "DownwardsSpeed (km/h)",
+	}
+	ret = append(ret, gpsabl.GetSensorHeaders()...)
+	ret = append(ret, gpsabl.GetActivityHeaders()...)
+
+	return ret
+}
+
+// getCells - Get the cells of a table row, in the order of getHeaders. The time values are not valid, when timeValid is false
+func getCells(name string, info gpsabl.ExtendedTrackSummary, timeValid bool) []tableCell {
+	notValid := tableCell{Text: NotValidValue}
+	startTime, endTime, duration, movingTime, upwardsTime, downwardsTime := notValid, notValid, notValid, notValid, notValid, notValid
+	averageSpeed, upwardsSpeed, downwardsSpeed := notValid, notValid, notValid
+	if timeValid {
+		startTime = timeCell(info.StartTime)
+		endTime = timeCell(info.EndTime)
+		duration = durationCell(info.Duration)
+		movingTime = durationCell(info.MovingTime)
+		upwardsTime = durationCell(info.UpwardsTime)
+		downwardsTime = durationCell(info.DownwardsTime)
+		averageSpeed = floatCell(info.AverageSpeed * 3.6)
+		upwardsSpeed = floatCell(info.UpwardsSpeed * 3.6)
+		downwardsSpeed = floatCell(info.DownwardsSpeed * 3.6)
+	}
+
+	ret := []tableCell{
+		textCell(name),
+		startTime,
+		endTime,
+		duration,
+		floatCell(info.Distance / 1000),
+		floatCell(info.HorizontalDistance / 1000),
+		floatCell(info.AltitudeRange),
+		floatCell(float64(info.MinimumAltitude)),
+		floatCell(float64(info.MaximumAltitude)),
+		floatCell(float64(info.ElevationGain)),
+		floatCell(float64(info.ElevationLose)),
+		floatCell(info.UpwardsDistance / 1000),
+		floatCell(info.DownwardsDistance / 1000),
+		movingTime,
+		upwardsTime,
+		downwardsTime,
+		averageSpeed,
+		upwardsSpeed,
+		downwardsSpeed,
+	}
+
+	for _, values := range []gpsabl.SensorValues{info.HeartRate, info.Cadence, info.Power} {
+		if values.Valid() {
+			ret = append(ret, floatCell(values.Minimum), floatCell(values.Average), floatCell(values.Maximum))
+		} else {
+			ret = append(ret, textCell(""), textCell(""), textCell(""))
+		}
+	}
+	ret = append(ret, textCell(info.ActivityType), textCell(info.Gear))
+
+	return ret
+}
+
+// setNotApplicable - Write the cells of the given columns as "-". Cells that are not valid are kept
+func setNotApplicable(row []tableCell, columns ...int) {
+	for _, column := range columns {
+		if row[column].Text != NotValidValue {
+			row[column] = textCell("")
+		}
+	}
+}
+
+func getProfileID(index int) string {
+	return fmt.Sprintf("profile-%d", index+1)
+}
+
+// textCell - Get a cell that shows the text. An empty text is shown as "-"
+func textCell(text string) tableCell {
+	if text == "" {
+		return tableCell{Text: "-"}
+	}
+
+	return tableCell{Text: text, Value: text}
+}
+
+func floatCell(value float64) tableCell {
+	return tableCell{Text: fmt.Sprintf("%.2f", gpsabl.RoundFloat64To2Digits(value)), Value: fmt.Sprintf("%f", value)}
+}
+
+func timeCell(value time.Time) tableCell {
+	return tableCell{Text: value.Format(time.RFC3339), Value: fmt.Sprintf("%d", value.Unix())}
+}
+
+func durationCell(value time.Duration) tableCell {
+	return tableCell{Text: value.String(), Value: fmt.Sprintf("%.0f", value.Seconds())}
+}
+
+// documentStyle - The inline style sheet of the report
+const documentStyle = `body { font-family: sans-serif; margin: 1em 2em; color: #222; }
+h1 { font-size: 1.6em; }
+h2 { font-size: 1.2em; margin-top: 1.5em; }
+div.table { overflow-x: auto; }
+table { border-collapse: collapse; font-size: 0.85em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.5em; white-space: nowrap; }
+th { background: #eee; }
+td { text-align: right; }
+td:first-child { text-align: left; }
+tbody tr:nth-child(even) { background: #f7f7f7; }
+table.sortable th { cursor: pointer; }
+table.sortable th[aria-sort="ascending"]::after { content: " \25B2"; }
+table.sortable th[aria-sort="descending"]::after { content: " \25BC"; }
+figure { margin: 1em 0; max-width: 60em; }
+figcaption { font-weight: bold; }
+svg.profile { width: 100%; height: auto; font-size: 11px; }
+svg.profile .area { fill: #cde3f5; stroke: none; }
+svg.profile .line { fill: none; stroke: #1f6fb2; stroke-width: 1.5; }
+svg.profile .axis { stroke: #666; }
+`
+
+// documentScript - The inline script, that sorts a table by the column of the clicked header. The cells are compared by
+// their data-value, as numbers when both values are numbers. Cells without value are always last
+const documentScript = `document.querySelectorAll("table.sortable").forEach(function (table) {
+  table.querySelectorAll("th").forEach(function (header, column) {
+    header.addEventListener("click", function () {
+      var ascending = header.getAttribute("aria-sort") !== "ascending";
+      table.querySelectorAll("th").forEach(function (other) { other.removeAttribute("aria-sort"); });
+      header.setAttribute("aria-sort", ascending ? "ascending" : "descending");
+      var body = table.tBodies[0];
+      var rows = Array.prototype.slice.call(body.rows);
+      rows.sort(function (a, b) {
+        var x = a.cells[column].getAttribute("data-value");
+        var y = b.cells[column].getAttribute("data-value");
+        if (x === y) { return 0; }
+        if (x === "") { return 1; }
+        if (y === "") { return -1; }
+        var result = (isNaN(x) || isNaN(y)) ? x.localeCompare(y) : x - y;
+        return ascending ? result : -result;
+      });
+      rows.forEach(function (row) { body.appendChild(row); });
+    });
+  });
+});
+`
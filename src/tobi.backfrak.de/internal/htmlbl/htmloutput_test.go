@@ -0,0 +1,442 @@
+package htmlbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+func TestNewOutputFormater(t *testing.T) {
+	var orig HTMLOutputFormater
+	sut := orig.NewOutputFormater()
+
+	if sut.CheckFileExtension("my/output.html") == false {
+		t.Errorf("HTMLOutputFormater can not write *.html")
+	}
+
+	if sut.CheckFileExtension("my/output.HTML") == false {
+		t.Errorf("HTMLOutputFormater can not write *.HTML")
+	}
+
+	if sut.CheckFileExtension("my/output.md") == true {
+		t.Errorf("HTMLOutputFormater can write *.md")
+	}
+
+	if sut.CheckOutputFormaterType(HTMLOutputFormatertype) == false {
+		t.Errorf("HTMLOutputFormater can not write %s type", HTMLOutputFormatertype)
+	}
+
+	if sut.CheckOutputFormaterType(gpsabl.OutputFormaterType("MD")) == true {
+		t.Errorf("HTMLOutputFormater can write %s type", "MD")
+	}
+
+	ext := sut.GetFileExtensions()
+	if len(ext) != 1 || ext[0] != ".html" {
+		t.Errorf("The file extensions are %v, but should be %v", ext, []string{".html"})
+	}
+
+	form := sut.GetOutputFormaterTypes()
+	if len(form) != 1 || form[0] != gpsabl.OutputFormaterType("HTML") {
+		t.Errorf("The OutputFormaterTypes are %v, but should be %v", form, []string{"HTML"})
+	}
+
+	if sut.GetNumberOfOutputEntries() != -1 {
+		t.Errorf("The initial value of GetNumberOfOutputEntries is %d but should be %d", sut.GetNumberOfOutputEntries(), -1)
+	}
+
+	if sut.GetOutputTableLineCount() != 0 {
+		t.Errorf("The initial value of GetOutputTableLineCount is %d but should be %d", sut.GetOutputTableLineCount(), 0)
+	}
+
+	if sut.GetTextOutputFormater() != nil {
+		t.Errorf("The HTMLOutputFormater is a TextOutputFormater")
+	}
+}
+
+func TestHTMLOutputFormaterIsOutputFormater(t *testing.T) {
+	formaters := []gpsabl.OutputFormater{NewHTMLOutputFormater()}
+
+	if len(formaters) != 1 {
+		t.Errorf("The number of formaters is %d, but should be %d", len(formaters), 1)
+	}
+}
+
+func TestAddOutPut(t *testing.T) {
+	sut := NewHTMLOutputFormater()
+
+	if err := sut.AddOutPut(getTrackFileTwoTracksWithTime(), gpsabl.TRACK, false); err != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+
+	if sut.GetOutputTableLineCount() != 2 {
+		t.Errorf("The number of lines is %d, but should be %d", sut.GetOutputTableLineCount(), 2)
+	}
+
+	if len(getProfile(sut.lineBuffer[0])) != 3 {
+		t.Errorf("The profile of the line has %d points, but should have %d", len(getProfile(sut.lineBuffer[0])), 3)
+	}
+
+	err := sut.AddOutPut(getSimpleTrackFile(), "abc", false)
+	switch err.(type) {
+	case *gpsabl.DepthParameterNotKnownError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a DepthParameterNotKnownError, got \"%v\"", err)
+	}
+}
+
+func TestAddOutPutDuplicateFilter(t *testing.T) {
+	sut := NewHTMLOutputFormater()
+
+	sut.AddOutPut(getSimpleTrackFileWithTime(), gpsabl.FILE, true)
+	sut.AddOutPut(getSimpleTrackFileWithTime(), gpsabl.FILE, true)
+	if sut.GetOutputTableLineCount() != 1 {
+		t.Errorf("The number of lines is %d, but should be %d", sut.GetOutputTableLineCount(), 1)
+	}
+
+	sut.AddOutPut(getSimpleTrackFileWithTime(), gpsabl.FILE, false)
+	if sut.GetOutputTableLineCount() != 2 {
+		t.Errorf("The number of lines is %d, but should be %d", sut.GetOutputTableLineCount(), 2)
+	}
+}
+
+func TestGetOutputLinesSummaryNone(t *testing.T) {
+	sut := NewHTMLOutputFormater()
+	sut.AddOutPut(getTrackFileTwoTracksWithTime(), gpsabl.TRACK, false)
+
+	lines, err := sut.GetOutputLines(gpsabl.NONE)
+	if err != nil {
+		t.Fatalf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+	document := strings.Join(lines, "")
+
+	checkDocument(t, document)
+	if strings.Count(getTableBody(document, "tracks"), "<tr>") != 2 {
+		t.Errorf("The track table contains %d rows, but should contain %d", strings.Count(getTableBody(document, "tracks"), "<tr>"), 2)
+	}
+
+	if strings.Contains(document, "id=\"summary\"") {
+		t.Errorf("The document contains a summary table")
+	}
+
+	if strings.Count(document, "<figure id=\"profile-") != 2 {
+		t.Errorf("The document contains %d profiles, but should contain %d", strings.Count(document, "<figure id=\"profile-"), 2)
+	}
+
+	if !strings.Contains(document, "<a href=\"#profile-1\">") {
+		t.Errorf("The track table does not link to the profiles")
+	}
+
+	if sut.entriesToWriteCount != 2 {
+		t.Errorf("The number of entries to write is %d, but should be %d", sut.entriesToWriteCount, 2)
+	}
+}
+
+func TestGetOutputLinesSummaryOnly(t *testing.T) {
+	sut := NewHTMLOutputFormater()
+	sut.AddOutPut(getTrackFileTwoTracksWithTime(), gpsabl.TRACK, false)
+
+	lines, err := sut.GetOutputLines(gpsabl.ONLY)
+	if err != nil {
+		t.Fatalf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+	document := strings.Join(lines, "")
+
+	checkDocument(t, document)
+	if strings.Contains(document, "id=\"tracks\"") || strings.Contains(document, "<figure") {
+		t.Errorf("The document contains the tracks, but should only contain the summary")
+	}
+
+	body := getTableBody(document, "summary")
+	if strings.Count(body, "<tr>") != 4 {
+		t.Errorf("The summary table contains %d rows, but should contain %d", strings.Count(body, "<tr>"), 4)
+	}
+
+	for _, name := range []string{"Sum", "Average", "Minimum", "Maximum"} {
+		if !strings.Contains(body, fmt.Sprintf(">%s</td>", name)) {
+			t.Errorf("The summary table does not contain the %s row", name)
+		}
+	}
+
+	if sut.entriesToWriteCount != 4 {
+		t.Errorf("The number of entries to write is %d, but should be %d", sut.entriesToWriteCount, 4)
+	}
+}
+
+func TestGetOutputLinesSummaryAdditional(t *testing.T) {
+	sut := NewHTMLOutputFormater()
+	sut.AddOutPut(getTrackFileTwoTracksWithTime(), gpsabl.TRACK, false)
+
+	lines, err := sut.GetOutputLines(gpsabl.ADDITIONAL)
+	if err != nil {
+		t.Fatalf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+	document := strings.Join(lines, "")
+
+	checkDocument(t, document)
+	if strings.Count(getTableBody(document, "tracks"), "<tr>") != 2 {
+		t.Errorf("The track table contains %d rows, but should contain %d", strings.Count(getTableBody(document, "tracks"), "<tr>"), 2)
+	}
+
+	if strings.Count(getTableBody(document, "summary"), "<tr>") != 4 {
+		t.Errorf("The summary table contains %d rows, but should contain %d", strings.Count(getTableBody(document, "summary"), "<tr>"), 4)
+	}
+
+	if strings.Index(document, "id=\"summary\"") < strings.Index(document, "id=\"tracks\"") {
+		t.Errorf("The summary table is written before the track table")
+	}
+}
+
+func TestGetOutputLinesNoLines(t *testing.T) {
+	sut := NewHTMLOutputFormater()
+
+	for _, summary := range gpsabl.GetValidSummaryArgs() {
+		lines, err := sut.GetOutputLines(summary)
+		if err != nil {
+			t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+		}
+
+		if len(lines) != 0 {
+			t.Errorf("Got %d lines for the %s summary, but expected none", len(lines), summary)
+		}
+	}
+}
+
+func TestGetOutputLinesSummaryUnValid(t *testing.T) {
+	sut := NewHTMLOutputFormater()
+	sut.AddOutPut(getSimpleTrackFile(), gpsabl.FILE, false)
+
+	_, err := sut.GetOutputLines("abc")
+	switch err.(type) {
+	case *gpsabl.SummaryParamaterNotKnown:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a SummaryParamaterNotKnown, got \"%v\"", err)
+	}
+}
+
+func TestGetOutputLinesWithoutTime(t *testing.T) {
+	sut := NewHTMLOutputFormater()
+	sut.AddOutPut(getSimpleTrackFile(), gpsabl.FILE, false)
+	sut.AddOutPut(getSimpleTrackFileWithTime(), gpsabl.FILE, false)
+
+	lines, _ := sut.GetOutputLines(gpsabl.ADDITIONAL)
+	tracks := getTableBody(strings.Join(lines, ""), "tracks")
+	summary := getTableBody(strings.Join(lines, ""), "summary")
+
+	// StartTime, EndTime, TrackTime, MovingTime, UpwardsTime, DownwardsTime and the three speeds
+	if strings.Count(tracks, NotValidValue) != 9 {
+		t.Errorf("The track table contains %d not valid values, but should contain %d", strings.Count(tracks, NotValidValue), 9)
+	}
+
+	if !strings.Contains(summary, NotValidValue) {
+		t.Errorf("The summary table contains time values, but not all tracks have time data")
+	}
+}
+
+func TestGetOutputLinesEscapesNames(t *testing.T) {
+	sut := NewHTMLOutputFormater()
+	file := getSimpleTrackFile()
+	file.Name = "<b>Tom & Jerry</b>"
+	sut.AddOutPut(file, gpsabl.FILE, false)
+
+	lines, _ := sut.GetOutputLines(gpsabl.NONE)
+	document := strings.Join(lines, "")
+	if strings.Contains(document, file.Name) {
+		t.Errorf("The name of the file is not escaped")
+	}
+
+	if !strings.Contains(document, "&lt;b&gt;Tom &amp; Jerry&lt;/b&gt;") {
+		t.Errorf("The escaped name of the file is not found")
+	}
+}
+
+func TestGetOutputLinesWithWaypoints(t *testing.T) {
+	sut := NewHTMLOutputFormater()
+	sut.SetAddWaypoints(true)
+	file := getSimpleTrackFile()
+	file.Waypoints = []gpsabl.Waypoint{{Name: "Hut", Latitude: 50.1, Longitude: 8.6, Elevation: 110}}
+	sut.AddOutPut(file, gpsabl.FILE, false)
+
+	lines, _ := sut.GetOutputLines(gpsabl.NONE)
+	body := getTableBody(strings.Join(lines, ""), "waypoints")
+	if strings.Count(body, "<tr>") != 1 || !strings.Contains(body, ">Hut</td>") {
+		t.Errorf("The waypoint table does not contain the waypoint: %s", body)
+	}
+
+	lines, _ = sut.GetOutputLines(gpsabl.ONLY)
+	if strings.Contains(strings.Join(lines, ""), "id=\"waypoints\"") {
+		t.Errorf("The waypoints are written with the \"only\" summary")
+	}
+}
+
+func TestGetOutputLinesSorted(t *testing.T) {
+	sut := NewHTMLOutputFormater()
+	file2014 := getSimpleTrackFileWithTime()
+	file2014.Name = "Track 2014"
+	file2015 := getTrackFileWithDifferentTime()
+	file2015.Name = "Track 2015"
+	sut.AddOutPut(file2014, gpsabl.FILE, false)
+	sut.AddOutPut(file2015, gpsabl.FILE, false)
+
+	lines, _ := sut.GetOutputLines(gpsabl.NONE)
+	document := strings.Join(lines, "")
+	if strings.Index(document, "Track 2014") > strings.Index(document, "Track 2015") {
+		t.Errorf("The lines are not written in the order they were added")
+	}
+
+	sut.SetSorting(gpsabl.OutputSorting{Column: gpsabl.STARTTIME, Order: gpsabl.DESCENDING})
+	lines, _ = sut.GetOutputLines(gpsabl.NONE)
+	document = strings.Join(lines, "")
+	if strings.Index(document, "Track 2015") > strings.Index(document, "Track 2014") {
+		t.Errorf("The lines are not sorted by the StartTime descending")
+	}
+}
+
+func TestWriteOutput(t *testing.T) {
+	dir, errDir := ioutil.TempDir("", "htmlbl")
+	if errDir != nil {
+		t.Fatalf("Can not create a temp dir: %s", errDir)
+	}
+	defer os.RemoveAll(dir)
+
+	out, _ := os.Create(filepath.Join(dir, "report.html"))
+	sut := NewHTMLOutputFormater()
+	sut.AddOutPut(getTrackFileTwoTracksWithTime(), gpsabl.SEGMENT, false)
+	if err := sut.WriteOutput(out, gpsabl.ADDITIONAL); err != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+	out.Close()
+
+	if sut.GetNumberOfOutputEntries() != 2 {
+		t.Errorf("The number of written entries is %d, but should be %d", sut.GetNumberOfOutputEntries(), 2)
+	}
+
+	content, _ := ioutil.ReadFile(filepath.Join(dir, "report.html"))
+	checkDocument(t, string(content))
+}
+
+func TestWriteOutputNoTrack(t *testing.T) {
+	sut := NewHTMLOutputFormater()
+	if err := sut.WriteOutput(os.Stdout, gpsabl.ADDITIONAL); err != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+
+	if sut.GetNumberOfOutputEntries() != 0 {
+		t.Errorf("The number of written entries is %d, but should be %d", sut.GetNumberOfOutputEntries(), 0)
+	}
+}
+
+// checkDocument - Check the document is a html document, that loads nothing from the network
+func checkDocument(t *testing.T, document string) {
+	if !strings.HasPrefix(document, "<!DOCTYPE html>") || !strings.HasSuffix(document, "</html>\n") {
+		t.Errorf("The output is not a html document")
+	}
+
+	for _, external := range []string{"src=", "http://", "https://", "@import", "<link"} {
+		if strings.Contains(strings.ReplaceAll(document, "xmlns=\"http://www.w3.org/2000/svg\"", ""), external) {
+			t.Errorf("The document contains \"%s\", but should not load anything", external)
+		}
+	}
+
+	if !strings.Contains(document, "<style>") || !strings.Contains(document, "<script>") {
+		t.Errorf("The document does not contain the inline style and script")
+	}
+}
+
+// getTableBody - Get the <tbody> of the table with the id
+func getTableBody(document string, id string) string {
+	start := strings.Index(document, fmt.Sprintf("<table id=\"%s\"", id))
+	if start < 0 {
+		return ""
+	}
+	table := document[start:]
+
+	return table[strings.Index(table, "<tbody>"):strings.Index(table, "</tbody>")]
+}
+
+func getTrackFileWithDifferentTime() gpsabl.TrackFile {
+	file := getSimpleTrackFileWithTime()
+	for i := range file.Tracks[0].TrackSegments[0].TrackPoints {
+		file.Tracks[0].TrackSegments[0].TrackPoints[i].Time = file.Tracks[0].TrackSegments[0].TrackPoints[i].Time.AddDate(1, 0, 0)
+	}
+	gpsabl.FillTrackSegmentValues(&file.Tracks[0].TrackSegments[0])
+	gpsabl.FillTrackValues(&file.Tracks[0])
+	gpsabl.FillTrackFileValues(&file)
+
+	return file
+}
+
+func getTrackFileTwoTracks() gpsabl.TrackFile {
+	trackFile := getSimpleTrackFile()
+	trackFile.Tracks = append(trackFile.Tracks, getSimpleTrackFile().Tracks...)
+	gpsabl.FillTrackFileValues(&trackFile)
+
+	return trackFile
+}
+
+func getTrackFileTwoTracksWithTime() gpsabl.TrackFile {
+	trackFile := getSimpleTrackFileWithTime()
+	trackFile.Tracks = append(trackFile.Tracks, getSimpleTrackFileWithTime().Tracks...)
+	gpsabl.FillTrackFileValues(&trackFile)
+
+	return trackFile
+}
+
+func getSimpleTrackFile() gpsabl.TrackFile {
+	return getTrackFileFromPoints(getSimpleTrackPointArray(false))
+}
+
+func getSimpleTrackFileWithTime() gpsabl.TrackFile {
+	return getTrackFileFromPoints(getSimpleTrackPointArray(true))
+}
+
+func getTrackFileFromPoints(points []gpsabl.TrackPoint) gpsabl.TrackFile {
+	seg := gpsabl.TrackSegment{}
+	seg.TrackPoints = points
+	gpsabl.FillTrackSegmentValues(&seg)
+
+	trk := gpsabl.Track{}
+	trk.TrackSegments = []gpsabl.TrackSegment{seg}
+	trk.NumberOfSegments = 1
+	gpsabl.FillTrackValues(&trk)
+
+	ret := gpsabl.NewTrackFile("/mys/track/file")
+	ret.Tracks = []gpsabl.Track{trk}
+	ret.NumberOfTracks = 1
+	gpsabl.FillTrackFileValues(&ret)
+
+	return ret
+}
+
+func getSimpleTrackPointArray(withTime bool) []gpsabl.TrackPoint {
+	t1, _ := time.Parse(time.RFC3339, "2014-08-22T17:19:33Z")
+	points := []gpsabl.TrackPoint{
+		{Latitude: 50.11484790, Longitude: 8.684885500, Elevation: 109.0},
+		{Latitude: 50.11495750, Longitude: 8.684874770, Elevation: 108.0},
+		{Latitude: 50.11484790, Longitude: 8.684885500, Elevation: 109.0},
+	}
+	for i := range points {
+		points[i].TimeValid = withTime
+		if withTime {
+			points[i].Time = t1.Add(time.Duration(i*10) * time.Second)
+		}
+	}
+
+	gpsabl.FillDistancesTrackPoint(&points[0], gpsabl.TrackPoint{}, points[1])
+	gpsabl.FillDistancesTrackPoint(&points[1], points[0], points[2])
+	gpsabl.FillDistancesTrackPoint(&points[2], points[1], gpsabl.TrackPoint{})
+	gpsabl.FillValuesTrackPointArray(points, "none", 0.3, 10.0)
+
+	return points
+}
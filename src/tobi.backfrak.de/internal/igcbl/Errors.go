@@ -0,0 +1,73 @@
+package igcbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import "fmt"
+
+// IgcFileError - Error when trying to load something that is not an IGC flight log
+type IgcFileError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+}
+
+func (e *IgcFileError) Error() string { // Implement the Error Interface for the IgcFileError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newIgcFileError - Get a new IgcFileError struct
+func newIgcFileError(fileName string) *IgcFileError {
+	return &IgcFileError{fmt.Sprintf("The file \"%s\" is not an IGC flight log", fileName), fileName}
+}
+
+// EmptyIgcFileError - Error when trying to load an IGC flight log that does not contain any B record
+type EmptyIgcFileError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+}
+
+func (e *EmptyIgcFileError) Error() string { // Implement the Error Interface for the EmptyIgcFileError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newEmptyIgcFileError - Get a new EmptyIgcFileError struct
+func newEmptyIgcFileError(fileName string) *EmptyIgcFileError {
+	return &EmptyIgcFileError{fmt.Sprintf("The file \"%s\" does not contain any B record.", fileName), fileName}
+}
+
+// IgcRecordError - Error when a B record of an IGC flight log can not be parsed
+type IgcRecordError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+	// Line - The number of the line with the record that caused this error
+	Line int
+}
+
+func (e *IgcRecordError) Error() string { // Implement the Error Interface for the IgcRecordError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newIgcRecordError - Get a new IgcRecordError struct
+func newIgcRecordError(fileName string, line int) *IgcRecordError {
+	return &IgcRecordError{fmt.Sprintf("The B record in line %d of the file \"%s\" can not be parsed.", line, fileName), fileName, line}
+}
+
+// AltitudeSourceNotKnownError - Error when the given altitude source is not known
+type AltitudeSourceNotKnownError struct {
+	err string
+	// GivenValue - The altitude source that caused this error
+	GivenValue AltitudeSource
+}
+
+func (e *AltitudeSourceNotKnownError) Error() string { // Implement the Error Interface for the AltitudeSourceNotKnownError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// NewAltitudeSourceNotKnownError - Get a new AltitudeSourceNotKnownError struct
+func NewAltitudeSourceNotKnownError(givenValue AltitudeSource) *AltitudeSourceNotKnownError {
+	return &AltitudeSourceNotKnownError{fmt.Sprintf("The given -igc-altitude \"%s\" is not known.", givenValue), givenValue}
+}
@@ -0,0 +1,58 @@
+package igcbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"strings"
+	"testing"
+)
+
+func TestIgcFileErrorStruct(t *testing.T) {
+
+	path := "/some/sample/path"
+	err := newIgcFileError(path)
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of IgcFileError does not contain the expected Path")
+	}
+
+	if err.File != path {
+		t.Errorf("The IgcFileError.File does not match the expected value")
+	}
+}
+
+func TestEmptyIgcFileError(t *testing.T) {
+	path := "/some/sample/path"
+	err := newEmptyIgcFileError(path)
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of EmptyIgcFileError does not contain the expected Path")
+	}
+
+	if err.File != path {
+		t.Errorf("The EmptyIgcFileError.File does not match the expected value")
+	}
+}
+
+func TestIgcRecordError(t *testing.T) {
+	path := "/some/sample/path"
+	err := newIgcRecordError(path, 42)
+	if strings.Contains(err.Error(), path) == false || strings.Contains(err.Error(), "42") == false {
+		t.Errorf("The error message of IgcRecordError does not contain the expected Path and Line")
+	}
+
+	if err.File != path || err.Line != 42 {
+		t.Errorf("The IgcRecordError.File or IgcRecordError.Line does not match the expected value")
+	}
+}
+
+func TestAltitudeSourceNotKnownError(t *testing.T) {
+	err := NewAltitudeSourceNotKnownError("radar")
+	if strings.Contains(err.Error(), "radar") == false {
+		t.Errorf("The error message of AltitudeSourceNotKnownError does not contain the expected value")
+	}
+
+	if err.GivenValue != "radar" {
+		t.Errorf("The AltitudeSourceNotKnownError.GivenValue does not match the expected value")
+	}
+}
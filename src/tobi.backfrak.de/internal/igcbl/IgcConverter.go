@@ -0,0 +1,174 @@
+package igcbl
+
+import (
+	"fmt"
+	"strings"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+// AltitudeSource - Tells which altitude of the B records is used as elevation
+type AltitudeSource string
+
+const (
+	// PRESSURE - The barometric pressure altitude is used
+	PRESSURE AltitudeSource = "pressure"
+	// GNSS - The GNSS altitude is used
+	GNSS AltitudeSource = "gnss"
+)
+
+// GetValidAltitudeSources - Get the valid values for the AltitudeSource
+func GetValidAltitudeSources() []AltitudeSource {
+	return []AltitudeSource{PRESSURE, GNSS}
+}
+
+// GetValidAltitudeSourcesString - Get the valid values for the AltitudeSource as one string
+func GetValidAltitudeSourcesString() string {
+	ret := ""
+	for _, str := range GetValidAltitudeSources() {
+		ret = fmt.Sprintf("%s %s", str, ret)
+	}
+
+	return ret
+}
+
+// CheckValidAltitudeSource - Check if a string is a valid AltitudeSource
+func CheckValidAltitudeSource(given AltitudeSource) bool {
+	for _, str := range GetValidAltitudeSources() {
+		if str == given {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ConvertIgc - Convert a igcbl.Igc to a gpsabl.TrackFile with one gpsabl.Track and one gpsabl.TrackSegment.
+// The altitudeSource is used as elevation, if the log contains values of this source, otherwise the other one
+func ConvertIgc(igc Igc, filePath string, altitudeSource AltitudeSource, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+
+	if len(igc.Records) <= 0 {
+		return gpsabl.TrackFile{}, newEmptyIgcFileError(filePath)
+	}
+
+	res := gpsabl.NewTrackFile(filePath)
+	track := gpsabl.Track{}
+
+	usePressure := hasPressureAltitude(igc.Records)
+	if altitudeSource == GNSS && hasGnssAltitude(igc.Records) {
+		usePressure = false
+	}
+
+	seg, err := convertRecords(igc, usePressure, correction, minimalMovingSpeed, minimalStepHight)
+	if err != nil {
+		return gpsabl.TrackFile{}, err
+	}
+
+	track.TrackSegments = []gpsabl.TrackSegment{seg}
+	track.NumberOfSegments = len(track.TrackSegments)
+	gpsabl.FillTrackValues(&track)
+
+	res.Name = getPilotNames(igc)
+	res.Description = getGliderDescription(igc)
+	res.Tracks = []gpsabl.Track{track}
+	res.NumberOfTracks = len(res.Tracks)
+	gpsabl.FillTrackFileValues(&res)
+
+	return res, nil
+}
+
+func convertRecords(igc Igc, usePressure bool, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackSegment, error) {
+	res := gpsabl.TrackSegment{}
+	pointCount := len(igc.Records)
+	basic := make([]gpsabl.TrackPoint, pointCount)
+	date := igc.Date
+	var gnssAltitude float32
+	for i, record := range igc.Records {
+		// The B records contain only the time of day, so the date changes when a flight lasts over midnight
+		if i > 0 && record.TimeOfDay < igc.Records[i-1].TimeOfDay {
+			date = date.AddDate(0, 0, 1)
+		}
+
+		pnt := gpsabl.TrackPoint{}
+		pnt.Latitude = float32(record.Latitude)
+		pnt.Longitude = float32(record.Longitude)
+		if usePressure {
+			pnt.Elevation = record.PressureAltitude
+		} else {
+			// Without a 3D fix the GNSS altitude is not valid, so the one of the fix before is used
+			if record.Valid {
+				gnssAltitude = record.GnssAltitude
+			}
+			pnt.Elevation = gnssAltitude
+		}
+		if igc.DateValid {
+			pnt.Time = date.Add(record.TimeOfDay)
+			pnt.TimeValid = true
+		}
+		basic[i] = pnt
+	}
+
+	ret := gpsabl.FillDistances(basic)
+
+	err := gpsabl.FillValuesTrackPointArray(ret, correction, minimalMovingSpeed, minimalStepHight)
+	if err != nil {
+		return gpsabl.TrackSegment{}, err
+	}
+
+	res.TrackPoints = ret
+	gpsabl.FillTrackSegmentValues(&res)
+
+	return res, nil
+}
+
+// hasPressureAltitude - Loggers without pressure sensor write 00000 as pressure altitude
+func hasPressureAltitude(records []BRecord) bool {
+	for _, record := range records {
+		if record.PressureAltitude != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasGnssAltitude - Loggers without GNSS altitude write 00000 as GNSS altitude
+func hasGnssAltitude(records []BRecord) bool {
+	for _, record := range records {
+		if record.Valid && record.GnssAltitude != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getPilotNames - Get the pilot, and the co-pilot if there is one
+func getPilotNames(igc Igc) string {
+	if igc.CoPilot != "" && strings.ToUpper(igc.CoPilot) != "NIL" {
+		return fmt.Sprintf("%s & %s", igc.Pilot, igc.CoPilot)
+	}
+
+	return igc.Pilot
+}
+
+// getGliderDescription - Get the glider type, followed by the registration and competition ID like "ASK 21 (D-1234, MM)"
+func getGliderDescription(igc Igc) string {
+	var ids []string
+	for _, id := range []string{igc.GliderID, igc.CompetitionID} {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) <= 0 {
+		return igc.GliderType
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%s (%s)", igc.GliderType, strings.Join(ids, ", ")))
+}
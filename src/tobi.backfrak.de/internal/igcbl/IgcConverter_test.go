@@ -0,0 +1,178 @@
+package igcbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func TestConvertIgcPressureAltitude(t *testing.T) {
+	igc, err := ReadIgc(testhelper.GetValidFile("igc", "01.igc"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	file, convErr := ConvertIgc(igc, "my/path.igc", PRESSURE, gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	if file.Name != "Max Mustermann" {
+		t.Errorf("The Name is \"%s\", but should be \"%s\"", file.Name, "Max Mustermann")
+	}
+
+	if file.Description != "ASK 21 (D-1234, MM)" {
+		t.Errorf("The Description is \"%s\", but should be \"%s\"", file.Description, "ASK 21 (D-1234, MM)")
+	}
+
+	// The file was created out of testdata/valid-gpx/12.gpx, with a pressure altitude 25 m above the elevation
+	if file.MinimumAltitude != 270 || file.MaximumAltitude != 329 {
+		t.Errorf("The MinimumAltitude and MaximumAltitude are %f and %f, but should be %f and %f", file.MinimumAltitude, file.MaximumAltitude, 270.0, 329.0)
+	}
+
+	if file.StartTime.Format(time.RFC3339) != "2014-08-22T16:48:52Z" || file.EndTime.Format(time.RFC3339) != "2014-08-22T17:19:42Z" {
+		t.Errorf("The StartTime and EndTime are %s and %s, but should be %s and %s", file.StartTime.Format(time.RFC3339), file.EndTime.Format(time.RFC3339), "2014-08-22T16:48:52Z", "2014-08-22T17:19:42Z")
+	}
+
+	if gpsabl.RoundFloat64To2Digits(file.Distance) != 4616.1 {
+		t.Errorf("The Distance is %f, but should be %f", file.Distance, 4616.1)
+	}
+}
+
+func TestConvertIgcGnssAltitude(t *testing.T) {
+	igc, err := ReadIgc(testhelper.GetValidFile("igc", "01.igc"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	file, convErr := ConvertIgc(igc, "my/path.igc", GNSS, gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	if file.MinimumAltitude != 245 || file.MaximumAltitude != 304 {
+		t.Errorf("The MinimumAltitude and MaximumAltitude are %f and %f, but should be %f and %f", file.MinimumAltitude, file.MaximumAltitude, 245.0, 304.0)
+	}
+}
+
+func TestConvertIgcWithoutPressureSensor(t *testing.T) {
+	igc, err := ReadIgc(testhelper.GetValidFile("igc", "02.igc"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	// The logger writes no pressure altitude, so the GNSS altitude is used
+	file, convErr := ConvertIgc(igc, "my/path.igc", PRESSURE, gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	if file.MinimumAltitude != 337 || file.MaximumAltitude != 368 {
+		t.Errorf("The MinimumAltitude and MaximumAltitude are %f and %f, but should be %f and %f", file.MinimumAltitude, file.MaximumAltitude, 337.0, 368.0)
+	}
+
+	// The record 10 has no 3D fix, so the altitude of the record before is used
+	points := file.Tracks[0].TrackSegments[0].TrackPoints
+	if points[10].Elevation != points[9].Elevation {
+		t.Errorf("The Elevation of point 10 is %f, but should be %f", points[10].Elevation, points[9].Elevation)
+	}
+
+	if file.Description != "Advance Alpha 7" {
+		t.Errorf("The Description is \"%s\", but should be \"%s\"", file.Description, "Advance Alpha 7")
+	}
+}
+
+func TestConvertIgcOverMidnight(t *testing.T) {
+	igc, err := ReadIgc(testhelper.GetValidFile("igc", "02.igc"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	file, convErr := ConvertIgc(igc, "my/path.igc", PRESSURE, gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	if file.StartTime.Format(time.RFC3339) != "2024-12-31T23:55:00Z" || file.EndTime.Format(time.RFC3339) != "2025-01-01T00:15:05Z" {
+		t.Errorf("The StartTime and EndTime are %s and %s, but should be %s and %s", file.StartTime.Format(time.RFC3339), file.EndTime.Format(time.RFC3339), "2024-12-31T23:55:00Z", "2025-01-01T00:15:05Z")
+	}
+
+	if file.TimeDataValid != true {
+		t.Errorf("The TimeDataValid is false, but should be true")
+	}
+}
+
+func TestConvertIgcWithoutDate(t *testing.T) {
+	igc, err := ReadIgc(testhelper.GetValidFile("igc", "01.igc"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+	igc.DateValid = false
+
+	file, convErr := ConvertIgc(igc, "my/path.igc", PRESSURE, gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	if file.TimeDataValid != false {
+		t.Errorf("The TimeDataValid is true, but the file has no date")
+	}
+}
+
+func TestConvertIgcWithoutBRecord(t *testing.T) {
+	igc, err := ReadIgc(testhelper.GetInvalidFile("igc", "01.igc"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	_, convErr := ConvertIgc(igc, "my/path.igc", PRESSURE, gpsabl.NO, 0.3, 10.0)
+	switch convErr.(type) {
+	case *EmptyIgcFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *EmptyIgcFileError, got \"%v\"", convErr)
+	}
+}
+
+func TestGetPilotNames(t *testing.T) {
+	igc := Igc{Pilot: "Max Mustermann", CoPilot: "Erika Musterfrau"}
+	if getPilotNames(igc) != "Max Mustermann & Erika Musterfrau" {
+		t.Errorf("The pilot names are \"%s\", but should be \"%s\"", getPilotNames(igc), "Max Mustermann & Erika Musterfrau")
+	}
+
+	igc.CoPilot = "nil"
+	if getPilotNames(igc) != "Max Mustermann" {
+		t.Errorf("The pilot names are \"%s\", but should be \"%s\"", getPilotNames(igc), "Max Mustermann")
+	}
+}
+
+func TestGetGliderDescription(t *testing.T) {
+	igc := Igc{GliderID: "D-1234"}
+	if getGliderDescription(igc) != "(D-1234)" {
+		t.Errorf("The glider description is \"%s\", but should be \"%s\"", getGliderDescription(igc), "(D-1234)")
+	}
+}
+
+func TestAltitudeSources(t *testing.T) {
+	for _, source := range GetValidAltitudeSources() {
+		if CheckValidAltitudeSource(source) != true {
+			t.Errorf("The AltitudeSource \"%s\" is not valid", source)
+		}
+
+		if strings.Contains(GetValidAltitudeSourcesString(), string(source)) == false {
+			t.Errorf("The AltitudeSource \"%s\" is not in \"%s\"", source, GetValidAltitudeSourcesString())
+		}
+	}
+
+	if CheckValidAltitudeSource("radar") != false {
+		t.Errorf("The AltitudeSource \"radar\" is valid")
+	}
+}
@@ -0,0 +1,158 @@
+package igcbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"strings"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+const IgcBuffer gpsabl.InputFileType = "IgcBuffer"
+
+// The file extension this Reader can read
+const FileExtension string = ".igc"
+
+// IgcFile - The struct to handle *.igc flight logs
+type IgcFile struct {
+	gpsabl.TrackFile
+	input gpsabl.InputFile
+	// AltitudeSource - The altitude of the B records used as elevation. PRESSURE is used when not set
+	AltitudeSource AltitudeSource
+}
+
+// NewIgcFile - Constructor for the IgcFile struct
+func NewIgcFile(filePath string) IgcFile {
+	igc := IgcFile{}
+	igc.FilePath = filePath
+	igc.input = *gpsabl.NewInputFileWithPath(filePath)
+	igc.AltitudeSource = PRESSURE
+
+	return igc
+}
+
+// NewReader - Get a new reader for IGC flight logs that will read the data in the given gpsabl.InputFile.
+// The new reader uses the AltitudeSource of this reader
+// Implement the gpsabl.TrackReader interface for *.igc files
+func (igc *IgcFile) NewReader(data gpsabl.InputFile) gpsabl.TrackReader {
+	newIgc := IgcFile{}
+	newIgc.input = data
+	newIgc.AltitudeSource = igc.AltitudeSource
+	if data.Type == gpsabl.FilePath {
+		newIgc.FilePath = data.Name
+	}
+
+	return &newIgc
+}
+
+// ReadTracks - Read the *.igc from the input from FilePath or InputFile.Buffer, and return a gpsabl.TrackFile struct that contains all information
+// Implement the gpsabl.TrackReader interface for *.igc files
+func (igc *IgcFile) ReadTracks(correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	var err error
+	var ret gpsabl.TrackFile
+	if igc.input.Type == gpsabl.FilePath {
+		ret, err = ReadIgcFile(igc.FilePath, igc.getAltitudeSource(), correction, minimalMovingSpeed, minimalStepHight)
+	} else if igc.input.Type == IgcBuffer {
+		var buffer []byte
+		buffer, err = igc.input.ReadContent()
+		if err == nil {
+			ret, err = ReadBuffer(buffer, igc.input.Name, igc.getAltitudeSource(), correction, minimalMovingSpeed, minimalStepHight)
+		}
+	} else {
+		err = gpsabl.NewUnKnownInputFileTypeError(igc.input.Name)
+	}
+
+	if err == nil {
+		igc.TrackFile = ret
+	}
+
+	return ret, err
+}
+
+// CheckInputFile - Check if a gpsabl.InputFile can be handled by the IgcFile reader
+// Implement the gpsabl.TrackReader interface for *.igc files
+func (igc *IgcFile) CheckInputFile(input gpsabl.InputFile) bool {
+	if input.Type == IgcBuffer {
+		return true
+	} else if input.Type == gpsabl.FilePath && igc.CheckFile(input.Name) {
+		return true
+	}
+
+	return false
+}
+
+// ReadBuffer - Read the IGC data from a buffer, and return a gpsabl.TrackFile struct that contains all information
+func ReadBuffer(buffer []byte, name string, altitudeSource AltitudeSource, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	content, readErr := readIGCBuffer(buffer, name)
+	if readErr != nil {
+		return gpsabl.TrackFile{}, readErr
+	}
+	ret, convertError := ConvertIgc(content, name, altitudeSource, correction, minimalMovingSpeed, minimalStepHight)
+	if convertError != nil {
+		return gpsabl.TrackFile{}, convertError
+	}
+
+	return ret, nil
+}
+
+// CheckFile - Check if a file can be read by the IgcFile "class"
+// Implement the gpsabl.TrackReader interface for *.igc files
+func (igc *IgcFile) CheckFile(path string) bool {
+	if strings.HasSuffix(strings.ToLower(path), FileExtension) { // If the file is a *.igc, we can read it
+		return true
+	}
+
+	return false
+}
+
+// CheckBuffer - Check if a buffer can be read by he IgcFile "class". This is the case if it starts with an A record and contains the HFDTE header
+// Implement the gpsabl.TrackReader interface for *.igc files
+func (igc *IgcFile) CheckBuffer(buffer []byte) bool {
+	return isIGCBuffer(buffer)
+}
+
+// NewInputFileForBuffer - Get a new InputFile for a buffer containing a IGC files content
+// Implement the gpsabl.TrackReader interface for *.igc files
+func (igc *IgcFile) NewInputFileForBuffer(buffer []byte, name string) *gpsabl.InputFile {
+	file := gpsabl.InputFile{}
+	file.Name = name
+	file.Type = IgcBuffer
+	file.Buffer = buffer
+
+	return &file
+}
+
+// GetValidFileExtensions - Get a list of file extensions this reader can read
+// Implement the gpsabl.TrackReader interface for *.igc files
+func (igc *IgcFile) GetValidFileExtensions() []string {
+
+	extensions := []string{FileExtension}
+
+	return extensions
+}
+
+// ReadIgcFile - Reads a *.igc file
+func ReadIgcFile(filePath string, altitudeSource AltitudeSource, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	igc, fileError := ReadIgc(filePath)
+
+	if fileError != nil {
+		return gpsabl.TrackFile{}, fileError
+	}
+
+	ret, convertError := ConvertIgc(igc, filePath, altitudeSource, correction, minimalMovingSpeed, minimalStepHight)
+	if convertError != nil {
+		return gpsabl.TrackFile{}, convertError
+	}
+
+	return ret, nil
+}
+
+func (igc *IgcFile) getAltitudeSource() AltitudeSource {
+	if igc.AltitudeSource == "" {
+		return PRESSURE
+	}
+
+	return igc.AltitudeSource
+}
@@ -0,0 +1,223 @@
+package igcbl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+func TestTrackReaderAltitudeSource(t *testing.T) {
+	igc := NewIgcFile(testhelper.GetValidFile("igc", "01.igc"))
+	igc.AltitudeSource = GNSS
+
+	file, err := igc.ReadTracks("none", 0.3, 10.0)
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if file.MinimumAltitude != 245 {
+		t.Errorf("The MinimumAltitude is %f, but should be %f", file.MinimumAltitude, 245.0)
+	}
+
+	if igc.Name != "Max Mustermann" {
+		t.Errorf("The IgcFile.Name is \"%s\", but should be \"%s\"", igc.Name, "Max Mustermann")
+	}
+}
+
+func TestNewReaderKeepsAltitudeSource(t *testing.T) {
+	igc := IgcFile{}
+	igc.AltitudeSource = GNSS
+	sut := igc.NewReader(*gpsabl.NewInputFileWithPath(testhelper.GetValidFile("igc", "01.igc")))
+
+	file, err := sut.ReadTracks("none", 0.3, 10.0)
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if file.MinimumAltitude != 245 {
+		t.Errorf("The MinimumAltitude is %f, but should be %f", file.MinimumAltitude, 245.0)
+	}
+
+	// A reader without AltitudeSource uses the pressure altitude
+	empty := IgcFile{}
+	if empty.getAltitudeSource() != PRESSURE {
+		t.Errorf("The altitude source is %s, but should be %s", empty.getAltitudeSource(), PRESSURE)
+	}
+}
+
+func TestTrackReaderInValidCorrectionParameter(t *testing.T) {
+	igc := NewIgcFile(testhelper.GetValidFile("igc", "02.igc"))
+
+	_, err := igc.ReadTracks("asdfg", 0.3, 10.0)
+	if err != nil {
+		switch ty := err.(type) {
+		case *gpsabl.CorrectionParameterNotKnownError:
+			fmt.Println("OK")
+		default:
+			t.Errorf("The Error ReadTracks gave is of the wrong type. The type is %v", ty)
+		}
+	} else {
+		t.Errorf("ReadTracks did not return a error, but was expected")
+	}
+}
+
+func TestReadAllValidIgcDueInterface(t *testing.T) {
+	files, _ := ioutil.ReadDir(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-igc"))
+
+	for _, file := range files {
+		igcFile := NewIgcFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-igc", file.Name()))
+		if igcFile.CheckFile(file.Name()) && file.IsDir() == false {
+			iIgc := gpsabl.TrackReader(&igcFile)
+
+			track, err := iIgc.ReadTracks("none", 0.3, 10.0)
+			if err != nil {
+				t.Errorf("Got the error \"%s\" while reading file %s.", err.Error(), filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-igc", file.Name()))
+			}
+			if track.Distance <= 0.0 {
+				t.Errorf("The track.Distance is %f but should not be less then %f", track.Distance, 0.0)
+			}
+		}
+	}
+}
+
+func TestReadAllInValidIgcDueInterface(t *testing.T) {
+	files, _ := ioutil.ReadDir(filepath.Join(testhelper.GetProjectRoot(), "testdata", "invalid-igc"))
+
+	for _, file := range files {
+		igcFile := NewIgcFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "invalid-igc", file.Name()))
+		if igcFile.CheckFile(file.Name()) && file.IsDir() == false {
+			iIgc := gpsabl.TrackReader(&igcFile)
+
+			_, err := iIgc.ReadTracks("none", 0.3, 10.0)
+			if err == nil {
+				t.Errorf("Got no error while reading file %s.", filepath.Join(testhelper.GetProjectRoot(), "testdata", "invalid-igc", file.Name()))
+			}
+		}
+	}
+}
+
+func TestNewReaderWithValidFilePath(t *testing.T) {
+	igc := IgcFile{}
+	file := testhelper.GetValidFile("igc", "01.igc")
+	checkRes := igc.CheckFile(file)
+	input := *gpsabl.NewInputFileWithPath(file)
+
+	sut := igc.NewReader(input)
+
+	if checkRes != true {
+		t.Errorf("IgcFile can not read %s", file)
+	}
+
+	if igc.CheckInputFile(input) != true {
+		t.Errorf("IgcFile can not read the input %s", file)
+	}
+
+	trk, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+	if err != nil {
+		t.Errorf("Got error \"%s\" but expect none", err)
+	}
+
+	if trk.FilePath != file {
+		t.Errorf("The trk.FilePath %s is not the given path %s", trk.FilePath, file)
+	}
+}
+
+func TestNewReaderWithValidBuffer(t *testing.T) {
+	for _, name := range []string{"01.igc", "02.igc"} {
+		igc := IgcFile{}
+		buffer, createErr := testhelper.GetValidFileBuffer("igc", name)
+		if createErr != nil {
+			t.Fatalf("Got error \"%s\" while creating the input buffer", createErr)
+		}
+		checkRes := igc.CheckBuffer(buffer)
+		input := *igc.NewInputFileForBuffer(buffer, "Buffer 1")
+
+		sut := igc.NewReader(input)
+
+		if checkRes != true {
+			t.Errorf("IgcFile can not read %s from buffer", name)
+		}
+
+		if igc.CheckInputFile(input) != true {
+			t.Errorf("IgcFile can not read the input buffer")
+		}
+
+		trk, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+		if err != nil {
+			t.Errorf("Got error \"%s\" but expect none", err)
+		}
+
+		if trk.FilePath != "Buffer 1" {
+			t.Errorf("The trk.FilePath %s is not the given path %s", trk.FilePath, "Buffer 1")
+		}
+	}
+}
+
+func TestNewReaderWithUnknownInputType(t *testing.T) {
+	igc := IgcFile{}
+	input := gpsabl.InputFile{Type: "Unknown", Name: "Buffer 1"}
+	sut := igc.NewReader(input)
+
+	if igc.CheckInputFile(input) != false {
+		t.Errorf("IgcFile can read an input of unknown type")
+	}
+
+	_, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+	switch err.(type) {
+	case *gpsabl.UnKnownInputFileTypeError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *gpsabl.UnKnownInputFileTypeError, got \"%v\"", err)
+	}
+}
+
+func TestCheckBuffer(t *testing.T) {
+	igc := IgcFile{}
+	gpx, _ := testhelper.GetValidGpxBuffer("01.gpx")
+	if igc.CheckBuffer(gpx) != false {
+		t.Errorf("IgcFile can read a gpx buffer")
+	}
+
+	invalid, _ := testhelper.GetInvalidFileBuffer("igc", "02.igc")
+	if igc.CheckBuffer(invalid) != false {
+		t.Errorf("IgcFile can read a buffer without A record")
+	}
+
+	if igc.CheckBuffer([]byte("AXXXABC\nB1648524516395N01342911EA0029600271\n")) != false {
+		t.Errorf("IgcFile can read a buffer without HFDTE header")
+	}
+}
+
+func TestCheckFile(t *testing.T) {
+	igc := IgcFile{}
+
+	if igc.CheckFile("my/path/file.IGC") != true {
+		t.Errorf("IgcFile can not read *.IGC files")
+	}
+
+	if igc.CheckFile("my/path/file.igc") != true {
+		t.Errorf("IgcFile can not read *.igc files")
+	}
+
+	if igc.CheckFile("my/path/file.gpx") != false {
+		t.Errorf("IgcFile can read *.gpx files")
+	}
+}
+
+func TestGetValidFileExtensions(t *testing.T) {
+	igc := IgcFile{}
+	extensions := igc.GetValidFileExtensions()
+
+	if len(extensions) != 1 || extensions[0] != FileExtension {
+		t.Errorf("The valid file extensions are %v, but should be [%s]", extensions, FileExtension)
+	}
+}
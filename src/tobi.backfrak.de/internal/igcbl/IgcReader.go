@@ -0,0 +1,203 @@
+package igcbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"bytes"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The minimal length of a B record: B HHMMSS DDMMmmmN DDDMMmmmE V PPPPP GGGGG
+const bRecordLength = 35
+
+// Igc - Represents the content of an IGC flight log
+type Igc struct {
+	// Date - The date of the flight, given in the HFDTE header
+	Date      time.Time
+	DateValid bool
+	// Pilot - The pilot in charge, given in the HFPLT header
+	Pilot string
+	// CoPilot - The second crew member, given in the HFCM2 header
+	CoPilot string
+	// GliderType - The glider type, given in the HFGTY header
+	GliderType string
+	// GliderID - The glider registration, given in the HFGID header
+	GliderID string
+	// CompetitionID - The competition ID, given in the HFCID header
+	CompetitionID string
+	Records       []BRecord
+}
+
+// BRecord - Represents one fix of an IGC flight log
+type BRecord struct {
+	// TimeOfDay - The UTC time since midnight
+	TimeOfDay time.Duration
+	Latitude  float64
+	Longitude float64
+	// Valid - True for a 3D fix (A), false for a 2D fix or no GNSS data (V)
+	Valid            bool
+	PressureAltitude float32
+	GnssAltitude     float32
+}
+
+// ReadIgc - Read an IGC flight log file
+func ReadIgc(fileName string) (Igc, error) {
+	fileBuffer, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return Igc{}, err
+	}
+	return readIGCBuffer(fileBuffer, fileName)
+}
+
+func readIGCBuffer(fileBuffer []byte, fileName string) (Igc, error) {
+	if !isIGCBuffer(fileBuffer) {
+		return Igc{}, newIgcFileError(fileName)
+	}
+
+	igc := Igc{}
+	for i, line := range getLines(fileBuffer) {
+		if strings.HasPrefix(line, "H") {
+			addHeaderValue(&igc, line)
+		} else if strings.HasPrefix(line, "B") {
+			record, valid := parseBRecord(line)
+			if !valid {
+				return Igc{}, newIgcRecordError(fileName, i+1)
+			}
+			igc.Records = append(igc.Records, record)
+		}
+	}
+
+	return igc, nil
+}
+
+// isIGCBuffer - Tell if a buffer starts with the A record and contains the mandatory HFDTE header of an IGC flight log
+func isIGCBuffer(buffer []byte) bool {
+	lines := getLines(buffer)
+	if len(lines) <= 0 || !strings.HasPrefix(lines[0], "A") || len(lines[0]) < 4 {
+		return false
+	}
+
+	for _, line := range lines {
+		if len(line) >= 5 && line[0] == 'H' && line[2:5] == "DTE" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getLines - Get the non empty lines of the buffer, without line endings
+func getLines(buffer []byte) []string {
+	var ret []string
+	for _, line := range bytes.Split(buffer, []byte("\n")) {
+		str := strings.TrimSpace(string(line))
+		if str != "" {
+			ret = append(ret, str)
+		}
+	}
+
+	return ret
+}
+
+// addHeaderValue - H records look like "HFPLTPILOTINCHARGE:Max Mustermann" or in the old format like "HFDTE220814"
+func addHeaderValue(igc *Igc, line string) {
+	if len(line) < 5 {
+		return
+	}
+
+	value := line[5:]
+	colonIndex := strings.Index(line, ":")
+	if colonIndex >= 0 {
+		value = line[colonIndex+1:]
+	}
+	value = strings.TrimSpace(value)
+
+	switch line[2:5] {
+	case "DTE":
+		// The new format adds the flight number like "311224,01"
+		if len(value) >= 6 {
+			date, err := time.Parse("020106", value[:6])
+			if err == nil {
+				igc.Date = date
+				igc.DateValid = true
+			}
+		}
+	case "PLT":
+		igc.Pilot = value
+	case "CM2":
+		igc.CoPilot = value
+	case "GTY":
+		igc.GliderType = value
+	case "GID":
+		igc.GliderID = value
+	case "CID":
+		igc.CompetitionID = value
+	}
+}
+
+// parseBRecord - Parse a B record like "B1648524516395N01342911EA0029600271". Extensions defined in the I record are ignored
+func parseBRecord(line string) (BRecord, bool) {
+	record := BRecord{}
+	if len(line) < bRecordLength {
+		return record, false
+	}
+
+	hours, hErr := strconv.Atoi(line[1:3])
+	minutes, mErr := strconv.Atoi(line[3:5])
+	seconds, sErr := strconv.Atoi(line[5:7])
+	if hErr != nil || mErr != nil || sErr != nil {
+		return record, false
+	}
+	record.TimeOfDay = time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+
+	lat, latValid := convertDegreesMinutes(line[7:14], 2, line[14], 'N', 'S')
+	lon, lonValid := convertDegreesMinutes(line[15:23], 3, line[23], 'E', 'W')
+	if !latValid || !lonValid {
+		return record, false
+	}
+	record.Latitude = lat
+	record.Longitude = lon
+
+	switch line[24] {
+	case 'A':
+		record.Valid = true
+	case 'V':
+		record.Valid = false
+	default:
+		return record, false
+	}
+
+	pressure, pErr := strconv.Atoi(line[25:30])
+	gnss, gErr := strconv.Atoi(line[30:35])
+	if pErr != nil || gErr != nil {
+		return record, false
+	}
+	record.PressureAltitude = float32(pressure)
+	record.GnssAltitude = float32(gnss)
+
+	return record, true
+}
+
+// convertDegreesMinutes - Convert a IGC "DDMMmmm" or "DDDMMmmm" value to degrees. The minutes have three implicit decimal places
+func convertDegreesMinutes(value string, degreeDigits int, hemisphere byte, positive byte, negative byte) (float64, bool) {
+	degrees, dErr := strconv.Atoi(value[:degreeDigits])
+	minutes, mErr := strconv.Atoi(value[degreeDigits:])
+	if dErr != nil || mErr != nil {
+		return 0, false
+	}
+
+	ret := float64(degrees) + float64(minutes)/60000.0
+	switch hemisphere {
+	case positive:
+		return ret, true
+	case negative:
+		return -ret, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,148 @@
+package igcbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func TestReadValidIgc01(t *testing.T) {
+	igc, err := ReadIgc(testhelper.GetValidFile("igc", "01.igc"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if igc.DateValid != true || igc.Date.Format("2006-01-02") != "2014-08-22" {
+		t.Errorf("The Date is %s, but should be %s", igc.Date.Format("2006-01-02"), "2014-08-22")
+	}
+
+	if igc.Pilot != "Max Mustermann" || igc.CoPilot != "NIL" {
+		t.Errorf("The Pilot and CoPilot are \"%s\" and \"%s\", but should be \"%s\" and \"%s\"", igc.Pilot, igc.CoPilot, "Max Mustermann", "NIL")
+	}
+
+	if igc.GliderType != "ASK 21" || igc.GliderID != "D-1234" || igc.CompetitionID != "MM" {
+		t.Errorf("The glider is \"%s\", \"%s\", \"%s\", but should be \"%s\", \"%s\", \"%s\"", igc.GliderType, igc.GliderID, igc.CompetitionID, "ASK 21", "D-1234", "MM")
+	}
+
+	// The B records of this file contain extensions defined in the I record
+	if len(igc.Records) != 413 {
+		t.Errorf("The number of Records is %d, but should be %d", len(igc.Records), 413)
+	}
+}
+
+func TestReadValidIgcNewDateFormat(t *testing.T) {
+	igc, err := ReadIgc(testhelper.GetValidFile("igc", "02.igc"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if igc.DateValid != true || igc.Date.Format("2006-01-02") != "2024-12-31" {
+		t.Errorf("The Date is %s, but should be %s", igc.Date.Format("2006-01-02"), "2024-12-31")
+	}
+
+	if igc.GliderID != "" {
+		t.Errorf("The GliderID is \"%s\", but should be empty", igc.GliderID)
+	}
+
+	if igc.Records[10].Valid != false {
+		t.Errorf("The record 10 is valid, but has a 2D fix")
+	}
+}
+
+func TestReadIgcWithoutARecord(t *testing.T) {
+	_, err := ReadIgc(testhelper.GetInvalidFile("igc", "02.igc"))
+	switch v := err.(type) {
+	case nil:
+		t.Errorf("No error, when reading a file without A record")
+	case *IgcFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *IgcFileError, got a %s", reflect.TypeOf(v))
+	}
+}
+
+func TestReadIgcWithInvalidBRecord(t *testing.T) {
+	_, err := ReadIgc(testhelper.GetInvalidFile("igc", "03.igc"))
+	switch v := err.(type) {
+	case nil:
+		t.Errorf("No error, when reading a file with invalid B record")
+	case *IgcRecordError:
+		if v.Line != 4 {
+			t.Errorf("The Line is %d, but should be %d", v.Line, 4)
+		}
+	default:
+		t.Errorf("Expected a *IgcRecordError, got a %s", reflect.TypeOf(v))
+	}
+}
+
+func TestReadNotExistIgc(t *testing.T) {
+	_, err := ReadIgc(testhelper.GetInvalidFile("igc", "not-exist.igc"))
+	switch v := err.(type) {
+	case nil:
+		t.Errorf("No error, when reading an not existing IGC file")
+	case *os.PathError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *os.PathError, got a %s", reflect.TypeOf(v))
+	}
+}
+
+func TestParseBRecord(t *testing.T) {
+	record, valid := parseBRecord("B1648524516395S01342911WA-001200271")
+	if valid != true {
+		t.Fatalf("A valid B record is not valid")
+	}
+
+	if record.TimeOfDay != 16*time.Hour+48*time.Minute+52*time.Second {
+		t.Errorf("The TimeOfDay is %s, but should be %s", record.TimeOfDay, 16*time.Hour+48*time.Minute+52*time.Second)
+	}
+
+	if float32(record.Latitude) != -45.273250 || float32(record.Longitude) != -13.715183 {
+		t.Errorf("The position is %f, %f, but should be %f, %f", record.Latitude, record.Longitude, -45.273250, -13.715183)
+	}
+
+	if record.PressureAltitude != -12 || record.GnssAltitude != 271 {
+		t.Errorf("The altitudes are %f and %f, but should be %f and %f", record.PressureAltitude, record.GnssAltitude, -12.0, 271.0)
+	}
+
+	_, valid = parseBRecord("B1648524516395N01342911EA00296")
+	if valid != false {
+		t.Errorf("A B record that is to short is valid")
+	}
+
+	_, valid = parseBRecord("B1648524516395X01342911EA0029600271")
+	if valid != false {
+		t.Errorf("A B record with unknown hemisphere is valid")
+	}
+
+	_, valid = parseBRecord("B1648524516395N01342911EX0029600271")
+	if valid != false {
+		t.Errorf("A B record with unknown fix validity is valid")
+	}
+}
+
+func TestAddHeaderValue(t *testing.T) {
+	igc := Igc{}
+	addHeaderValue(&igc, "HFDTE010203")
+	if igc.DateValid != true || igc.Date.Format("2006-01-02") != "2003-02-01" {
+		t.Errorf("The Date is %s, but should be %s", igc.Date.Format("2006-01-02"), "2003-02-01")
+	}
+
+	addHeaderValue(&igc, "HFDTEDATE:xx0203,01")
+	if igc.Date.Format("2006-01-02") != "2003-02-01" {
+		t.Errorf("The Date is %s, but an invalid date should not change it", igc.Date.Format("2006-01-02"))
+	}
+
+	addHeaderValue(&igc, "HPPLTPILOT: Max Mustermann ")
+	if igc.Pilot != "Max Mustermann" {
+		t.Errorf("The Pilot is \"%s\", but should be \"%s\"", igc.Pilot, "Max Mustermann")
+	}
+}
@@ -22,12 +22,20 @@ const FileExtension = ".json"
 type JSONOutput struct {
 	Statistics []gpsabl.OutputLine
 	Summary    []gpsabl.OutputLine
+	Waypoints  []gpsabl.Waypoint `json:",omitempty"`
 }
 
 // JSONOutputFormater - type that formats TrackSummary into json style
 type JSONOutputFormater struct {
+	// Tell if the waypoints of the TrackFiles should be added to the output
+	AddWaypoints bool
+
+	// Sorting - The order the lines of the Statistics are written in. The lines are written in the order they are added by default
+	Sorting gpsabl.OutputSorting
+
 	writtenEntiresCount int
 	lineBuffer          []gpsabl.OutputLine
+	waypointBuffer      []gpsabl.Waypoint
 	mux                 sync.Mutex
 }
 
@@ -36,6 +44,7 @@ func NewJSONOutputFormater() *JSONOutputFormater {
 	ret := JSONOutputFormater{}
 	ret.writtenEntiresCount = -1
 	ret.lineBuffer = []gpsabl.OutputLine{}
+	ret.waypointBuffer = []gpsabl.Waypoint{}
 
 	return &ret
 }
@@ -52,6 +61,16 @@ func (formater *JSONOutputFormater) GetTextOutputFormater() gpsabl.TextOutputFor
 	return nil
 }
 
+// SetAddWaypoints - Set the value of formater.AddWaypoints
+func (formater *JSONOutputFormater) SetAddWaypoints(value bool) {
+	formater.AddWaypoints = value
+}
+
+// SetSorting - Set the value of formater.Sorting
+func (formater *JSONOutputFormater) SetSorting(sorting gpsabl.OutputSorting) {
+	formater.Sorting = sorting
+}
+
 // AddOutPut - Add the output values of a TrackFile to the out file buffer. Implements the gpsabl.OutputFormater interface
 func (formater *JSONOutputFormater) AddOutPut(trackFile gpsabl.TrackFile, depth gpsabl.DepthArg, filterDuplicate bool) error {
 	var lines []gpsabl.OutputLine
@@ -69,6 +88,12 @@ func (formater *JSONOutputFormater) AddOutPut(trackFile gpsabl.TrackFile, depth
 		lines = gpsabl.StripOutlines(linesFromFile)
 	}
 
+	if formater.AddWaypoints && len(trackFile.Waypoints) > 0 {
+		formater.mux.Lock()
+		formater.waypointBuffer = append(formater.waypointBuffer, trackFile.Waypoints...)
+		formater.mux.Unlock()
+	}
+
 	if len(lines) > 0 {
 		formater.mux.Lock()
 		defer formater.mux.Unlock()
@@ -107,6 +132,7 @@ func (formater *JSONOutputFormater) GetOutput(summary gpsabl.SummaryArg) (JSONOu
 
 	formater.mux.Lock()
 	defer formater.mux.Unlock()
+	gpsabl.SortOutputLines(formater.lineBuffer, formater.Sorting)
 	var ret JSONOutput
 	switch summary {
 	case gpsabl.NONE:
@@ -120,6 +146,10 @@ func (formater *JSONOutputFormater) GetOutput(summary gpsabl.SummaryArg) (JSONOu
 		return JSONOutput{}, gpsabl.NewSummaryParamaterNotKnown(summary)
 	}
 
+	if formater.AddWaypoints && summary != gpsabl.ONLY && len(formater.waypointBuffer) > 0 {
+		ret.Waypoints = gpsabl.GetSortedWaypoints(formater.waypointBuffer)
+	}
+
 	return ret, nil
 }
 
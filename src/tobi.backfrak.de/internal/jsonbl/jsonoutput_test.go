@@ -286,6 +286,72 @@ func TestGetOutputNoSummary(t *testing.T) {
 	}
 }
 
+func TestGetOutputSorted(t *testing.T) {
+	sut := NewJSONOutputFormater()
+	trk1 := getSimpleTrackFileWithTime()
+	trk1.Name = "First"
+	err1 := sut.AddOutPut(trk1, gpsabl.FILE, false)
+	if err1 != nil {
+		t.Errorf("Got an error but expected none")
+	}
+	trk2 := getTrackFileWithDifferentTime()
+	trk2.Name = "Second"
+	err2 := sut.AddOutPut(trk2, gpsabl.FILE, false)
+	if err2 != nil {
+		t.Errorf("Got an error but expected none")
+	}
+
+	res, err3 := sut.GetOutput(gpsabl.NONE)
+	if err3 != nil {
+		t.Errorf("Got an error but expected none")
+	}
+	if res.Statistics[0].Name != "First" || res.Statistics[1].Name != "Second" {
+		t.Errorf("The lines are not in the order they were added")
+	}
+
+	sut.SetSorting(gpsabl.OutputSorting{Column: gpsabl.STARTTIME, Order: gpsabl.DESCENDING})
+	res, err3 = sut.GetOutput(gpsabl.NONE)
+	if err3 != nil {
+		t.Errorf("Got an error but expected none")
+	}
+	if res.Statistics[0].Name != "Second" || res.Statistics[1].Name != "First" {
+		t.Errorf("The lines are not sorted descending by the StartTime")
+	}
+}
+
+func TestGetOutputWithWaypoints(t *testing.T) {
+	sut := NewJSONOutputFormater()
+	trk := getSimpleTrackFileWithTime()
+	trk.Waypoints = []gpsabl.Waypoint{{Name: "B", TrackName: "T", DistanceAlongTrack: 20}, {Name: "A", TrackName: "T", DistanceAlongTrack: 10}}
+
+	err := sut.AddOutPut(trk, gpsabl.TRACK, false)
+	if err != nil {
+		t.Errorf("Got an error but expected none")
+	}
+
+	res, _ := sut.GetOutput(gpsabl.NONE)
+	if len(res.Waypoints) != 0 {
+		t.Errorf("Got %d waypoints, but AddWaypoints is not set", len(res.Waypoints))
+	}
+
+	sut = NewJSONOutputFormater()
+	sut.SetAddWaypoints(true)
+	err = sut.AddOutPut(trk, gpsabl.TRACK, false)
+	if err != nil {
+		t.Errorf("Got an error but expected none")
+	}
+
+	res, _ = sut.GetOutput(gpsabl.ADDITIONAL)
+	if len(res.Waypoints) != 2 || res.Waypoints[0].Name != "A" {
+		t.Errorf("GetOutput did not return the expected waypoints: %v", res.Waypoints)
+	}
+
+	res, _ = sut.GetOutput(gpsabl.ONLY)
+	if len(res.Waypoints) != 0 {
+		t.Errorf("Got %d waypoints, but the summary only output should contain none", len(res.Waypoints))
+	}
+}
+
 func TestGetOutputOnlySummary(t *testing.T) {
 	sut := NewJSONOutputFormater()
 	trk1 := getSimpleTrackFileWithTime()
@@ -698,3 +764,26 @@ func getTrackFileWithTimeGaps() gpsabl.TrackFile {
 
 	return file
 }
+
+func TestGetOutputWithSensorData(t *testing.T) {
+	sut := NewJSONOutputFormater()
+	trk := getSimpleTrackFileWithTime()
+	trk.Cadence = gpsabl.SensorValues{Minimum: 80, Average: 85, Maximum: 90, Samples: 3}
+	err := sut.AddOutPut(trk, gpsabl.FILE, false)
+	if err != nil {
+		t.Errorf("Got an error but expected none")
+	}
+
+	res, _ := sut.GetOutput(gpsabl.ADDITIONAL)
+	if res.Statistics[0].Data.GetSensorSummary().Cadence != trk.Cadence {
+		t.Errorf("The Cadence is %v, but should be %v", res.Statistics[0].Data.GetSensorSummary().Cadence, trk.Cadence)
+	}
+
+	if res.Summary[0].Data.GetSensorSummary().Cadence.Valid() {
+		t.Errorf("The Cadence in the sum line is valid, but should not")
+	}
+
+	if res.Summary[1].Data.GetSensorSummary().Cadence.Average != 85 {
+		t.Errorf("The average Cadence is %f, but should be %f", res.Summary[1].Data.GetSensorSummary().Cadence.Average, 85.0)
+	}
+}
@@ -0,0 +1,83 @@
+package kmlbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"strings"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+// KmlFileError - Error when trying to load something that is no kml or kmz file
+type KmlFileError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+}
+
+func (e *KmlFileError) Error() string { // Implement the Error Interface for the KmlFileError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newKmlFileError - Get a new KmlFileError struct
+func newKmlFileError(fileName string) *KmlFileError {
+	return &KmlFileError{fmt.Sprintf("The file \"%s\" is not a kml or kmz file", fileName), fileName}
+}
+
+// EmptyKmlFileError - Error when trying to load a kml file that does not contain any valid track
+type EmptyKmlFileError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+}
+
+func (e *EmptyKmlFileError) Error() string { // Implement the Error Interface for the EmptyKmlFileError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newEmptyKmlFileError - Get a new EmptyKmlFileError struct
+func newEmptyKmlFileError(fileName string) *EmptyKmlFileError {
+	return &EmptyKmlFileError{fmt.Sprintf("The file \"%s\" does not contain any valid tracks.", fileName), fileName}
+}
+
+// KmlCoordinateError - Error when a kml file contains a coordinate that can not be parsed
+type KmlCoordinateError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+	// Coordinate - The coordinate that could not be parsed
+	Coordinate string
+}
+
+func (e *KmlCoordinateError) Error() string { // Implement the Error Interface for the KmlCoordinateError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newKmlCoordinateError - Get a new KmlCoordinateError struct
+func newKmlCoordinateError(fileName string, coordinate string) *KmlCoordinateError {
+	return &KmlCoordinateError{fmt.Sprintf("The file \"%s\" contains the invalid coordinate \"%s\"", fileName, coordinate), fileName, coordinate}
+}
+
+// ColorColumnNotKnownError - Error when the given -kml-color-by column is not known or contains no numbers
+type ColorColumnNotKnownError struct {
+	err string
+	// GivenValue - The column that is not known
+	GivenValue string
+}
+
+func (e *ColorColumnNotKnownError) Error() string { // Implement the Error Interface for the ColorColumnNotKnownError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newColorColumnNotKnownError - Get a new ColorColumnNotKnownError struct
+func newColorColumnNotKnownError(givenValue string) *ColorColumnNotKnownError {
+	var columns []string
+	for _, column := range gpsabl.GetValidNumberColumns() {
+		columns = append(columns, string(column))
+	}
+
+	return &ColorColumnNotKnownError{fmt.Sprintf("The given -kml-color-by \"%s\" is not known. Valid values are: %s", givenValue, strings.Join(columns, " ")), givenValue}
+}
@@ -0,0 +1,63 @@
+package kmlbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"strings"
+	"testing"
+)
+
+func TestKmlFileErrorStruct(t *testing.T) {
+
+	path := "/some/sample/path"
+	err := newKmlFileError(path)
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of KmlFileError does not contain the expected Path")
+	}
+
+	if err.File != path {
+		t.Errorf("The KmlFileError.File does not match the expected value")
+	}
+}
+
+func TestEmptyKmlFileError(t *testing.T) {
+	path := "/some/sample/path"
+	err := newEmptyKmlFileError(path)
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of EmptyKmlFileError does not contain the expected Path")
+	}
+
+	if err.File != path {
+		t.Errorf("The EmptyKmlFileError.File does not match the expected value")
+	}
+}
+
+func TestKmlCoordinateError(t *testing.T) {
+	path := "/some/sample/path"
+	coordinate := "11.0172,abc,310.0"
+	err := newKmlCoordinateError(path, coordinate)
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of KmlCoordinateError does not contain the expected Path")
+	}
+
+	if strings.Contains(err.Error(), coordinate) == false {
+		t.Errorf("The error message of KmlCoordinateError does not contain the expected coordinate")
+	}
+
+	if err.File != path || err.Coordinate != coordinate {
+		t.Errorf("The KmlCoordinateError.File or KmlCoordinateError.Coordinate does not match the expected value")
+	}
+}
+
+func TestColorColumnNotKnownError(t *testing.T) {
+	err := newColorColumnNotKnownError("Gear")
+	if strings.Contains(err.Error(), "Gear") == false || strings.Contains(err.Error(), "AverageSpeed") == false {
+		t.Errorf("The error message of ColorColumnNotKnownError does not contain the given and the valid values")
+	}
+
+	if err.GivenValue != "Gear" {
+		t.Errorf("The ColorColumnNotKnownError.GivenValue does not match the expected value")
+	}
+}
@@ -0,0 +1,210 @@
+package kmlbl
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+// ConvertKml - Convert a kmlbl.Kml to a gpsabl.TrackFile. Each Placemark containing lines becomes a gpsabl.Track,
+// each LineString or gx:Track a gpsabl.TrackSegment
+func ConvertKml(kml Kml, filePath string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+
+	res := gpsabl.NewTrackFile(filePath)
+
+	for _, placemark := range getPlacemarks(kml.Container) {
+		track, err := convertPlacemark(placemark, filePath, correction, minimalMovingSpeed, minimalStepHight)
+		if err != nil {
+			return gpsabl.TrackFile{}, err
+		}
+
+		// Add only tracks that contain segments, Placemarks with a Point are no tracks
+		if len(track.TrackSegments) > 0 {
+			res.Tracks = append(res.Tracks, track)
+		}
+	}
+
+	if len(res.Tracks) <= 0 {
+		return gpsabl.TrackFile{}, newEmptyKmlFileError(filePath)
+	}
+
+	res.Name, res.Description = getFileNameAndDescription(kml)
+	res.NumberOfTracks = len(res.Tracks)
+	gpsabl.FillTrackFileValues(&res)
+
+	return res, nil
+}
+
+func convertPlacemark(placemark Placemark, filePath string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.Track, error) {
+	res := gpsabl.Track{}
+
+	segments, err := convertGeometry(placemark.Geometry, filePath, correction, minimalMovingSpeed, minimalStepHight)
+	if err != nil {
+		return gpsabl.Track{}, err
+	}
+
+	res.TrackSegments = segments
+	res.Name = placemark.Name
+	res.Description = placemark.Description
+	res.NumberOfSegments = len(res.TrackSegments)
+	gpsabl.FillTrackValues(&res)
+
+	return res, nil
+}
+
+func convertGeometry(geometry Geometry, filePath string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) ([]gpsabl.TrackSegment, error) {
+	var res []gpsabl.TrackSegment
+
+	for _, line := range geometry.LineStrings {
+		points, err := convertCoordinates(line.Coordinates, filePath)
+		if err != nil {
+			return nil, err
+		}
+		seg, segErr := convertSegment(points, correction, minimalMovingSpeed, minimalStepHight)
+		if segErr != nil {
+			return nil, segErr
+		}
+		if len(seg.TrackPoints) > 0 {
+			res = append(res, seg)
+		}
+	}
+
+	for _, track := range geometry.Tracks {
+		points, err := convertTrack(track, filePath)
+		if err != nil {
+			return nil, err
+		}
+		seg, segErr := convertSegment(points, correction, minimalMovingSpeed, minimalStepHight)
+		if segErr != nil {
+			return nil, segErr
+		}
+		if len(seg.TrackPoints) > 0 {
+			res = append(res, seg)
+		}
+	}
+
+	multis := []Geometry{}
+	multis = append(multis, geometry.MultiTracks...)
+	multis = append(multis, geometry.MultiGeometries...)
+	for _, multi := range multis {
+		segments, err := convertGeometry(multi, filePath, correction, minimalMovingSpeed, minimalStepHight)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, segments...)
+	}
+
+	return res, nil
+}
+
+func convertSegment(points []gpsabl.TrackPoint, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackSegment, error) {
+	res := gpsabl.TrackSegment{}
+	if len(points) <= 0 {
+		return res, nil
+	}
+
+	ret := gpsabl.FillDistances(points)
+
+	err := gpsabl.FillValuesTrackPointArray(ret, correction, minimalMovingSpeed, minimalStepHight)
+	if err != nil {
+		return gpsabl.TrackSegment{}, err
+	}
+
+	res.TrackPoints = ret
+	gpsabl.FillTrackSegmentValues(&res)
+
+	return res, nil
+}
+
+// convertCoordinates - Convert the content of a <coordinates> element, a whitespace separated list of "lon,lat[,alt]" tuples
+func convertCoordinates(coordinates string, filePath string) ([]gpsabl.TrackPoint, error) {
+	var ret []gpsabl.TrackPoint
+	for _, tuple := range strings.Fields(coordinates) {
+		pnt, err := convertCoordinate(strings.Split(tuple, ","), tuple, filePath)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, pnt)
+	}
+
+	return ret, nil
+}
+
+// convertTrack - Convert a gx:Track, where each <gx:coord> is a "lon lat alt" tuple and the <when> with the same index is the time of the point
+func convertTrack(track Track, filePath string) ([]gpsabl.TrackPoint, error) {
+	var ret []gpsabl.TrackPoint
+	for i, coord := range track.Coords {
+		pnt, err := convertCoordinate(strings.Fields(coord), coord, filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		if i < len(track.Whens) {
+			t, tErr := time.Parse(time.RFC3339, strings.TrimSpace(track.Whens[i]))
+
+			// In case the time stamp of the track point is not in the specified format, it is not valid
+			if tErr == nil {
+				pnt.Time = t
+				pnt.TimeValid = true
+			}
+		}
+		ret = append(ret, pnt)
+	}
+
+	return ret, nil
+}
+
+func convertCoordinate(values []string, coordinate string, filePath string) (gpsabl.TrackPoint, error) {
+	pnt := gpsabl.TrackPoint{}
+	if len(values) < 2 {
+		return gpsabl.TrackPoint{}, newKmlCoordinateError(filePath, coordinate)
+	}
+
+	lon, lonErr := strconv.ParseFloat(values[0], 32)
+	lat, latErr := strconv.ParseFloat(values[1], 32)
+	if lonErr != nil || latErr != nil {
+		return gpsabl.TrackPoint{}, newKmlCoordinateError(filePath, coordinate)
+	}
+	pnt.Longitude = float32(lon)
+	pnt.Latitude = float32(lat)
+
+	if len(values) > 2 {
+		alt, altErr := strconv.ParseFloat(values[2], 32)
+		if altErr != nil {
+			return gpsabl.TrackPoint{}, newKmlCoordinateError(filePath, coordinate)
+		}
+		pnt.Elevation = float32(alt)
+	}
+
+	return pnt, nil
+}
+
+// getPlacemarks - Get all Placemarks of a container, followed by the Placemarks of the Documents and Folders inside
+func getPlacemarks(container Container) []Placemark {
+	ret := []Placemark{}
+	ret = append(ret, container.Placemarks...)
+	for _, document := range container.Documents {
+		ret = append(ret, getPlacemarks(document)...)
+	}
+	for _, folder := range container.Folders {
+		ret = append(ret, getPlacemarks(folder)...)
+	}
+
+	return ret
+}
+
+// getFileNameAndDescription - The name and description of a kml file are the ones of the root element or of the first Document
+func getFileNameAndDescription(kml Kml) (string, string) {
+	if kml.Name == "" && kml.Description == "" && len(kml.Documents) > 0 {
+		return kml.Documents[0].Name, kml.Documents[0].Description
+	}
+
+	return kml.Name, kml.Description
+}
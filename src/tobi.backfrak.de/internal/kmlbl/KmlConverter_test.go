@@ -0,0 +1,173 @@
+package kmlbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func TestConvertKmlLineString(t *testing.T) {
+	kml, err := ReadKml(testhelper.GetValidFile("kml", "01.kml"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	file, convErr := ConvertKml(kml, "my/path.kml", gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	// The file was created out of testdata/valid-gpx/01.gpx, so the values should be the gpx values
+	if file.Distance != 18478.293509238614 {
+		t.Errorf("The Distance is %f, but should be %f", file.Distance, 18478.293509238614)
+	}
+
+	if file.Name != "KML name" || file.Description != "A valid KML Track" {
+		t.Errorf("The Name is \"%s\" and the Description \"%s\", but should be \"%s\" and \"%s\"", file.Name, file.Description, "KML name", "A valid KML Track")
+	}
+
+	if file.Tracks[0].Name != "Track name" {
+		t.Errorf("The track Name is %s, but should be %s", file.Tracks[0].Name, "Track name")
+	}
+
+	if file.TimeDataValid != false {
+		t.Errorf("The TimeDataValid is true, but a LineString has no time data")
+	}
+}
+
+func TestConvertKmlGxMultiTrack(t *testing.T) {
+	kml, err := ReadKml(testhelper.GetValidFile("kml", "02.kml"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	file, convErr := ConvertKml(kml, "my/path.kml", gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	// The file was created out of testdata/valid-gpx/02.gpx, so the values should be the gpx values
+	if file.Distance != 37823.344979382266 {
+		t.Errorf("The Distance is %f, but should be %f", file.Distance, 37823.344979382266)
+	}
+
+	if file.Tracks[0].NumberOfSegments != 2 {
+		t.Errorf("The NumberOfSegments is %d, but should be %d", file.Tracks[0].NumberOfSegments, 2)
+	}
+
+	if file.StartTime.Format(time.RFC3339) != "2019-08-18T09:11:01Z" {
+		t.Errorf("The StartTime is %s, but should be %s", file.StartTime.Format(time.RFC3339), "2019-08-18T09:11:01Z")
+	}
+
+	if file.MovingTime != 5600*time.Second {
+		t.Errorf("The MovingTime is %s, but should be %s", file.MovingTime, 5600*time.Second)
+	}
+}
+
+func TestConvertKmlMultiGeometry(t *testing.T) {
+	kml, err := ReadKml(testhelper.GetValidFile("kml", "03.kml"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	file, convErr := ConvertKml(kml, "my/path.kml", gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	// The Placemark with the Point is not a track
+	if file.NumberOfTracks != 1 {
+		t.Errorf("The NumberOfTracks is %d, but should be %d", file.NumberOfTracks, 1)
+	}
+
+	if file.Tracks[0].NumberOfSegments != 2 {
+		t.Errorf("The NumberOfSegments is %d, but should be %d", file.Tracks[0].NumberOfSegments, 2)
+	}
+
+	if gpsabl.RoundFloat64To2Digits(file.Distance) != 18478.29 {
+		t.Errorf("The Distance is %f, but should be %f", file.Distance, 18478.29)
+	}
+}
+
+func TestConvertKmlWithoutTracks(t *testing.T) {
+	kml, err := ReadKml(testhelper.GetInvalidFile("kml", "01.kml"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	_, convErr := ConvertKml(kml, "my/path.kml", gpsabl.NO, 0.3, 10.0)
+	switch convErr.(type) {
+	case *EmptyKmlFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *EmptyKmlFileError, got \"%v\"", convErr)
+	}
+}
+
+func TestConvertKmlWithInvalidCoordinate(t *testing.T) {
+	kml, err := ReadKml(testhelper.GetInvalidFile("kml", "02.kml"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	_, convErr := ConvertKml(kml, "my/path.kml", gpsabl.NO, 0.3, 10.0)
+	switch convErr.(type) {
+	case *KmlCoordinateError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *KmlCoordinateError, got \"%v\"", convErr)
+	}
+}
+
+func TestConvertCoordinates(t *testing.T) {
+	points, err := convertCoordinates(" 11.5,49.25,310.5\n\t11.6,49.3 ", "my/path.kml")
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(points) != 2 {
+		t.Fatalf("Got %d points, but expected %d", len(points), 2)
+	}
+
+	if points[0].Longitude != 11.5 || points[0].Latitude != 49.25 || points[0].Elevation != 310.5 {
+		t.Errorf("The first point is %f, %f, %f, but should be %f, %f, %f", points[0].Longitude, points[0].Latitude, points[0].Elevation, 11.5, 49.25, 310.5)
+	}
+
+	if points[1].Elevation != 0.0 {
+		t.Errorf("The Elevation of a point without altitude is %f, but should be %f", points[1].Elevation, 0.0)
+	}
+
+	_, errShort := convertCoordinates("11.5", "my/path.kml")
+	switch errShort.(type) {
+	case *KmlCoordinateError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *KmlCoordinateError, got \"%v\"", errShort)
+	}
+}
+
+func TestConvertTrackWithInvalidWhen(t *testing.T) {
+	track := Track{}
+	track.Whens = []string{"2025-03-01T10:00:00Z", "yesterday"}
+	track.Coords = []string{"11.5 49.25 310.5", "11.6 49.3 311", "11.7 49.35 312"}
+
+	points, err := convertTrack(track, "my/path.kml")
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if points[0].TimeValid != true {
+		t.Errorf("The time of the first point is not valid")
+	}
+
+	if points[1].TimeValid != false || points[2].TimeValid != false {
+		t.Errorf("The time of a point without a valid when element is valid")
+	}
+}
@@ -0,0 +1,181 @@
+package kmlbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"archive/zip"
+	"bytes"
+	"path"
+	"strings"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+const KmlBuffer gpsabl.InputFileType = "KmlBuffer"
+
+// The file extension this Reader can read
+const FileExtension string = ".kml"
+
+// The file extension of zipped kml files this Reader can read
+const KmzFileExtension string = ".kmz"
+
+// KmlFile - The struct to handle *.kml and *.kmz data files
+type KmlFile struct {
+	gpsabl.TrackFile
+	input gpsabl.InputFile
+}
+
+// NewKmlFile - Constructor for the KmlFile struct
+func NewKmlFile(filePath string) KmlFile {
+	kml := KmlFile{}
+	kml.FilePath = filePath
+	kml.input = *gpsabl.NewInputFileWithPath(filePath)
+
+	return kml
+}
+
+// NewReader - Get a new reader for KML files that will read the data in the given gpsabl.InputFile
+// Implement the gpsabl.TrackReader interface for *.kml files
+func (kml *KmlFile) NewReader(data gpsabl.InputFile) gpsabl.TrackReader {
+	newKml := KmlFile{}
+	newKml.input = data
+	if data.Type == gpsabl.FilePath {
+		newKml.FilePath = data.Name
+	}
+
+	return &newKml
+}
+
+// ReadTracks - Read the *.kml or *.kmz from the input from FilePath or InputFile.Buffer, and return a gpsabl.TrackFile struct that contains all information
+// Implement the gpsabl.TrackReader interface for *.kml files
+func (kml *KmlFile) ReadTracks(correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	var err error
+	var ret gpsabl.TrackFile
+	if kml.input.Type == gpsabl.FilePath {
+		ret, err = ReadKmlFile(kml.FilePath, correction, minimalMovingSpeed, minimalStepHight)
+	} else if kml.input.Type == KmlBuffer {
+		var buffer []byte
+		buffer, err = kml.input.ReadContent()
+		if err == nil {
+			ret, err = ReadBuffer(buffer, kml.input.Name, correction, minimalMovingSpeed, minimalStepHight)
+		}
+	} else {
+		err = gpsabl.NewUnKnownInputFileTypeError(kml.input.Name)
+	}
+
+	if err == nil {
+		kml.TrackFile = ret
+	}
+
+	return ret, err
+}
+
+// CheckInputFile - Check if a gpsabl.InputFile can be handled by the KmlFile reader
+// Implement the gpsabl.TrackReader interface for *.kml files
+func (kml *KmlFile) CheckInputFile(input gpsabl.InputFile) bool {
+	if input.Type == KmlBuffer {
+		return true
+	} else if input.Type == gpsabl.FilePath && kml.CheckFile(input.Name) {
+		return true
+	}
+
+	return false
+}
+
+// ReadBuffer - Read the kml or kmz data from a buffer, and return a gpsabl.TrackFile struct that contains all information
+func ReadBuffer(buffer []byte, name string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	content, readErr := readKMLBuffer(buffer, name)
+	if readErr != nil {
+		return gpsabl.TrackFile{}, readErr
+	}
+	ret, convertError := ConvertKml(content, name, correction, minimalMovingSpeed, minimalStepHight)
+	if convertError != nil {
+		return gpsabl.TrackFile{}, convertError
+	}
+
+	return ret, nil
+}
+
+// CheckFile - Check if a file can be read by the KmlFile "class"
+// Implement the gpsabl.TrackReader interface for *.kml files
+func (kml *KmlFile) CheckFile(path string) bool {
+	lowerPath := strings.ToLower(path)
+	if strings.HasSuffix(lowerPath, FileExtension) || strings.HasSuffix(lowerPath, KmzFileExtension) { // If the file is a *.kml or *.kmz, we can read it
+		return true
+	}
+
+	return false
+}
+
+// CheckBuffer - Check if a buffer can be read by he KmlFile "class". This is the case if the xml root element is <kml>,
+// or the buffer is a zip archive that contains a *.kml file
+// Implement the gpsabl.TrackReader interface for *.kml files
+func (kml *KmlFile) CheckBuffer(buffer []byte) bool {
+	if isKmzBuffer(buffer) {
+		return checkKmzBuffer(buffer)
+	}
+
+	res, _ := kml.SniffContent(buffer)
+
+	return res
+}
+
+// SniffContent - Check if the start of a file or buffer is a xml document with the <kml> root element.
+// Implement the gpsabl.ContentSniffer interface for *.kml files. *.kmz archives can not be recognized by the start of the file
+func (kml *KmlFile) SniffContent(head []byte) (bool, string) {
+	return gpsabl.SniffXMLRoot(head, "kml", []string{"http://www.opengis.net/kml/", "http://earth.google.com/kml/"})
+}
+
+// NewInputFileForBuffer - Get a new InputFile for a buffer containing a kml or kmz files content
+// Implement the gpsabl.TrackReader interface for *.kml files
+func (kml *KmlFile) NewInputFileForBuffer(buffer []byte, name string) *gpsabl.InputFile {
+	file := gpsabl.InputFile{}
+	file.Name = name
+	file.Type = KmlBuffer
+	file.Buffer = buffer
+
+	return &file
+}
+
+// GetValidFileExtensions - Get a list of file extensions this reader can read
+// Implement the gpsabl.TrackReader interface for *.kml files
+func (kml *KmlFile) GetValidFileExtensions() []string {
+
+	extensions := []string{FileExtension, KmzFileExtension}
+
+	return extensions
+}
+
+// ReadKmlFile - Reads a *.kml or *.kmz file
+func ReadKmlFile(filePath string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	kml, fileError := ReadKml(filePath)
+
+	if fileError != nil {
+		return gpsabl.TrackFile{}, fileError
+	}
+
+	ret, convertError := ConvertKml(kml, filePath, correction, minimalMovingSpeed, minimalStepHight)
+	if convertError != nil {
+		return gpsabl.TrackFile{}, convertError
+	}
+
+	return ret, nil
+}
+
+// checkKmzBuffer - Check if a zip archive contains a *.kml file
+func checkKmzBuffer(buffer []byte) bool {
+	archive, err := zip.NewReader(bytes.NewReader(buffer), int64(len(buffer)))
+	if err != nil {
+		return false
+	}
+
+	for _, file := range archive.File {
+		if strings.ToLower(path.Ext(file.Name)) == FileExtension {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,218 @@
+package kmlbl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+func TestTrackReaderKmz(t *testing.T) {
+	kml := NewKmlFile(testhelper.GetValidFile("kml", "04.kmz"))
+
+	file, err := kml.ReadTracks("none", 0.3, 10.0)
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if file.Distance != 37823.344979382266 {
+		t.Errorf("The Distance is %f, but should be %f", file.Distance, 37823.344979382266)
+	}
+
+	if file.FilePath != testhelper.GetValidFile("kml", "04.kmz") {
+		t.Errorf("The FilePath is %s, but should be %s", file.FilePath, testhelper.GetValidFile("kml", "04.kmz"))
+	}
+
+	if kml.Distance != file.Distance {
+		t.Errorf("The KmlFile.Distance is %f, but should be %f", kml.Distance, file.Distance)
+	}
+}
+
+func TestTrackReaderInValidCorrectionParameter(t *testing.T) {
+	kml := NewKmlFile(testhelper.GetValidFile("kml", "02.kml"))
+
+	_, err := kml.ReadTracks("asdfg", 0.3, 10.0)
+	if err != nil {
+		switch ty := err.(type) {
+		case *gpsabl.CorrectionParameterNotKnownError:
+			fmt.Println("OK")
+		default:
+			t.Errorf("The Error ReadTracks gave is of the wrong type. The type is %v", ty)
+		}
+	} else {
+		t.Errorf("ReadTracks did not return a error, but was expected")
+	}
+}
+
+func TestReadAllValidKmlDueInterface(t *testing.T) {
+	files, _ := ioutil.ReadDir(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-kml"))
+
+	for _, file := range files {
+		kmlFile := NewKmlFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-kml", file.Name()))
+		if kmlFile.CheckFile(file.Name()) && file.IsDir() == false {
+			iKml := gpsabl.TrackReader(&kmlFile)
+
+			track, err := iKml.ReadTracks("none", 0.3, 10.0)
+			if err != nil {
+				t.Errorf("Got the error \"%s\" while reading file %s.", err.Error(), filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-kml", file.Name()))
+			}
+			if track.Distance <= 0.0 {
+				t.Errorf("The track.Distance is %f but should not be less then %f", track.Distance, 0.0)
+			}
+		}
+	}
+}
+
+func TestReadAllInValidKmlDueInterface(t *testing.T) {
+	files, _ := ioutil.ReadDir(filepath.Join(testhelper.GetProjectRoot(), "testdata", "invalid-kml"))
+
+	for _, file := range files {
+		kmlFile := NewKmlFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "invalid-kml", file.Name()))
+		if kmlFile.CheckFile(file.Name()) && file.IsDir() == false {
+			iKml := gpsabl.TrackReader(&kmlFile)
+
+			_, err := iKml.ReadTracks("none", 0.3, 10.0)
+			if err == nil {
+				t.Errorf("Got no error while reading file %s.", filepath.Join(testhelper.GetProjectRoot(), "testdata", "invalid-kml", file.Name()))
+			}
+		}
+	}
+}
+
+func TestNewReaderWithValidFilePath(t *testing.T) {
+	kml := KmlFile{}
+	file := testhelper.GetValidFile("kml", "01.kml")
+	checkRes := kml.CheckFile(file)
+	input := *gpsabl.NewInputFileWithPath(file)
+
+	sut := kml.NewReader(input)
+
+	if checkRes != true {
+		t.Errorf("KmlFile can not read %s", file)
+	}
+
+	if kml.CheckInputFile(input) != true {
+		t.Errorf("KmlFile can not read the input %s", file)
+	}
+
+	trk, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+	if err != nil {
+		t.Errorf("Got error \"%s\" but expect none", err)
+	}
+
+	if trk.FilePath != file {
+		t.Errorf("The trk.FilePath %s is not the given path %s", trk.FilePath, file)
+	}
+}
+
+func TestNewReaderWithValidBuffer(t *testing.T) {
+	for _, name := range []string{"01.kml", "04.kmz"} {
+		kml := KmlFile{}
+		buffer, createErr := testhelper.GetValidFileBuffer("kml", name)
+		if createErr != nil {
+			t.Fatalf("Got error \"%s\" while creating the input buffer", createErr)
+		}
+		checkRes := kml.CheckBuffer(buffer)
+		input := *kml.NewInputFileForBuffer(buffer, "Buffer 1")
+
+		sut := kml.NewReader(input)
+
+		if checkRes != true {
+			t.Errorf("KmlFile can not read %s from buffer", name)
+		}
+
+		if kml.CheckInputFile(input) != true {
+			t.Errorf("KmlFile can not read the input buffer")
+		}
+
+		trk, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+		if err != nil {
+			t.Errorf("Got error \"%s\" but expect none", err)
+		}
+
+		if trk.FilePath != "Buffer 1" {
+			t.Errorf("The trk.FilePath %s is not the given path %s", trk.FilePath, "Buffer 1")
+		}
+	}
+}
+
+func TestNewReaderWithUnknownInputType(t *testing.T) {
+	kml := KmlFile{}
+	input := gpsabl.InputFile{Type: "Unknown", Name: "Buffer 1"}
+	sut := kml.NewReader(input)
+
+	if kml.CheckInputFile(input) != false {
+		t.Errorf("KmlFile can read an input of unknown type")
+	}
+
+	_, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+	switch err.(type) {
+	case *gpsabl.UnKnownInputFileTypeError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *gpsabl.UnKnownInputFileTypeError, got \"%v\"", err)
+	}
+}
+
+func TestCheckBuffer(t *testing.T) {
+	kml := KmlFile{}
+	gpx, _ := testhelper.GetValidGpxBuffer("01.gpx")
+	if kml.CheckBuffer(gpx) != false {
+		t.Errorf("KmlFile can read a gpx buffer")
+	}
+
+	kmz, _ := testhelper.GetInvalidFileBuffer("kml", "03.kmz")
+	if kml.CheckBuffer(kmz) != false {
+		t.Errorf("KmlFile can read a zip buffer without kml file")
+	}
+
+	if kml.CheckBuffer([]byte("<km")) != false {
+		t.Errorf("KmlFile can read a buffer that is to short")
+	}
+}
+
+func TestCheckFile(t *testing.T) {
+	kml := KmlFile{}
+
+	if kml.CheckFile("my/path/file.KML") != true {
+		t.Errorf("KmlFile can not read *.KML files")
+	}
+
+	if kml.CheckFile("my/path/file.kmz") != true {
+		t.Errorf("KmlFile can not read *.kmz files")
+	}
+
+	if kml.CheckFile("my/path/file.gpx") != false {
+		t.Errorf("KmlFile can read *.gpx files")
+	}
+}
+
+func TestGetValidFileExtensions(t *testing.T) {
+	kml := KmlFile{}
+	extensions := kml.GetValidFileExtensions()
+
+	if len(extensions) != 2 || extensions[0] != FileExtension || extensions[1] != KmzFileExtension {
+		t.Errorf("The valid file extensions are %v, but should be [%s %s]", extensions, FileExtension, KmzFileExtension)
+	}
+}
+
+func TestSniffContent(t *testing.T) {
+	kml := KmlFile{}
+	buffer, _ := testhelper.GetValidFileBuffer("kml", "01.kml")
+	if res, reason := kml.SniffContent(buffer); res != true || reason == "" {
+		t.Errorf("The kml root element is not detected. The reason is \"%s\"", reason)
+	}
+
+	gpx, _ := testhelper.GetValidGpxBuffer("01.gpx")
+	if res, _ := kml.SniffContent(gpx); res != false {
+		t.Errorf("A gpx buffer is detected as kml")
+	}
+}
@@ -0,0 +1,552 @@
+package kmlbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+// KmlOutputFormatertype - The gpsabl.OutputFormaterType this formater is responsible for
+const KmlOutputFormatertype gpsabl.OutputFormaterType = "KML"
+
+// DefaultColorColumn - The column the lines are colored by, when nothing else is given
+const DefaultColorColumn gpsabl.SortColumn = gpsabl.AVERAGESPEED
+
+// ColorSteps - The number of colors between the lowest and the highest value of the color column
+const ColorSteps = 10
+
+// NotValidValue - The value written in the balloon, when a value is not valid
+const NotValidValue = "not valid"
+
+const notValidStyleID = "line-not-valid"
+const notValidColor = "ff888888"
+const lineWidth = 4
+
+// kmlOutput - The kml document that is written
+type kmlOutput struct {
+	XMLName     xml.Name          `xml:"kml"`
+	Namespace   string            `xml:"xmlns,attr"`
+	GxNamespace string            `xml:"xmlns:gx,attr"`
+	Document    kmlOutputDocument `xml:"Document"`
+}
+
+type kmlOutputDocument struct {
+	Name        string               `xml:"name,omitempty"`
+	Description *kmlOutputCData      `xml:"description,omitempty"`
+	Styles      []kmlOutputStyle     `xml:"Style"`
+	Placemarks  []kmlOutputPlacemark `xml:"Placemark"`
+}
+
+// kmlOutputCData - A text that is written as CDATA section, so the html of a balloon needs no escaping
+type kmlOutputCData struct {
+	Text string `xml:",cdata"`
+}
+
+type kmlOutputStyle struct {
+	ID        string `xml:"id,attr"`
+	LineColor string `xml:"LineStyle>color"`
+	LineWidth int    `xml:"LineStyle>width"`
+}
+
+type kmlOutputPlacemark struct {
+	Name          string                  `xml:"name"`
+	Description   *kmlOutputCData         `xml:"description,omitempty"`
+	StyleURL      string                  `xml:"styleUrl,omitempty"`
+	Point         *kmlOutputPoint         `xml:"Point,omitempty"`
+	LineString    *kmlOutputLineString    `xml:"LineString,omitempty"`
+	MultiGeometry *kmlOutputMultiGeometry `xml:"MultiGeometry,omitempty"`
+	Track         *kmlOutputTrack         `xml:"gx:Track,omitempty"`
+	MultiTrack    *kmlOutputMultiTrack    `xml:"gx:MultiTrack,omitempty"`
+}
+
+type kmlOutputPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlOutputLineString struct {
+	Tessellate  int    `xml:"tessellate"`
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlOutputMultiGeometry struct {
+	LineStrings []kmlOutputLineString `xml:"LineString"`
+}
+
+type kmlOutputTrack struct {
+	Whens  []string `xml:"when"`
+	Coords []string `xml:"gx:coord"`
+}
+
+type kmlOutputMultiTrack struct {
+	Tracks []kmlOutputTrack `xml:"gx:Track"`
+}
+
+// KmlOutputFormater - type that writes the output lines as Placemarks of a kml file, that can be viewed in Google Earth
+type KmlOutputFormater struct {
+	// ColorColumn - The column the color of a line depends on. All lines get the color of the lowest value, when it is gpsabl.INPUTORDER
+	ColorColumn gpsabl.SortColumn
+
+	// UseTrack - Tell if the segments are written as <gx:Track> with time stamps, instead of <LineString>.
+	// Lines that contain points without time are always written as <LineString>
+	UseTrack bool
+
+	// CorrectedElevation - Tell if the corrected elevation is written instead of the elevation read from the file
+	CorrectedElevation bool
+
+	// Tell if the waypoints of the TrackFiles should be added as Placemarks with a Point
+	AddWaypoints bool
+
+	// Sorting - The order the Placemarks are written in. The lines are written in the order they are added by default
+	Sorting gpsabl.OutputSorting
+
+	writtenEntiresCount int
+	lineBuffer          []gpsabl.OutputLine
+	waypointBuffer      []gpsabl.Waypoint
+	fileCount           int
+	fileName            string
+	fileDescription     string
+	mux                 sync.Mutex
+}
+
+// NewKmlOutputFormater - Get a new instance of the KmlOutputFormater
+func NewKmlOutputFormater() *KmlOutputFormater {
+	ret := KmlOutputFormater{}
+	ret.ColorColumn = DefaultColorColumn
+	ret.writtenEntiresCount = -1
+	ret.lineBuffer = []gpsabl.OutputLine{}
+	ret.waypointBuffer = []gpsabl.Waypoint{}
+
+	return &ret
+}
+
+// NewColorColumn - Get the column for the KmlOutputFormater.ColorColumn. The value is not case sensitive and may be empty,
+// so all lines get the same color. Returns an error when the column is not known or contains no numbers
+func NewColorColumn(value string) (gpsabl.SortColumn, error) {
+	if strings.TrimSpace(value) == "" {
+		return gpsabl.INPUTORDER, nil
+	}
+
+	for _, column := range gpsabl.GetValidNumberColumns() {
+		if strings.EqualFold(string(column), strings.TrimSpace(value)) {
+			return column, nil
+		}
+	}
+
+	return gpsabl.INPUTORDER, newColorColumnNotKnownError(value)
+}
+
+// NewOutputFormater -  Get a new gpsabl.OutputFormater of this type
+func (formater *KmlOutputFormater) NewOutputFormater() gpsabl.OutputFormater {
+	ret := NewKmlOutputFormater()
+
+	return gpsabl.OutputFormater(ret)
+}
+
+// GetTextOutputFormater - Get the gpsabl.TextOutputFormater. This is always nil for this formater
+func (formater *KmlOutputFormater) GetTextOutputFormater() gpsabl.TextOutputFormater {
+	return nil
+}
+
+// SetAddWaypoints - Set the value of formater.AddWaypoints
+func (formater *KmlOutputFormater) SetAddWaypoints(value bool) {
+	formater.AddWaypoints = value
+}
+
+// SetSorting - Set the value of formater.Sorting
+func (formater *KmlOutputFormater) SetSorting(sorting gpsabl.OutputSorting) {
+	formater.Sorting = sorting
+}
+
+// AddOutPut - Add the output lines of a TrackFile to the buffer. The lines keep their track points, since they are needed for the geometry
+func (formater *KmlOutputFormater) AddOutPut(trackFile gpsabl.TrackFile, depth gpsabl.DepthArg, filterDuplicate bool) error {
+	var lines []gpsabl.OutputLine
+	linesFromFile, err := gpsabl.GetOutlines(trackFile, depth)
+	if err != nil {
+		return err
+	}
+	if filterDuplicate {
+		for _, line := range linesFromFile {
+			if gpsabl.OutputContainsLineByTimeStamps(lines, line) == false && gpsabl.OutputContainsLineByTimeStamps(formater.lineBuffer, line) == false {
+				lines = append(lines, line)
+			}
+		}
+	} else {
+		lines = linesFromFile
+	}
+
+	formater.mux.Lock()
+	defer formater.mux.Unlock()
+	formater.fileCount++
+	if formater.fileCount == 1 {
+		formater.fileName = trackFile.Name
+		formater.fileDescription = trackFile.Description
+	}
+	if formater.AddWaypoints {
+		formater.waypointBuffer = append(formater.waypointBuffer, trackFile.Waypoints...)
+	}
+	formater.lineBuffer = append(formater.lineBuffer, lines...)
+
+	return nil
+}
+
+// WriteOutput - Write the kml document to the output file. The summary is not part of the kml file, so only the value is checked
+func (formater *KmlOutputFormater) WriteOutput(outFile *os.File, summary gpsabl.SummaryArg) error {
+	output, errGet := formater.getOutput(summary)
+	if errGet != nil {
+		return errGet
+	}
+
+	entries := len(output.Document.Placemarks)
+	if entries > 0 {
+		if _, errWrite := outFile.WriteString(xml.Header); errWrite != nil {
+			return errWrite
+		}
+
+		encoder := xml.NewEncoder(outFile)
+		encoder.Indent("", "  ")
+		if errEncode := encoder.Encode(output); errEncode != nil {
+			return errEncode
+		}
+
+		if _, errWrite := outFile.WriteString("\n"); errWrite != nil {
+			return errWrite
+		}
+	}
+
+	formater.writtenEntiresCount = entries
+
+	return nil
+}
+
+// getOutput - Get the kml document that will be written to the file
+func (formater *KmlOutputFormater) getOutput(summary gpsabl.SummaryArg) (kmlOutput, error) {
+	if !gpsabl.CheckValidSummaryArg(string(summary)) {
+		return kmlOutput{}, gpsabl.NewSummaryParamaterNotKnown(summary)
+	}
+
+	formater.mux.Lock()
+	defer formater.mux.Unlock()
+	gpsabl.SortOutputLines(formater.lineBuffer, formater.Sorting)
+
+	ret := kmlOutput{}
+	ret.Namespace = "http://www.opengis.net/kml/2.2"
+	ret.GxNamespace = "http://www.google.com/kml/ext/2.2"
+
+	// The name and description of the file are only known, when the tracks of one file are written
+	if formater.fileCount == 1 {
+		ret.Document.Name = formater.fileName
+		if formater.fileDescription != "" {
+			ret.Document.Description = &kmlOutputCData{Text: html.EscapeString(formater.fileDescription)}
+		}
+	}
+
+	ret.Document.Styles = getStyles()
+	minimum, maximum, valid := formater.getColorRange()
+	for _, line := range formater.lineBuffer {
+		placemark := formater.convertLine(line)
+		placemark.StyleURL = fmt.Sprintf("#%s", notValidStyleID)
+		if formater.ColorColumn == gpsabl.INPUTORDER {
+			placemark.StyleURL = fmt.Sprintf("#%s", getStyleID(0))
+		} else if value, ok := formater.getColorValue(line); ok && valid {
+			placemark.StyleURL = fmt.Sprintf("#%s", getStyleID(getColorStep(value, minimum, maximum)))
+		}
+		ret.Document.Placemarks = append(ret.Document.Placemarks, placemark)
+	}
+
+	for _, wpt := range gpsabl.GetSortedWaypoints(formater.waypointBuffer) {
+		ret.Document.Placemarks = append(ret.Document.Placemarks, formater.convertWaypoint(wpt))
+	}
+
+	return ret, nil
+}
+
+// CheckOutputFormaterType - Check if this OutputFormater is responsible for the given gpsabl.OutputFormaterType
+func (formater *KmlOutputFormater) CheckOutputFormaterType(formaterType gpsabl.OutputFormaterType) bool {
+	if formaterType == KmlOutputFormatertype {
+		return true
+	}
+
+	return false
+}
+
+// GetOutputFormaterTypes - Get the list of gpsabl.OutputFormaterType this formater can write
+func (formater *KmlOutputFormater) GetOutputFormaterTypes() []gpsabl.OutputFormaterType {
+	return []gpsabl.OutputFormaterType{KmlOutputFormatertype}
+}
+
+// CheckFileExtension - Check if this OutputFormater can write the given output file
+func (formater *KmlOutputFormater) CheckFileExtension(filePath string) bool {
+	if strings.HasSuffix(strings.ToLower(filePath), FileExtension) {
+		return true
+	}
+
+	return false
+}
+
+// GetFileExtensions - Get the list of file extensions this formater can write
+func (formater *KmlOutputFormater) GetFileExtensions() []string {
+	return []string{FileExtension}
+}
+
+// GetOutputTableLineCount - Get the number of lines in the buffer, without the waypoints
+func (formater *KmlOutputFormater) GetOutputTableLineCount() int {
+	return len(formater.lineBuffer)
+}
+
+// Tells the number if output entries that are written to output.
+// * -1: When output was not written yet
+// * 0: Output was written but contains no entries, may because no entry passes the given filter
+// * >0: The number of entries written to the outputs
+func (formater *KmlOutputFormater) GetNumberOfOutputEntries() int {
+	return formater.writtenEntiresCount
+}
+
+// convertLine - Get the Placemark of an output line, with the summary values in the balloon
+func (formater *KmlOutputFormater) convertLine(line gpsabl.OutputLine) kmlOutputPlacemark {
+	ret := kmlOutputPlacemark{Name: line.Name}
+	ret.Description = &kmlOutputCData{Text: getBalloon(gpsabl.StripOutlines([]gpsabl.OutputLine{line})[0].Data.(gpsabl.ExtendedTrackSummary))}
+
+	var segments []gpsabl.TrackSegment
+	switch data := line.Data.(type) {
+	case gpsabl.TrackFile:
+		for _, track := range data.Tracks {
+			segments = append(segments, track.TrackSegments...)
+		}
+	case gpsabl.Track:
+		segments = data.TrackSegments
+	case gpsabl.TrackSegment:
+		segments = []gpsabl.TrackSegment{data}
+	}
+
+	allTimesValid := true
+	var pointSegments []gpsabl.TrackSegment
+	for _, segment := range segments {
+		if len(segment.TrackPoints) == 0 {
+			continue
+		}
+		pointSegments = append(pointSegments, segment)
+		for _, pnt := range segment.TrackPoints {
+			allTimesValid = allTimesValid && pnt.TimeValid
+		}
+	}
+
+	if formater.UseTrack && allTimesValid && len(pointSegments) > 0 {
+		var tracks []kmlOutputTrack
+		for _, segment := range pointSegments {
+			tracks = append(tracks, formater.getTrack(segment))
+		}
+		if len(tracks) == 1 {
+			ret.Track = &tracks[0]
+		} else {
+			ret.MultiTrack = &kmlOutputMultiTrack{Tracks: tracks}
+		}
+
+		return ret
+	}
+
+	var lineStrings []kmlOutputLineString
+	for _, segment := range pointSegments {
+		var coordinates []string
+		for _, pnt := range segment.TrackPoints {
+			coordinates = append(coordinates, strings.Join(formater.getCoordinate(pnt), ","))
+		}
+		lineStrings = append(lineStrings, kmlOutputLineString{Tessellate: 1, Coordinates: strings.Join(coordinates, " ")})
+	}
+	switch len(lineStrings) {
+	case 0:
+	case 1:
+		ret.LineString = &lineStrings[0]
+	default:
+		ret.MultiGeometry = &kmlOutputMultiGeometry{LineStrings: lineStrings}
+	}
+
+	return ret
+}
+
+// getTrack - Get the <gx:Track> of a segment, where all points have a valid time
+func (formater *KmlOutputFormater) getTrack(segment gpsabl.TrackSegment) kmlOutputTrack {
+	ret := kmlOutputTrack{}
+	for _, pnt := range segment.TrackPoints {
+		ret.Whens = append(ret.Whens, pnt.Time.UTC().Format(time.RFC3339Nano))
+		ret.Coords = append(ret.Coords, strings.Join(formater.getCoordinate(pnt), " "))
+	}
+
+	return ret
+}
+
+// getCoordinate - Get the longitude, latitude and altitude of a point. The altitude is left out, when the point has none
+func (formater *KmlOutputFormater) getCoordinate(pnt gpsabl.TrackPoint) []string {
+	ret := []string{formatFloat32(pnt.Longitude), formatFloat32(pnt.Latitude)}
+	if formater.CorrectedElevation {
+		ret = append(ret, formatFloat32(pnt.CorectedElevation))
+	} else if !pnt.ElevationMissing {
+		ret = append(ret, formatFloat32(pnt.Elevation))
+	}
+
+	return ret
+}
+
+// convertWaypoint - Get the Placemark of a waypoint
+func (formater *KmlOutputFormater) convertWaypoint(wpt gpsabl.Waypoint) kmlOutputPlacemark {
+	ret := kmlOutputPlacemark{Name: wpt.Name}
+	if wpt.Description != "" {
+		ret.Description = &kmlOutputCData{Text: html.EscapeString(wpt.Description)}
+	}
+	coordinates := []string{formatFloat32(wpt.Longitude), formatFloat32(wpt.Latitude), formatFloat32(wpt.Elevation)}
+	ret.Point = &kmlOutputPoint{Coordinates: strings.Join(coordinates, ",")}
+
+	return ret
+}
+
+// getColorValue - Get the value of a line in the ColorColumn
+func (formater *KmlOutputFormater) getColorValue(line gpsabl.OutputLine) (float64, bool) {
+	return gpsabl.GetNumberValue(line, formater.ColorColumn)
+}
+
+// getColorRange - Get the lowest and the highest valid value of the ColorColumn. Not valid when no line has a valid value
+func (formater *KmlOutputFormater) getColorRange() (float64, float64, bool) {
+	minimum, maximum, valid := 0.0, 0.0, false
+	for _, line := range formater.lineBuffer {
+		value, ok := formater.getColorValue(line)
+		if !ok {
+			continue
+		}
+		if !valid || value < minimum {
+			minimum = value
+		}
+		if !valid || value > maximum {
+			maximum = value
+		}
+		valid = true
+	}
+
+	return minimum, maximum, valid
+}
+
+// getColorStep - Get the step of the color of a value between the minimum and the maximum. All values get the first step, when the range is empty
+func getColorStep(value float64, minimum float64, maximum float64) int {
+	if maximum <= minimum {
+		return 0
+	}
+
+	step := int(math.Round((value - minimum) / (maximum - minimum) * float64(ColorSteps-1)))
+	if step < 0 {
+		return 0
+	}
+	if step > ColorSteps-1 {
+		return ColorSteps - 1
+	}
+
+	return step
+}
+
+// GetStepColor - Get the kml color (aabbggrr) of a color step. The colors go from green for the lowest value over yellow to red for the highest value
+func GetStepColor(step int) string {
+	position := float64(step) / float64(ColorSteps-1)
+	red, green := 255, 255
+	if position < 0.5 {
+		red = int(math.Round(position * 2 * 255))
+	} else {
+		green = int(math.Round((1 - position) * 2 * 255))
+	}
+
+	return fmt.Sprintf("ff00%02x%02x", green, red)
+}
+
+// getStyles - Get the line styles of all color steps and the style of the lines without a valid value
+func getStyles() []kmlOutputStyle {
+	ret := []kmlOutputStyle{}
+	for step := 0; step < ColorSteps; step++ {
+		ret = append(ret, kmlOutputStyle{ID: getStyleID(step), LineColor: GetStepColor(step), LineWidth: lineWidth})
+	}
+	ret = append(ret, kmlOutputStyle{ID: notValidStyleID, LineColor: notValidColor, LineWidth: lineWidth})
+
+	return ret
+}
+
+func getStyleID(step int) string {
+	return fmt.Sprintf("line-%d", step)
+}
+
+// getBalloon - Get the html table with the summary values of a line, that is shown in the balloon of the Placemark
+func getBalloon(info gpsabl.ExtendedTrackSummary) string {
+	startTime, endTime, duration, movingTime, upwardsTime, downwardsTime := NotValidValue, NotValidValue, NotValidValue, NotValidValue, NotValidValue, NotValidValue
+	averageSpeed, upwardsSpeed, downwardsSpeed := NotValidValue, NotValidValue, NotValidValue
+	if info.TimeDataValid {
+		startTime = info.StartTime.Format(time.RFC3339)
+		endTime = info.EndTime.Format(time.RFC3339)
+		duration = info.Duration.String()
+		movingTime = info.MovingTime.String()
+		upwardsTime = info.UpwardsTime.String()
+		downwardsTime = info.DownwardsTime.String()
+		averageSpeed = formatFloat64(info.AverageSpeed * 3.6)
+		upwardsSpeed = formatFloat64(info.UpwardsSpeed * 3.6)
+		downwardsSpeed = formatFloat64(info.DownwardsSpeed * 3.6)
+	}
+
+	rows := [][]string{
+		{"StartTime", startTime},
+		{"EndTime", endTime},
+		{"TrackTime", duration},
+		{"Distance (km)", formatFloat64(info.Distance / 1000)},
+		{"HorizontalDistance (km)", formatFloat64(info.HorizontalDistance / 1000)},
+		{"AltitudeRange (m)", formatFloat64(info.AltitudeRange)},
+		{"MinimumAltitude (m)", formatFloat64(float64(info.MinimumAltitude))},
+		{"MaximumAltitude (m)", formatFloat64(float64(info.MaximumAltitude))},
+		{"ElevationGain (m)", formatFloat64(float64(info.ElevationGain))},
+		{"ElevationLose (m)", formatFloat64(float64(info.ElevationLose))},
+		{"UpwardsDistance (km)", formatFloat64(info.UpwardsDistance / 1000)},
+		{"DownwardsDistance (km)", formatFloat64(info.DownwardsDistance / 1000)},
+		{"MovingTime", movingTime},
+		{"UpwardsTime", upwardsTime},
+		{"DownwardsTime", downwardsTime},
+		{"AverageSpeed (km/h)", averageSpeed},
+		{"UpwardsSpeed (km/h)", upwardsSpeed},
+		{"DownwardsSpeed (km/h)", downwardsSpeed},
+	}
+
+	headers := gpsabl.GetSensorHeaders()
+	for i, values := range []gpsabl.SensorValues{info.HeartRate, info.Cadence, info.Power} {
+		if !values.Valid() {
+			continue
+		}
+		rows = append(rows, []string{headers[i*3], formatFloat64(values.Minimum)})
+		rows = append(rows, []string{headers[i*3+1], formatFloat64(values.Average)})
+		rows = append(rows, []string{headers[i*3+2], formatFloat64(values.Maximum)})
+	}
+
+	if info.ActivityType != "" {
+		rows = append(rows, []string{"ActivityType", info.ActivityType})
+	}
+	if info.Gear != "" {
+		rows = append(rows, []string{"Gear", info.Gear})
+	}
+
+	ret := []string{"<table>"}
+	for _, row := range rows {
+		ret = append(ret, fmt.Sprintf("<tr><th align=\"left\">%s</th><td align=\"right\">%s</td></tr>", html.EscapeString(row[0]), html.EscapeString(row[1])))
+	}
+	ret = append(ret, "</table>")
+
+	return strings.Join(ret, "")
+}
+
+func formatFloat64(value float64) string {
+	return fmt.Sprintf("%.2f", gpsabl.RoundFloat64To2Digits(value))
+}
+
+func formatFloat32(value float32) string {
+	return strconv.FormatFloat(float64(value), 'f', -1, 32)
+}
@@ -0,0 +1,308 @@
+package kmlbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func TestKmlOutputFormaterIsOutputFormater(t *testing.T) {
+	var orig KmlOutputFormater
+	sut := orig.NewOutputFormater()
+
+	if sut.CheckFileExtension("my/output.kml") == false || sut.CheckFileExtension("my/output.KML") == false {
+		t.Errorf("KmlOutputFormater can not write *.kml")
+	}
+
+	if sut.CheckFileExtension("my/output.kmz") == true {
+		t.Errorf("KmlOutputFormater can write *.kmz")
+	}
+
+	if sut.CheckOutputFormaterType(KmlOutputFormatertype) == false {
+		t.Errorf("KmlOutputFormater can not write %s type", KmlOutputFormatertype)
+	}
+
+	if len(sut.GetOutputFormaterTypes()) != 1 || sut.GetOutputFormaterTypes()[0] != "KML" {
+		t.Errorf("The OutputFormaterTypes are %v, but should be %v", sut.GetOutputFormaterTypes(), []string{"KML"})
+	}
+
+	if sut.GetNumberOfOutputEntries() != -1 {
+		t.Errorf("The initial value of GetNumberOfOutputEntries is %d but should be %d", sut.GetNumberOfOutputEntries(), -1)
+	}
+
+	if (sut.(*KmlOutputFormater)).ColorColumn != DefaultColorColumn {
+		t.Errorf("The ColorColumn is %s, but should be %s", (sut.(*KmlOutputFormater)).ColorColumn, DefaultColorColumn)
+	}
+}
+
+func TestKmlOutputGxTrackReadAgain(t *testing.T) {
+	input, _ := ReadKmlFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-kml", "02.kml"), gpsabl.STEPS, 0.3, 10.0)
+
+	sut := NewKmlOutputFormater()
+	sut.UseTrack = true
+	sut.AddOutPut(input, gpsabl.TRACK, false)
+	output, _ := sut.getOutput(gpsabl.NONE)
+	if output.Document.Placemarks[0].MultiTrack == nil || len(output.Document.Placemarks[0].MultiTrack.Tracks) != len(input.Tracks[0].TrackSegments) {
+		t.Fatalf("The Placemark does not contain a gx:MultiTrack with a gx:Track for each segment")
+	}
+
+	actual := writeAndReadKml(t, sut)
+	if len(actual.Tracks) != 1 || len(actual.Tracks[0].TrackSegments) != len(input.Tracks[0].TrackSegments) {
+		t.Fatalf("The written file does not contain the track with %d segments", len(input.Tracks[0].TrackSegments))
+	}
+
+	if actual.Distance != input.Distance || actual.ElevationGain != input.ElevationGain {
+		t.Errorf("The Distance and ElevationGain are %f, %f, but should be %f, %f", actual.Distance, actual.ElevationGain, input.Distance, input.ElevationGain)
+	}
+
+	if !actual.TimeDataValid || actual.StartTime != input.StartTime || actual.EndTime != input.EndTime {
+		t.Errorf("The StartTime and EndTime are %s, %s, but should be %s, %s", actual.StartTime, actual.EndTime, input.StartTime, input.EndTime)
+	}
+}
+
+func TestKmlOutputLineStringReadAgain(t *testing.T) {
+	input, _ := ReadKmlFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-kml", "02.kml"), gpsabl.STEPS, 0.3, 10.0)
+
+	sut := NewKmlOutputFormater()
+	sut.AddOutPut(input, gpsabl.TRACK, false)
+	output, _ := sut.getOutput(gpsabl.NONE)
+	if output.Document.Placemarks[0].MultiGeometry == nil || output.Document.Placemarks[0].MultiTrack != nil {
+		t.Fatalf("The Placemark does not contain a MultiGeometry of LineStrings")
+	}
+
+	actual := writeAndReadKml(t, sut)
+	if actual.Distance != input.Distance {
+		t.Errorf("The Distance is %f, but should be %f", actual.Distance, input.Distance)
+	}
+
+	if actual.TimeDataValid {
+		t.Errorf("The time data of a LineString is valid")
+	}
+}
+
+func TestKmlOutputGxTrackWithoutTime(t *testing.T) {
+	input, _ := ReadKmlFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-kml", "01.kml"), gpsabl.STEPS, 0.3, 10.0)
+
+	sut := NewKmlOutputFormater()
+	sut.UseTrack = true
+	sut.AddOutPut(input, gpsabl.SEGMENT, false)
+	output, _ := sut.getOutput(gpsabl.NONE)
+	for _, placemark := range output.Document.Placemarks {
+		if placemark.LineString == nil || placemark.Track != nil {
+			t.Errorf("The segment without time is not written as LineString")
+		}
+	}
+}
+
+func TestKmlOutputColors(t *testing.T) {
+	file := gpsabl.NewTrackFile("my/file.gpx")
+	for _, gain := range []float32{100, 550, 1000} {
+		track := gpsabl.Track{}
+		track.ElevationGain = gain
+		file.Tracks = append(file.Tracks, track)
+	}
+
+	sut := NewKmlOutputFormater()
+	sut.ColorColumn = gpsabl.ELEVATIONGAIN
+	sut.AddOutPut(file, gpsabl.TRACK, false)
+	output, _ := sut.getOutput(gpsabl.NONE)
+
+	expected := []string{"#line-0", "#line-5", "#line-9"}
+	for i, placemark := range output.Document.Placemarks {
+		if placemark.StyleURL != expected[i] {
+			t.Errorf("The style of Placemark %d is %s, but should be %s", i, placemark.StyleURL, expected[i])
+		}
+	}
+
+	if len(output.Document.Styles) != ColorSteps+1 {
+		t.Errorf("The document contains %d styles, but should contain %d", len(output.Document.Styles), ColorSteps+1)
+	}
+
+	// The tracks have no time data, so there is no average speed
+	sut.ColorColumn = gpsabl.AVERAGESPEED
+	output, _ = sut.getOutput(gpsabl.NONE)
+	for i, placemark := range output.Document.Placemarks {
+		if placemark.StyleURL != "#"+notValidStyleID {
+			t.Errorf("The style of Placemark %d is %s, but should be %s", i, placemark.StyleURL, "#"+notValidStyleID)
+		}
+	}
+
+	sut.ColorColumn = gpsabl.INPUTORDER
+	output, _ = sut.getOutput(gpsabl.NONE)
+	for i, placemark := range output.Document.Placemarks {
+		if placemark.StyleURL != "#line-0" {
+			t.Errorf("The style of Placemark %d is %s, but should be %s", i, placemark.StyleURL, "#line-0")
+		}
+	}
+}
+
+func TestGetStepColor(t *testing.T) {
+	// Green for the lowest, red for the highest value
+	expected := map[int]string{0: "ff00ff00", ColorSteps - 1: "ff0000ff"}
+	for step, color := range expected {
+		if GetStepColor(step) != color {
+			t.Errorf("The color of step %d is %s, but should be %s", step, GetStepColor(step), color)
+		}
+	}
+
+	if getColorStep(5, 5, 5) != 0 || getColorStep(20, 0, 10) != ColorSteps-1 || getColorStep(-1, 0, 10) != 0 {
+		t.Errorf("The color steps of values outside the range are not the first or the last step")
+	}
+}
+
+func TestNewColorColumn(t *testing.T) {
+	column, err := NewColorColumn("averagespeed")
+	if err != nil || column != gpsabl.AVERAGESPEED {
+		t.Errorf("The column is %s, but should be %s", column, gpsabl.AVERAGESPEED)
+	}
+
+	column, err = NewColorColumn(" ")
+	if err != nil || column != gpsabl.INPUTORDER {
+		t.Errorf("The column is %s, but should be %s", column, gpsabl.INPUTORDER)
+	}
+
+	for _, value := range []string{"Gear", "abc"} {
+		_, err = NewColorColumn(value)
+		switch err.(type) {
+		case *ColorColumnNotKnownError:
+			fmt.Println("OK")
+		default:
+			t.Errorf("Expected a ColorColumnNotKnownError, got \"%v\"", err)
+		}
+	}
+}
+
+func TestKmlOutputBalloon(t *testing.T) {
+	input, _ := ReadKmlFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-kml", "01.kml"), gpsabl.STEPS, 0.3, 10.0)
+
+	sut := NewKmlOutputFormater()
+	sut.AddOutPut(input, gpsabl.FILE, false)
+	output, _ := sut.getOutput(gpsabl.NONE)
+	balloon := output.Document.Placemarks[0].Description.Text
+	distance := fmt.Sprintf("<tr><th align=\"left\">Distance (km)</th><td align=\"right\">%s</td></tr>", formatFloat64(input.Distance/1000))
+	if !strings.Contains(balloon, distance) {
+		t.Errorf("The balloon does not contain the distance: %s", balloon)
+	}
+
+	if !strings.Contains(balloon, "<td align=\"right\">not valid</td>") {
+		t.Errorf("The balloon of a track without time does not contain not valid times: %s", balloon)
+	}
+
+	if strings.Contains(balloon, "HeartRate") {
+		t.Errorf("The balloon of a track without heart rate contains heart rate values: %s", balloon)
+	}
+
+	content := writeKml(t, sut)
+	if !strings.Contains(content, "<description><![CDATA[<table>") {
+		t.Errorf("The balloon is not written as CDATA section")
+	}
+}
+
+func TestKmlOutputWaypointsAndName(t *testing.T) {
+	input, _ := ReadKmlFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-kml", "01.kml"), gpsabl.STEPS, 0.3, 10.0)
+	input.Waypoints = []gpsabl.Waypoint{{Name: "Start", Latitude: 49.415942, Longitude: 11.017447}}
+
+	sut := NewKmlOutputFormater()
+	sut.SetAddWaypoints(true)
+	sut.AddOutPut(input, gpsabl.FILE, false)
+	output, _ := sut.getOutput(gpsabl.NONE)
+	if len(output.Document.Placemarks) != 2 || output.Document.Placemarks[1].Point == nil {
+		t.Fatalf("The output does not contain the waypoint as Placemark with a Point")
+	}
+
+	if output.Document.Name != input.Name {
+		t.Errorf("The name of the document is \"%s\", but should be \"%s\"", output.Document.Name, input.Name)
+	}
+
+	// The reader skips the waypoints
+	actual := writeAndReadKml(t, sut)
+	if len(actual.Tracks) != 1 {
+		t.Errorf("The written file contains %d tracks, but should contain %d", len(actual.Tracks), 1)
+	}
+
+	sut.AddOutPut(input, gpsabl.FILE, false)
+	output, _ = sut.getOutput(gpsabl.NONE)
+	if output.Document.Name != "" {
+		t.Errorf("The name of the document is \"%s\", but should be empty for more than one file", output.Document.Name)
+	}
+}
+
+func TestKmlOutputNoTrack(t *testing.T) {
+	sut := NewKmlOutputFormater()
+	if err := sut.WriteOutput(os.Stdout, gpsabl.ADDITIONAL); err != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+
+	if sut.GetNumberOfOutputEntries() != 0 {
+		t.Errorf("The number of written entries is %d, but should be %d", sut.GetNumberOfOutputEntries(), 0)
+	}
+}
+
+func TestKmlOutputNotValidArguments(t *testing.T) {
+	sut := NewKmlOutputFormater()
+	err := sut.WriteOutput(os.Stdout, "abc")
+	switch err.(type) {
+	case *gpsabl.SummaryParamaterNotKnown:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a SummaryParamaterNotKnown, got \"%v\"", err)
+	}
+
+	err = sut.AddOutPut(gpsabl.NewTrackFile("my/file.gpx"), "abc", false)
+	switch err.(type) {
+	case *gpsabl.DepthParameterNotKnownError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a DepthParameterNotKnownError, got \"%v\"", err)
+	}
+}
+
+// writeKml - Write the output of the formater to a temp file, and get the content of the file
+func writeKml(t *testing.T, sut *KmlOutputFormater) string {
+	path := writeKmlToTempFile(t, sut)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	content, _ := ioutil.ReadFile(path)
+
+	return string(content)
+}
+
+// writeAndReadKml - Write the output of the formater to a temp file, and read the file again
+func writeAndReadKml(t *testing.T, sut *KmlOutputFormater) gpsabl.TrackFile {
+	path := writeKmlToTempFile(t, sut)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	ret, errRead := ReadKmlFile(path, gpsabl.STEPS, 0.3, 10.0)
+	if errRead != nil {
+		t.Fatalf("Can not read the written file: %s", errRead.Error())
+	}
+
+	return ret
+}
+
+// writeKmlToTempFile - Write the output of the formater to a new temp file, and get the path of the file
+func writeKmlToTempFile(t *testing.T, sut *KmlOutputFormater) string {
+	dir, errDir := ioutil.TempDir("", "kmlbl")
+	if errDir != nil {
+		t.Fatalf("Can not create a temp dir: %s", errDir)
+	}
+
+	path := filepath.Join(dir, "out.kml")
+	out, _ := os.Create(path)
+	defer out.Close()
+	if errWrite := sut.WriteOutput(out, gpsabl.NONE); errWrite != nil {
+		t.Fatalf("Got an error but did not expect one. The error is: %s", errWrite.Error())
+	}
+
+	return path
+}
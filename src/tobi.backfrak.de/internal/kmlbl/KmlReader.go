@@ -0,0 +1,124 @@
+package kmlbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"path"
+	"strings"
+)
+
+// zipMagic - The first bytes of a zip archive, like a *.kmz file
+const zipMagic string = "PK\x03\x04"
+
+// Kml - Represents the content of a kml file
+type Kml struct {
+	Container
+}
+
+// Container - Represents a kml Document or Folder, that may contain Placemarks and further containers
+type Container struct {
+	Name        string      `xml:"name"`
+	Description string      `xml:"description"`
+	Documents   []Container `xml:"Document"`
+	Folders     []Container `xml:"Folder"`
+	Placemarks  []Placemark `xml:"Placemark"`
+}
+
+// Placemark - Represents one Placemark in a kml file
+type Placemark struct {
+	Name        string `xml:"name"`
+	Description string `xml:"description"`
+	Geometry
+}
+
+// Geometry - Represents the geometries of a Placemark, a MultiGeometry or a gx:MultiTrack, that can be converted to tracks
+type Geometry struct {
+	LineStrings     []LineString `xml:"LineString"`
+	Tracks          []Track      `xml:"Track"`
+	MultiTracks     []Geometry   `xml:"MultiTrack"`
+	MultiGeometries []Geometry   `xml:"MultiGeometry"`
+}
+
+// LineString - Represents one LineString in a kml file
+type LineString struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+// Track - Represents one gx:Track in a kml file
+type Track struct {
+	Whens  []string `xml:"when"`
+	Coords []string `xml:"coord"`
+}
+
+// ReadKml - Read a kml or kmz file
+func ReadKml(fileName string) (Kml, error) {
+	fileBuffer, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return Kml{}, err
+	}
+	return readKMLBuffer(fileBuffer, fileName)
+}
+
+func readKMLBuffer(fileBuffer []byte, fileName string) (Kml, error) {
+	xmlBuffer := fileBuffer
+	if isKmzBuffer(fileBuffer) {
+		var unzipErr error
+		xmlBuffer, unzipErr = unzipKMZBuffer(fileBuffer, fileName)
+		if unzipErr != nil {
+			return Kml{}, unzipErr
+		}
+	}
+
+	kml := Kml{}
+	err := xml.Unmarshal(xmlBuffer, &kml)
+	if err != nil {
+		return Kml{}, err
+	}
+
+	if len(kml.Documents) <= 0 && len(kml.Folders) <= 0 && len(kml.Placemarks) <= 0 {
+		return Kml{}, newKmlFileError(fileName)
+	}
+
+	return kml, nil
+}
+
+// isKmzBuffer - Tell if the buffer contains a zip archive
+func isKmzBuffer(buffer []byte) bool {
+	return len(buffer) >= len(zipMagic) && string(buffer[:len(zipMagic)]) == zipMagic
+}
+
+// unzipKMZBuffer - Get the content of the main kml file in a kmz archive. This is the doc.kml, or if not present the first *.kml in the archive
+func unzipKMZBuffer(buffer []byte, fileName string) ([]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(buffer), int64(len(buffer)))
+	if err != nil {
+		return nil, err
+	}
+
+	var kmlFile *zip.File
+	for _, file := range archive.File {
+		if strings.ToLower(path.Ext(file.Name)) != FileExtension {
+			continue
+		}
+		if kmlFile == nil || strings.ToLower(file.Name) == "doc.kml" {
+			kmlFile = file
+		}
+	}
+
+	if kmlFile == nil {
+		return nil, newKmlFileError(fileName)
+	}
+
+	reader, openErr := kmlFile.Open()
+	if openErr != nil {
+		return nil, openErr
+	}
+	defer reader.Close()
+
+	return ioutil.ReadAll(reader)
+}
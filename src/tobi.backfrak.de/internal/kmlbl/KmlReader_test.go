@@ -0,0 +1,129 @@
+package kmlbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func TestReadValidKml01(t *testing.T) {
+	kml, err := ReadKml(testhelper.GetValidFile("kml", "01.kml"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(kml.Documents) != 1 {
+		t.Fatalf("The number of Documents is %d, but should be %d", len(kml.Documents), 1)
+	}
+
+	if kml.Documents[0].Name != "KML name" {
+		t.Errorf("The Document name is %s, but should be %s", kml.Documents[0].Name, "KML name")
+	}
+
+	if len(kml.Documents[0].Placemarks) != 1 {
+		t.Fatalf("The number of Placemarks is %d, but should be %d", len(kml.Documents[0].Placemarks), 1)
+	}
+
+	placemark := kml.Documents[0].Placemarks[0]
+	if len(placemark.LineStrings) != 1 {
+		t.Errorf("The number of LineStrings is %d, but should be %d", len(placemark.LineStrings), 1)
+	}
+
+	if len(strings.Fields(placemark.LineStrings[0].Coordinates)) != 637 {
+		t.Errorf("The number of coordinates is %d, but should be %d", len(strings.Fields(placemark.LineStrings[0].Coordinates)), 637)
+	}
+}
+
+func TestReadValidKmlWithGxTrack(t *testing.T) {
+	kml, err := ReadKml(testhelper.GetValidFile("kml", "02.kml"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	placemark := kml.Documents[0].Folders[0].Placemarks[0]
+	if len(placemark.MultiTracks) != 1 {
+		t.Fatalf("The number of gx:MultiTracks is %d, but should be %d", len(placemark.MultiTracks), 1)
+	}
+
+	if len(placemark.MultiTracks[0].Tracks) != 2 {
+		t.Fatalf("The number of gx:Tracks is %d, but should be %d", len(placemark.MultiTracks[0].Tracks), 2)
+	}
+
+	track := placemark.MultiTracks[0].Tracks[0]
+	if len(track.Whens) != len(track.Coords) {
+		t.Errorf("The gx:Track has %d when elements, but %d gx:coord elements", len(track.Whens), len(track.Coords))
+	}
+}
+
+func TestReadValidKmz(t *testing.T) {
+	kmz, err := ReadKml(testhelper.GetValidFile("kml", "04.kmz"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	kml, _ := ReadKml(testhelper.GetValidFile("kml", "02.kml"))
+	if reflect.DeepEqual(kmz, kml) == false {
+		t.Errorf("The content of 04.kmz differs from the content of 02.kml")
+	}
+}
+
+func TestReadKmzWithoutKml(t *testing.T) {
+	_, err := ReadKml(testhelper.GetInvalidFile("kml", "03.kmz"))
+	switch v := err.(type) {
+	case nil:
+		t.Errorf("No error, when reading a kmz file without kml")
+	case *KmlFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *KmlFileError, got a %s", reflect.TypeOf(v))
+	}
+}
+
+func TestReadNoKmlBuffer(t *testing.T) {
+	buffer, _ := testhelper.GetValidGpxBuffer("01.gpx")
+	_, err := readKMLBuffer(buffer, "Buffer")
+	switch v := err.(type) {
+	case nil:
+		t.Errorf("No error, when reading a gpx file as kml")
+	case *KmlFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *KmlFileError, got a %s", reflect.TypeOf(v))
+	}
+}
+
+func TestReadNotExistKml(t *testing.T) {
+	_, err := ReadKml(testhelper.GetInvalidFile("kml", "not-exist.kml"))
+	switch v := err.(type) {
+	case nil:
+		t.Errorf("No error, when reading an not existing kml file")
+	case *os.PathError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *os.PathError, got a %s", reflect.TypeOf(v))
+	}
+}
+
+func TestIsKmzBuffer(t *testing.T) {
+	kmz, _ := testhelper.GetValidFileBuffer("kml", "04.kmz")
+	if isKmzBuffer(kmz) != true {
+		t.Errorf("The kmz buffer is not detected as kmz")
+	}
+
+	kml, _ := testhelper.GetValidFileBuffer("kml", "01.kml")
+	if isKmzBuffer(kml) != false {
+		t.Errorf("The kml buffer is detected as kmz")
+	}
+
+	if isKmzBuffer([]byte("PK")) != false {
+		t.Errorf("A buffer that is to short is detected as kmz")
+	}
+}
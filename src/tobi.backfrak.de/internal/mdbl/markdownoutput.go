@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"os"
 	"runtime"
-	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -40,9 +39,13 @@ type MDOutputFormater struct {
 	writtenEntiresCount int
 	entriesToWriteCount int
 	lineBuffer          []gpsabl.OutputLine
+	waypointBuffer      []gpsabl.Waypoint
 	mux                 sync.Mutex
 	TrackListText       string
 	SummaryText         string
+	WaypointListText    string
+	AddWaypoints        bool
+	Sorting             gpsabl.OutputSorting
 }
 
 // NewMDOutputFormater - Get a new MDOutputFormater
@@ -55,6 +58,8 @@ func NewMDOutputFormater() *MDOutputFormater {
 	ret.Separator = "|"
 	ret.TrackListText = "List of Tracks:"
 	ret.SummaryText = "Summary table:"
+	ret.WaypointListText = "List of Waypoints:"
+	ret.waypointBuffer = []gpsabl.Waypoint{}
 
 	return &ret
 }
@@ -80,6 +85,16 @@ func (formater *MDOutputFormater) SetTimeFormat(timeFormat string) error {
 	return nil
 }
 
+// SetAddWaypoints - Set the value of formater.AddWaypoints
+func (formater *MDOutputFormater) SetAddWaypoints(value bool) {
+	formater.AddWaypoints = value
+}
+
+// SetSorting - Set the value of formater.Sorting
+func (formater *MDOutputFormater) SetSorting(sorting gpsabl.OutputSorting) {
+	formater.Sorting = sorting
+}
+
 // GetTextOutputFormater - Get the gpsabl.TextOutputFormater of ths formater
 func (formater *MDOutputFormater) GetTextOutputFormater() gpsabl.TextOutputFormater {
 	return nil
@@ -108,6 +123,12 @@ func (formater *MDOutputFormater) AddOutPut(trackFile gpsabl.TrackFile, depth gp
 		lines = linesFromFile
 	}
 
+	if formater.AddWaypoints && len(trackFile.Waypoints) > 0 {
+		formater.mux.Lock()
+		formater.waypointBuffer = append(formater.waypointBuffer, trackFile.Waypoints...)
+		formater.mux.Unlock()
+	}
+
 	if len(lines) > 0 {
 		formater.mux.Lock()
 		defer formater.mux.Unlock()
@@ -178,9 +199,7 @@ func (formater *MDOutputFormater) GetLines() []string {
 
 	formater.mux.Lock()
 	defer formater.mux.Unlock()
-	sort.Slice(formater.lineBuffer, func(i, j int) bool {
-		return formater.lineBuffer[i].Data.GetStartTime().Before(formater.lineBuffer[j].Data.GetStartTime())
-	})
+	gpsabl.SortOutputLines(formater.lineBuffer, formater.Sorting)
 	for _, line := range formater.lineBuffer {
 		ret = append(ret, formater.FormatTrackSummary(line.Data, line.Name))
 	}
@@ -236,6 +255,9 @@ func (formater *MDOutputFormater) GetOutputLines(summary gpsabl.SummaryArg) ([]s
 	if formater.entriesToWriteCount > 0 {
 		outputLines = append(outputLines, headerLines...)
 		outputLines = append(outputLines, contentLines...)
+		if summary != gpsabl.ONLY {
+			outputLines = append(outputLines, formater.GetWaypointLines()...)
+		}
 		if summary == gpsabl.ADDITIONAL {
 			outputLines = append(outputLines, GetNewLine())
 			outputLines = append(outputLines, fmt.Sprintf("%s%s", formater.SummaryText, GetNewLine()))
@@ -249,6 +271,58 @@ func (formater *MDOutputFormater) GetOutputLines(summary gpsabl.SummaryArg) ([]s
 	return contentLines, nil
 }
 
+// GetWaypointLines - Get the waypoint table that is written after the track table. Empty if formater.AddWaypoints is false or no waypoint was added
+func (formater *MDOutputFormater) GetWaypointLines() []string {
+	ret := []string{}
+	formater.mux.Lock()
+	defer formater.mux.Unlock()
+	if !formater.AddWaypoints || len(formater.waypointBuffer) == 0 {
+		return ret
+	}
+
+	header := formater.Separator
+	headerContentSeparator := formater.Separator
+	for _, name := range gpsabl.GetWaypointHeaders() {
+		header = fmt.Sprintf("%s %s %s", header, name, formater.Separator)
+		headerContentSeparator = fmt.Sprintf("%s %s %s", headerContentSeparator, " :----: ", formater.Separator)
+	}
+
+	ret = append(ret, GetNewLine())
+	ret = append(ret, fmt.Sprintf("%s%s", formater.WaypointListText, GetNewLine()))
+	ret = append(ret, GetNewLine())
+	ret = append(ret, fmt.Sprintf("%s%s", header, GetNewLine()))
+	ret = append(ret, fmt.Sprintf("%s%s", headerContentSeparator, GetNewLine()))
+	for _, wpt := range gpsabl.GetSortedWaypoints(formater.waypointBuffer) {
+		ret = append(ret, formater.FormatWaypoint(wpt))
+	}
+
+	return ret
+}
+
+// FormatWaypoint - Create the table line for a gpsabl.Waypoint
+func (formater *MDOutputFormater) FormatWaypoint(wpt gpsabl.Waypoint) string {
+	trackName := NotValidValue
+	distanceAlongTrack := NotValidValue
+	distanceFromTrack := NotValidValue
+	if wpt.TrackValuesValid {
+		trackName = wpt.TrackName
+		distanceAlongTrack = fmt.Sprintf("%.2f", gpsabl.RoundFloat64To2Digits(wpt.DistanceAlongTrack/1000))
+		distanceFromTrack = fmt.Sprintf("%.2f", gpsabl.RoundFloat64To2Digits(wpt.DistanceFromTrack))
+	}
+
+	return fmt.Sprintf("%s %s %s %s %s %s %s %s %s %f %s %f %s %.2f %s%s",
+		formater.Separator,
+		wpt.Name, formater.Separator,
+		trackName, formater.Separator,
+		distanceAlongTrack, formater.Separator,
+		distanceFromTrack, formater.Separator,
+		wpt.Latitude, formater.Separator,
+		wpt.Longitude, formater.Separator,
+		gpsabl.RoundFloat64To2Digits(float64(wpt.Elevation)), formater.Separator,
+		GetNewLine(),
+	)
+}
+
 // getOutPutEntries - Add the output of a TrackFile
 func (formater *MDOutputFormater) getOutPutEntries(trackFile gpsabl.TrackFile, depth gpsabl.DepthArg) ([]gpsabl.OutputLine, error) {
 
@@ -261,7 +335,7 @@ func (formater *MDOutputFormater) GetHeader() string {
 	movingTimeHeader, _ := formater.getTimeDurationHeader("MovingTime")
 	upwardsTimeHeader, _ := formater.getTimeDurationHeader("UpwardsTime")
 	downwardsTimeHeader, _ := formater.getTimeDurationHeader("DownwardsTime")
-	ret := fmt.Sprintf("%s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s%s",
+	ret := fmt.Sprintf("%s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s%s%s",
 		formater.Separator,
 		"Name", formater.Separator,
 		"StartTime", formater.Separator,
@@ -282,6 +356,8 @@ func (formater *MDOutputFormater) GetHeader() string {
 		"AverageSpeed (km/h)", formater.Separator,
 		"UpwardsSpeed (km/h)", formater.Separator,
 		"DownwardsSpeed (km/h)", formater.Separator,
+		formater.getSensorHeader(),
+		formater.getActivityHeader(),
 		GetNewLine(),
 	)
 
@@ -289,7 +365,7 @@ func (formater *MDOutputFormater) GetHeader() string {
 }
 
 func (formater *MDOutputFormater) GetHeaderContentSeparator() string {
-	ret := fmt.Sprintf("%s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s%s",
+	ret := fmt.Sprintf("%s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s %s%s%s",
 		formater.Separator,
 		" :----: ", formater.Separator,
 		" :----: ", formater.Separator,
@@ -310,6 +386,8 @@ func (formater *MDOutputFormater) GetHeaderContentSeparator() string {
 		" :----: ", formater.Separator,
 		" :----: ", formater.Separator,
 		" :----: ", formater.Separator,
+		formater.getSensorHeaderContentSeparator(),
+		formater.getActivityHeaderContentSeparator(),
 		GetNewLine(),
 	)
 
@@ -324,7 +402,7 @@ func (formater *MDOutputFormater) FormatTrackSummary(info gpsabl.TrackSummaryPro
 		moveTime, _ := formater.formatTimeDuration(info.GetMovingTime())
 		upTime, _ := formater.formatTimeDuration(info.GetUpwardsTime())
 		downTime, _ := formater.formatTimeDuration(info.GetDownwardsTime())
-		ret = fmt.Sprintf("%s %s %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s%s",
+		ret = fmt.Sprintf("%s %s %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s %s%s%s",
 			formater.Separator,
 			name, formater.Separator,
 			info.GetStartTime().Format(string(formater.timeFormater)), formater.Separator,
@@ -345,10 +423,12 @@ func (formater *MDOutputFormater) FormatTrackSummary(info gpsabl.TrackSummaryPro
 			gpsabl.RoundFloat64To2Digits(info.GetAvarageSpeed()*3.6), formater.Separator,
 			gpsabl.RoundFloat64To2Digits(info.GetUpwardsSpeed()*3.6), formater.Separator,
 			gpsabl.RoundFloat64To2Digits(info.GetDownwardsSpeed()*3.6), formater.Separator,
+			formater.formatSensorSummary(info.GetSensorSummary()),
+			formater.formatActivity(info.GetActivityType(), info.GetGear()),
 			GetNewLine(),
 		)
 	} else {
-		ret = fmt.Sprintf("%s %s %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %s %s %s %s %s %s %s %s %s %s %s %s%s",
+		ret = fmt.Sprintf("%s %s %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %s %s %s %s %s %s %s %s %s %s %s %s %s%s%s",
 			formater.Separator,
 			name, formater.Separator,
 			NotValidValue, formater.Separator,
@@ -369,6 +449,8 @@ func (formater *MDOutputFormater) FormatTrackSummary(info gpsabl.TrackSummaryPro
 			NotValidValue, formater.Separator,
 			NotValidValue, formater.Separator,
 			NotValidValue, formater.Separator,
+			formater.formatSensorSummary(info.GetSensorSummary()),
+			formater.formatActivity(info.GetActivityType(), info.GetGear()),
 			GetNewLine(),
 		)
 	}
@@ -383,7 +465,7 @@ func (formater *MDOutputFormater) formatMinMaxSummary(info gpsabl.ExtendedTrackS
 		moveTime, _ := formater.formatTimeDuration(info.MovingTime)
 		upTime, _ := formater.formatTimeDuration(info.UpwardsTime)
 		downTime, _ := formater.formatTimeDuration(info.DownwardsTime)
-		ret = fmt.Sprintf("%s %s %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s%s",
+		ret = fmt.Sprintf("%s %s %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s %s%s%s",
 			formater.Separator,
 			fmt.Sprintf("**%s**", name), formater.Separator,
 			info.StartTime.Format(string(formater.timeFormater)), formater.Separator,
@@ -404,10 +486,12 @@ func (formater *MDOutputFormater) formatMinMaxSummary(info gpsabl.ExtendedTrackS
 			gpsabl.RoundFloat64To2Digits(info.AverageSpeed*3.6), formater.Separator,
 			gpsabl.RoundFloat64To2Digits(info.UpwardsSpeed*3.6), formater.Separator,
 			gpsabl.RoundFloat64To2Digits(info.DownwardsSpeed*3.6), formater.Separator,
+			formater.formatSensorSummary(info.SensorSummary),
+			formater.formatActivity("", ""),
 			GetNewLine(),
 		)
 	} else {
-		ret = fmt.Sprintf("%s %s %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %s %s %s %s %s %s %s %s %s %s %s %s%s",
+		ret = fmt.Sprintf("%s %s %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %.2f %s %s %s %s %s %s %s %s %s %s %s %s %s %s%s%s",
 			formater.Separator,
 			fmt.Sprintf("**%s**", name), formater.Separator,
 			NotValidValue, formater.Separator,
@@ -428,6 +512,8 @@ func (formater *MDOutputFormater) formatMinMaxSummary(info gpsabl.ExtendedTrackS
 			NotValidValue, formater.Separator,
 			NotValidValue, formater.Separator,
 			NotValidValue, formater.Separator,
+			formater.formatSensorSummary(info.SensorSummary),
+			formater.formatActivity("", ""),
 			GetNewLine(),
 		)
 	}
@@ -443,7 +529,7 @@ func (formater *MDOutputFormater) formatAverageSummary(info gpsabl.ExtendedTrack
 		moveTime, _ := formater.formatTimeDuration(info.MovingTime)
 		upTime, _ := formater.formatTimeDuration(info.UpwardsTime)
 		downTime, _ := formater.formatTimeDuration(info.DownwardsTime)
-		ret = fmt.Sprintf("%s %s %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s %s %s %s %s %.2f %s %.2f %s %.2f %s %.2f %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s%s",
+		ret = fmt.Sprintf("%s %s %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s %s %s %s %s %.2f %s %.2f %s %.2f %s %.2f %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s %s%s%s",
 			formater.Separator,
 			"**Average**:", formater.Separator,
 			"-", formater.Separator,
@@ -464,10 +550,12 @@ func (formater *MDOutputFormater) formatAverageSummary(info gpsabl.ExtendedTrack
 			gpsabl.RoundFloat64To2Digits(info.AverageSpeed*3.6), formater.Separator,
 			gpsabl.RoundFloat64To2Digits(info.UpwardsSpeed*3.6), formater.Separator,
 			gpsabl.RoundFloat64To2Digits(info.DownwardsSpeed*3.6), formater.Separator,
+			formater.formatSensorSummary(info.SensorSummary),
+			formater.formatActivity("", ""),
 			GetNewLine(),
 		)
 	} else {
-		ret = fmt.Sprintf("%s %s %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s %s %s %s %s %.2f %s %.2f %s %.2f %s %.2f %s %s %s %s %s %s %s %s %s %s %s %s %s%s",
+		ret = fmt.Sprintf("%s %s %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s %s %s %s %s %.2f %s %.2f %s %.2f %s %.2f %s %s %s %s %s %s %s %s %s %s %s %s %s %s%s%s",
 			formater.Separator,
 			"**Average:**", formater.Separator,
 			NotValidValue, formater.Separator,
@@ -488,6 +576,8 @@ func (formater *MDOutputFormater) formatAverageSummary(info gpsabl.ExtendedTrack
 			NotValidValue, formater.Separator,
 			NotValidValue, formater.Separator,
 			NotValidValue, formater.Separator,
+			formater.formatSensorSummary(info.SensorSummary),
+			formater.formatActivity("", ""),
 			GetNewLine(),
 		)
 	}
@@ -502,7 +592,7 @@ func (formater *MDOutputFormater) formatSumSummary(info gpsabl.ExtendedTrackSumm
 		moveTime, _ := formater.formatTimeDuration(info.MovingTime)
 		upTime, _ := formater.formatTimeDuration(info.UpwardsTime)
 		downTime, _ := formater.formatTimeDuration(info.DownwardsTime)
-		ret = fmt.Sprintf("%s %s %s %s %s %s %s %s %s %.2f %s %.2f %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s %.2f %s %s %s %s %s %s %s %s %s %s %s %s %s%s",
+		ret = fmt.Sprintf("%s %s %s %s %s %s %s %s %s %.2f %s %.2f %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s %.2f %s %s %s %s %s %s %s %s %s %s %s %s %s %s%s%s",
 			formater.Separator,
 			"**Sum:**", formater.Separator,
 			"-", formater.Separator,
@@ -523,10 +613,12 @@ func (formater *MDOutputFormater) formatSumSummary(info gpsabl.ExtendedTrackSumm
 			"-", formater.Separator,
 			"-", formater.Separator,
 			"-", formater.Separator,
+			formater.formatSensorSummary(gpsabl.SensorSummary{}),
+			formater.formatActivity("", ""),
 			GetNewLine(),
 		)
 	} else {
-		ret = fmt.Sprintf("%s %s %s %s %s %s %s %s %s %.2f %s %.2f %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s %.2f %s %s %s %s %s %s %s %s %s %s %s %s %s%s",
+		ret = fmt.Sprintf("%s %s %s %s %s %s %s %s %s %.2f %s %.2f %s %s %s %s %s %s %s %.2f %s %.2f %s %.2f %s %.2f %s %s %s %s %s %s %s %s %s %s %s %s %s %s%s%s",
 			formater.Separator,
 			"**Sum:**", formater.Separator,
 			NotValidValue, formater.Separator,
@@ -547,6 +639,8 @@ func (formater *MDOutputFormater) formatSumSummary(info gpsabl.ExtendedTrackSumm
 			NotValidValue, formater.Separator,
 			NotValidValue, formater.Separator,
 			NotValidValue, formater.Separator,
+			formater.formatSensorSummary(gpsabl.SensorSummary{}),
+			formater.formatActivity("", ""),
 			GetNewLine(),
 		)
 	}
@@ -554,6 +648,76 @@ func (formater *MDOutputFormater) formatSumSummary(info gpsabl.ExtendedTrackSumm
 	return ret
 }
 
+// getSensorHeader - Get the header columns of the sensor values
+func (formater *MDOutputFormater) getSensorHeader() string {
+	ret := ""
+	for _, header := range gpsabl.GetSensorHeaders() {
+		ret = fmt.Sprintf("%s%s %s ", ret, header, formater.Separator)
+	}
+
+	return strings.TrimSuffix(ret, " ")
+}
+
+// getSensorHeaderContentSeparator - Get the header content separator columns of the sensor values
+func (formater *MDOutputFormater) getSensorHeaderContentSeparator() string {
+	ret := ""
+	for range gpsabl.GetSensorHeaders() {
+		ret = fmt.Sprintf("%s%s %s ", ret, " :----: ", formater.Separator)
+	}
+
+	return strings.TrimSuffix(ret, " ")
+}
+
+// formatSensorSummary - Get the columns of the sensor values. Values without samples are written as "-"
+func (formater *MDOutputFormater) formatSensorSummary(sensor gpsabl.SensorSummary) string {
+	ret := ""
+	for _, values := range []gpsabl.SensorValues{sensor.HeartRate, sensor.Cadence, sensor.Power} {
+		if values.Valid() {
+			ret = fmt.Sprintf("%s%.2f %s %.2f %s %.2f %s ", ret,
+				gpsabl.RoundFloat64To2Digits(values.Minimum), formater.Separator,
+				gpsabl.RoundFloat64To2Digits(values.Average), formater.Separator,
+				gpsabl.RoundFloat64To2Digits(values.Maximum), formater.Separator)
+		} else {
+			ret = fmt.Sprintf("%s%s %s %s %s %s %s ", ret, "-", formater.Separator, "-", formater.Separator, "-", formater.Separator)
+		}
+	}
+
+	return strings.TrimSuffix(ret, " ")
+}
+
+// getActivityHeader - Get the header columns of the activity values
+func (formater *MDOutputFormater) getActivityHeader() string {
+	ret := ""
+	for _, header := range gpsabl.GetActivityHeaders() {
+		ret = fmt.Sprintf("%s %s %s", ret, header, formater.Separator)
+	}
+
+	return ret
+}
+
+// getActivityHeaderContentSeparator - Get the header content separator columns of the activity values
+func (formater *MDOutputFormater) getActivityHeaderContentSeparator() string {
+	ret := ""
+	for range gpsabl.GetActivityHeaders() {
+		ret = fmt.Sprintf("%s %s %s", ret, " :----: ", formater.Separator)
+	}
+
+	return ret
+}
+
+// formatActivity - Get the columns of the activity values. Unknown values are written as "-"
+func (formater *MDOutputFormater) formatActivity(activityType string, gear string) string {
+	ret := ""
+	for _, value := range []string{activityType, gear} {
+		if value == "" {
+			value = "-"
+		}
+		ret = fmt.Sprintf("%s %s %s", ret, value, formater.Separator)
+	}
+
+	return ret
+}
+
 // GetNewLine - Get the new line string depending on the OS
 func GetNewLine() string {
 	if runtime.GOOS == "windows" {
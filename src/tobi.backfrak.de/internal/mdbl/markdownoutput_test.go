@@ -15,7 +15,7 @@ import (
 // by a BSD-style license that can be found in the
 // LICENSE file.
 
-const numberOfPipeExpected = 20
+const numberOfPipeExpected = 31
 const numberOfNotValideExpected = 9
 
 func TestTextOutputFormater(t *testing.T) {
@@ -554,44 +554,44 @@ func TestAddOutPutMixedTimeAndNoTime(t *testing.T) {
 		t.Errorf("The number of lines was not expected. Got %d, expected %d", len(lines), 2)
 	}
 
-	if strings.Count(lines[1], "|") != numberOfPipeExpected {
-		t.Errorf("The Number of semicolons in the line is %d but %d was expected", strings.Count(lines[1], "|"), numberOfPipeExpected)
+	if strings.Count(lines[0], "|") != numberOfPipeExpected {
+		t.Errorf("The Number of semicolons in the line is %d but %d was expected", strings.Count(lines[0], "|"), numberOfPipeExpected)
 	}
 
-	if strings.Count(lines[0], "0.02 |") != 2 {
-		t.Errorf("The output does not contain the distance as expected. It is: %s", lines[0])
+	if strings.Count(lines[1], "0.02 |") != 2 {
+		t.Errorf("The output does not contain the distance as expected. It is: %s", lines[1])
 	}
 
-	if strings.Count(lines[0], "1.00 |") != 3 {
-		t.Errorf("The output does not contain the ElevationGain as expected. It is: %s", lines[0])
+	if strings.Count(lines[1], "1.00 |") != 3 {
+		t.Errorf("The output does not contain the ElevationGain as expected. It is: %s", lines[1])
 	}
 
-	if strings.Count(lines[0], "0.01 |") != 2 {
-		t.Errorf("The output does not contain the UpwardsDistance as expected. It is: %s", lines[0])
+	if strings.Count(lines[1], "0.01 |") != 2 {
+		t.Errorf("The output does not contain the UpwardsDistance as expected. It is: %s", lines[1])
 	}
 
-	if strings.Count(lines[1], "2014-08-22T17:19:33Z |") != 1 {
-		t.Errorf("The output does not contain the StartTime as expected. It is: %s", lines[1])
+	if strings.Count(lines[0], "2014-08-22T17:19:33Z |") != 1 {
+		t.Errorf("The output does not contain the StartTime as expected. It is: %s", lines[0])
 	}
 
-	if strings.Count(lines[1], "2014-08-22T17:19:53Z |") != 1 {
-		t.Errorf("The output does not contain the EndTime as expected. It is: %s", lines[1])
+	if strings.Count(lines[0], "2014-08-22T17:19:53Z |") != 1 {
+		t.Errorf("The output does not contain the EndTime as expected. It is: %s", lines[0])
 	}
 
-	if strings.Count(lines[1], "20s |") != 2 {
-		t.Errorf("The output does not contain the MovingTime as expected. It is: %s", lines[1])
+	if strings.Count(lines[0], "20s |") != 2 {
+		t.Errorf("The output does not contain the MovingTime as expected. It is: %s", lines[0])
 	}
 
-	if strings.Count(lines[1], "4.30 |") != 3 {
-		t.Errorf("The output does not contain the AvarageSpeed as expected. It is: %s", lines[1])
+	if strings.Count(lines[0], "4.30 |") != 3 {
+		t.Errorf("The output does not contain the AvarageSpeed as expected. It is: %s", lines[0])
 	}
 
-	if strings.Count(lines[0], "not valid |") != numberOfNotValideExpected {
-		t.Errorf("The output does not contain the Time values as often as expected. Found it %d times in: %s", strings.Count(lines[0], "not valid |"), lines[0])
+	if strings.Count(lines[1], "not valid |") != numberOfNotValideExpected {
+		t.Errorf("The output does not contain the Time values as often as expected. Found it %d times in: %s", strings.Count(lines[1], "not valid |"), lines[1])
 	}
 
-	if strings.Count(lines[1], "10s") != 2 {
-		t.Errorf("The output does not contain the Time values as often as expected. Found it %d times in: %s", strings.Count(lines[1], "10s |"), lines[1])
+	if strings.Count(lines[0], "10s") != 2 {
+		t.Errorf("The output does not contain the Time values as often as expected. Found it %d times in: %s", strings.Count(lines[0], "10s |"), lines[0])
 	}
 }
 
@@ -682,6 +682,7 @@ func TestOutputIsSorted(t *testing.T) {
 	if err != nil {
 		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
 	}
+	frt.SetSorting(gpsabl.OutputSorting{Column: gpsabl.STARTTIME, Order: gpsabl.ASCENDING})
 	lines := frt.GetLines()
 
 	slpitLineOne := strings.Split(lines[0], "|")
@@ -695,6 +696,55 @@ func TestOutputIsSorted(t *testing.T) {
 
 }
 
+func TestOutputKeepsInputOrder(t *testing.T) {
+	frt := NewMDOutputFormater()
+	trackFile1 := getTrackFileWithDifferentTime()
+	err := frt.AddOutPut(trackFile1, "file", false)
+	if err != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+	trackFile2 := getSimpleTrackFileWithTime()
+	err = frt.AddOutPut(trackFile2, "file", false)
+	if err != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+	lines := frt.GetLines()
+
+	slpitLineOne := strings.Split(lines[0], "|")
+	slpitLineTwo := strings.Split(lines[1], "|")
+	if strings.TrimSpace(slpitLineOne[2]) != "2015-08-22T17:19:33Z" {
+		t.Errorf("The lines are not in the right order")
+	}
+	if strings.TrimSpace(slpitLineTwo[2]) != "2014-08-22T17:19:33Z" {
+		t.Errorf("The lines are not in the right order")
+	}
+}
+
+func TestOutputIsSortedDescending(t *testing.T) {
+	frt := NewMDOutputFormater()
+	trackFile1 := getSimpleTrackFileWithTime()
+	err := frt.AddOutPut(trackFile1, "file", false)
+	if err != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+	trackFile2 := getTrackFileWithDifferentTime()
+	err = frt.AddOutPut(trackFile2, "file", false)
+	if err != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+	frt.SetSorting(gpsabl.OutputSorting{Column: gpsabl.STARTTIME, Order: gpsabl.DESCENDING})
+	lines := frt.GetLines()
+
+	slpitLineOne := strings.Split(lines[0], "|")
+	slpitLineTwo := strings.Split(lines[1], "|")
+	if strings.TrimSpace(slpitLineOne[2]) != "2015-08-22T17:19:33Z" {
+		t.Errorf("The lines are not in the right order")
+	}
+	if strings.TrimSpace(slpitLineTwo[2]) != "2014-08-22T17:19:33Z" {
+		t.Errorf("The lines are not in the right order")
+	}
+}
+
 func TestGetStatisticSummaryLinesWithTime(t *testing.T) {
 	frt := NewMDOutputFormater()
 	trackFile1 := getTrackFileWithDifferentTime()
@@ -775,6 +825,40 @@ func TestGetOutputLinesSummaryNone(t *testing.T) {
 	}
 }
 
+func TestGetOutputLinesWithWaypoints(t *testing.T) {
+	frt := NewMDOutputFormater()
+	trackFile := getSimpleTrackFileWithTime()
+	trackFile.Waypoints = []gpsabl.Waypoint{
+		{Name: "Top", Elevation: 108.0, TrackValuesValid: true, TrackName: "My Track", DistanceAlongTrack: 1234.5, DistanceFromTrack: 3.5},
+	}
+
+	frt.SetAddWaypoints(true)
+	err := frt.AddOutPut(trackFile, "file", false)
+	if err != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", err.Error())
+	}
+
+	lines, errOut := frt.GetOutputLines("none")
+	if errOut != nil {
+		t.Errorf("Got an error but did not expect one. The error is: %s", errOut.Error())
+	}
+	if len(lines) != 9 {
+		t.Fatalf("Got %d lines, but expected %d", len(lines), 9)
+	}
+
+	if lines[4] != frt.WaypointListText+GetNewLine() {
+		t.Errorf("The waypoint list text line is \"%s\"", lines[4])
+	}
+
+	if strings.Count(lines[6], "|") != 8 || strings.Count(lines[7], "|") != 8 {
+		t.Errorf("The waypoint table header is not as expected: \"%s\" \"%s\"", lines[6], lines[7])
+	}
+
+	if lines[8] != "| Top | My Track | 1.23 | 3.50 | 0.000000 | 0.000000 | 108.00 |"+GetNewLine() {
+		t.Errorf("The waypoint line is \"%s\"", lines[8])
+	}
+}
+
 func TestGetOutputLinesSummaryOnly(t *testing.T) {
 	frt := NewMDOutputFormater()
 	trackFile1 := getTrackFileWithDifferentTime()
@@ -1422,3 +1506,50 @@ func getTrackFileWithTimeGaps() gpsabl.TrackFile {
 
 	return file
 }
+
+func TestFormatOutPutWithSensorData(t *testing.T) {
+	formater := NewMDOutputFormater()
+	trackFile := getSimpleTrackFile()
+	trackFile.Power = gpsabl.SensorValues{Minimum: 180, Average: 210.25, Maximum: 250, Samples: 3}
+
+	err := formater.AddOutPut(trackFile, "file", false)
+	if err != nil {
+		t.Errorf("Got a error but did not expect one. The error is: %s", err.Error())
+	}
+
+	header := formater.GetHeader()
+	if strings.Contains(header, "| AveragePower (W) |") == false {
+		t.Errorf("The header does not contain the power as expected. It is: %s", header)
+	}
+
+	if strings.Count(formater.GetHeaderContentSeparator(), "|") != numberOfPipeExpected {
+		t.Errorf("The header content separator has %d pipes, but expected %d", strings.Count(formater.GetHeaderContentSeparator(), "|"), numberOfPipeExpected)
+	}
+
+	lines := formater.GetLines()
+	if strings.HasSuffix(lines[0], "| - | - | - | - | - | - | 180.00 | 210.25 | 250.00 | - | - |"+GetNewLine()) == false {
+		t.Errorf("The output does not contain the sensor values as expected. It is: %s", lines[0])
+	}
+}
+
+func TestFormatOutPutWithActivity(t *testing.T) {
+	formater := NewMDOutputFormater()
+	trackFile := getSimpleTrackFile()
+	trackFile.ActivityType = "Ride"
+	trackFile.Gear = "My Bike"
+
+	err := formater.AddOutPut(trackFile, "file", false)
+	if err != nil {
+		t.Errorf("Got a error but did not expect one. The error is: %s", err.Error())
+	}
+
+	header := formater.GetHeader()
+	if strings.HasSuffix(header, "| ActivityType | Gear |"+GetNewLine()) == false {
+		t.Errorf("The header does not contain the activity columns as expected. It is: %s", header)
+	}
+
+	lines := formater.GetLines()
+	if strings.HasSuffix(lines[0], "| Ride | My Bike |"+GetNewLine()) == false {
+		t.Errorf("The output does not contain the activity values as expected. It is: %s", lines[0])
+	}
+}
@@ -0,0 +1,39 @@
+package nmeabl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import "fmt"
+
+// NmeaFileError - Error when trying to load something that does not contain valid NMEA 0183 sentences
+type NmeaFileError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+}
+
+func (e *NmeaFileError) Error() string { // Implement the Error Interface for the NmeaFileError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newNmeaFileError - Get a new NmeaFileError struct
+func newNmeaFileError(fileName string) *NmeaFileError {
+	return &NmeaFileError{fmt.Sprintf("The file \"%s\" is not a NMEA 0183 log file", fileName), fileName}
+}
+
+// EmptyNmeaFileError - Error when trying to load a NMEA log that does not contain any valid position fix
+type EmptyNmeaFileError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+}
+
+func (e *EmptyNmeaFileError) Error() string { // Implement the Error Interface for the EmptyNmeaFileError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newEmptyNmeaFileError - Get a new EmptyNmeaFileError struct
+func newEmptyNmeaFileError(fileName string) *EmptyNmeaFileError {
+	return &EmptyNmeaFileError{fmt.Sprintf("The file \"%s\" does not contain any valid position fix.", fileName), fileName}
+}
@@ -0,0 +1,35 @@
+package nmeabl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"strings"
+	"testing"
+)
+
+func TestNmeaFileErrorStruct(t *testing.T) {
+
+	path := "/some/sample/path"
+	err := newNmeaFileError(path)
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of NmeaFileError does not contain the expected Path")
+	}
+
+	if err.File != path {
+		t.Errorf("The NmeaFileError.File does not match the expected value")
+	}
+}
+
+func TestEmptyNmeaFileError(t *testing.T) {
+	path := "/some/sample/path"
+	err := newEmptyNmeaFileError(path)
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of EmptyNmeaFileError does not contain the expected Path")
+	}
+
+	if err.File != path {
+		t.Errorf("The EmptyNmeaFileError.File does not match the expected value")
+	}
+}
@@ -0,0 +1,104 @@
+package nmeabl
+
+import (
+	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+// ConvertNmea - Convert a nmeabl.Nmea to a gpsabl.TrackFile with one gpsabl.Track. A new gpsabl.TrackSegment is started
+// after a fix loss, or when the time between two fixes is longer than maximalTimeGap
+func ConvertNmea(nmea Nmea, filePath string, maximalTimeGap time.Duration, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+
+	res := gpsabl.NewTrackFile(filePath)
+	track := gpsabl.Track{}
+
+	for _, fixes := range splitFixes(nmea.Fixes, maximalTimeGap) {
+		seg, err := convertFixes(fixes, correction, minimalMovingSpeed, minimalStepHight)
+		if err != nil {
+			return gpsabl.TrackFile{}, err
+		}
+		track.TrackSegments = append(track.TrackSegments, seg)
+	}
+
+	if len(track.TrackSegments) <= 0 {
+		return gpsabl.TrackFile{}, newEmptyNmeaFileError(filePath)
+	}
+
+	track.NumberOfSegments = len(track.TrackSegments)
+	gpsabl.FillTrackValues(&track)
+
+	res.Tracks = []gpsabl.Track{track}
+	res.NumberOfTracks = len(res.Tracks)
+	gpsabl.FillTrackFileValues(&res)
+
+	return res, nil
+}
+
+// splitFixes - Split the fixes into segments. Invalid fixes end a segment and are dropped
+func splitFixes(fixes []Fix, maximalTimeGap time.Duration) [][]Fix {
+	var ret [][]Fix
+	var current []Fix
+
+	for _, fix := range fixes {
+		if !fix.Valid {
+			if len(current) > 0 {
+				ret = append(ret, current)
+				current = nil
+			}
+			continue
+		}
+
+		if len(current) > 0 {
+			last := current[len(current)-1]
+			if last.TimeValid && fix.TimeValid && fix.Time.Sub(last.Time) > maximalTimeGap {
+				ret = append(ret, current)
+				current = nil
+			}
+		}
+		current = append(current, fix)
+	}
+
+	if len(current) > 0 {
+		ret = append(ret, current)
+	}
+
+	return ret
+}
+
+func convertFixes(fixes []Fix, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackSegment, error) {
+	res := gpsabl.TrackSegment{}
+	pointCount := len(fixes)
+	basic := make([]gpsabl.TrackPoint, pointCount)
+	for i, fix := range fixes {
+		basic[i] = convertBasicPointValues(fix)
+	}
+
+	ret := gpsabl.FillDistances(basic)
+
+	err := gpsabl.FillValuesTrackPointArray(ret, correction, minimalMovingSpeed, minimalStepHight)
+	if err != nil {
+		return gpsabl.TrackSegment{}, err
+	}
+
+	res.TrackPoints = ret
+	gpsabl.FillTrackSegmentValues(&res)
+
+	return res, nil
+}
+
+func convertBasicPointValues(fix Fix) gpsabl.TrackPoint {
+	pnt := gpsabl.TrackPoint{}
+	pnt.Latitude = float32(fix.Latitude)
+	pnt.Longitude = float32(fix.Longitude)
+	pnt.Elevation = fix.Altitude
+	pnt.TimeValid = fix.TimeValid
+	pnt.Time = fix.Time
+
+	return pnt
+}
@@ -0,0 +1,105 @@
+package nmeabl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func TestConvertNmeaTimeGap(t *testing.T) {
+	nmea, err := ReadNmea(testhelper.GetValidFile("nmea", "01.nmea"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	file, convErr := ConvertNmea(nmea, "my/path.nmea", time.Hour, gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	// The file was created out of testdata/valid-gpx/02.gpx, where the segments are more then an hour apart
+	if file.Tracks[0].NumberOfSegments != 2 {
+		t.Errorf("The NumberOfSegments is %d, but should be %d", file.Tracks[0].NumberOfSegments, 2)
+	}
+
+	if gpsabl.RoundFloat64To2Digits(file.Distance) != 37823.34 {
+		t.Errorf("The Distance is %f, but should be %f", file.Distance, 37823.34)
+	}
+
+	if file.MovingTime != 5600*time.Second {
+		t.Errorf("The MovingTime is %s, but should be %s", file.MovingTime, 5600*time.Second)
+	}
+
+	// With the default gap, the pause inside the first segment starts a new segment as well
+	file, convErr = ConvertNmea(nmea, "my/path.nmea", DefaultMaximalTimeGap, gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	if file.Tracks[0].NumberOfSegments != 3 {
+		t.Errorf("The NumberOfSegments is %d, but should be %d", file.Tracks[0].NumberOfSegments, 3)
+	}
+}
+
+func TestConvertNmeaFixLoss(t *testing.T) {
+	nmea, err := ReadNmea(testhelper.GetValidFile("nmea", "02.nmea"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	file, convErr := ConvertNmea(nmea, "my/path.nmea", DefaultMaximalTimeGap, gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	if file.Tracks[0].NumberOfSegments != 2 {
+		t.Fatalf("The NumberOfSegments is %d, but should be %d", file.Tracks[0].NumberOfSegments, 2)
+	}
+
+	if len(file.Tracks[0].TrackSegments[0].TrackPoints) != 100 || len(file.Tracks[0].TrackSegments[1].TrackPoints) != 235 {
+		t.Errorf("The segments contain %d and %d points, but should contain %d and %d", len(file.Tracks[0].TrackSegments[0].TrackPoints), len(file.Tracks[0].TrackSegments[1].TrackPoints), 100, 235)
+	}
+
+	if file.StartTime.Format(time.RFC3339) != "2016-06-05T10:45:59Z" {
+		t.Errorf("The StartTime is %s, but should be %s", file.StartTime.Format(time.RFC3339), "2016-06-05T10:45:59Z")
+	}
+}
+
+func TestConvertNmeaWithoutFix(t *testing.T) {
+	nmea, err := ReadNmea(testhelper.GetInvalidFile("nmea", "01.nmea"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	_, convErr := ConvertNmea(nmea, "my/path.nmea", DefaultMaximalTimeGap, gpsabl.NO, 0.3, 10.0)
+	switch convErr.(type) {
+	case *EmptyNmeaFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *EmptyNmeaFileError, got \"%v\"", convErr)
+	}
+}
+
+func TestSplitFixes(t *testing.T) {
+	start := time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC)
+	fixes := []Fix{
+		Fix{Time: start, TimeValid: true, Valid: true},
+		Fix{Time: start.Add(time.Second), TimeValid: true, Valid: true},
+		Fix{Time: start.Add(2 * time.Second), TimeValid: true, Valid: false},
+		Fix{Time: start.Add(3 * time.Second), TimeValid: true, Valid: true},
+		Fix{Time: start.Add(20 * time.Second), TimeValid: true, Valid: true},
+		Fix{Time: start.Add(21 * time.Second), TimeValid: true, Valid: true},
+	}
+
+	split := splitFixes(fixes, 10*time.Second)
+	if len(split) != 3 || len(split[0]) != 2 || len(split[1]) != 1 || len(split[2]) != 2 {
+		t.Errorf("The fixes are split into %d segments, but should be split into 3 segments with 2, 1, 2 fixes", len(split))
+	}
+}
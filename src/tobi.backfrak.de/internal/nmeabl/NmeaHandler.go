@@ -0,0 +1,176 @@
+package nmeabl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+const NmeaBuffer gpsabl.InputFileType = "NmeaBuffer"
+
+// The file extension this Reader can read
+const FileExtension string = ".nmea"
+
+// The short file extension of NMEA logs this Reader can read
+const ShortFileExtension string = ".nma"
+
+// DefaultMaximalTimeGap - The time between two fixes, that starts a new segment, when nothing else is configured
+const DefaultMaximalTimeGap time.Duration = 60 * time.Second
+
+// NmeaFile - The struct to handle *.nmea data files
+type NmeaFile struct {
+	gpsabl.TrackFile
+	input gpsabl.InputFile
+	// MaximalTimeGap - A time between two fixes longer than this starts a new segment. DefaultMaximalTimeGap is used when not set
+	MaximalTimeGap time.Duration
+}
+
+// NewNmeaFile - Constructor for the NmeaFile struct
+func NewNmeaFile(filePath string) NmeaFile {
+	nmea := NmeaFile{}
+	nmea.FilePath = filePath
+	nmea.input = *gpsabl.NewInputFileWithPath(filePath)
+	nmea.MaximalTimeGap = DefaultMaximalTimeGap
+
+	return nmea
+}
+
+// NewReader - Get a new reader for NMEA files that will read the data in the given gpsabl.InputFile.
+// The new reader uses the MaximalTimeGap of this reader
+// Implement the gpsabl.TrackReader interface for *.nmea files
+func (nmea *NmeaFile) NewReader(data gpsabl.InputFile) gpsabl.TrackReader {
+	newNmea := NmeaFile{}
+	newNmea.input = data
+	newNmea.MaximalTimeGap = nmea.MaximalTimeGap
+	if data.Type == gpsabl.FilePath {
+		newNmea.FilePath = data.Name
+	}
+
+	return &newNmea
+}
+
+// ReadTracks - Read the *.nmea from the input from FilePath or InputFile.Buffer, and return a gpsabl.TrackFile struct that contains all information
+// Implement the gpsabl.TrackReader interface for *.nmea files
+func (nmea *NmeaFile) ReadTracks(correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	var err error
+	var ret gpsabl.TrackFile
+	if nmea.input.Type == gpsabl.FilePath {
+		ret, err = ReadNmeaFile(nmea.FilePath, nmea.getMaximalTimeGap(), correction, minimalMovingSpeed, minimalStepHight)
+	} else if nmea.input.Type == NmeaBuffer {
+		var buffer []byte
+		buffer, err = nmea.input.ReadContent()
+		if err == nil {
+			ret, err = ReadBuffer(buffer, nmea.input.Name, nmea.getMaximalTimeGap(), correction, minimalMovingSpeed, minimalStepHight)
+		}
+	} else {
+		err = gpsabl.NewUnKnownInputFileTypeError(nmea.input.Name)
+	}
+
+	if err == nil {
+		nmea.TrackFile = ret
+	}
+
+	return ret, err
+}
+
+// CheckInputFile - Check if a gpsabl.InputFile can be handled by the NmeaFile reader
+// Implement the gpsabl.TrackReader interface for *.nmea files
+func (nmea *NmeaFile) CheckInputFile(input gpsabl.InputFile) bool {
+	if input.Type == NmeaBuffer {
+		return true
+	} else if input.Type == gpsabl.FilePath && nmea.CheckFile(input.Name) {
+		return true
+	}
+
+	return false
+}
+
+// ReadBuffer - Read the NMEA data from a buffer, and return a gpsabl.TrackFile struct that contains all information
+func ReadBuffer(buffer []byte, name string, maximalTimeGap time.Duration, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	content, readErr := readNMEABuffer(buffer, name)
+	if readErr != nil {
+		return gpsabl.TrackFile{}, readErr
+	}
+	ret, convertError := ConvertNmea(content, name, maximalTimeGap, correction, minimalMovingSpeed, minimalStepHight)
+	if convertError != nil {
+		return gpsabl.TrackFile{}, convertError
+	}
+
+	return ret, nil
+}
+
+// CheckFile - Check if a file can be read by the NmeaFile "class"
+// Implement the gpsabl.TrackReader interface for *.nmea files
+func (nmea *NmeaFile) CheckFile(path string) bool {
+	lowerPath := strings.ToLower(path)
+	if strings.HasSuffix(lowerPath, FileExtension) || strings.HasSuffix(lowerPath, ShortFileExtension) { // If the file is a *.nmea or *.nma, we can read it
+		return true
+	}
+
+	return false
+}
+
+// CheckBuffer - Check if a buffer can be read by he NmeaFile "class". This is the case if it contains a GGA or RMC sentence with valid checksum
+// Implement the gpsabl.TrackReader interface for *.nmea files
+func (nmea *NmeaFile) CheckBuffer(buffer []byte) bool {
+	for _, line := range bytes.Split(buffer, []byte("\n")) {
+		fields, valid := parseSentence(string(bytes.TrimSpace(line)))
+		if valid {
+			sentenceType := getSentenceType(fields)
+			if sentenceType == "GGA" || sentenceType == "RMC" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NewInputFileForBuffer - Get a new InputFile for a buffer containing a NMEA files content
+// Implement the gpsabl.TrackReader interface for *.nmea files
+func (nmea *NmeaFile) NewInputFileForBuffer(buffer []byte, name string) *gpsabl.InputFile {
+	file := gpsabl.InputFile{}
+	file.Name = name
+	file.Type = NmeaBuffer
+	file.Buffer = buffer
+
+	return &file
+}
+
+// GetValidFileExtensions - Get a list of file extensions this reader can read
+// Implement the gpsabl.TrackReader interface for *.nmea files
+func (nmea *NmeaFile) GetValidFileExtensions() []string {
+
+	extensions := []string{FileExtension, ShortFileExtension}
+
+	return extensions
+}
+
+// ReadNmeaFile - Reads a *.nmea file
+func ReadNmeaFile(filePath string, maximalTimeGap time.Duration, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	nmea, fileError := ReadNmea(filePath)
+
+	if fileError != nil {
+		return gpsabl.TrackFile{}, fileError
+	}
+
+	ret, convertError := ConvertNmea(nmea, filePath, maximalTimeGap, correction, minimalMovingSpeed, minimalStepHight)
+	if convertError != nil {
+		return gpsabl.TrackFile{}, convertError
+	}
+
+	return ret, nil
+}
+
+func (nmea *NmeaFile) getMaximalTimeGap() time.Duration {
+	if nmea.MaximalTimeGap <= 0 {
+		return DefaultMaximalTimeGap
+	}
+
+	return nmea.MaximalTimeGap
+}
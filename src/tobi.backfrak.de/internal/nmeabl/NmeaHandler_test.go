@@ -0,0 +1,224 @@
+package nmeabl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+func TestTrackReaderMaximalTimeGap(t *testing.T) {
+	nmea := NewNmeaFile(testhelper.GetValidFile("nmea", "01.nmea"))
+	nmea.MaximalTimeGap = time.Hour
+
+	file, err := nmea.ReadTracks("none", 0.3, 10.0)
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if file.Tracks[0].NumberOfSegments != 2 {
+		t.Errorf("The NumberOfSegments is %d, but should be %d", file.Tracks[0].NumberOfSegments, 2)
+	}
+
+	if nmea.Distance != file.Distance {
+		t.Errorf("The NmeaFile.Distance is %f, but should be %f", nmea.Distance, file.Distance)
+	}
+}
+
+func TestNewReaderKeepsMaximalTimeGap(t *testing.T) {
+	nmea := NmeaFile{}
+	nmea.MaximalTimeGap = time.Hour
+	sut := nmea.NewReader(*gpsabl.NewInputFileWithPath(testhelper.GetValidFile("nmea", "01.nmea")))
+
+	file, err := sut.ReadTracks("none", 0.3, 10.0)
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if file.Tracks[0].NumberOfSegments != 2 {
+		t.Errorf("The NumberOfSegments is %d, but should be %d", file.Tracks[0].NumberOfSegments, 2)
+	}
+
+	// A reader without MaximalTimeGap uses the DefaultMaximalTimeGap
+	empty := NmeaFile{}
+	if empty.getMaximalTimeGap() != DefaultMaximalTimeGap {
+		t.Errorf("The maximal time gap is %s, but should be %s", empty.getMaximalTimeGap(), DefaultMaximalTimeGap)
+	}
+}
+
+func TestTrackReaderInValidCorrectionParameter(t *testing.T) {
+	nmea := NewNmeaFile(testhelper.GetValidFile("nmea", "02.nmea"))
+
+	_, err := nmea.ReadTracks("asdfg", 0.3, 10.0)
+	if err != nil {
+		switch ty := err.(type) {
+		case *gpsabl.CorrectionParameterNotKnownError:
+			fmt.Println("OK")
+		default:
+			t.Errorf("The Error ReadTracks gave is of the wrong type. The type is %v", ty)
+		}
+	} else {
+		t.Errorf("ReadTracks did not return a error, but was expected")
+	}
+}
+
+func TestReadAllValidNmeaDueInterface(t *testing.T) {
+	files, _ := ioutil.ReadDir(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-nmea"))
+
+	for _, file := range files {
+		nmeaFile := NewNmeaFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-nmea", file.Name()))
+		if nmeaFile.CheckFile(file.Name()) && file.IsDir() == false {
+			iNmea := gpsabl.TrackReader(&nmeaFile)
+
+			track, err := iNmea.ReadTracks("none", 0.3, 10.0)
+			if err != nil {
+				t.Errorf("Got the error \"%s\" while reading file %s.", err.Error(), filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-nmea", file.Name()))
+			}
+			if track.Distance <= 0.0 {
+				t.Errorf("The track.Distance is %f but should not be less then %f", track.Distance, 0.0)
+			}
+		}
+	}
+}
+
+func TestReadAllInValidNmeaDueInterface(t *testing.T) {
+	files, _ := ioutil.ReadDir(filepath.Join(testhelper.GetProjectRoot(), "testdata", "invalid-nmea"))
+
+	for _, file := range files {
+		nmeaFile := NewNmeaFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "invalid-nmea", file.Name()))
+		if nmeaFile.CheckFile(file.Name()) && file.IsDir() == false {
+			iNmea := gpsabl.TrackReader(&nmeaFile)
+
+			_, err := iNmea.ReadTracks("none", 0.3, 10.0)
+			if err == nil {
+				t.Errorf("Got no error while reading file %s.", filepath.Join(testhelper.GetProjectRoot(), "testdata", "invalid-nmea", file.Name()))
+			}
+		}
+	}
+}
+
+func TestNewReaderWithValidFilePath(t *testing.T) {
+	nmea := NmeaFile{}
+	file := testhelper.GetValidFile("nmea", "01.nmea")
+	checkRes := nmea.CheckFile(file)
+	input := *gpsabl.NewInputFileWithPath(file)
+
+	sut := nmea.NewReader(input)
+
+	if checkRes != true {
+		t.Errorf("NmeaFile can not read %s", file)
+	}
+
+	if nmea.CheckInputFile(input) != true {
+		t.Errorf("NmeaFile can not read the input %s", file)
+	}
+
+	trk, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+	if err != nil {
+		t.Errorf("Got error \"%s\" but expect none", err)
+	}
+
+	if trk.FilePath != file {
+		t.Errorf("The trk.FilePath %s is not the given path %s", trk.FilePath, file)
+	}
+}
+
+func TestNewReaderWithValidBuffer(t *testing.T) {
+	for _, name := range []string{"01.nmea", "02.nmea"} {
+		nmea := NmeaFile{}
+		buffer, createErr := testhelper.GetValidFileBuffer("nmea", name)
+		if createErr != nil {
+			t.Fatalf("Got error \"%s\" while creating the input buffer", createErr)
+		}
+		checkRes := nmea.CheckBuffer(buffer)
+		input := *nmea.NewInputFileForBuffer(buffer, "Buffer 1")
+
+		sut := nmea.NewReader(input)
+
+		if checkRes != true {
+			t.Errorf("NmeaFile can not read %s from buffer", name)
+		}
+
+		if nmea.CheckInputFile(input) != true {
+			t.Errorf("NmeaFile can not read the input buffer")
+		}
+
+		trk, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+		if err != nil {
+			t.Errorf("Got error \"%s\" but expect none", err)
+		}
+
+		if trk.FilePath != "Buffer 1" {
+			t.Errorf("The trk.FilePath %s is not the given path %s", trk.FilePath, "Buffer 1")
+		}
+	}
+}
+
+func TestNewReaderWithUnknownInputType(t *testing.T) {
+	nmea := NmeaFile{}
+	input := gpsabl.InputFile{Type: "Unknown", Name: "Buffer 1"}
+	sut := nmea.NewReader(input)
+
+	if nmea.CheckInputFile(input) != false {
+		t.Errorf("NmeaFile can read an input of unknown type")
+	}
+
+	_, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+	switch err.(type) {
+	case *gpsabl.UnKnownInputFileTypeError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *gpsabl.UnKnownInputFileTypeError, got \"%v\"", err)
+	}
+}
+
+func TestCheckBuffer(t *testing.T) {
+	nmea := NmeaFile{}
+	gpx, _ := testhelper.GetValidGpxBuffer("01.gpx")
+	if nmea.CheckBuffer(gpx) != false {
+		t.Errorf("NmeaFile can read a gpx buffer")
+	}
+
+	invalid, _ := testhelper.GetInvalidFileBuffer("nmea", "02.nmea")
+	if nmea.CheckBuffer(invalid) != false {
+		t.Errorf("NmeaFile can read a buffer without valid sentence")
+	}
+
+	if nmea.CheckBuffer([]byte("$GPGSV,1,1,00*79")) != false {
+		t.Errorf("NmeaFile can read a buffer without GGA or RMC sentence")
+	}
+}
+
+func TestCheckFile(t *testing.T) {
+	nmea := NmeaFile{}
+
+	if nmea.CheckFile("my/path/file.NMEA") != true {
+		t.Errorf("NmeaFile can not read *.NMEA files")
+	}
+
+	if nmea.CheckFile("my/path/file.nma") != true {
+		t.Errorf("NmeaFile can not read *.nma files")
+	}
+
+	if nmea.CheckFile("my/path/file.gpx") != false {
+		t.Errorf("NmeaFile can read *.gpx files")
+	}
+}
+
+func TestGetValidFileExtensions(t *testing.T) {
+	nmea := NmeaFile{}
+	extensions := nmea.GetValidFileExtensions()
+
+	if len(extensions) != 2 || extensions[0] != FileExtension || extensions[1] != ShortFileExtension {
+		t.Errorf("The valid file extensions are %v, but should be [%s %s]", extensions, FileExtension, ShortFileExtension)
+	}
+}
@@ -0,0 +1,295 @@
+package nmeabl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Nmea - Represents the position fixes found in a NMEA 0183 log
+type Nmea struct {
+	Fixes []Fix
+	// ValidSentences - The number of GGA and RMC sentences with a valid checksum
+	ValidSentences int
+	// InvalidSentences - The number of sentences with a missing or wrong checksum. These sentences are ignored
+	InvalidSentences int
+}
+
+// Fix - One epoch of the receiver, combined out of the GGA and RMC sentences with the same time of day
+type Fix struct {
+	Time      time.Time
+	TimeValid bool
+	Latitude  float64
+	Longitude float64
+	// Altitude - The GGA altitude, or the altitude of the last epoch with GGA sentence
+	Altitude float32
+	// Valid - False if the receiver reported a fix loss, or the epoch has no position
+	Valid bool
+}
+
+// epoch - The values read for one time of day, before the date is known for sure
+type epoch struct {
+	timeOfDay      string
+	sinceMidnight  time.Duration
+	timeOfDayValid bool
+	date           time.Time
+	dateValid      bool
+	latitude       float64
+	longitude      float64
+	positionValid  bool
+	altitude       float32
+	altitudeValid  bool
+	fixLost        bool
+}
+
+// ReadNmea - Read a NMEA 0183 log file
+func ReadNmea(fileName string) (Nmea, error) {
+	fileBuffer, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return Nmea{}, err
+	}
+	return readNMEABuffer(fileBuffer, fileName)
+}
+
+func readNMEABuffer(fileBuffer []byte, fileName string) (Nmea, error) {
+	nmea := Nmea{}
+	var epochs []epoch
+	current := epoch{}
+
+	for _, line := range bytes.Split(fileBuffer, []byte("\n")) {
+		start := bytes.IndexByte(line, '$')
+		if start < 0 {
+			continue
+		}
+
+		fields, valid := parseSentence(string(bytes.TrimSpace(line[start:])))
+		if !valid {
+			nmea.InvalidSentences++
+			continue
+		}
+
+		sentenceType := getSentenceType(fields)
+		if sentenceType != "GGA" && sentenceType != "RMC" {
+			continue
+		}
+		nmea.ValidSentences++
+
+		// Sentences without time of day can not be assigned to an epoch
+		sinceMidnight, timeValid := parseTimeOfDay(fields[1])
+		if !timeValid {
+			continue
+		}
+
+		// All sentences of one epoch carry the same time of day
+		if fields[1] != current.timeOfDay && current.timeOfDay != "" {
+			epochs = append(epochs, current)
+			current = epoch{}
+		}
+		current.timeOfDay = fields[1]
+		current.sinceMidnight = sinceMidnight
+		current.timeOfDayValid = true
+
+		if sentenceType == "GGA" {
+			addGGAValues(&current, fields)
+		} else {
+			addRMCValues(&current, fields)
+		}
+	}
+
+	if current.timeOfDay != "" {
+		epochs = append(epochs, current)
+	}
+
+	if nmea.ValidSentences <= 0 {
+		return Nmea{}, newNmeaFileError(fileName)
+	}
+
+	nmea.Fixes = getFixes(epochs)
+
+	return nmea, nil
+}
+
+// parseSentence - Split a NMEA sentence like "$GPGGA,...*hh" in its fields, when the checksum is valid
+func parseSentence(sentence string) ([]string, bool) {
+	if !strings.HasPrefix(sentence, "$") {
+		return nil, false
+	}
+
+	starIndex := strings.LastIndex(sentence, "*")
+	if starIndex < 0 || len(sentence) < starIndex+3 {
+		return nil, false
+	}
+
+	body := sentence[1:starIndex]
+	if fmt.Sprintf("%02X", getChecksum(body)) != strings.ToUpper(sentence[starIndex+1:starIndex+3]) {
+		return nil, false
+	}
+
+	return strings.Split(body, ","), true
+}
+
+// getChecksum - The NMEA checksum is the XOR of all characters between "$" and "*"
+func getChecksum(body string) byte {
+	var checksum byte
+	for i := 0; i < len(body); i++ {
+		checksum ^= body[i]
+	}
+
+	return checksum
+}
+
+// getSentenceType - Get the sentence type like "GGA" out of the address field like "GPGGA" or "GNGGA"
+func getSentenceType(fields []string) string {
+	if len(fields) < 2 || len(fields[0]) != 5 {
+		return ""
+	}
+
+	return fields[0][2:]
+}
+
+// addGGAValues - $--GGA,hhmmss.ss,llll.ll,a,yyyyy.yy,a,q,nn,h.h,a.a,M,g.g,M,...
+func addGGAValues(current *epoch, fields []string) {
+	if len(fields) < 10 {
+		return
+	}
+
+	if fields[6] == "" || fields[6] == "0" {
+		current.fixLost = true
+		return
+	}
+
+	setPosition(current, fields[2], fields[3], fields[4], fields[5])
+	alt, altErr := strconv.ParseFloat(fields[9], 32)
+	if altErr == nil {
+		current.altitude = float32(alt)
+		current.altitudeValid = true
+	}
+}
+
+// addRMCValues - $--RMC,hhmmss.ss,A,llll.ll,a,yyyyy.yy,a,x.x,x.x,ddmmyy,...
+func addRMCValues(current *epoch, fields []string) {
+	if len(fields) < 10 {
+		return
+	}
+
+	date, dateErr := time.Parse("020106", fields[9])
+	if dateErr == nil {
+		current.date = date
+		current.dateValid = true
+	}
+
+	if fields[2] != "A" {
+		current.fixLost = true
+		return
+	}
+
+	if !current.positionValid {
+		setPosition(current, fields[3], fields[4], fields[5], fields[6])
+	}
+}
+
+// parseTimeOfDay - Get the time since midnight out of a hhmmss.ss value. False if the value is empty or not valid
+func parseTimeOfDay(value string) (time.Duration, bool) {
+	if len(value) < 6 {
+		return 0, false
+	}
+
+	hours, hErr := strconv.Atoi(value[0:2])
+	minutes, mErr := strconv.Atoi(value[2:4])
+	seconds, sErr := strconv.ParseFloat(value[4:], 64)
+	if hErr != nil || mErr != nil || sErr != nil {
+		return 0, false
+	}
+
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds*float64(time.Second)), true
+}
+
+func setPosition(current *epoch, lat string, northSouth string, lon string, eastWest string) {
+	latitude, latErr := convertDegreesMinutes(lat, 2)
+	longitude, lonErr := convertDegreesMinutes(lon, 3)
+	if latErr != nil || lonErr != nil {
+		return
+	}
+
+	if northSouth == "S" {
+		latitude = -latitude
+	}
+	if eastWest == "W" {
+		longitude = -longitude
+	}
+
+	current.latitude = latitude
+	current.longitude = longitude
+	current.positionValid = true
+}
+
+// convertDegreesMinutes - Convert a NMEA "ddmm.mmmm" or "dddmm.mmmm" value to degrees
+func convertDegreesMinutes(value string, degreeDigits int) (float64, error) {
+	if len(value) < degreeDigits+2 {
+		return 0, strconv.ErrSyntax
+	}
+
+	degrees, dErr := strconv.ParseFloat(value[:degreeDigits], 64)
+	if dErr != nil {
+		return 0, dErr
+	}
+
+	minutes, mErr := strconv.ParseFloat(value[degreeDigits:], 64)
+	if mErr != nil {
+		return 0, mErr
+	}
+
+	return degrees + minutes/60.0, nil
+}
+
+// getFixes - Combine the epochs to fixes. Epochs without RMC sentence get the date of the epoch before, or after
+func getFixes(epochs []epoch) []Fix {
+	// Forward, with a date change when the time of day jumps back over midnight
+	for i := 1; i < len(epochs); i++ {
+		if !epochs[i].dateValid && epochs[i-1].dateValid {
+			epochs[i].date = epochs[i-1].date
+			if epochs[i].sinceMidnight < epochs[i-1].sinceMidnight {
+				epochs[i].date = epochs[i].date.AddDate(0, 0, 1)
+			}
+			epochs[i].dateValid = true
+		}
+	}
+
+	// Backward for the epochs before the first RMC sentence
+	for i := len(epochs) - 2; i >= 0; i-- {
+		if !epochs[i].dateValid && epochs[i+1].dateValid {
+			epochs[i].date = epochs[i+1].date
+			if epochs[i].sinceMidnight > epochs[i+1].sinceMidnight {
+				epochs[i].date = epochs[i].date.AddDate(0, 0, -1)
+			}
+			epochs[i].dateValid = true
+		}
+	}
+
+	var fixes []Fix
+	var altitude float32
+	for _, ep := range epochs {
+		fix := Fix{}
+		if ep.altitudeValid {
+			altitude = ep.altitude
+		}
+		fix.Altitude = altitude
+		fix.Latitude = ep.latitude
+		fix.Longitude = ep.longitude
+		fix.Valid = ep.positionValid && !ep.fixLost
+		if ep.dateValid && ep.timeOfDayValid {
+			fix.Time = ep.date.Add(ep.sinceMidnight)
+			fix.TimeValid = true
+		}
+		fixes = append(fixes, fix)
+	}
+
+	return fixes
+}
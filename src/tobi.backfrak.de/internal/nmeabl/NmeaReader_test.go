@@ -0,0 +1,185 @@
+package nmeabl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func TestReadValidNmea01(t *testing.T) {
+	nmea, err := ReadNmea(testhelper.GetValidFile("nmea", "01.nmea"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	// The file was created out of testdata/valid-gpx/02.gpx, with one GGA and one RMC sentence per point
+	if len(nmea.Fixes) != 1113 {
+		t.Errorf("The number of Fixes is %d, but should be %d", len(nmea.Fixes), 1113)
+	}
+
+	if nmea.ValidSentences != 2226 || nmea.InvalidSentences != 0 {
+		t.Errorf("The number of valid and invalid sentences is %d and %d, but should be %d and %d", nmea.ValidSentences, nmea.InvalidSentences, 2226, 0)
+	}
+
+	first := nmea.Fixes[0]
+	if first.Time.Format(time.RFC3339) != "2019-08-18T09:11:01Z" || first.TimeValid != true {
+		t.Errorf("The Time of the first fix is %s, but should be %s", first.Time.Format(time.RFC3339), "2019-08-18T09:11:01Z")
+	}
+
+	if float32(first.Latitude) != 49.419270 || float32(first.Longitude) != 11.018949 {
+		t.Errorf("The position of the first fix is %f, %f, but should be %f, %f", first.Latitude, first.Longitude, 49.419270, 11.018949)
+	}
+
+	if first.Altitude != 359.79 {
+		t.Errorf("The Altitude of the first fix is %f, but should be %f", first.Altitude, 359.79)
+	}
+}
+
+func TestReadValidNmeaWithFixLossAndInvalidChecksum(t *testing.T) {
+	nmea, err := ReadNmea(testhelper.GetValidFile("nmea", "02.nmea"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(nmea.Fixes) != 336 {
+		t.Errorf("The number of Fixes is %d, but should be %d", len(nmea.Fixes), 336)
+	}
+
+	if nmea.InvalidSentences != 1 {
+		t.Errorf("The number of InvalidSentences is %d, but should be %d", nmea.InvalidSentences, 1)
+	}
+
+	if nmea.Fixes[100].Valid != false {
+		t.Errorf("The fix 100 is valid, but the receiver lost the fix")
+	}
+
+	// The GGA sentence of fix 150 has a wrong checksum, so the position is taken from RMC and the altitude from the fix before
+	if nmea.Fixes[150].Valid != true || nmea.Fixes[150].Altitude != nmea.Fixes[149].Altitude {
+		t.Errorf("The fix 150 is not valid or the altitude %f is not the one of the fix before %f", nmea.Fixes[150].Altitude, nmea.Fixes[149].Altitude)
+	}
+}
+
+func TestReadNmeaWithoutValidSentence(t *testing.T) {
+	_, err := ReadNmea(testhelper.GetInvalidFile("nmea", "02.nmea"))
+	switch v := err.(type) {
+	case nil:
+		t.Errorf("No error, when reading a file without valid NMEA sentence")
+	case *NmeaFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *NmeaFileError, got a %s", reflect.TypeOf(v))
+	}
+}
+
+func TestReadNmeaWithoutTimeOfDay(t *testing.T) {
+	bodies := []string{
+		"GPGGA,100000.00,4925.156200,N,01101.136940,E,1,08,0.9,359.790,M,47.0,M,,",
+		"GPRMC,100000.00,A,4925.156200,N,01101.136940,E,0.0,0.0,010325,,,A",
+		"GPGGA,,4925.156300,N,01101.136940,E,1,08,0.9,360.790,M,47.0,M,,",
+		"GPGGA,ab0001.00,4925.156400,N,01101.136940,E,1,08,0.9,361.790,M,47.0,M,,",
+		"GPGGA,100001.00,4925.156500,N,01101.136940,E,1,08,0.9,362.790,M,47.0,M,,",
+		"GPRMC,100001.00,A,4925.156500,N,01101.136940,E,0.0,0.0,010325,,,A"}
+	var buffer []byte
+	for _, body := range bodies {
+		buffer = append(buffer, []byte(fmt.Sprintf("$%s*%02X\n", body, getChecksum(body)))...)
+	}
+
+	nmea, err := readNMEABuffer(buffer, "my/path.nmea")
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if nmea.ValidSentences != 6 {
+		t.Errorf("The number of ValidSentences is %d, but should be %d", nmea.ValidSentences, 6)
+	}
+
+	if len(nmea.Fixes) != 2 {
+		t.Fatalf("The number of Fixes is %d, but should be %d", len(nmea.Fixes), 2)
+	}
+
+	expected := time.Date(2025, time.March, 1, 10, 0, 1, 0, time.UTC)
+	if nmea.Fixes[1].Time != expected || nmea.Fixes[1].Altitude != 362.79 {
+		t.Errorf("The second fix is taken at %s with altitude %f, but should be taken at %s with altitude %f", nmea.Fixes[1].Time, nmea.Fixes[1].Altitude, expected, 362.79)
+	}
+}
+
+func TestReadNotExistNmea(t *testing.T) {
+	_, err := ReadNmea(testhelper.GetInvalidFile("nmea", "not-exist.nmea"))
+	switch v := err.(type) {
+	case nil:
+		t.Errorf("No error, when reading an not existing NMEA file")
+	case *os.PathError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *os.PathError, got a %s", reflect.TypeOf(v))
+	}
+}
+
+func TestParseSentence(t *testing.T) {
+	fields, valid := parseSentence("$GPGGA,091101.00,4925.156200,N,01101.136940,E,1,08,0.9,359.790,M,47.0,M,,*65")
+	if valid != true {
+		t.Fatalf("A valid sentence is not valid")
+	}
+
+	if len(fields) != 15 || fields[0] != "GPGGA" || getSentenceType(fields) != "GGA" {
+		t.Errorf("The sentence is split into %d fields with address %s", len(fields), fields[0])
+	}
+
+	_, valid = parseSentence("$GPGGA,091101.00,4925.156200,N,01101.136940,E,1,08,0.9,359.790,M,47.0,M,,*66")
+	if valid != false {
+		t.Errorf("A sentence with wrong checksum is valid")
+	}
+
+	_, valid = parseSentence("$GPGGA,091101.00,4925.156200,N,01101.136940,E,1,08,0.9,359.790,M,47.0,M,,")
+	if valid != false {
+		t.Errorf("A sentence without checksum is valid")
+	}
+
+	_, valid = parseSentence("$GPGGA*5")
+	if valid != false {
+		t.Errorf("A sentence with a short checksum is valid")
+	}
+}
+
+func TestConvertDegreesMinutes(t *testing.T) {
+	lat, latErr := convertDegreesMinutes("4930.000", 2)
+	if latErr != nil || lat != 49.5 {
+		t.Errorf("The latitude is %f, but should be %f", lat, 49.5)
+	}
+
+	lon, lonErr := convertDegreesMinutes("01115.000", 3)
+	if lonErr != nil || lon != 11.25 {
+		t.Errorf("The longitude is %f, but should be %f", lon, 11.25)
+	}
+
+	_, shortErr := convertDegreesMinutes("49", 2)
+	if shortErr == nil {
+		t.Errorf("No error, when converting a value that is to short")
+	}
+}
+
+func TestGetFixesOverMidnight(t *testing.T) {
+	date := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	epochs := []epoch{
+		epoch{sinceMidnight: 23*time.Hour + 59*time.Minute, timeOfDayValid: true, positionValid: true},
+		epoch{sinceMidnight: 23*time.Hour + 59*time.Minute + 30*time.Second, timeOfDayValid: true, date: date, dateValid: true, positionValid: true},
+		epoch{sinceMidnight: 10 * time.Second, timeOfDayValid: true, positionValid: true},
+	}
+
+	fixes := getFixes(epochs)
+	if fixes[0].Time.Format(time.RFC3339) != "2025-03-01T23:59:00Z" {
+		t.Errorf("The Time of the first fix is %s, but should be %s", fixes[0].Time.Format(time.RFC3339), "2025-03-01T23:59:00Z")
+	}
+
+	if fixes[2].Time.Format(time.RFC3339) != "2025-03-02T00:00:10Z" {
+		t.Errorf("The Time of the last fix is %s, but should be %s", fixes[2].Time.Format(time.RFC3339), "2025-03-02T00:00:10Z")
+	}
+}
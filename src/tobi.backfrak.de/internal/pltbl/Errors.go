@@ -0,0 +1,57 @@
+package pltbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import "fmt"
+
+// PltFileError - Error when trying to load something that is not an OziExplorer track file
+type PltFileError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+}
+
+func (e *PltFileError) Error() string { // Implement the Error Interface for the PltFileError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newPltFileError - Get a new PltFileError struct
+func newPltFileError(fileName string) *PltFileError {
+	return &PltFileError{fmt.Sprintf("The file \"%s\" is not an OziExplorer track file", fileName), fileName}
+}
+
+// EmptyPltFileError - Error when trying to load an OziExplorer track file that does not contain any track point
+type EmptyPltFileError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+}
+
+func (e *EmptyPltFileError) Error() string { // Implement the Error Interface for the EmptyPltFileError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newEmptyPltFileError - Get a new EmptyPltFileError struct
+func newEmptyPltFileError(fileName string) *EmptyPltFileError {
+	return &EmptyPltFileError{fmt.Sprintf("The file \"%s\" does not contain any track point.", fileName), fileName}
+}
+
+// PltPointError - Error when a track point line of an OziExplorer track file can not be parsed
+type PltPointError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+	// Line - The number of the line that could not be parsed
+	Line int
+}
+
+func (e *PltPointError) Error() string { // Implement the Error Interface for the PltPointError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newPltPointError - Get a new PltPointError struct
+func newPltPointError(fileName string, line int) *PltPointError {
+	return &PltPointError{fmt.Sprintf("The track point in line %d of the file \"%s\" can not be parsed.", line, fileName), fileName, line}
+}
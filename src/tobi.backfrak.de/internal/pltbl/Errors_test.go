@@ -0,0 +1,50 @@
+package pltbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"strings"
+	"testing"
+)
+
+func TestPltFileError(t *testing.T) {
+	path := "/some/sample/path"
+	err := newPltFileError(path)
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of PltFileError does not contain the expected Path")
+	}
+
+	if err.File != path {
+		t.Errorf("The PltFileError.File does not match the expected value")
+	}
+}
+
+func TestEmptyPltFileError(t *testing.T) {
+	path := "/some/sample/path"
+	err := newEmptyPltFileError(path)
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of EmptyPltFileError does not contain the expected Path")
+	}
+
+	if err.File != path {
+		t.Errorf("The EmptyPltFileError.File does not match the expected value")
+	}
+}
+
+func TestPltPointError(t *testing.T) {
+	path := "/some/sample/path"
+	err := newPltPointError(path, 8)
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of PltPointError does not contain the expected Path")
+	}
+
+	if strings.Contains(err.Error(), "line 8") == false {
+		t.Errorf("The error message of PltPointError does not contain the expected line")
+	}
+
+	if err.File != path || err.Line != 8 {
+		t.Errorf("The PltPointError values do not match the expected values")
+	}
+}
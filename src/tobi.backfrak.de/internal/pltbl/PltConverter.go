@@ -0,0 +1,84 @@
+package pltbl
+
+import (
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+// ConvertPlt - Convert a pltbl.Plt to a gpsabl.TrackFile with one gpsabl.Track. Each point with the segment start code
+// starts a new gpsabl.TrackSegment. Points without altitude get the elevation of the point before, see gpsabl.FillMissingElevation
+func ConvertPlt(plt Plt, filePath string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+
+	if len(plt.Points) <= 0 {
+		return gpsabl.TrackFile{}, newEmptyPltFileError(filePath)
+	}
+
+	res := gpsabl.NewTrackFile(filePath)
+	track := gpsabl.Track{}
+	track.Name = plt.Description
+
+	for _, points := range splitSegments(plt.Points) {
+		seg, err := convertPoints(points, correction, minimalMovingSpeed, minimalStepHight)
+		if err != nil {
+			return gpsabl.TrackFile{}, err
+		}
+		track.TrackSegments = append(track.TrackSegments, seg)
+	}
+
+	track.NumberOfSegments = len(track.TrackSegments)
+	gpsabl.FillTrackValues(&track)
+
+	res.Name = plt.Description
+	res.Tracks = []gpsabl.Track{track}
+	res.NumberOfTracks = len(res.Tracks)
+	gpsabl.FillTrackFileValues(&res)
+
+	return res, nil
+}
+
+// splitSegments - Split the points at each point with the segment start code
+func splitSegments(points []PltPoint) [][]PltPoint {
+	var ret [][]PltPoint
+	start := 0
+	for i, point := range points {
+		if point.NewSegment && i > start {
+			ret = append(ret, points[start:i])
+			start = i
+		}
+	}
+
+	return append(ret, points[start:])
+}
+
+func convertPoints(points []PltPoint, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackSegment, error) {
+	res := gpsabl.TrackSegment{}
+	pointCount := len(points)
+	basic := make([]gpsabl.TrackPoint, pointCount)
+	for i, point := range points {
+		pnt := gpsabl.TrackPoint{}
+		pnt.Latitude = float32(point.Latitude)
+		pnt.Longitude = float32(point.Longitude)
+		pnt.Elevation = point.Altitude
+		pnt.ElevationMissing = !point.AltitudeValid
+		pnt.Time = point.Time
+		pnt.TimeValid = point.TimeValid
+		basic[i] = pnt
+	}
+	gpsabl.FillMissingElevation(basic)
+
+	ret := gpsabl.FillDistances(basic)
+
+	err := gpsabl.FillValuesTrackPointArray(ret, correction, minimalMovingSpeed, minimalStepHight)
+	if err != nil {
+		return gpsabl.TrackSegment{}, err
+	}
+
+	res.TrackPoints = ret
+	gpsabl.FillTrackSegmentValues(&res)
+
+	return res, nil
+}
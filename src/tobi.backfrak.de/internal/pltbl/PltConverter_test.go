@@ -0,0 +1,101 @@
+package pltbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func TestConvertPlt(t *testing.T) {
+	plt, err := ReadPlt(testhelper.GetValidFile("plt", "01.plt"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	file, convErr := ConvertPlt(plt, "my/path.plt", gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	if file.Name != "Istria Hike" || file.Tracks[0].Name != "Istria Hike" {
+		t.Errorf("The Name is \"%s\", but should be \"%s\"", file.Name, "Istria Hike")
+	}
+
+	// The file was created out of testdata/valid-gpx/12.gpx, the -999 altitude must not change the MinimumAltitude
+	if gpsabl.RoundFloat64To2Digits(float64(file.MinimumAltitude)) != 245 || gpsabl.RoundFloat64To2Digits(float64(file.MaximumAltitude)) != 304.01 {
+		t.Errorf("The MinimumAltitude and MaximumAltitude are %f and %f, but should be %f and %f", file.MinimumAltitude, file.MaximumAltitude, 245.0, 304.01)
+	}
+
+	if file.StartTime.Format(time.RFC3339) != "2014-08-22T16:48:52Z" || file.EndTime.Format(time.RFC3339) != "2014-08-22T17:19:42Z" {
+		t.Errorf("The StartTime and EndTime are %s and %s, but should be %s and %s", file.StartTime.Format(time.RFC3339), file.EndTime.Format(time.RFC3339), "2014-08-22T16:48:52Z", "2014-08-22T17:19:42Z")
+	}
+
+	if file.Tracks[0].NumberOfSegments != 1 {
+		t.Errorf("The NumberOfSegments is %d, but should be %d", file.Tracks[0].NumberOfSegments, 1)
+	}
+
+	pnt := file.Tracks[0].TrackSegments[0].TrackPoints[10]
+	if pnt.ElevationMissing != true || pnt.Elevation != file.Tracks[0].TrackSegments[0].TrackPoints[9].Elevation {
+		t.Errorf("The point without altitude has the Elevation %f and ElevationMissing %t", pnt.Elevation, pnt.ElevationMissing)
+	}
+}
+
+func TestConvertPltSegments(t *testing.T) {
+	plt, err := ReadPlt(testhelper.GetValidFile("plt", "02.plt"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	file, convErr := ConvertPlt(plt, "my/path.plt", gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	if file.Tracks[0].NumberOfSegments != 2 {
+		t.Fatalf("The NumberOfSegments is %d, but should be %d", file.Tracks[0].NumberOfSegments, 2)
+	}
+
+	if len(file.Tracks[0].TrackSegments[0].TrackPoints) != 3 || len(file.Tracks[0].TrackSegments[1].TrackPoints) != 3 {
+		t.Errorf("The segments have %d and %d points, but should have 3 each",
+			len(file.Tracks[0].TrackSegments[0].TrackPoints), len(file.Tracks[0].TrackSegments[1].TrackPoints))
+	}
+
+	// The first point has no altitude, so it gets the one of the second point: 846.5 ft
+	if int(file.MinimumAltitude) != 256 {
+		t.Errorf("The MinimumAltitude is %f, but should be %f", file.MinimumAltitude, 256.0)
+	}
+}
+
+func TestConvertEmptyPlt(t *testing.T) {
+	plt, err := ReadPlt(testhelper.GetInvalidFile("plt", "03.plt"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	_, convErr := ConvertPlt(plt, "my/path.plt", gpsabl.NO, 0.3, 10.0)
+	switch convErr.(type) {
+	case *EmptyPltFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *EmptyPltFileError, got \"%v\"", convErr)
+	}
+}
+
+func TestConvertPltInValidCorrectionParameter(t *testing.T) {
+	plt, _ := ReadPlt(testhelper.GetValidFile("plt", "02.plt"))
+
+	_, err := ConvertPlt(plt, "my/path.plt", "asdfg", 0.3, 10.0)
+	switch err.(type) {
+	case *gpsabl.CorrectionParameterNotKnownError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *gpsabl.CorrectionParameterNotKnownError, got \"%v\"", err)
+	}
+}
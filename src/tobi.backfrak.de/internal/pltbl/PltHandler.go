@@ -0,0 +1,145 @@
+package pltbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"strings"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+const PltBuffer gpsabl.InputFileType = "PltBuffer"
+
+// The file extension this Reader can read
+const FileExtension string = ".plt"
+
+// PltFile - The struct to handle *.plt OziExplorer track files
+type PltFile struct {
+	gpsabl.TrackFile
+	input gpsabl.InputFile
+}
+
+// NewPltFile - Constructor for the PltFile struct
+func NewPltFile(filePath string) PltFile {
+	plt := PltFile{}
+	plt.FilePath = filePath
+	plt.input = *gpsabl.NewInputFileWithPath(filePath)
+
+	return plt
+}
+
+// NewReader - Get a new reader for OziExplorer track files that will read the data in the given gpsabl.InputFile
+// Implement the gpsabl.TrackReader interface for *.plt files
+func (plt *PltFile) NewReader(data gpsabl.InputFile) gpsabl.TrackReader {
+	newPlt := PltFile{}
+	newPlt.input = data
+	if data.Type == gpsabl.FilePath {
+		newPlt.FilePath = data.Name
+	}
+
+	return &newPlt
+}
+
+// ReadTracks - Read the *.plt from the input from FilePath or InputFile.Buffer, and return a gpsabl.TrackFile struct that contains all information
+// Implement the gpsabl.TrackReader interface for *.plt files
+func (plt *PltFile) ReadTracks(correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	var err error
+	var ret gpsabl.TrackFile
+	if plt.input.Type == gpsabl.FilePath {
+		ret, err = ReadPltFile(plt.FilePath, correction, minimalMovingSpeed, minimalStepHight)
+	} else if plt.input.Type == PltBuffer {
+		var buffer []byte
+		buffer, err = plt.input.ReadContent()
+		if err == nil {
+			ret, err = ReadBuffer(buffer, plt.input.Name, correction, minimalMovingSpeed, minimalStepHight)
+		}
+	} else {
+		err = gpsabl.NewUnKnownInputFileTypeError(plt.input.Name)
+	}
+
+	if err == nil {
+		plt.TrackFile = ret
+	}
+
+	return ret, err
+}
+
+// CheckInputFile - Check if a gpsabl.InputFile can be handled by the PltFile reader
+// Implement the gpsabl.TrackReader interface for *.plt files
+func (plt *PltFile) CheckInputFile(input gpsabl.InputFile) bool {
+	if input.Type == PltBuffer {
+		return true
+	} else if input.Type == gpsabl.FilePath && plt.CheckFile(input.Name) {
+		return true
+	}
+
+	return false
+}
+
+// ReadBuffer - Read the OziExplorer track data from a buffer, and return a gpsabl.TrackFile struct that contains all information
+func ReadBuffer(buffer []byte, name string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	content, readErr := readPLTBuffer(buffer, name)
+	if readErr != nil {
+		return gpsabl.TrackFile{}, readErr
+	}
+	ret, convertError := ConvertPlt(content, name, correction, minimalMovingSpeed, minimalStepHight)
+	if convertError != nil {
+		return gpsabl.TrackFile{}, convertError
+	}
+
+	return ret, nil
+}
+
+// CheckFile - Check if a file can be read by the PltFile "class"
+// Implement the gpsabl.TrackReader interface for *.plt files
+func (plt *PltFile) CheckFile(path string) bool {
+	if strings.HasSuffix(strings.ToLower(path), FileExtension) { // If the file is a *.plt, we can read it
+		return true
+	}
+
+	return false
+}
+
+// CheckBuffer - Check if a buffer can be read by he PltFile "class". This is the case if it starts with the OziExplorer track file header
+// Implement the gpsabl.TrackReader interface for *.plt files
+func (plt *PltFile) CheckBuffer(buffer []byte) bool {
+	return isPLTBuffer(buffer)
+}
+
+// NewInputFileForBuffer - Get a new InputFile for a buffer containing a OziExplorer track files content
+// Implement the gpsabl.TrackReader interface for *.plt files
+func (plt *PltFile) NewInputFileForBuffer(buffer []byte, name string) *gpsabl.InputFile {
+	file := gpsabl.InputFile{}
+	file.Name = name
+	file.Type = PltBuffer
+	file.Buffer = buffer
+
+	return &file
+}
+
+// GetValidFileExtensions - Get a list of file extensions this reader can read
+// Implement the gpsabl.TrackReader interface for *.plt files
+func (plt *PltFile) GetValidFileExtensions() []string {
+
+	extensions := []string{FileExtension}
+
+	return extensions
+}
+
+// ReadPltFile - Reads a *.plt file
+func ReadPltFile(filePath string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	plt, fileError := ReadPlt(filePath)
+
+	if fileError != nil {
+		return gpsabl.TrackFile{}, fileError
+	}
+
+	ret, convertError := ConvertPlt(plt, filePath, correction, minimalMovingSpeed, minimalStepHight)
+	if convertError != nil {
+		return gpsabl.TrackFile{}, convertError
+	}
+
+	return ret, nil
+}
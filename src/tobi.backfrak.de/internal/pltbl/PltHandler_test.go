@@ -0,0 +1,180 @@
+package pltbl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+func TestTrackReaderInValidCorrectionParameter(t *testing.T) {
+	plt := NewPltFile(testhelper.GetValidFile("plt", "02.plt"))
+
+	_, err := plt.ReadTracks("asdfg", 0.3, 10.0)
+	if err != nil {
+		switch ty := err.(type) {
+		case *gpsabl.CorrectionParameterNotKnownError:
+			fmt.Println("OK")
+		default:
+			t.Errorf("The Error ReadTracks gave is of the wrong type. The type is %v", ty)
+		}
+	} else {
+		t.Errorf("ReadTracks did not return a error, but was expected")
+	}
+}
+
+func TestReadAllValidPltDueInterface(t *testing.T) {
+	files, _ := ioutil.ReadDir(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-plt"))
+
+	for _, file := range files {
+		pltFile := NewPltFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-plt", file.Name()))
+		if pltFile.CheckFile(file.Name()) && file.IsDir() == false {
+			iPlt := gpsabl.TrackReader(&pltFile)
+
+			track, err := iPlt.ReadTracks("none", 0.3, 10.0)
+			if err != nil {
+				t.Errorf("Got the error \"%s\" while reading file %s.", err.Error(), filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-plt", file.Name()))
+			}
+			if track.Distance <= 0.0 {
+				t.Errorf("The track.Distance is %f but should not be less then %f", track.Distance, 0.0)
+			}
+		}
+	}
+}
+
+func TestReadAllInValidPltDueInterface(t *testing.T) {
+	files, _ := ioutil.ReadDir(filepath.Join(testhelper.GetProjectRoot(), "testdata", "invalid-plt"))
+
+	for _, file := range files {
+		pltFile := NewPltFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "invalid-plt", file.Name()))
+		if pltFile.CheckFile(file.Name()) && file.IsDir() == false {
+			iPlt := gpsabl.TrackReader(&pltFile)
+
+			_, err := iPlt.ReadTracks("none", 0.3, 10.0)
+			if err == nil {
+				t.Errorf("Got no error while reading file %s.", filepath.Join(testhelper.GetProjectRoot(), "testdata", "invalid-plt", file.Name()))
+			}
+		}
+	}
+}
+
+func TestNewReaderWithValidFilePath(t *testing.T) {
+	plt := PltFile{}
+	file := testhelper.GetValidFile("plt", "01.plt")
+	checkRes := plt.CheckFile(file)
+	input := *gpsabl.NewInputFileWithPath(file)
+
+	sut := plt.NewReader(input)
+
+	if checkRes != true {
+		t.Errorf("PltFile can not read %s", file)
+	}
+
+	if plt.CheckInputFile(input) != true {
+		t.Errorf("PltFile can not read the input %s", file)
+	}
+
+	trk, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+	if err != nil {
+		t.Errorf("Got error \"%s\" but expect none", err)
+	}
+
+	if trk.FilePath != file {
+		t.Errorf("The trk.FilePath %s is not the given path %s", trk.FilePath, file)
+	}
+}
+
+func TestNewReaderWithValidBuffer(t *testing.T) {
+	for _, name := range []string{"01.plt", "02.plt"} {
+		plt := PltFile{}
+		buffer, createErr := testhelper.GetValidFileBuffer("plt", name)
+		if createErr != nil {
+			t.Fatalf("Got error \"%s\" while creating the input buffer", createErr)
+		}
+		checkRes := plt.CheckBuffer(buffer)
+		input := *plt.NewInputFileForBuffer(buffer, "Buffer 1")
+
+		sut := plt.NewReader(input)
+
+		if checkRes != true {
+			t.Errorf("PltFile can not read %s from buffer", name)
+		}
+
+		if plt.CheckInputFile(input) != true {
+			t.Errorf("PltFile can not read the input buffer")
+		}
+
+		trk, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+		if err != nil {
+			t.Errorf("Got error \"%s\" but expect none", err)
+		}
+
+		if trk.FilePath != "Buffer 1" {
+			t.Errorf("The trk.FilePath %s is not the given path %s", trk.FilePath, "Buffer 1")
+		}
+	}
+}
+
+func TestNewReaderWithUnknownInputType(t *testing.T) {
+	plt := PltFile{}
+	input := gpsabl.InputFile{Type: "Unknown", Name: "Buffer 1"}
+	sut := plt.NewReader(input)
+
+	if plt.CheckInputFile(input) != false {
+		t.Errorf("PltFile can read an input of unknown type")
+	}
+
+	_, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+	switch err.(type) {
+	case *gpsabl.UnKnownInputFileTypeError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *gpsabl.UnKnownInputFileTypeError, got \"%v\"", err)
+	}
+}
+
+func TestCheckBuffer(t *testing.T) {
+	plt := PltFile{}
+	gpx, _ := testhelper.GetValidGpxBuffer("01.gpx")
+	if plt.CheckBuffer(gpx) != false {
+		t.Errorf("PltFile can read a gpx buffer")
+	}
+
+	invalid, _ := testhelper.GetInvalidFileBuffer("plt", "01.plt")
+	if plt.CheckBuffer(invalid) != false {
+		t.Errorf("PltFile can read an OziExplorer waypoint file")
+	}
+}
+
+func TestCheckFile(t *testing.T) {
+	plt := PltFile{}
+
+	if plt.CheckFile("my/path/file.PLT") != true {
+		t.Errorf("PltFile can not read *.PLT files")
+	}
+
+	if plt.CheckFile("my/path/file.plt") != true {
+		t.Errorf("PltFile can not read *.plt files")
+	}
+
+	if plt.CheckFile("my/path/file.gpx") != false {
+		t.Errorf("PltFile can read *.gpx files")
+	}
+}
+
+func TestGetValidFileExtensions(t *testing.T) {
+	plt := PltFile{}
+	extensions := plt.GetValidFileExtensions()
+
+	if len(extensions) != 1 || extensions[0] != FileExtension {
+		t.Errorf("The valid file extensions are %v, but should be [%s]", extensions, FileExtension)
+	}
+}
@@ -0,0 +1,166 @@
+package pltbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"bytes"
+	"io/ioutil"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fileHeader - The first line of an OziExplorer track file starts with this text
+const fileHeader string = "OziExplorer Track Point File"
+
+// headerLines - The number of header lines before the first track point
+const headerLines int = 6
+
+// feetToMeter - OziExplorer track files store the altitude in feet
+const feetToMeter float64 = 0.3048
+
+// InvalidAltitude - The altitude OziExplorer writes for points without altitude
+const InvalidAltitude float64 = -777
+
+// NoAltitude - The altitude some programs write for points without altitude
+const NoAltitude float64 = -999
+
+// delphiEpoch - The day 0 of the Delphi TDateTime format, used by OziExplorer for the point time
+var delphiEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// Plt - Represents the content of an OziExplorer track file
+type Plt struct {
+	// Datum - The map datum, given in the second line
+	Datum string
+	// Description - The track description, given in the fifth line
+	Description string
+	Points      []PltPoint
+}
+
+// PltPoint - Represents one track point line of an OziExplorer track file
+type PltPoint struct {
+	Latitude  float64
+	Longitude float64
+	// NewSegment - True when the point starts a new segment
+	NewSegment bool
+	// Altitude - The altitude in m
+	Altitude float32
+	// AltitudeValid - False when the file contains the -777 or -999 "no altitude" value for the point
+	AltitudeValid bool
+	Time          time.Time
+	TimeValid     bool
+}
+
+// ReadPlt - Read an OziExplorer track file
+func ReadPlt(fileName string) (Plt, error) {
+	fileBuffer, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return Plt{}, err
+	}
+	return readPLTBuffer(fileBuffer, fileName)
+}
+
+func readPLTBuffer(fileBuffer []byte, fileName string) (Plt, error) {
+	if !isPLTBuffer(fileBuffer) {
+		return Plt{}, newPltFileError(fileName)
+	}
+
+	plt := Plt{}
+	for i, line := range bytes.Split(fileBuffer, []byte("\n")) {
+		str := strings.TrimSpace(string(line))
+		switch {
+		case i == 1:
+			plt.Datum = str
+		case i == 4:
+			plt.Description = getTrackDescription(str)
+		case i >= headerLines && str != "":
+			point, valid := parsePointLine(str)
+			if !valid {
+				return Plt{}, newPltPointError(fileName, i+1)
+			}
+			plt.Points = append(plt.Points, point)
+		}
+	}
+
+	return plt, nil
+}
+
+// isPLTBuffer - Tell if a buffer starts with the header of an OziExplorer track file
+func isPLTBuffer(buffer []byte) bool {
+	return bytes.HasPrefix(bytes.TrimLeft(buffer, "\ufeff \t\r\n"), []byte(fileHeader))
+}
+
+// getTrackDescription - The fifth line looks like "0,2,255,My Track,0,0,2,8421376", the fourth field is the description
+func getTrackDescription(line string) string {
+	fields := strings.Split(line, ",")
+	if len(fields) < 4 {
+		return ""
+	}
+
+	return strings.TrimSpace(fields[3])
+}
+
+// parsePointLine - Parse a point line like "  45.273245,  13.715185,1,889.1,41873.7006019,22-Aug-14, 4:48:52 PM".
+// The fields are latitude, longitude, segment start code, altitude in feet, Delphi date, date and time as text
+func parsePointLine(line string) (PltPoint, bool) {
+	point := PltPoint{}
+	fields := strings.Split(line, ",")
+	if len(fields) < 4 {
+		return point, false
+	}
+
+	lat, latErr := strconv.ParseFloat(strings.TrimSpace(fields[0]), 64)
+	lon, lonErr := strconv.ParseFloat(strings.TrimSpace(fields[1]), 64)
+	if latErr != nil || lonErr != nil || math.Abs(lat) > 90 || math.Abs(lon) > 180 {
+		return point, false
+	}
+	point.Latitude = lat
+	point.Longitude = lon
+	point.NewSegment = strings.TrimSpace(fields[2]) == "1"
+
+	altitude, altErr := strconv.ParseFloat(strings.TrimSpace(fields[3]), 64)
+	if altErr != nil {
+		return point, false
+	}
+	if altitude != InvalidAltitude && altitude != NoAltitude {
+		point.Altitude = float32(altitude * feetToMeter)
+		point.AltitudeValid = true
+	}
+
+	point.Time, point.TimeValid = getPointTime(fields)
+
+	return point, true
+}
+
+// getPointTime - Get the time from the Delphi date field, or from the date and time text fields if the Delphi date is not set
+func getPointTime(fields []string) (time.Time, bool) {
+	if len(fields) > 4 {
+		days, err := strconv.ParseFloat(strings.TrimSpace(fields[4]), 64)
+		if err == nil && days > 0 {
+			return ConvertDelphiDate(days), true
+		}
+	}
+
+	if len(fields) > 6 {
+		text := strings.TrimSpace(fields[5]) + " " + strings.TrimSpace(fields[6])
+		for _, layout := range []string{"02-Jan-06 3:04:05 PM", "02-Jan-06 15:04:05"} {
+			ret, err := time.Parse(layout, text)
+			if err == nil {
+				return ret, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// ConvertDelphiDate - Convert a Delphi TDateTime, the days since 1899-12-30 with the time as fraction, to a time.Time.
+// The result is rounded to seconds, since the seven decimal places OziExplorer writes are not more exact
+func ConvertDelphiDate(days float64) time.Time {
+	seconds := math.Round(days * 24 * 60 * 60)
+
+	return delphiEpoch.Add(time.Duration(seconds) * time.Second)
+}
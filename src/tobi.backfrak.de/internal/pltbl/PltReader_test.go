@@ -0,0 +1,136 @@
+package pltbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func TestReadValidPlt01(t *testing.T) {
+	plt, err := ReadPlt(testhelper.GetValidFile("plt", "01.plt"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if plt.Datum != "WGS 84" {
+		t.Errorf("The Datum is \"%s\", but should be \"%s\"", plt.Datum, "WGS 84")
+	}
+
+	if plt.Description != "Istria Hike" {
+		t.Errorf("The Description is \"%s\", but should be \"%s\"", plt.Description, "Istria Hike")
+	}
+
+	if len(plt.Points) != 413 {
+		t.Fatalf("The number of Points is %d, but should be %d", len(plt.Points), 413)
+	}
+
+	first := plt.Points[0]
+	if first.NewSegment != true || first.Latitude != 45.273245 || first.Longitude != 13.715185 {
+		t.Errorf("The first point is %v", first)
+	}
+
+	// 889.1 ft
+	if first.AltitudeValid != true || int(first.Altitude*10) != 2709 {
+		t.Errorf("The Altitude is %f, but should be %f", first.Altitude, 270.9)
+	}
+
+	if first.TimeValid != true || first.Time.Format(time.RFC3339) != "2014-08-22T16:48:52Z" {
+		t.Errorf("The Time is %s, but should be %s", first.Time.Format(time.RFC3339), "2014-08-22T16:48:52Z")
+	}
+
+	if plt.Points[10].AltitudeValid != false {
+		t.Errorf("The point 10 with -999 altitude has a valid altitude of %f", plt.Points[10].Altitude)
+	}
+}
+
+func TestReadValidPltWithoutDelphiDate(t *testing.T) {
+	plt, err := ReadPlt(testhelper.GetValidFile("plt", "02.plt"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(plt.Points) != 6 {
+		t.Fatalf("The number of Points is %d, but should be %d", len(plt.Points), 6)
+	}
+
+	if plt.Points[1].TimeValid != true || plt.Points[1].Time.Format(time.RFC3339) != "2014-08-22T16:49:07Z" {
+		t.Errorf("The Time is %s, but should be %s", plt.Points[1].Time.Format(time.RFC3339), "2014-08-22T16:49:07Z")
+	}
+
+	if plt.Points[0].AltitudeValid != false || plt.Points[4].AltitudeValid != false {
+		t.Errorf("The points with -777 altitude have a valid altitude")
+	}
+
+	if plt.Points[3].NewSegment != true || plt.Points[2].NewSegment != false {
+		t.Errorf("The segment start codes are not read as expected")
+	}
+}
+
+func TestReadPltWrongHeader(t *testing.T) {
+	_, err := ReadPlt(testhelper.GetInvalidFile("plt", "01.plt"))
+	switch err.(type) {
+	case *PltFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *PltFileError, got \"%v\"", err)
+	}
+}
+
+func TestReadPltInvalidPoint(t *testing.T) {
+	_, err := ReadPlt(testhelper.GetInvalidFile("plt", "02.plt"))
+	switch ty := err.(type) {
+	case *PltPointError:
+		if ty.Line != 8 {
+			t.Errorf("The Line is %d, but should be %d", ty.Line, 8)
+		}
+	default:
+		t.Errorf("Expected a *PltPointError, got \"%v\"", err)
+	}
+}
+
+func TestReadPltNotExistingFile(t *testing.T) {
+	_, err := ReadPlt(testhelper.GetInvalidFile("plt", "not-existing.plt"))
+	switch err.(type) {
+	case *os.PathError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *os.PathError, got \"%v\"", err)
+	}
+}
+
+func TestConvertDelphiDate(t *testing.T) {
+	if ConvertDelphiDate(0).Format(time.RFC3339) != "1899-12-30T00:00:00Z" {
+		t.Errorf("The day 0 is %s, but should be %s", ConvertDelphiDate(0).Format(time.RFC3339), "1899-12-30T00:00:00Z")
+	}
+
+	// The OziExplorer documentation example
+	if ConvertDelphiDate(36169.6307194).Format(time.RFC3339) != "1999-01-09T15:08:14Z" {
+		t.Errorf("The date is %s, but should be %s", ConvertDelphiDate(36169.6307194).Format(time.RFC3339), "1999-01-09T15:08:14Z")
+	}
+}
+
+func TestParsePointLine(t *testing.T) {
+	point, valid := parsePointLine("-27.350436, 153.055540,0,-999,36169.6307194, 09-Jan-99, 3:08:14 PM")
+	if valid != true {
+		t.Fatalf("The point line was not parsed")
+	}
+
+	if point.Latitude != -27.350436 || point.Longitude != 153.05554 || point.AltitudeValid != false {
+		t.Errorf("The point is %v", point)
+	}
+
+	if _, valid := parsePointLine("-27.350436, 153.055540,0"); valid != false {
+		t.Errorf("A point line without altitude is valid")
+	}
+
+	if _, valid := parsePointLine("-97.350436, 153.055540,0,100,0"); valid != false {
+		t.Errorf("A point line with a latitude out of range is valid")
+	}
+}
@@ -0,0 +1,43 @@
+package sqlitebl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import "fmt"
+
+// SqliteFileError - Error when the output file is no SQLite database gpsa can read and append the tracks to
+type SqliteFileError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+	// Reason - Why the file can not be read
+	Reason string
+}
+
+func (e *SqliteFileError) Error() string { // Implement the Error Interface for the SqliteFileError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newSqliteFileError - Get a new SqliteFileError struct
+func newSqliteFileError(fileName string, reason string) *SqliteFileError {
+	return &SqliteFileError{fmt.Sprintf("The file \"%s\" is not a SQLite database the tracks can be appended to: %s", fileName, reason), fileName, reason}
+}
+
+// SchemaNotKnownError - Error when a table of gpsa in the database misses a column gpsa writes, or the column has an other type
+type SchemaNotKnownError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+	// Object - The name of the table that is not known
+	Object string
+}
+
+func (e *SchemaNotKnownError) Error() string { // Implement the Error Interface for the SchemaNotKnownError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newSchemaNotKnownError - Get a new SchemaNotKnownError struct
+func newSchemaNotKnownError(fileName string, object string) *SchemaNotKnownError {
+	return &SchemaNotKnownError{fmt.Sprintf("The database \"%s\" contains the table \"%s\" without the columns written by gpsa, so the tracks can not be appended", fileName, object), fileName, object}
+}
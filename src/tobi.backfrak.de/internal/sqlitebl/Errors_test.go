@@ -0,0 +1,43 @@
+package sqlitebl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"strings"
+	"testing"
+)
+
+func TestSqliteFileError(t *testing.T) {
+	path := "/some/sample/path"
+	reason := "the page size is not valid"
+	err := newSqliteFileError(path, reason)
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of SqliteFileError does not contain the expected Path")
+	}
+
+	if strings.Contains(err.Error(), reason) == false {
+		t.Errorf("The error message of SqliteFileError does not contain the expected reason")
+	}
+
+	if err.File != path || err.Reason != reason {
+		t.Errorf("The SqliteFileError.File or SqliteFileError.Reason does not match the expected value")
+	}
+}
+
+func TestSchemaNotKnownError(t *testing.T) {
+	path := "/some/sample/path"
+	err := newSchemaNotKnownError(path, "runs")
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of SchemaNotKnownError does not contain the expected Path")
+	}
+
+	if strings.Contains(err.Error(), "runs") == false {
+		t.Errorf("The error message of SchemaNotKnownError does not contain the expected table name")
+	}
+
+	if err.File != path || err.Object != "runs" {
+		t.Errorf("The SchemaNotKnownError.File or SchemaNotKnownError.Object does not match the expected value")
+	}
+}
@@ -0,0 +1,828 @@
+package sqlitebl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+import (
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+
+	// The pure go SQLite driver, so gpsa is still built without cgo
+	_ "modernc.org/sqlite"
+)
+
+// SqliteOutputFormatertype - The gpsabl.OutputFormaterType this formater is responsible for
+const SqliteOutputFormatertype gpsabl.OutputFormaterType = "SQLITE"
+
+// timeLayout - The layout of the times in the database. The fixed number of digits keeps the order of the texts the
+// order of the times, and the date and time functions of SQLite understand this layout
+const timeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// The names of the tables the formater writes
+const (
+	filesTable     = "files"
+	tracksTable    = "tracks"
+	segmentsTable  = "segments"
+	pointsTable    = "points"
+	waypointsTable = "waypoints"
+)
+
+// sqliteColumn - A column of a table the formater writes
+type sqliteColumn struct {
+	name     string
+	dataType string
+}
+
+// sqliteTable - A table the formater writes
+type sqliteTable struct {
+	name    string
+	columns []sqliteColumn
+	// indexColumn - The column an index is written for. This is the column with the Id of the parent row, like the FileId of
+	// a track, or the Path of a file, so the files already stored are found fast
+	indexColumn string
+}
+
+// sqliteTables - The tables of the database, in the order they are written. The Id of each table is the rowid
+var sqliteTables = []sqliteTable{
+	{filesTable, append([]sqliteColumn{
+		{"Id", "INTEGER PRIMARY KEY"},
+		{"Path", "TEXT NOT NULL"},
+		{"Hash", "TEXT NOT NULL"},
+		{"ImportTime", "TEXT NOT NULL"},
+		{"Name", "TEXT"},
+		{"Description", "TEXT"},
+	}, getSummaryColumns()...), "Path"},
+	{tracksTable, append([]sqliteColumn{
+		{"Id", "INTEGER PRIMARY KEY"},
+		{"FileId", "INTEGER NOT NULL REFERENCES files (Id)"},
+		{"Number", "INTEGER NOT NULL"},
+		{"Name", "TEXT"},
+		{"Description", "TEXT"},
+		{"IsRoute", "INTEGER NOT NULL"},
+	}, getSummaryColumns()...), "FileId"},
+	{segmentsTable, append([]sqliteColumn{
+		{"Id", "INTEGER PRIMARY KEY"},
+		{"TrackId", "INTEGER NOT NULL REFERENCES tracks (Id)"},
+		{"Number", "INTEGER NOT NULL"},
+		{"Calories", "INTEGER"},
+		{"Intensity", "TEXT"},
+		{"TriggerMethod", "TEXT"},
+	}, getSummaryColumns()...), "TrackId"},
+	{pointsTable, []sqliteColumn{
+		{"Id", "INTEGER PRIMARY KEY"},
+		{"SegmentId", "INTEGER NOT NULL REFERENCES segments (Id)"},
+		{"Number", "INTEGER NOT NULL"},
+		{"Latitude", "REAL NOT NULL"},
+		{"Longitude", "REAL NOT NULL"},
+		{"Elevation", "REAL"},
+		{"CorrectedElevation", "REAL"},
+		{"Time", "TEXT"},
+		{"DistanceToThisPoint", "REAL"},
+		{"DistanceBefore", "REAL"},
+		{"DistanceNext", "REAL"},
+		{"HorizontalDistanceBefore", "REAL"},
+		{"HorizontalDistanceNext", "REAL"},
+		{"VerticalDistanceBefore", "REAL"},
+		{"VerticalDistanceNext", "REAL"},
+		{"CountUpwards", "INTEGER"},
+		{"CountDownwards", "INTEGER"},
+		{"CountMoving", "INTEGER"},
+		{"MovingTime", "REAL"},
+		{"TimeDurationBefore", "REAL"},
+		{"TimeDurationNext", "REAL"},
+		{"UpwardsTime", "REAL"},
+		{"DownwardsTime", "REAL"},
+		{"AverageSpeed", "REAL"},
+		{"SpeedBefore", "REAL"},
+		{"SpeedNext", "REAL"},
+		{"RecordedSpeed", "REAL"},
+		{"HeartRate", "INTEGER"},
+		{"Cadence", "INTEGER"},
+		{"Power", "INTEGER"},
+		{"Temperature", "REAL"},
+	}, "SegmentId"},
+	{waypointsTable, []sqliteColumn{
+		{"Id", "INTEGER PRIMARY KEY"},
+		{"FileId", "INTEGER NOT NULL REFERENCES files (Id)"},
+		{"Name", "TEXT"},
+		{"Description", "TEXT"},
+		{"Latitude", "REAL NOT NULL"},
+		{"Longitude", "REAL NOT NULL"},
+		{"Elevation", "REAL"},
+		{"Time", "TEXT"},
+		{"TrackName", "TEXT"},
+		{"DistanceAlongTrack", "REAL"},
+		{"DistanceFromTrack", "REAL"},
+	}, "FileId"},
+}
+
+// getSummaryColumns - Get the columns of the summary values of the files, tracks and segments. They are named like the
+// gpsabl.SortColumn values. Distances are given in m, times in s and speeds in m/s
+func getSummaryColumns() []sqliteColumn {
+	ret := []sqliteColumn{}
+	for _, column := range gpsabl.GetValidSortColumns() {
+		switch column {
+		case gpsabl.NAME:
+		case gpsabl.STARTTIME, gpsabl.ENDTIME, gpsabl.ACTIVITYTYPE, gpsabl.GEAR:
+			ret = append(ret, sqliteColumn{string(column), "TEXT"})
+		default:
+			ret = append(ret, sqliteColumn{string(column), "REAL"})
+		}
+	}
+
+	return ret
+}
+
+// getSQL - Get the CREATE TABLE statement of the table. The table is only created, when the database does not contain it yet
+func (table sqliteTable) getSQL() string {
+	columns := []string{}
+	for _, column := range table.columns {
+		columns = append(columns, fmt.Sprintf("  %s %s", column.name, column.dataType))
+	}
+
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n%s\n)", table.name, strings.Join(columns, ",\n"))
+}
+
+// getIndexSQL - Get the CREATE INDEX statement of the index of the indexColumn
+func (table sqliteTable) getIndexSQL() string {
+	return fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_%s ON %s (%s)", table.name, table.indexColumn, table.name, table.indexColumn)
+}
+
+// getInsertSQL - Get the INSERT statement of a row of the table. The Id is given by SQLite
+func (table sqliteTable) getInsertSQL() string {
+	columns := []string{}
+	values := []string{}
+	for _, column := range table.columns[1:] {
+		columns = append(columns, column.name)
+		values = append(values, "?")
+	}
+
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table.name, strings.Join(columns, ", "), strings.Join(values, ", "))
+}
+
+// hashedTrackFile - A TrackFile together with the hash of the file content. It is the data of the lines in the buffer
+type hashedTrackFile struct {
+	gpsabl.TrackFile
+	hash string
+}
+
+// sqlQueryer - A database or transaction, the stored files are looked up in
+type sqlQueryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// SqliteOutputFormater - type that writes the TrackFiles into a SQLite database. Each file is written with its tracks, segments,
+// track points and waypoints into the tables "files", "tracks", "segments", "points" and "waypoints", so the depth is not used.
+// When the output file is an existing database, the files are appended and files already stored, with the same path and hash, are skipped
+type SqliteOutputFormater struct {
+	// Tell if the waypoints of the TrackFiles should be listed. Not used, the waypoints are part of the file data and always written
+	AddWaypoints bool
+
+	// Sorting - The order the files are added to the database in. The files are added in the order they are added by default
+	Sorting gpsabl.OutputSorting
+
+	writtenEntiresCount int
+	skippedFileCount    int
+	fileBuffer          []gpsabl.OutputLine
+	// database - The existing database the files are appended to. It is open from ReadDatabase until WriteOutput
+	database *sql.DB
+	mux      sync.Mutex
+}
+
+// NewSqliteOutputFormater - Get a new SqliteOutputFormater
+func NewSqliteOutputFormater() *SqliteOutputFormater {
+	ret := SqliteOutputFormater{}
+	ret.writtenEntiresCount = -1
+	ret.fileBuffer = []gpsabl.OutputLine{}
+
+	return &ret
+}
+
+// NewOutputFormater -  Get a new gpsabl.OutputFormater of this type
+func (formater *SqliteOutputFormater) NewOutputFormater() gpsabl.OutputFormater {
+	ret := NewSqliteOutputFormater()
+
+	return gpsabl.OutputFormater(ret)
+}
+
+// GetTextOutputFormater - Get the gpsabl.TextOutputFormater. This is always nil for this formater
+func (formater *SqliteOutputFormater) GetTextOutputFormater() gpsabl.TextOutputFormater {
+	return nil
+}
+
+// SetAddWaypoints - Set the value of formater.AddWaypoints
+func (formater *SqliteOutputFormater) SetAddWaypoints(value bool) {
+	formater.AddWaypoints = value
+}
+
+// SetSorting - Set the value of formater.Sorting
+func (formater *SqliteOutputFormater) SetSorting(sorting gpsabl.OutputSorting) {
+	formater.Sorting = sorting
+}
+
+// ReadDatabase - Open the database of the output file, so the new files are appended to it. The database stays open until
+// WriteOutput is called. Returns an error, when the file is no SQLite database, or the tables of gpsa miss columns
+func (formater *SqliteOutputFormater) ReadDatabase(outFile *os.File) error {
+	database, errOpen := openDatabase(outFile.Name())
+	if errOpen != nil {
+		return errOpen
+	}
+
+	formater.mux.Lock()
+	defer formater.mux.Unlock()
+	if formater.database != nil {
+		formater.database.Close()
+	}
+	formater.database = database
+
+	return nil
+}
+
+// SkipImportedFile - Tell if the database already contains a file with the path and hash, so the file does not need to be read.
+// The file is counted as skipped then
+func (formater *SqliteOutputFormater) SkipImportedFile(path string, hash string) (bool, error) {
+	formater.mux.Lock()
+	defer formater.mux.Unlock()
+	if formater.database == nil {
+		return false, nil
+	}
+
+	imported, err := containsFile(formater.database, path, hash)
+	if err != nil {
+		return false, err
+	}
+	if imported {
+		formater.skippedFileCount++
+	}
+
+	return imported, nil
+}
+
+// AddOutPut - Add a TrackFile to the internal buffer, so it can be written out later. The hash is calculated from the file
+// at the FilePath of the TrackFile. Use AddFile when the content is not read from this file
+func (formater *SqliteOutputFormater) AddOutPut(trackFile gpsabl.TrackFile, depth gpsabl.DepthArg, filterDuplicate bool) error {
+	switch depth {
+	case gpsabl.FILE, gpsabl.TRACK, gpsabl.SEGMENT:
+	default:
+		return gpsabl.NewDepthParameterNotKnownError(depth)
+	}
+
+	hash, errHash := gpsabl.NewInputFileWithPath(trackFile.FilePath).GetContentHash()
+	if errHash != nil {
+		return errHash
+	}
+
+	return formater.AddFile(trackFile, hash, filterDuplicate)
+}
+
+// AddFile - Add a TrackFile together with the hash of its content to the internal buffer. The file is skipped, when the
+// database or the buffer already contain a file with the same path and hash. When filterDuplicate is set, files with the
+// same start and end time as an other file are skipped too
+func (formater *SqliteOutputFormater) AddFile(trackFile gpsabl.TrackFile, hash string, filterDuplicate bool) error {
+	lines, err := gpsabl.GetOutlines(trackFile, gpsabl.FILE)
+	if err != nil {
+		return err
+	}
+	line := gpsabl.OutputLine{Name: lines[0].Name, Data: hashedTrackFile{trackFile, hash}}
+
+	formater.mux.Lock()
+	defer formater.mux.Unlock()
+	if formater.bufferContainsFile(trackFile.FilePath, hash) {
+		formater.skippedFileCount++
+		return nil
+	}
+	if formater.database != nil {
+		imported, errImported := containsFile(formater.database, trackFile.FilePath, hash)
+		if errImported != nil {
+			return errImported
+		}
+		if imported {
+			formater.skippedFileCount++
+			return nil
+		}
+	}
+
+	if filterDuplicate {
+		if gpsabl.OutputContainsLineByTimeStamps(formater.fileBuffer, line) {
+			return nil
+		}
+		if formater.database != nil {
+			found, errFound := containsTimeStamps(formater.database, line)
+			if errFound != nil {
+				return errFound
+			}
+			if found {
+				return nil
+			}
+		}
+	}
+
+	formater.fileBuffer = append(formater.fileBuffer, line)
+
+	return nil
+}
+
+// bufferContainsFile - Tell if the buffer contains a file with the path and hash
+func (formater *SqliteOutputFormater) bufferContainsFile(path string, hash string) bool {
+	for _, line := range formater.fileBuffer {
+		file := line.Data.(hashedTrackFile)
+		if file.FilePath == path && file.hash == hash {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WriteOutput - Write the new files to the database of the output file. The summary is not part of the database, so only the value is checked.
+// The files are inserted in one transaction, so either all or none of them are stored. The database is closed afterwards.
+// When the output is StdOut, the database is written to a temporary file, that is copied to StdOut
+func (formater *SqliteOutputFormater) WriteOutput(outFile *os.File, summary gpsabl.SummaryArg) error {
+	switch summary {
+	case gpsabl.NONE, gpsabl.ONLY, gpsabl.ADDITIONAL:
+	default:
+		return gpsabl.NewSummaryParamaterNotKnown(summary)
+	}
+
+	if outFile == os.Stdout {
+		return formater.writeStdOut(outFile)
+	}
+
+	fileCount, errWrite := formater.writeDatabase(outFile.Name(), time.Now())
+	if errWrite != nil {
+		return errWrite
+	}
+	formater.writtenEntiresCount = fileCount
+
+	return nil
+}
+
+// writeStdOut - Write the database to a temporary file and copy it to the outFile. Nothing is written when no file was added
+func (formater *SqliteOutputFormater) writeStdOut(outFile *os.File) error {
+	dir, errDir := ioutil.TempDir("", "gpsa")
+	if errDir != nil {
+		return errDir
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "gpsa.sqlite")
+	fileCount, errWrite := formater.writeDatabase(path, time.Now())
+	if errWrite != nil {
+		return errWrite
+	}
+
+	if fileCount > 0 {
+		content, errRead := ioutil.ReadFile(path)
+		if errRead != nil {
+			return errRead
+		}
+		if _, errOut := outFile.Write(content); errOut != nil {
+			return errOut
+		}
+	}
+	formater.writtenEntiresCount = fileCount
+
+	return nil
+}
+
+// writeDatabase - Insert the files of the buffer into the database at the path and get the number of files stored in it.
+// The tables are created, when the database does not contain them yet. Nothing is written, when no file was added
+func (formater *SqliteOutputFormater) writeDatabase(path string, importTime time.Time) (int, error) {
+	formater.mux.Lock()
+	defer formater.mux.Unlock()
+	database := formater.database
+	formater.database = nil
+	if database == nil {
+		var errOpen error
+		database, errOpen = openDatabase(path)
+		if errOpen != nil {
+			return 0, errOpen
+		}
+	}
+	defer database.Close()
+
+	if len(formater.fileBuffer) > 0 {
+		gpsabl.SortOutputLines(formater.fileBuffer, formater.Sorting)
+		skipped, errInsert := insertFiles(database, formater.fileBuffer, importTime)
+		if errInsert != nil {
+			return 0, errInsert
+		}
+		formater.skippedFileCount += skipped
+	}
+
+	return getFileCount(database)
+}
+
+// openDatabase - Open the SQLite database at the path and check, that the tables of gpsa it contains have the columns
+// gpsa writes. Other tables, views, indexes, triggers and columns are not touched
+func openDatabase(path string) (*sql.DB, error) {
+	database, errOpen := sql.Open("sqlite", path)
+	if errOpen != nil {
+		return nil, newSqliteFileError(path, errOpen.Error())
+	}
+
+	// The busy timeout is set for the connection, so there is only one. Other processes may write the database at the same time
+	database.SetMaxOpenConns(1)
+	if _, errPragma := database.Exec("PRAGMA busy_timeout = 10000"); errPragma != nil {
+		database.Close()
+		return nil, newSqliteFileError(path, errPragma.Error())
+	}
+
+	if errSchema := checkSchema(database, path); errSchema != nil {
+		database.Close()
+		return nil, errSchema
+	}
+
+	return database, nil
+}
+
+// checkSchema - Check that each table of gpsa, the database contains, has the columns with the types gpsa writes.
+// Tables that are missing are created when the files are written
+func checkSchema(database *sql.DB, path string) error {
+	for _, table := range sqliteTables {
+		rows, errQuery := database.Query(fmt.Sprintf("PRAGMA table_info(%s)", table.name))
+		if errQuery != nil {
+			return newSqliteFileError(path, errQuery.Error())
+		}
+
+		columns := make(map[string]string)
+		for rows.Next() {
+			var cid, notNull, primaryKey int
+			var name, dataType string
+			var defaultValue interface{}
+			if errScan := rows.Scan(&cid, &name, &dataType, &notNull, &defaultValue, &primaryKey); errScan != nil {
+				rows.Close()
+				return newSqliteFileError(path, errScan.Error())
+			}
+			columns[strings.ToLower(name)] = strings.ToUpper(dataType)
+		}
+		errRows := rows.Err()
+		rows.Close()
+		if errRows != nil {
+			return newSqliteFileError(path, errRows.Error())
+		}
+
+		if len(columns) == 0 {
+			continue
+		}
+		for _, column := range table.columns {
+			dataType, found := columns[strings.ToLower(column.name)]
+			if !found || dataType != strings.Fields(column.dataType)[0] {
+				return newSchemaNotKnownError(path, table.name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// getFileCount - Get the number of files stored in the database. This is 0, when the database does not contain the files table
+func getFileCount(database *sql.DB) (int, error) {
+	exists, errExists := filesTableExists(database)
+	if errExists != nil || !exists {
+		return 0, errExists
+	}
+
+	ret := 0
+	if errCount := database.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s", filesTable)).Scan(&ret); errCount != nil {
+		return 0, errCount
+	}
+
+	return ret, nil
+}
+
+// containsFile - Tell if the files table contains a file with the path and hash. A database without the table contains no files
+func containsFile(queryer sqlQueryer, path string, hash string) (bool, error) {
+	return containsRow(queryer, fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE Path = ? AND Hash = ?", filesTable), path, hash)
+}
+
+// containsTimeStamps - Tell if the files table contains a file with the same start and end time as the line
+func containsTimeStamps(queryer sqlQueryer, line gpsabl.OutputLine) (bool, error) {
+	if !line.Data.GetTimeDataValid() {
+		return false, nil
+	}
+
+	startTime := line.Data.GetStartTime().UTC().Format(timeLayout)
+	endTime := line.Data.GetEndTime().UTC().Format(timeLayout)
+	return containsRow(queryer, fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s = ? AND %s = ?", filesTable, gpsabl.STARTTIME, gpsabl.ENDTIME), startTime, endTime)
+}
+
+// containsRow - Tell if the COUNT(*) query of the files table finds a row. A database without the table contains no files
+func containsRow(queryer sqlQueryer, query string, args ...interface{}) (bool, error) {
+	exists, errExists := filesTableExists(queryer)
+	if errExists != nil || !exists {
+		return false, errExists
+	}
+
+	count := 0
+	if errCount := queryer.QueryRow(query, args...).Scan(&count); errCount != nil {
+		return false, errCount
+	}
+
+	return count > 0, nil
+}
+
+// filesTableExists - Tell if the database contains the files table. A new database gets the tables, when the first files are written
+func filesTableExists(queryer sqlQueryer) (bool, error) {
+	count := 0
+	if err := queryer.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", filesTable).Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// insertFiles - Insert the files of the lines with their tracks, segments, points and waypoints in one transaction. The tables
+// are created first, when they do not exist. Files an other process stored in the meantime are skipped, their number is returned
+func insertFiles(database *sql.DB, lines []gpsabl.OutputLine, importTime time.Time) (int, error) {
+	tx, errBegin := database.Begin()
+	if errBegin != nil {
+		return 0, errBegin
+	}
+
+	skipped, errInsert := insertFilesInTransaction(tx, lines, importTime)
+	if errInsert != nil {
+		tx.Rollback()
+		return 0, errInsert
+	}
+
+	return skipped, tx.Commit()
+}
+
+// insertFilesInTransaction - Create the tables and insert the files of the lines within the transaction
+func insertFilesInTransaction(tx *sql.Tx, lines []gpsabl.OutputLine, importTime time.Time) (int, error) {
+	statements := make(map[string]*sql.Stmt)
+	for _, table := range sqliteTables {
+		if _, errCreate := tx.Exec(table.getSQL()); errCreate != nil {
+			return 0, errCreate
+		}
+		if _, errIndex := tx.Exec(table.getIndexSQL()); errIndex != nil {
+			return 0, errIndex
+		}
+
+		statement, errPrepare := tx.Prepare(table.getInsertSQL())
+		if errPrepare != nil {
+			return 0, errPrepare
+		}
+		defer statement.Close()
+		statements[table.name] = statement
+	}
+
+	skipped := 0
+	for _, line := range lines {
+		file := line.Data.(hashedTrackFile)
+		imported, errImported := containsFile(tx, file.FilePath, file.hash)
+		if errImported != nil {
+			return 0, errImported
+		}
+		if imported {
+			skipped++
+			continue
+		}
+
+		if errInsert := insertFile(statements, file, importTime); errInsert != nil {
+			return 0, errInsert
+		}
+	}
+
+	return skipped, nil
+}
+
+// insertFile - Insert the rows of the file, its tracks, segments, points and waypoints with the prepared statements of the tables
+func insertFile(statements map[string]*sql.Stmt, file hashedTrackFile, importTime time.Time) error {
+	values := []interface{}{file.FilePath, file.hash, importTime.UTC().Format(timeLayout), getTextValue(file.Name), getTextValue(file.Description)}
+	fileID, errFile := insertRow(statements[filesTable], append(values, getSummaryValues(file.TrackFile)...))
+	if errFile != nil {
+		return errFile
+	}
+
+	for iTrack, track := range file.Tracks {
+		values := []interface{}{fileID, int64(iTrack + 1), getTextValue(track.Name), getTextValue(track.Description), getBoolValue(track.IsRoute)}
+		trackID, errTrack := insertRow(statements[tracksTable], append(values, getSummaryValues(track)...))
+		if errTrack != nil {
+			return errTrack
+		}
+
+		for iSegment, segment := range track.TrackSegments {
+			values := []interface{}{trackID, int64(iSegment + 1), getIntValue(segment.Calories, segment.Calories != 0), getTextValue(segment.Intensity), getTextValue(segment.TriggerMethod)}
+			segmentID, errSegment := insertRow(statements[segmentsTable], append(values, getSummaryValues(segment)...))
+			if errSegment != nil {
+				return errSegment
+			}
+
+			for _, point := range segment.TrackPoints {
+				if _, errPoint := insertRow(statements[pointsTable], getPointValues(segmentID, point)); errPoint != nil {
+					return errPoint
+				}
+			}
+		}
+	}
+
+	for _, waypoint := range file.Waypoints {
+		if _, errWaypoint := insertRow(statements[waypointsTable], getWaypointValues(fileID, waypoint)); errWaypoint != nil {
+			return errWaypoint
+		}
+	}
+
+	return nil
+}
+
+// insertRow - Insert a row with the prepared statement and get its Id
+func insertRow(statement *sql.Stmt, values []interface{}) (int64, error) {
+	result, errExec := statement.Exec(values...)
+	if errExec != nil {
+		return 0, errExec
+	}
+
+	return result.LastInsertId()
+}
+
+// CheckOutputFormaterType - Check if this OutputFormater is responsible for the given gpsabl.OutputFormaterType
+func (formater *SqliteOutputFormater) CheckOutputFormaterType(formaterType gpsabl.OutputFormaterType) bool {
+	if formaterType == SqliteOutputFormatertype {
+		return true
+	}
+
+	return false
+}
+
+// GetOutputFormaterTypes - Get the list of gpsabl.OutputFormaterType this formater can write
+func (formater *SqliteOutputFormater) GetOutputFormaterTypes() []gpsabl.OutputFormaterType {
+	return []gpsabl.OutputFormaterType{SqliteOutputFormatertype}
+}
+
+// CheckFileExtension - Check if this OutputFormater can write the given output file
+func (formater *SqliteOutputFormater) CheckFileExtension(filePath string) bool {
+	for _, extension := range formater.GetFileExtensions() {
+		if strings.HasSuffix(strings.ToLower(filePath), extension) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetFileExtensions - Get the list of file extensions this formater can write
+func (formater *SqliteOutputFormater) GetFileExtensions() []string {
+	return []string{".sqlite", ".db"}
+}
+
+// GetOutputTableLineCount - Get the number of files in the buffer, that are not stored in the database yet
+func (formater *SqliteOutputFormater) GetOutputTableLineCount() int {
+	return len(formater.fileBuffer)
+}
+
+// Tells the number if output entries that are written to output.
+// * -1: When output was not written yet
+// * 0: Output was written but contains no entries, may because no entry passes the given filter
+// * >0: The number of files stored in the database, including the files that were stored before
+func (formater *SqliteOutputFormater) GetNumberOfOutputEntries() int {
+	return formater.writtenEntiresCount
+}
+
+// GetSkippedFileCount - Get the number of files that were not added, because the database already contains them
+func (formater *SqliteOutputFormater) GetSkippedFileCount() int {
+	return formater.skippedFileCount
+}
+
+// getSummaryValues - Get the values of the summary columns. Values that are not valid, like the times of a track without time stamps, are NULL
+func getSummaryValues(data gpsabl.TrackSummaryProvider) []interface{} {
+	line := gpsabl.OutputLine{Data: data}
+	ret := []interface{}{}
+	for _, column := range gpsabl.GetValidSortColumns() {
+		switch column {
+		case gpsabl.NAME:
+		case gpsabl.STARTTIME:
+			ret = append(ret, getTimeValue(data.GetStartTime(), data.GetTimeDataValid()))
+		case gpsabl.ENDTIME:
+			ret = append(ret, getTimeValue(data.GetEndTime(), data.GetTimeDataValid()))
+		case gpsabl.ACTIVITYTYPE:
+			ret = append(ret, getTextValue(data.GetActivityType()))
+		case gpsabl.GEAR:
+			ret = append(ret, getTextValue(data.GetGear()))
+		default:
+			value, valid := gpsabl.GetNumberValue(line, column)
+			ret = append(ret, getFloatValue(value, valid))
+		}
+	}
+
+	return ret
+}
+
+// getPointValues - Get the values of a row of the points table
+func getPointValues(segmentID int64, point gpsabl.TrackPoint) []interface{} {
+	return []interface{}{
+		segmentID,
+		int64(point.Number),
+		getFloat32Value(point.Latitude, true),
+		getFloat32Value(point.Longitude, true),
+		getFloat32Value(point.Elevation, !point.ElevationMissing),
+		getFloat32Value(point.CorectedElevation, true),
+		getTimeValue(point.Time, point.TimeValid),
+		point.DistanceToThisPoint,
+		point.DistanceBefore,
+		point.DistanceNext,
+		point.HorizontalDistanceBefore,
+		point.HorizontalDistanceNext,
+		getFloat32Value(point.VerticalDistanceBefore, true),
+		getFloat32Value(point.VerticalDistanceNext, true),
+		getBoolValue(point.CountUpwards),
+		getBoolValue(point.CountDownwards),
+		getBoolValue(point.CountMoving),
+		getFloatValue(point.MovingTime.Seconds(), point.TimeValid),
+		getFloatValue(point.TimeDurationBefore.Seconds(), point.TimeValid),
+		getFloatValue(point.TimeDurationNext.Seconds(), point.TimeValid),
+		getFloatValue(point.UpwardsTime.Seconds(), point.TimeValid),
+		getFloatValue(point.DownwardsTime.Seconds(), point.TimeValid),
+		getFloatValue(point.AvarageSpeed, point.TimeValid),
+		getFloatValue(point.SpeedBefore, point.TimeValid),
+		getFloatValue(point.SpeedNext, point.TimeValid),
+		getFloatValue(point.RecordedSpeed, point.RecordedSpeedValid),
+		getIntValue(point.HeartRate, point.HeartRateValid),
+		getIntValue(point.Cadence, point.CadenceValid),
+		getIntValue(point.Power, point.PowerValid),
+		getFloat32Value(point.Temperature, point.TemperatureValid),
+	}
+}
+
+// getWaypointValues - Get the values of a row of the waypoints table
+func getWaypointValues(fileID int64, waypoint gpsabl.Waypoint) []interface{} {
+	return []interface{}{
+		fileID,
+		getTextValue(waypoint.Name),
+		getTextValue(waypoint.Description),
+		getFloat32Value(waypoint.Latitude, true),
+		getFloat32Value(waypoint.Longitude, true),
+		getFloat32Value(waypoint.Elevation, true),
+		getTimeValue(waypoint.Time, waypoint.TimeValid),
+		getTextValue(waypoint.TrackName),
+		getFloatValue(waypoint.DistanceAlongTrack, waypoint.TrackValuesValid),
+		getFloatValue(waypoint.DistanceFromTrack, waypoint.TrackValuesValid),
+	}
+}
+
+// getTextValue - Get the value of a text column. Empty texts are NULL
+func getTextValue(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+
+	return value
+}
+
+// getTimeValue - Get the value of a time column, as UTC time in the timeLayout
+func getTimeValue(value time.Time, valid bool) interface{} {
+	if !valid {
+		return nil
+	}
+
+	return value.UTC().Format(timeLayout)
+}
+
+// getBoolValue - Get the value of a boolean column. SQLite stores booleans as 0 and 1
+func getBoolValue(value bool) interface{} {
+	if value {
+		return int64(1)
+	}
+
+	return int64(0)
+}
+
+// getIntValue - Get the value of an integer column, NULL when the value is not valid
+func getIntValue(value int, valid bool) interface{} {
+	if !valid {
+		return nil
+	}
+
+	return int64(value)
+}
+
+// getFloatValue - Get the value of a real column, NULL when the value is not valid
+func getFloatValue(value float64, valid bool) interface{} {
+	if !valid {
+		return nil
+	}
+
+	return value
+}
+
+// getFloat32Value - Get the value of a float32 as float64, with the digits of the float32. So 347.02 is written as 347.02 and not as 347.0199890136719
+func getFloat32Value(value float32, valid bool) interface{} {
+	if !valid {
+		return nil
+	}
+
+	ret, _ := strconv.ParseFloat(strconv.FormatFloat(float64(value), 'g', -1, 32), 64)
+	return ret
+}
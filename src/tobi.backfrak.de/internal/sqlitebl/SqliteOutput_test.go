@@ -0,0 +1,482 @@
+package sqlitebl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"bytes"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/gpxbl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func TestSqliteOutputFormaterIsOutputFormater(t *testing.T) {
+	var orig SqliteOutputFormater
+	sut := orig.NewOutputFormater()
+
+	if sut.CheckFileExtension("my/archive.sqlite") == false || sut.CheckFileExtension("my/archive.DB") == false {
+		t.Errorf("SqliteOutputFormater can not write *.sqlite and *.db")
+	}
+
+	if sut.CheckFileExtension("my/archive.sql") == true {
+		t.Errorf("SqliteOutputFormater can write *.sql")
+	}
+
+	if sut.CheckOutputFormaterType(SqliteOutputFormatertype) == false {
+		t.Errorf("SqliteOutputFormater can not write %s type", SqliteOutputFormatertype)
+	}
+
+	if len(sut.GetOutputFormaterTypes()) != 1 || sut.GetOutputFormaterTypes()[0] != "SQLITE" {
+		t.Errorf("The OutputFormaterTypes are %v, but should be %v", sut.GetOutputFormaterTypes(), []string{"SQLITE"})
+	}
+
+	if sut.GetNumberOfOutputEntries() != -1 {
+		t.Errorf("The initial value of GetNumberOfOutputEntries is %d but should be %d", sut.GetNumberOfOutputEntries(), -1)
+	}
+
+	if sut.GetTextOutputFormater() != nil {
+		t.Errorf("The SqliteOutputFormater has a TextOutputFormater")
+	}
+}
+
+func TestSqliteOutputWriteAndReadAgain(t *testing.T) {
+	input := readGpxFile(t, "01.gpx")
+	sut := NewSqliteOutputFormater()
+	if err := sut.AddOutPut(input, gpsabl.TRACK, false); err != nil {
+		t.Fatalf("Error while adding the file: %s", err.Error())
+	}
+
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+	database := writeAndOpenDatabase(t, sut, filepath.Join(dir, "archive.sqlite"))
+	defer database.Close()
+	if sut.GetNumberOfOutputEntries() != 1 {
+		t.Errorf("The GetNumberOfOutputEntries is %d, but should be %d", sut.GetNumberOfOutputEntries(), 1)
+	}
+
+	hash, _ := gpsabl.NewInputFileWithPath(input.FilePath).GetContentHash()
+	var path, storedHash string
+	var distance float64
+	database.QueryRow("SELECT Path, Hash, Distance FROM files").Scan(&path, &storedHash, &distance)
+	if path != input.FilePath || storedHash != hash {
+		t.Fatalf("The database does not contain the file with its path and hash")
+	}
+	if distance != input.GetDistance() {
+		t.Errorf("The Distance of the file is %f, but should be %f", distance, input.GetDistance())
+	}
+
+	pointCount := 0
+	for _, track := range input.Tracks {
+		for _, segment := range track.TrackSegments {
+			pointCount += len(segment.TrackPoints)
+		}
+	}
+	if countRows(t, database, "tracks") != len(input.Tracks) || countRows(t, database, "points") != pointCount {
+		t.Errorf("The database contains %d tracks and %d points, but should contain %d and %d", countRows(t, database, "tracks"), countRows(t, database, "points"), len(input.Tracks), pointCount)
+	}
+
+	point := input.Tracks[0].TrackSegments[0].TrackPoints[1]
+	var segmentID int64
+	var elevation, distanceToThisPoint float64
+	var pointTime sql.NullString
+	errPoint := database.QueryRow("SELECT SegmentId, Elevation, DistanceToThisPoint, Time FROM points WHERE Id = 2").Scan(&segmentID, &elevation, &distanceToThisPoint, &pointTime)
+	if errPoint != nil {
+		t.Fatalf("Error while reading the point: %s", errPoint.Error())
+	}
+	if segmentID != 1 || elevation != getFloat32Value(point.Elevation, true) || distanceToThisPoint != point.DistanceToThisPoint {
+		t.Errorf("The values of the point are %d, %f and %f, but should match the track point %v", segmentID, elevation, distanceToThisPoint, point)
+	}
+	if point.TimeValid == false && pointTime.Valid {
+		t.Errorf("The Time of a point without time stamp is %s, but should be NULL", pointTime.String)
+	}
+}
+
+func TestSqliteOutputAppend(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+	outPath := filepath.Join(dir, "archive.db")
+	first := NewSqliteOutputFormater()
+	first.AddOutPut(readGpxFile(t, "01.gpx"), gpsabl.FILE, false)
+	first.AddOutPut(readGpxFile(t, "02.gpx"), gpsabl.FILE, false)
+	before := writeAndOpenDatabase(t, first, outPath)
+	beforePoints := countRows(t, before, "points")
+	beforeSegments := countRows(t, before, "segments")
+	before.Close()
+
+	sut := NewSqliteOutputFormater()
+	outFile := openDatabaseFile(t, outPath)
+	defer outFile.Close()
+	if err := sut.ReadDatabase(outFile); err != nil {
+		t.Fatalf("Error while reading the database: %s", err.Error())
+	}
+	sut.AddOutPut(readGpxFile(t, "02.gpx"), gpsabl.FILE, false)
+	sut.AddOutPut(readGpxFile(t, "03.gpx"), gpsabl.FILE, false)
+	sut.AddOutPut(readGpxFile(t, "01.gpx"), gpsabl.FILE, false)
+	if sut.GetSkippedFileCount() != 2 || sut.GetOutputTableLineCount() != 1 {
+		t.Errorf("The GetSkippedFileCount is %d and the GetOutputTableLineCount %d, but should be %d and %d", sut.GetSkippedFileCount(), sut.GetOutputTableLineCount(), 2, 1)
+	}
+
+	if err := sut.WriteOutput(outFile, gpsabl.NONE); err != nil {
+		t.Fatalf("Error while writing the database: %s", err.Error())
+	}
+	if sut.GetNumberOfOutputEntries() != 3 {
+		t.Errorf("The GetNumberOfOutputEntries is %d, but should be %d", sut.GetNumberOfOutputEntries(), 3)
+	}
+
+	after := openTestDatabase(t, outPath)
+	defer after.Close()
+	var path string
+	after.QueryRow("SELECT Path FROM files WHERE Id = 3").Scan(&path)
+	if countRows(t, after, "files") != 3 || filepath.Base(path) != "03.gpx" {
+		t.Fatalf("The database does not contain the appended file")
+	}
+
+	var firstNewPoint, segmentID int64
+	after.QueryRow("SELECT MIN(Id), MIN(SegmentId) FROM points WHERE Id > ?", beforePoints).Scan(&firstNewPoint, &segmentID)
+	if firstNewPoint != int64(beforePoints+1) || segmentID != int64(beforeSegments+1) {
+		t.Errorf("The Ids of the appended points do not continue the Ids of the database")
+	}
+}
+
+func TestSqliteOutputNothingToAppend(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+	outPath := filepath.Join(dir, "archive.db")
+	first := NewSqliteOutputFormater()
+	first.AddOutPut(readGpxFile(t, "01.gpx"), gpsabl.FILE, false)
+	writeAndOpenDatabase(t, first, outPath).Close()
+	content, _ := ioutil.ReadFile(outPath)
+
+	sut := NewSqliteOutputFormater()
+	outFile := openDatabaseFile(t, outPath)
+	defer outFile.Close()
+	sut.ReadDatabase(outFile)
+	sut.AddOutPut(readGpxFile(t, "01.gpx"), gpsabl.FILE, false)
+	if err := sut.WriteOutput(outFile, gpsabl.NONE); err != nil {
+		t.Fatalf("Error while writing the database: %s", err.Error())
+	}
+
+	actual, _ := ioutil.ReadFile(outPath)
+	if !bytes.Equal(actual, content) {
+		t.Errorf("The database is changed, but no file was appended")
+	}
+	if sut.GetNumberOfOutputEntries() != 1 {
+		t.Errorf("The GetNumberOfOutputEntries is %d, but should be %d", sut.GetNumberOfOutputEntries(), 1)
+	}
+}
+
+func TestSqliteOutputSkipImportedFile(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+	outPath := filepath.Join(dir, "archive.db")
+	input := readGpxFile(t, "01.gpx")
+	hash, _ := gpsabl.NewInputFileWithPath(input.FilePath).GetContentHash()
+
+	sut := NewSqliteOutputFormater()
+	if skip, err := sut.SkipImportedFile(input.FilePath, hash); skip || err != nil {
+		t.Errorf("The file is skipped, but there is no database")
+	}
+	sut.AddFile(input, hash, false)
+	writeAndOpenDatabase(t, sut, outPath).Close()
+
+	sut = NewSqliteOutputFormater()
+	outFile := openDatabaseFile(t, outPath)
+	defer outFile.Close()
+	sut.ReadDatabase(outFile)
+	if skip, err := sut.SkipImportedFile(input.FilePath, hash); !skip || err != nil {
+		t.Errorf("The file stored in the database is not skipped")
+	}
+	if skip, _ := sut.SkipImportedFile(input.FilePath, "other"); skip {
+		t.Errorf("The file with an other hash is skipped")
+	}
+	if skip, _ := sut.SkipImportedFile(filepath.Join("archive", "01.gpx"), hash); skip {
+		t.Errorf("The file with an other path is skipped")
+	}
+	if sut.GetSkippedFileCount() != 1 {
+		t.Errorf("The GetSkippedFileCount is %d, but should be %d", sut.GetSkippedFileCount(), 1)
+	}
+	sut.WriteOutput(outFile, gpsabl.NONE)
+}
+
+func TestSqliteOutputOtherProcessAppended(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+	outPath := filepath.Join(dir, "archive.db")
+	first := NewSqliteOutputFormater()
+	first.AddOutPut(readGpxFile(t, "01.gpx"), gpsabl.FILE, false)
+	writeAndOpenDatabase(t, first, outPath).Close()
+
+	sut := NewSqliteOutputFormater()
+	outFile := openDatabaseFile(t, outPath)
+	defer outFile.Close()
+	sut.ReadDatabase(outFile)
+	sut.AddOutPut(readGpxFile(t, "02.gpx"), gpsabl.FILE, false)
+	sut.AddOutPut(readGpxFile(t, "03.gpx"), gpsabl.FILE, false)
+
+	// An other process appends a file in the meantime
+	other := NewSqliteOutputFormater()
+	otherFile := openDatabaseFile(t, outPath)
+	other.ReadDatabase(otherFile)
+	other.AddOutPut(readGpxFile(t, "03.gpx"), gpsabl.FILE, false)
+	if err := other.WriteOutput(otherFile, gpsabl.NONE); err != nil {
+		t.Fatalf("Error while writing the database: %s", err.Error())
+	}
+	otherFile.Close()
+
+	if err := sut.WriteOutput(outFile, gpsabl.NONE); err != nil {
+		t.Fatalf("Error while writing the database: %s", err.Error())
+	}
+	if sut.GetNumberOfOutputEntries() != 3 || sut.GetSkippedFileCount() != 1 {
+		t.Errorf("The GetNumberOfOutputEntries is %d and the GetSkippedFileCount %d, but should be %d and %d", sut.GetNumberOfOutputEntries(), sut.GetSkippedFileCount(), 3, 1)
+	}
+}
+
+func TestSqliteOutputKeepsUserObjects(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+	outPath := filepath.Join(dir, "archive.db")
+	first := NewSqliteOutputFormater()
+	first.AddOutPut(readGpxFile(t, "01.gpx"), gpsabl.FILE, false)
+	database := writeAndOpenDatabase(t, first, outPath)
+	for _, statement := range []string{
+		"CREATE INDEX myidx ON files (Name)",
+		"CREATE VIEW paths AS SELECT Path FROM files",
+		"CREATE TABLE notes (FileId INTEGER, Note TEXT)",
+		"ALTER TABLE files ADD COLUMN Rating INTEGER",
+	} {
+		if _, err := database.Exec(statement); err != nil {
+			t.Fatalf("Error while executing \"%s\": %s", statement, err.Error())
+		}
+	}
+	database.Close()
+
+	sut := NewSqliteOutputFormater()
+	outFile := openDatabaseFile(t, outPath)
+	defer outFile.Close()
+	if err := sut.ReadDatabase(outFile); err != nil {
+		t.Fatalf("Error while reading a database with user objects: %s", err.Error())
+	}
+	sut.AddOutPut(readGpxFile(t, "02.gpx"), gpsabl.FILE, false)
+	if err := sut.WriteOutput(outFile, gpsabl.NONE); err != nil {
+		t.Fatalf("Error while writing a database with user objects: %s", err.Error())
+	}
+
+	database = openTestDatabase(t, outPath)
+	defer database.Close()
+	userObjects := 0
+	database.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE name IN ('myidx', 'paths', 'notes')").Scan(&userObjects)
+	if userObjects != 3 || countRows(t, database, "paths") != 2 {
+		t.Errorf("The user objects are not kept or the file is not added")
+	}
+}
+
+func TestSqliteOutputSameContentOtherPath(t *testing.T) {
+	input := readGpxFile(t, "05.gpx")
+	hash, _ := gpsabl.NewInputFileWithPath(input.FilePath).GetContentHash()
+	copied := input
+	copied.FilePath = filepath.Join("archive", "05.gpx")
+
+	sut := NewSqliteOutputFormater()
+	sut.AddFile(input, hash, false)
+	sut.AddFile(copied, hash, false)
+	if sut.GetOutputTableLineCount() != 2 || sut.GetSkippedFileCount() != 0 {
+		t.Errorf("The GetOutputTableLineCount is %d, but should be %d", sut.GetOutputTableLineCount(), 2)
+	}
+
+	sut = NewSqliteOutputFormater()
+	sut.AddFile(input, hash, true)
+	sut.AddFile(copied, hash, true)
+	if sut.GetOutputTableLineCount() != 1 || sut.GetSkippedFileCount() != 0 {
+		t.Errorf("The GetOutputTableLineCount is %d, but should be %d when duplicates are filtered", sut.GetOutputTableLineCount(), 1)
+	}
+}
+
+func TestSqliteOutputSorting(t *testing.T) {
+	sut := NewSqliteOutputFormater()
+	sut.SetSorting(gpsabl.OutputSorting{Column: gpsabl.NAME, Order: gpsabl.DESCENDING})
+	sut.AddOutPut(readGpxFile(t, "01.gpx"), gpsabl.FILE, false)
+	sut.AddOutPut(readGpxFile(t, "03.gpx"), gpsabl.FILE, false)
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+	database := writeAndOpenDatabase(t, sut, filepath.Join(dir, "archive.sqlite"))
+	defer database.Close()
+
+	var path string
+	database.QueryRow("SELECT Path FROM files WHERE Id = 1").Scan(&path)
+	if countRows(t, database, "files") != 2 || path != sut.fileBuffer[0].Data.(hashedTrackFile).FilePath {
+		t.Errorf("The files are not added in the sorted order")
+	}
+}
+
+func TestSqliteOutputStdOut(t *testing.T) {
+	sut := NewSqliteOutputFormater()
+	sut.AddOutPut(readGpxFile(t, "01.gpx"), gpsabl.FILE, false)
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+	outPath := filepath.Join(dir, "archive.db")
+	outFile, _ := os.Create(outPath)
+	if err := sut.writeStdOut(outFile); err != nil {
+		t.Fatalf("Error while writing the database: %s", err.Error())
+	}
+	outFile.Close()
+
+	database := openTestDatabase(t, outPath)
+	defer database.Close()
+	if countRows(t, database, "files") != 1 || sut.GetNumberOfOutputEntries() != 1 {
+		t.Errorf("The database written to the stream does not contain the file")
+	}
+}
+
+func TestSqliteOutputReadDatabaseEmptyFile(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+	outPath := filepath.Join(dir, "archive.db")
+	outFile := openDatabaseFile(t, outPath)
+	defer outFile.Close()
+
+	sut := NewSqliteOutputFormater()
+	if err := sut.ReadDatabase(outFile); err != nil {
+		t.Errorf("Error while reading an empty file: %s", err.Error())
+	}
+	if err := sut.WriteOutput(outFile, gpsabl.NONE); err != nil {
+		t.Errorf("Error while writing an empty file: %s", err.Error())
+	}
+
+	info, _ := os.Stat(outPath)
+	if sut.GetNumberOfOutputEntries() != 0 || info.Size() != 0 {
+		t.Errorf("The GetNumberOfOutputEntries is %d and the size %d, but both should be 0", sut.GetNumberOfOutputEntries(), info.Size())
+	}
+}
+
+func TestSqliteOutputReadDatabaseNoSqliteFile(t *testing.T) {
+	inPath := filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-gpx", "01.gpx")
+	inFile, _ := os.Open(inPath)
+	defer inFile.Close()
+
+	sut := NewSqliteOutputFormater()
+	err := sut.ReadDatabase(inFile)
+	switch err.(type) {
+	case *SqliteFileError:
+		if err.(*SqliteFileError).File != inPath {
+			t.Errorf("The SqliteFileError.File is %s, but should be %s", err.(*SqliteFileError).File, inPath)
+		}
+	default:
+		t.Errorf("The error is %v, but should be a SqliteFileError", err)
+	}
+}
+
+func TestSqliteOutputReadDatabaseSchemaNotKnown(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+	outPath := filepath.Join(dir, "archive.db")
+	database := openTestDatabase(t, outPath)
+	database.Exec("CREATE TABLE files (Id INTEGER PRIMARY KEY, Path TEXT)")
+	database.Close()
+
+	outFile := openDatabaseFile(t, outPath)
+	defer outFile.Close()
+	sut := NewSqliteOutputFormater()
+	err := sut.ReadDatabase(outFile)
+	switch err.(type) {
+	case *SchemaNotKnownError:
+		if err.(*SchemaNotKnownError).Object != "files" {
+			t.Errorf("The SchemaNotKnownError.Object is %s, but should be %s", err.(*SchemaNotKnownError).Object, "files")
+		}
+	default:
+		t.Errorf("The error is %v, but should be a SchemaNotKnownError", err)
+	}
+}
+
+func TestSqliteOutputNotValidArguments(t *testing.T) {
+	sut := NewSqliteOutputFormater()
+	if err := sut.AddOutPut(readGpxFile(t, "01.gpx"), "abc", false); err == nil {
+		t.Errorf("No error when the depth is not valid")
+	}
+
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+	outFile := openDatabaseFile(t, filepath.Join(dir, "archive.db"))
+	defer outFile.Close()
+	if err := sut.WriteOutput(outFile, "abc"); err == nil {
+		t.Errorf("No error when the summary is not valid")
+	}
+
+	input := readGpxFile(t, "01.gpx")
+	input.FilePath = filepath.Join("not", "existing", "01.gpx")
+	if err := sut.AddOutPut(input, gpsabl.FILE, false); err == nil {
+		t.Errorf("No error when the hash of the file can not be calculated")
+	}
+}
+
+func TestGetFloat32Value(t *testing.T) {
+	if getFloat32Value(347.02, true) != 347.02 {
+		t.Errorf("The value is %v, but should be %f", getFloat32Value(347.02, true), 347.02)
+	}
+
+	if getFloat32Value(347.02, false) != nil {
+		t.Errorf("The value is %v, but should be nil", getFloat32Value(347.02, false))
+	}
+}
+
+func readGpxFile(t *testing.T, name string) gpsabl.TrackFile {
+	gpx := gpxbl.NewGpxFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-gpx", name))
+	ret, err := gpx.ReadTracks(gpsabl.STEPS, 0.3, 10.0)
+	if err != nil {
+		t.Fatalf("Error while reading %s: %s", name, err.Error())
+	}
+
+	return ret
+}
+
+func createTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "sqlitebl")
+	if err != nil {
+		t.Fatalf("Error while creating the temp dir: %s", err.Error())
+	}
+
+	return dir
+}
+
+func openDatabaseFile(t *testing.T, path string) *os.File {
+	ret, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		t.Fatalf("Error while opening %s: %s", path, err.Error())
+	}
+
+	return ret
+}
+
+func openTestDatabase(t *testing.T, path string) *sql.DB {
+	ret, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("Error while opening the database %s: %s", path, err.Error())
+	}
+
+	return ret
+}
+
+func writeAndOpenDatabase(t *testing.T, sut *SqliteOutputFormater, path string) *sql.DB {
+	outFile := openDatabaseFile(t, path)
+	if err := sut.WriteOutput(outFile, gpsabl.NONE); err != nil {
+		t.Fatalf("Error while writing the database: %s", err.Error())
+	}
+	outFile.Close()
+
+	return openTestDatabase(t, path)
+}
+
+func countRows(t *testing.T, database *sql.DB, table string) int {
+	ret := 0
+	if err := database.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&ret); err != nil {
+		t.Fatalf("Error while counting the rows of %s: %s", table, err.Error())
+	}
+
+	return ret
+}
@@ -0,0 +1,22 @@
+module tobi.backfrak.de/internal/sqlitebl
+
+go 1.23.0
+
+require "tobi.backfrak.de/internal/gpsabl" v0.0.0
+replace  "tobi.backfrak.de/internal/gpsabl" v0.0.0 => "../gpsabl"
+require "tobi.backfrak.de/internal/gpxbl" v0.0.0
+replace  "tobi.backfrak.de/internal/gpxbl" v0.0.0 => "../gpxbl"
+require "tobi.backfrak.de/internal/testhelper" v0.0.0
+replace  "tobi.backfrak.de/internal/testhelper" v0.0.0 => "../testhelper"
+
+require "modernc.org/sqlite" v1.39.0
+require "github.com/dustin/go-humanize" v1.0.1 // indirect
+require "github.com/google/uuid" v1.6.0 // indirect
+require "github.com/mattn/go-isatty" v0.0.20 // indirect
+require "github.com/ncruces/go-strftime" v0.1.9 // indirect
+require "github.com/remyoudompheng/bigfft" v0.0.0-20230129092748-24d4a6f8daec // indirect
+require "golang.org/x/exp" v0.0.0-20250620022241-b7579e27df2b // indirect
+require "golang.org/x/sys" v0.34.0 // indirect
+require "modernc.org/libc" v1.66.3 // indirect
+require "modernc.org/mathutil" v1.7.1 // indirect
+require "modernc.org/memory" v1.11.0 // indirect
\ No newline at end of file
@@ -1,8 +1,8 @@
 package tcxbl
 
 import (
-	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"tobi.backfrak.de/internal/gpsabl"
@@ -86,75 +86,52 @@ func convertLap(lap Lap, correction gpsabl.CorrectionParameter, minimalMovingSpe
 		res.MovingTime = time.Duration(m * float64(time.Second))
 		res.EndTime = res.StartTime.Add(res.MovingTime)
 		res.TimeDataValid = true
+		res.HeartRate = getLapHeartRate(lap)
 	}
 
+	res.Calories, _ = getIntValue(lap.Calories)
+	res.Intensity = lap.Intensity
+	res.TriggerMethod = lap.TriggerMethod
+
 	return res, nil
 }
 
-func convertTrackpoints(points []Trackpoint, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) ([]gpsabl.TrackPoint, error) {
-	var ret []gpsabl.TrackPoint
-
-	pointCount := len(points)
-	if pointCount > 10 { // I think it makes only sense to use go routines for tracks with more then 10 points
-		c := make(chan gpsabl.TrackPoint, pointCount)
-		pointCounter := 0
-		for i, point := range points {
-			go goConvertPointDistance(point, i, &points, pointCount, c)
-
-		}
-		for pnt := range c {
-			ret = append(ret, pnt)
-			pointCounter++
-			if pointCounter == pointCount {
-				close(c)
-			}
-		}
-	} else {
-		for i, point := range points {
-			pnt := convertPointDistance(point, i, &points, pointCount)
-			ret = append(ret, pnt)
-		}
+// getLapHeartRate - Get the heart rate of a lap without trackpoints. The lap only knows the average and maximum, so the average is used as minimum
+func getLapHeartRate(lap Lap) gpsabl.SensorValues {
+	average, averageValid := getIntValue(lap.AverageHeartRateBpm.Value)
+	if !averageValid {
+		return gpsabl.SensorValues{}
 	}
 
-	sort.Slice(ret, func(i, j int) bool {
-		return ret[i].Number < ret[j].Number
-	})
-
-	err := gpsabl.FillValuesTrackPointArray(ret, correction, minimalMovingSpeed, minimalStepHight)
-	if err != nil {
-		return nil, err
+	ret := gpsabl.SensorValues{Minimum: float64(average), Average: float64(average), Maximum: float64(average), Samples: 1}
+	maximum, maximumValid := getIntValue(lap.MaximumHeartRateBpm.Value)
+	if maximumValid && maximum >= average {
+		ret.Maximum = float64(maximum)
 	}
 
-	return ret, nil
+	return ret
 }
 
-func goConvertPointDistance(point Trackpoint, i int, pnts *[]Trackpoint, pointCount int, c chan gpsabl.TrackPoint) {
-
-	c <- convertPointDistance(point, i, pnts, pointCount)
-}
-
-func convertPointDistance(point Trackpoint, i int, pnts *[]Trackpoint, pointCount int) gpsabl.TrackPoint {
-	pnt := convertBasicPointValues(point)
-	pnt.Number = i
-	points := *pnts
+func convertTrackpoints(points []Trackpoint, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) ([]gpsabl.TrackPoint, error) {
+	pointCount := len(points)
 
-	if i == 0 && pointCount > 1 {
-		pntNext := convertBasicPointValues(points[i+1])
-		gpsabl.FillDistancesTrackPoint(&pnt, gpsabl.TrackPoint{}, pntNext)
+	// The distances are calculated from the basic values of the points before and after
+	basic := make([]gpsabl.TrackPoint, pointCount)
+	for i, point := range points {
+		basic[i] = convertBasicPointValues(point)
 	}
 
-	if i > 0 && i < pointCount-1 {
-		pntNext := convertBasicPointValues(points[i+1])
-		pntBefore := convertBasicPointValues(points[i-1])
-		gpsabl.FillDistancesTrackPoint(&pnt, pntBefore, pntNext)
+	ret := gpsabl.FillDistances(basic)
+	for i := range ret {
+		convertSensorValues(&ret[i], points[i])
 	}
 
-	if i == pointCount-1 && pointCount > 1 {
-		pntBefore := convertBasicPointValues(points[i-1])
-		gpsabl.FillDistancesTrackPoint(&pnt, pntBefore, gpsabl.TrackPoint{})
+	err := gpsabl.FillValuesTrackPointArray(ret, correction, minimalMovingSpeed, minimalStepHight)
+	if err != nil {
+		return nil, err
 	}
 
-	return pnt
+	return ret, nil
 }
 
 func convertBasicPointValues(point Trackpoint) gpsabl.TrackPoint {
@@ -181,3 +158,34 @@ func convertBasicPointValues(point Trackpoint) gpsabl.TrackPoint {
 
 	return pnt
 }
+
+// convertSensorValues - Set the heart rate, cadence, power and recorded speed of the point
+func convertSensorValues(pnt *gpsabl.TrackPoint, point Trackpoint) {
+	pnt.HeartRate, pnt.HeartRateValid = getIntValue(point.HeartRateBpm.Value)
+	pnt.Cadence, pnt.CadenceValid = getIntValue(point.Cadence)
+	pnt.Power, pnt.PowerValid = getIntValue(point.TPX.Watts)
+	pnt.RecordedSpeed, pnt.RecordedSpeedValid = getFloatValue(point.TPX.Speed)
+}
+
+func getIntValue(value string) (int, bool) {
+	ret, valid := getFloatValue(value)
+	if !valid {
+		return 0, false
+	}
+
+	return int(ret + 0.5), true
+}
+
+func getFloatValue(value string) (float64, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	ret, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return ret, true
+}
@@ -1,9 +1,11 @@
 package tcxbl
 
 import (
+	"path/filepath"
 	"testing"
 	"time"
 
+	"tobi.backfrak.de/internal/gpsabl"
 	"tobi.backfrak.de/internal/testhelper"
 )
 
@@ -75,3 +77,120 @@ func TestConvertValidTcx03(t *testing.T) {
 		t.Errorf("The UpwardsSpeed is %f, but should be %f", trackFile.GetDownwardsSpeed(), 7.683785)
 	}
 }
+
+func TestConvertValidTcxWithSensorData(t *testing.T) {
+	tcx, readErr := ReadTcx(testhelper.GetValidTcx("08.tcx"))
+	if readErr != nil {
+		t.Errorf("ReadError, but none expected")
+	}
+
+	trackFile, convertErr := ConvertTcx(tcx, testhelper.GetValidTcx("08.tcx"), "none", 0.3, 10)
+	if convertErr != nil {
+		t.Errorf("ConvertError, but none expected")
+	}
+
+	seg := trackFile.Tracks[0].TrackSegments[0]
+	if seg.HeartRate.Minimum != 118 || seg.HeartRate.Average != 125 || seg.HeartRate.Maximum != 132 || seg.HeartRate.Samples != 15 {
+		t.Errorf("The HeartRate of the first lap is %v, but {118 125 132 15} is expected", seg.HeartRate)
+	}
+
+	if seg.Power.Minimum != 180 || seg.Power.Maximum != 250 {
+		t.Errorf("The Power of the first lap is %v, but should be between 180 and 250", seg.Power)
+	}
+
+	if seg.Calories != 12 || seg.Intensity != "Active" || seg.TriggerMethod != "Distance" {
+		t.Errorf("The lap values of the first lap are %d, %s, %s, but should be 12, Active, Distance", seg.Calories, seg.Intensity, seg.TriggerMethod)
+	}
+
+	pnt := seg.TrackPoints[3]
+	if !pnt.RecordedSpeedValid || pnt.RecordedSpeed != 3.8 {
+		t.Errorf("The RecordedSpeed is %f, but should be %f", pnt.RecordedSpeed, 3.8)
+	}
+
+	// The second lap has no trackpoints, so the lap values are used
+	seg = trackFile.Tracks[0].TrackSegments[1]
+	if seg.HeartRate.Minimum != 150 || seg.HeartRate.Average != 150 || seg.HeartRate.Maximum != 171 || seg.HeartRate.Samples != 1 {
+		t.Errorf("The HeartRate of the second lap is %v, but {150 150 171 1} is expected", seg.HeartRate)
+	}
+
+	if seg.Calories != 95 || seg.Intensity != "Resting" || seg.TriggerMethod != "Manual" {
+		t.Errorf("The lap values of the second lap are %d, %s, %s, but should be 95, Resting, Manual", seg.Calories, seg.Intensity, seg.TriggerMethod)
+	}
+
+	if trackFile.HeartRate.Maximum != 171 || trackFile.Cadence.Samples != 15 {
+		t.Errorf("The HeartRate %v or Cadence %v of the file are not as expected", trackFile.HeartRate, trackFile.Cadence)
+	}
+}
+
+func TestGetLapHeartRate(t *testing.T) {
+	lap := Lap{}
+	if getLapHeartRate(lap).Valid() {
+		t.Errorf("The heart rate of a lap without AverageHeartRateBpm is valid")
+	}
+
+	lap.AverageHeartRateBpm.Value = "140"
+	lap.MaximumHeartRateBpm.Value = "130"
+	heartRate := getLapHeartRate(lap)
+	if heartRate.Maximum != 140 {
+		t.Errorf("The Maximum is %f, but should be %f", heartRate.Maximum, 140.0)
+	}
+}
+
+func TestConvertSensorValues(t *testing.T) {
+	point := Trackpoint{}
+	point.Cadence = "abc"
+	point.TPX.Watts = " 310 "
+	pnt := convertBasicPointValues(point)
+	convertSensorValues(&pnt, point)
+
+	if pnt.HeartRateValid || pnt.CadenceValid || pnt.RecordedSpeedValid {
+		t.Errorf("A sensor value is valid, but should not")
+	}
+
+	if !pnt.PowerValid || pnt.Power != 310 {
+		t.Errorf("The Power is %d, but should be %d", pnt.Power, 310)
+	}
+}
+
+func BenchmarkConvertTrackpoints(b *testing.B) {
+	var laps []Lap
+	files, _ := filepath.Glob(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-tcx", "*.tcx"))
+	for _, file := range files {
+		tcx, err := ReadTcx(file)
+		if err != nil {
+			continue
+		}
+		for _, activities := range tcx.ActivityArray {
+			for _, activity := range activities.Activities {
+				laps = append(laps, activity.Laps...)
+			}
+		}
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, lap := range laps {
+			for _, track := range lap.Tracks {
+				if len(track.Trackpoints) == 0 {
+					continue
+				}
+				if _, err := convertTrackpoints(track.Trackpoints, gpsabl.STEPS, 0.3, 10.0); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkReadTcxFile(b *testing.B) {
+	files, _ := filepath.Glob(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-tcx", "*.tcx"))
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, file := range files {
+			if _, err := ReadTcxFile(file, gpsabl.STEPS, 0.3, 10.0); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
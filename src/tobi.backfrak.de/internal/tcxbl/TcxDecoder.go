@@ -0,0 +1,208 @@
+package tcxbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+import (
+	"encoding/xml"
+	"io"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+// tcxDecoder - Reads a TCX document token by token. Activities are converted lap by lap, so only the points
+// of one lap are held in their xml representation. The result is the same as ConvertTcx gives for the whole document
+type tcxDecoder struct {
+	decoder            *xml.Decoder
+	correction         gpsabl.CorrectionParameter
+	minimalMovingSpeed float64
+	minimalStepHight   float64
+	// dropTrackPoints - Remove the points of a lap, once its values are calculated
+	dropTrackPoints bool
+	// convertError - The first error converting a lap. The document is still read, the xml errors and the checks of the
+	// first activity are reported first
+	convertError error
+	// The values of the first <Activities>, <Activity> and <Lap>, that tell if the file is valid
+	activitiesCount       int
+	firstActivitiesLength int
+	firstActivityLaps     int
+	firstActivityID       string
+	firstLapTracks        int
+}
+
+// decodeTcx - Read a TCX document from the reader, and return a gpsabl.TrackFile struct that contains all information.
+// When dropTrackPoints is set, the tracks contain no points, but the values calculated from them
+func decodeTcx(reader io.Reader, filePath string, dropTrackPoints bool, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	d := tcxDecoder{}
+	d.decoder = xml.NewDecoder(reader)
+	d.correction = correction
+	d.minimalMovingSpeed = minimalMovingSpeed
+	d.minimalStepHight = minimalStepHight
+	d.dropTrackPoints = dropTrackPoints
+
+	return d.decodeFile(filePath)
+}
+
+func (d *tcxDecoder) decodeFile(filePath string) (gpsabl.TrackFile, error) {
+	res := gpsabl.NewTrackFile(filePath)
+
+	// Like xml.Unmarshal, the name of the root element is not checked
+	if errRoot := d.skipToRootElement(); errRoot != nil {
+		return gpsabl.TrackFile{}, errRoot
+	}
+
+	errChildren := d.decodeChildren(func(element xml.StartElement) error {
+		if element.Name.Local != "Activities" {
+			return d.decoder.Skip()
+		}
+
+		d.activitiesCount++
+		tracks, errActivities := d.decodeActivities(d.activitiesCount == 1)
+		res.Tracks = append(res.Tracks, tracks...)
+
+		return errActivities
+	})
+	if errChildren != nil {
+		return gpsabl.TrackFile{}, errChildren
+	}
+
+	if errCheck := d.checkFirstActivity(filePath); errCheck != nil {
+		return gpsabl.TrackFile{}, errCheck
+	}
+
+	if d.convertError != nil {
+		return gpsabl.TrackFile{}, d.convertError
+	}
+
+	res.NumberOfTracks = len(res.Tracks)
+	gpsabl.FillTrackFileValues(&res)
+
+	return res, nil
+}
+
+// decodeActivities - Read the <Activities> element the decoder is in, and convert each <Activity> to a track
+func (d *tcxDecoder) decodeActivities(first bool) ([]gpsabl.Track, error) {
+	var tracks []gpsabl.Track
+	activityCount := 0
+	errChildren := d.decodeChildren(func(element xml.StartElement) error {
+		if element.Name.Local != "Activity" {
+			return d.decoder.Skip()
+		}
+
+		activityCount++
+		track, errActivity := d.decodeActivity(first && activityCount == 1)
+		if d.convertError == nil {
+			tracks = append(tracks, track)
+		}
+
+		return errActivity
+	})
+
+	if first {
+		d.firstActivitiesLength = activityCount
+	}
+
+	return tracks, errChildren
+}
+
+// decodeActivity - Read the <Activity> element the decoder is in, and convert each <Lap> to a segment of the track
+func (d *tcxDecoder) decodeActivity(first bool) (gpsabl.Track, error) {
+	res := gpsabl.Track{}
+	lapCount := 0
+	errChildren := d.decodeChildren(func(element xml.StartElement) error {
+		switch element.Name.Local {
+		case "Id":
+			return d.decoder.DecodeElement(&res.Name, &element)
+		case "Lap":
+			lap := Lap{}
+			if errDecode := d.decoder.DecodeElement(&lap, &element); errDecode != nil {
+				return errDecode
+			}
+
+			lapCount++
+			if first && lapCount == 1 {
+				d.firstLapTracks = len(lap.Tracks)
+			}
+
+			if d.convertError == nil {
+				seg, errConvert := convertLap(lap, d.correction, d.minimalMovingSpeed, d.minimalStepHight)
+				if errConvert != nil {
+					d.convertError = errConvert
+				}
+				if d.dropTrackPoints {
+					seg.TrackPoints = nil
+				}
+				res.TrackSegments = append(res.TrackSegments, seg)
+			}
+
+			return nil
+		}
+
+		return d.decoder.Skip()
+	})
+
+	if first {
+		d.firstActivityLaps = lapCount
+		d.firstActivityID = res.Name
+	}
+
+	res.NumberOfSegments = len(res.TrackSegments)
+	if d.convertError == nil {
+		gpsabl.FillTrackValues(&res)
+	}
+
+	return res, errChildren
+}
+
+// checkFirstActivity - Check the first activity of the file has an ID and a lap with a track, like readTCXBuffer does
+func (d *tcxDecoder) checkFirstActivity(filePath string) error {
+	if d.activitiesCount <= 0 {
+		return newTcxFileError(filePath)
+	}
+
+	if d.firstActivitiesLength <= 0 || d.firstActivityLaps <= 0 || d.firstLapTracks <= 0 {
+		return newEmptyTcxFileError(filePath)
+	}
+
+	if d.firstActivityID == "" {
+		return newTcxFileError(filePath)
+	}
+
+	return nil
+}
+
+// decodeChildren - Call the handler for each child element of the element the decoder is in. The handler must
+// read the child element up to its end
+func (d *tcxDecoder) decodeChildren(handler func(element xml.StartElement) error) error {
+	for {
+		token, errToken := d.decoder.Token()
+		if errToken != nil {
+			return errToken
+		}
+
+		switch element := token.(type) {
+		case xml.EndElement:
+			return nil
+		case xml.StartElement:
+			if errHandler := handler(element); errHandler != nil {
+				return errHandler
+			}
+		}
+	}
+}
+
+// skipToRootElement - Read the tokens up to the start of the root element
+func (d *tcxDecoder) skipToRootElement() error {
+	for {
+		token, err := d.decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		if _, ok := token.(xml.StartElement); ok {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package tcxbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func TestDecodeTcxAllFilesLikeUnmarshal(t *testing.T) {
+	for _, dir := range []string{"valid-tcx", "invalid-tcx"} {
+		files, _ := ioutil.ReadDir(filepath.Join(testhelper.GetProjectRoot(), "testdata", dir))
+		for _, file := range files {
+			path := filepath.Join(testhelper.GetProjectRoot(), "testdata", dir, file.Name())
+			buffer, errRead := ioutil.ReadFile(path)
+			if errRead != nil {
+				t.Fatal(errRead)
+			}
+
+			for _, correction := range gpsabl.GetValidCorrectionParameters() {
+				checkDecodeTcxLikeUnmarshal(t, buffer, path, correction)
+			}
+		}
+	}
+}
+
+func TestDecodeTcxLikeUnmarshal(t *testing.T) {
+	lap := `<Lap StartTime="2020-01-01T10:00:00Z"><TotalTimeSeconds>60</TotalTimeSeconds><DistanceMeters>100</DistanceMeters><Calories>5</Calories>
+		<Track><Trackpoint><Time>2020-01-01T10:00:00Z</Time><Position><LatitudeDegrees>1</LatitudeDegrees><LongitudeDegrees>1</LongitudeDegrees></Position></Trackpoint>
+		<Trackpoint><Time>2020-01-01T10:01:00Z</Time><Position><LatitudeDegrees>1.001</LatitudeDegrees><LongitudeDegrees>1</LongitudeDegrees></Position></Trackpoint></Track></Lap>`
+	summaryLap := `<Lap StartTime="2020-01-01T11:00:00Z"><TotalTimeSeconds>60</TotalTimeSeconds><DistanceMeters>100</DistanceMeters></Lap>`
+	documents := map[string]string{
+		"two activities":      fmt.Sprintf(`<TrainingCenterDatabase><Activities><Activity><Id>A</Id>%s%s</Activity><Activity>%s<Id>B</Id></Activity></Activities><Activities/></TrainingCenterDatabase>`, lap, summaryLap, lap),
+		"no activities":       `<TrainingCenterDatabase><Courses/></TrainingCenterDatabase>`,
+		"empty activities":    fmt.Sprintf(`<TrainingCenterDatabase><Activities/><Activities><Activity><Id>A</Id>%s</Activity></Activities></TrainingCenterDatabase>`, lap),
+		"no laps":             `<TrainingCenterDatabase><Activities><Activity><Id>A</Id></Activity></Activities></TrainingCenterDatabase>`,
+		"summary lap first":   fmt.Sprintf(`<TrainingCenterDatabase><Activities><Activity><Id>A</Id>%s%s</Activity></Activities></TrainingCenterDatabase>`, summaryLap, lap),
+		"no id":               fmt.Sprintf(`<TrainingCenterDatabase><Activities><Activity>%s</Activity></Activities></TrainingCenterDatabase>`, lap),
+		"not valid lap":       fmt.Sprintf(`<TrainingCenterDatabase><Activities><Activity><Id>A</Id>%s<Lap StartTime="noon"></Lap></Activity></Activities></TrainingCenterDatabase>`, lap),
+		"not valid first lap": `<TrainingCenterDatabase><Activities><Activity><Id>A</Id><Lap StartTime="noon"></Lap></Activity></Activities></TrainingCenterDatabase>`,
+		"not complete":        fmt.Sprintf(`<TrainingCenterDatabase><Activities><Activity><Id>A</Id>%s`, lap),
+		"empty":               ``,
+	}
+
+	for name, document := range documents {
+		t.Run(name, func(t *testing.T) {
+			checkDecodeTcxLikeUnmarshal(t, []byte(document), name, gpsabl.LINEAR)
+		})
+	}
+
+	// A correction that is not valid fails the lap conversion
+	checkDecodeTcxLikeUnmarshal(t, []byte(documents["two activities"]), "not valid correction", gpsabl.CorrectionParameter("abc"))
+}
+
+func TestDecodeTcxDropTrackPoints(t *testing.T) {
+	files, _ := ioutil.ReadDir(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-tcx"))
+	for _, file := range files {
+		path := filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-tcx", file.Name())
+		buffer, errRead := ioutil.ReadFile(path)
+		if errRead != nil {
+			t.Fatal(errRead)
+		}
+
+		expected, errExpected := ReadBuffer(buffer, path, gpsabl.LINEAR, 0.3, 10.0)
+		actual, err := decodeTcx(bytes.NewReader(buffer), path, true, gpsabl.LINEAR, 0.3, 10.0)
+		if err != nil || errExpected != nil {
+			t.Fatalf("Got the errors \"%v\" and \"%v\" for %s, but expected none", err, errExpected, path)
+		}
+
+		for _, track := range actual.Tracks {
+			for _, segment := range track.TrackSegments {
+				if segment.TrackPoints != nil {
+					t.Errorf("The segment of %s contains %d points, but should contain none", path, len(segment.TrackPoints))
+				}
+			}
+		}
+
+		if fmt.Sprintf("%+v", actual) != fmt.Sprintf("%+v", gpsabl.StripTrackPoints(expected)) {
+			t.Errorf("The values of %s without track points are not the same as with track points", path)
+		}
+	}
+}
+
+// checkDecodeTcxLikeUnmarshal - Check the streamed document gives the same gpsabl.TrackFile or error as the unmarshaled document
+func checkDecodeTcxLikeUnmarshal(t *testing.T, buffer []byte, name string, correction gpsabl.CorrectionParameter) {
+	expected, expectedErr := readTCXBuffer(buffer, name)
+	var expectedFile gpsabl.TrackFile
+	if expectedErr == nil {
+		expectedFile, expectedErr = ConvertTcx(expected, name, correction, 0.3, 10.0)
+	}
+
+	actual, err := ReadBuffer(buffer, name, correction, 0.3, 10.0)
+	if fmt.Sprintf("%T %v", err, err) != fmt.Sprintf("%T %v", expectedErr, expectedErr) {
+		t.Errorf("Got the error \"%v\" for %s with %s correction, but \"%v\" was expected", err, name, correction, expectedErr)
+	}
+
+	// Compare the printed values, some values like the VerticalDistanceNext of the last point are NaN, that is never equal
+	if fmt.Sprintf("%+v", actual) != fmt.Sprintf("%+v", expectedFile) {
+		t.Errorf("The streamed TrackFile of %s with %s correction is not the same as the unmarshaled one", name, correction)
+	}
+}
@@ -5,6 +5,9 @@ package tcxbl
 // by a BSD-style license that can be found in the
 // LICENSE file.
 import (
+	"bytes"
+	"io"
+	"os"
 	"strings"
 
 	"tobi.backfrak.de/internal/gpsabl"
@@ -18,7 +21,10 @@ const FileExtension string = ".tcx"
 // TcxFile - The struct to handle *.gpx data files
 type TcxFile struct {
 	gpsabl.TrackFile
-	input gpsabl.InputFile
+	// DropTrackPoints - Remove the points of each lap, once its values are calculated. Set this when the points are
+	// not needed, so a file does not stay in memory with all its points
+	DropTrackPoints bool
+	input           gpsabl.InputFile
 }
 
 // NewTcxFile - Constructor for the TcxFile struct
@@ -31,10 +37,12 @@ func NewTcxFile(filePath string) TcxFile {
 }
 
 // NewReader - Get a new reader for TCX files that will read the data in the given gpsabl.InputFile
+// The new reader uses the DropTrackPoints of this reader
 // Implement the gpsabl.TrackReader interface for *.tcx files
 func (tcx *TcxFile) NewReader(data gpsabl.InputFile) gpsabl.TrackReader {
 	newTcx := TcxFile{}
 	newTcx.input = data
+	newTcx.DropTrackPoints = tcx.DropTrackPoints
 	if data.Type == gpsabl.FilePath {
 		newTcx.FilePath = data.Name
 	}
@@ -48,9 +56,14 @@ func (tcx *TcxFile) ReadTracks(correction gpsabl.CorrectionParameter, minimalMov
 	var err error
 	var ret gpsabl.TrackFile
 	if tcx.input.Type == gpsabl.FilePath {
-		ret, err = ReadTcxFile(tcx.FilePath, correction, minimalMovingSpeed, minimalStepHight)
+		ret, err = readTcxFile(tcx.FilePath, tcx.DropTrackPoints, correction, minimalMovingSpeed, minimalStepHight)
 	} else if tcx.input.Type == TcxBuffer {
-		ret, err = ReadBuffer(tcx.input.Buffer, tcx.input.Name, correction, minimalMovingSpeed, minimalStepHight)
+		var content io.ReadCloser
+		content, err = tcx.input.OpenContent()
+		if err == nil {
+			defer content.Close()
+			ret, err = decodeTcx(content, tcx.input.Name, tcx.DropTrackPoints, correction, minimalMovingSpeed, minimalStepHight)
+		}
 	} else {
 		err = gpsabl.NewUnKnownInputFileTypeError(tcx.input.Name)
 	}
@@ -76,16 +89,7 @@ func (tcx *TcxFile) CheckInputFile(input gpsabl.InputFile) bool {
 
 // ReadBuffer - Read the tcx data from a buffer, and return a gpsabl.TrackFile struct that contains all information
 func ReadBuffer(buffer []byte, name string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
-	content, readErr := readTCXBuffer(buffer, name)
-	if readErr != nil {
-		return gpsabl.TrackFile{}, readErr
-	}
-	ret, convertError := ConvertTcx(content, name, correction, minimalMovingSpeed, minimalStepHight)
-	if convertError != nil {
-		return gpsabl.TrackFile{}, convertError
-	}
-
-	return ret, nil
+	return decodeTcx(bytes.NewReader(buffer), name, false, correction, minimalMovingSpeed, minimalStepHight)
 }
 
 // CheckFile - Check if a file can be read by the TcxFile "class"
@@ -98,16 +102,18 @@ func (gpx *TcxFile) CheckFile(path string) bool {
 	return false
 }
 
-// CheckBuffer - Check if a buffer can be read by he TcxFile "class"
+// CheckBuffer - Check if a buffer can be read by he TcxFile "class". This is the case if the xml root element is <TrainingCenterDatabase>
 // Implement the gpsabl.TrackReader interface for *.tcx files
 func (gpx *TcxFile) CheckBuffer(buffer []byte) bool {
-	for i, _ := range buffer {
-		section := buffer[i : i+23]
-		if string(section) == "<TrainingCenterDatabase" {
-			return true
-		}
-	}
-	return false
+	res, _ := gpx.SniffContent(buffer)
+
+	return res
+}
+
+// SniffContent - Check if the start of a file or buffer is a xml document with the <TrainingCenterDatabase> root element
+// Implement the gpsabl.ContentSniffer interface for *.tcx files
+func (gpx *TcxFile) SniffContent(head []byte) (bool, string) {
+	return gpsabl.SniffXMLRoot(head, "TrainingCenterDatabase", []string{"http://www.garmin.com/xmlschemas/TrainingCenterDatabase/"})
 }
 
 // NewInputFileForBuffer - Get a new InputFile for a buffer containing a tcx files content
@@ -130,19 +136,17 @@ func (gpx *TcxFile) GetValidFileExtensions() []string {
 	return extensions
 }
 
-// ReadTcxFile - Reads a *.gpx file
+// ReadTcxFile - Reads a *.tcx file. The file is decoded as stream, and the activities are converted lap by lap
 func ReadTcxFile(filePath string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
-	ret := gpsabl.TrackFile{}
-	tcx, fileError := ReadTcx(filePath)
+	return readTcxFile(filePath, false, correction, minimalMovingSpeed, minimalStepHight)
+}
 
+func readTcxFile(filePath string, dropTrackPoints bool, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	file, fileError := os.Open(filePath)
 	if fileError != nil {
 		return gpsabl.TrackFile{}, fileError
 	}
+	defer file.Close()
 
-	ret, convertError := ConvertTcx(tcx, filePath, correction, minimalMovingSpeed, minimalStepHight)
-	if convertError != nil {
-		return gpsabl.TrackFile{}, convertError
-	}
-
-	return ret, nil
+	return decodeTcx(file, filePath, dropTrackPoints, correction, minimalMovingSpeed, minimalStepHight)
 }
@@ -232,3 +232,17 @@ func TestGetValidFileExtensions(t *testing.T) {
 	}
 
 }
+
+func TestSniffContent(t *testing.T) {
+	tcx := TcxFile{}
+	buffer, _ := testhelper.GetValidTcxBuffer("01.tcx")
+	res, reason := tcx.SniffContent(buffer)
+	if res != true || strings.Contains(reason, "TrainingCenterDatabase") == false {
+		t.Errorf("The TrainingCenterDatabase root element is not detected. The reason is \"%s\"", reason)
+	}
+
+	gpx, _ := testhelper.GetValidGpxBuffer("01.gpx")
+	if res, _ := tcx.SniffContent(gpx); res != false {
+		t.Errorf("A gpx buffer is detected as tcx")
+	}
+}
@@ -27,10 +27,15 @@ type Activity struct {
 
 // Lap - Represents one Lap in a TCX file
 type Lap struct {
-	DistanceMeters   string  `xml:"DistanceMeters"`
-	TotalTimeSeconds string  `xml:"TotalTimeSeconds"`
-	StartTime        string  `xml:"StartTime,attr"`
-	Tracks           []Track `xml:"Track"`
+	DistanceMeters      string       `xml:"DistanceMeters"`
+	TotalTimeSeconds    string       `xml:"TotalTimeSeconds"`
+	StartTime           string       `xml:"StartTime,attr"`
+	Calories            string       `xml:"Calories"`
+	AverageHeartRateBpm HeartRateBpm `xml:"AverageHeartRateBpm"`
+	MaximumHeartRateBpm HeartRateBpm `xml:"MaximumHeartRateBpm"`
+	Intensity           string       `xml:"Intensity"`
+	TriggerMethod       string       `xml:"TriggerMethod"`
+	Tracks              []Track      `xml:"Track"`
 }
 
 // Track - Represents one Track in a TCX file
@@ -43,6 +48,20 @@ type Trackpoint struct {
 	Time           string          `xml:"Time"`
 	AltitudeMeters float32         `xml:"AltitudeMeters"`
 	Position       PositionWrapper `xml:"Position"`
+	HeartRateBpm   HeartRateBpm    `xml:"HeartRateBpm"`
+	Cadence        string          `xml:"Cadence"`
+	TPX            TPX             `xml:"Extensions>TPX"`
+}
+
+// HeartRateBpm - Represents a heart rate value in a TCX file
+type HeartRateBpm struct {
+	Value string `xml:"Value"`
+}
+
+// TPX - Represents the Garmin ActivityExtension of a Trackpoint in a TCX file
+type TPX struct {
+	Speed string `xml:"Speed"`
+	Watts string `xml:"Watts"`
 }
 
 // PositionWrapper - Represents the Position in a TCX file
@@ -51,7 +70,7 @@ type PositionWrapper struct {
 	LongitudeDegrees float32 `xml:"LongitudeDegrees"`
 }
 
-// ReadTcx - Read a Tcx file
+// ReadTcx - Read a Tcx file into memory. ReadTcxFile reads and converts a file as stream
 func ReadTcx(fileName string) (Tcx, error) {
 	xmlfile, err := ioutil.ReadFile(fileName)
 	if err != nil {
@@ -150,3 +150,20 @@ func TestReadValidTcx01(t *testing.T) {
 	}
 
 }
+
+func TestReadValidTcxWithSensorData(t *testing.T) {
+	tcx, err := ReadTcx(testhelper.GetValidTcx("08.tcx"))
+	if err != nil {
+		t.Fatalf("Got the error \"%s\" but expected none", err.Error())
+	}
+
+	lap := tcx.ActivityArray[0].Activities[0].Laps[0]
+	if lap.Calories != "12" || lap.Intensity != "Active" || lap.TriggerMethod != "Distance" || lap.AverageHeartRateBpm.Value != "125" {
+		t.Errorf("The lap values are not read as expected: %v", lap)
+	}
+
+	pnt := lap.Tracks[0].Trackpoints[0]
+	if pnt.HeartRateBpm.Value != "118" || pnt.Cadence != "85" || pnt.TPX.Speed != "3.50" || pnt.TPX.Watts != "180" {
+		t.Errorf("The trackpoint values are not read as expected: %v", pnt)
+	}
+}
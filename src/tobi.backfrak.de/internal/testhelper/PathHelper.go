@@ -5,9 +5,8 @@ package testhelper
 // by a BSD-style license that can be found in the
 // LICENSE file.
 import (
-	"bufio"
 	"fmt"
-	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -22,7 +21,7 @@ import (
 func GetProjectRoot() string {
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, err.Error())
+		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
 	}
 	orgWD := wd
@@ -48,118 +47,67 @@ func isRootPath(wd string) bool {
 
 // GetValidGPX - Get the file path to a valid gpx file with the given name
 func GetValidGPX(name string) string {
-	rootDir := GetProjectRoot()
-
-	return filepath.Join(rootDir, "testdata", "valid-gpx", name)
+	return GetValidFile("gpx", name)
 }
 
+// GetValidGpxBuffer - Get the content of a valid gpx file with the given name
 func GetValidGpxBuffer(name string) ([]byte, error) {
-	filePath1 := GetValidGPX(name)
-	file1, _ := os.Open(filePath1)
-
-	var inputBytes []byte
-	reader1 := bufio.NewReader(file1)
-	for {
-		input, errRead1 := reader1.ReadByte()
-		if errRead1 != nil {
-			if errRead1 == io.EOF {
-				break
-			} else {
-				return nil, errRead1
-			}
-		}
-
-		inputBytes = append(inputBytes, input)
-	}
-
-	return inputBytes, nil
+	return GetValidFileBuffer("gpx", name)
 }
 
+// GetInvalidGpxBuffer - Get the content of a invalid gpx file with the given name
 func GetInvalidGpxBuffer(name string) ([]byte, error) {
-	filePath1 := GetInvalidGPX(name)
-	file1, _ := os.Open(filePath1)
-
-	var inputBytes []byte
-	reader1 := bufio.NewReader(file1)
-	for {
-		input, errRead1 := reader1.ReadByte()
-		if errRead1 != nil {
-			if errRead1 == io.EOF {
-				break
-			} else {
-				return nil, errRead1
-			}
-		}
-
-		inputBytes = append(inputBytes, input)
-	}
-
-	return inputBytes, nil
+	return GetInvalidFileBuffer("gpx", name)
 }
 
-// GetInvalidGPX - Get the file path to a valid gpx file with the given name
+// GetInvalidGPX - Get the file path to a invalid gpx file with the given name
 func GetInvalidGPX(name string) string {
-	rootDir := GetProjectRoot()
-
-	return filepath.Join(rootDir, "testdata", "invalid-gpx", name)
+	return GetInvalidFile("gpx", name)
 }
 
-// GetValidTcx - Get the file path to a valid gpx file with the given name
+// GetValidTcx - Get the file path to a valid tcx file with the given name
 func GetValidTcx(name string) string {
-	rootDir := GetProjectRoot()
-
-	return filepath.Join(rootDir, "testdata", "valid-tcx", name)
+	return GetValidFile("tcx", name)
 }
 
+// GetValidTcxBuffer - Get the content of a valid tcx file with the given name
 func GetValidTcxBuffer(name string) ([]byte, error) {
-	filePath1 := GetValidTcx(name)
-	file1, _ := os.Open(filePath1)
-
-	var inputBytes []byte
-	reader1 := bufio.NewReader(file1)
-	for {
-		input, errRead1 := reader1.ReadByte()
-		if errRead1 != nil {
-			if errRead1 == io.EOF {
-				break
-			} else {
-				return nil, errRead1
-			}
-		}
+	return GetValidFileBuffer("tcx", name)
+}
 
-		inputBytes = append(inputBytes, input)
-	}
+// GetInvalidTcxBuffer - Get the content of a invalid tcx file with the given name
+func GetInvalidTcxBuffer(name string) ([]byte, error) {
+	return GetInvalidFileBuffer("tcx", name)
+}
 
-	return inputBytes, nil
+// GetInvalidTcx - Get the file path to a invalid tcx file with the given name
+func GetInvalidTcx(name string) string {
+	return GetInvalidFile("tcx", name)
 }
 
-func GetInvalidTcxBuffer(name string) ([]byte, error) {
-	filePath1 := GetInvalidTcx(name)
-	file1, _ := os.Open(filePath1)
-
-	var inputBytes []byte
-	reader1 := bufio.NewReader(file1)
-	for {
-		input, errRead1 := reader1.ReadByte()
-		if errRead1 != nil {
-			if errRead1 == io.EOF {
-				break
-			} else {
-				return nil, errRead1
-			}
-		}
+// GetValidFile - Get the file path to a valid file of the format with the given name. The format is the
+// folder suffix in the testdata, like "fit" for "valid-fit". Bulk exports are directories in "valid-export"
+func GetValidFile(format string, name string) string {
+	rootDir := GetProjectRoot()
 
-		inputBytes = append(inputBytes, input)
-	}
+	return filepath.Join(rootDir, "testdata", "valid-"+format, name)
+}
 
-	return inputBytes, nil
+// GetValidFileBuffer - Get the content of a valid file of the format with the given name
+func GetValidFileBuffer(format string, name string) ([]byte, error) {
+	return ioutil.ReadFile(GetValidFile(format, name))
 }
 
-// GetInvalidTcx - Get the file path to a valid gpx file with the given name
-func GetInvalidTcx(name string) string {
+// GetInvalidFile - Get the file path to a invalid file of the format with the given name
+func GetInvalidFile(format string, name string) string {
 	rootDir := GetProjectRoot()
 
-	return filepath.Join(rootDir, "testdata", "invalid-tcx", name)
+	return filepath.Join(rootDir, "testdata", "invalid-"+format, name)
+}
+
+// GetInvalidFileBuffer - Get the content of a invalid file of the format with the given name
+func GetInvalidFileBuffer(format string, name string) ([]byte, error) {
+	return ioutil.ReadFile(GetInvalidFile(format, name))
 }
 
 func fileExists(filename string) bool {
@@ -0,0 +1,57 @@
+package unicsvbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import "fmt"
+
+// UnicsvFileError - Error when trying to load something that is not a unicsv file with latitude and longitude columns
+type UnicsvFileError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+}
+
+func (e *UnicsvFileError) Error() string { // Implement the Error Interface for the UnicsvFileError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newUnicsvFileError - Get a new UnicsvFileError struct
+func newUnicsvFileError(fileName string) *UnicsvFileError {
+	return &UnicsvFileError{fmt.Sprintf("The file \"%s\" is not a unicsv file with latitude and longitude columns", fileName), fileName}
+}
+
+// EmptyUnicsvFileError - Error when trying to load a unicsv file that does not contain any track point
+type EmptyUnicsvFileError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+}
+
+func (e *EmptyUnicsvFileError) Error() string { // Implement the Error Interface for the EmptyUnicsvFileError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newEmptyUnicsvFileError - Get a new EmptyUnicsvFileError struct
+func newEmptyUnicsvFileError(fileName string) *EmptyUnicsvFileError {
+	return &EmptyUnicsvFileError{fmt.Sprintf("The file \"%s\" does not contain any track point.", fileName), fileName}
+}
+
+// UnicsvPointError - Error when a line of a unicsv file can not be parsed
+type UnicsvPointError struct {
+	err string
+	// File - The path to the file that caused this error
+	File string
+	// Line - The number of the line that could not be parsed
+	Line int
+}
+
+func (e *UnicsvPointError) Error() string { // Implement the Error Interface for the UnicsvPointError struct
+	return fmt.Sprintf("Error: %s", e.err)
+}
+
+// newUnicsvPointError - Get a new UnicsvPointError struct
+func newUnicsvPointError(fileName string, line int) *UnicsvPointError {
+	return &UnicsvPointError{fmt.Sprintf("The track point in line %d of the file \"%s\" can not be parsed.", line, fileName), fileName, line}
+}
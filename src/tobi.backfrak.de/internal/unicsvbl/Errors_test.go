@@ -0,0 +1,50 @@
+package unicsvbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnicsvFileError(t *testing.T) {
+	path := "/some/sample/path"
+	err := newUnicsvFileError(path)
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of UnicsvFileError does not contain the expected Path")
+	}
+
+	if err.File != path {
+		t.Errorf("The UnicsvFileError.File does not match the expected value")
+	}
+}
+
+func TestEmptyUnicsvFileError(t *testing.T) {
+	path := "/some/sample/path"
+	err := newEmptyUnicsvFileError(path)
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of EmptyUnicsvFileError does not contain the expected Path")
+	}
+
+	if err.File != path {
+		t.Errorf("The EmptyUnicsvFileError.File does not match the expected value")
+	}
+}
+
+func TestUnicsvPointError(t *testing.T) {
+	path := "/some/sample/path"
+	err := newUnicsvPointError(path, 8)
+	if strings.Contains(err.Error(), path) == false {
+		t.Errorf("The error message of UnicsvPointError does not contain the expected Path")
+	}
+
+	if strings.Contains(err.Error(), "line 8") == false {
+		t.Errorf("The error message of UnicsvPointError does not contain the expected line")
+	}
+
+	if err.File != path || err.Line != 8 {
+		t.Errorf("The UnicsvPointError values do not match the expected values")
+	}
+}
@@ -0,0 +1,72 @@
+package unicsvbl
+
+import (
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+// ConvertUnicsv - Convert a unicsvbl.Unicsv to a gpsabl.TrackFile with one gpsabl.Track and one gpsabl.TrackSegment.
+// Points without altitude get the elevation of the point before, see gpsabl.FillMissingElevation
+func ConvertUnicsv(unicsv Unicsv, filePath string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+
+	if len(unicsv.Points) <= 0 {
+		return gpsabl.TrackFile{}, newEmptyUnicsvFileError(filePath)
+	}
+
+	res := gpsabl.NewTrackFile(filePath)
+	track := gpsabl.Track{}
+
+	seg, err := convertPoints(unicsv.Points, correction, minimalMovingSpeed, minimalStepHight)
+	if err != nil {
+		return gpsabl.TrackFile{}, err
+	}
+
+	track.TrackSegments = []gpsabl.TrackSegment{seg}
+	track.NumberOfSegments = len(track.TrackSegments)
+	gpsabl.FillTrackValues(&track)
+
+	res.Tracks = []gpsabl.Track{track}
+	res.NumberOfTracks = len(res.Tracks)
+	gpsabl.FillTrackFileValues(&res)
+
+	return res, nil
+}
+
+func convertPoints(points []UnicsvPoint, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackSegment, error) {
+	res := gpsabl.TrackSegment{}
+	pointCount := len(points)
+	basic := make([]gpsabl.TrackPoint, pointCount)
+	for i, point := range points {
+		pnt := gpsabl.TrackPoint{}
+		pnt.Latitude = float32(point.Latitude)
+		pnt.Longitude = float32(point.Longitude)
+		pnt.Elevation = point.Altitude
+		pnt.ElevationMissing = !point.AltitudeValid
+		pnt.Time = point.Time
+		pnt.TimeValid = point.TimeValid
+		pnt.HeartRate = point.HeartRate
+		pnt.HeartRateValid = point.HeartRateValid
+		pnt.Cadence = point.Cadence
+		pnt.CadenceValid = point.CadenceValid
+		pnt.Power = point.Power
+		pnt.PowerValid = point.PowerValid
+		basic[i] = pnt
+	}
+	gpsabl.FillMissingElevation(basic)
+
+	ret := gpsabl.FillDistances(basic)
+
+	err := gpsabl.FillValuesTrackPointArray(ret, correction, minimalMovingSpeed, minimalStepHight)
+	if err != nil {
+		return gpsabl.TrackSegment{}, err
+	}
+
+	res.TrackPoints = ret
+	gpsabl.FillTrackSegmentValues(&res)
+
+	return res, nil
+}
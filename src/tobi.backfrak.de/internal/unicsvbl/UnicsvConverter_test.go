@@ -0,0 +1,70 @@
+package unicsvbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func TestConvertUnicsv(t *testing.T) {
+	unicsv, err := ReadUnicsv(testhelper.GetValidFile("unicsv", "01.csv"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	file, convErr := ConvertUnicsv(unicsv, "my/path.csv", gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	// The file was created out of testdata/valid-gpx/12.gpx, the missing altitudes must not change the MinimumAltitude
+	if file.MinimumAltitude != 245 || file.MaximumAltitude != 304 {
+		t.Errorf("The MinimumAltitude and MaximumAltitude are %f and %f, but should be %f and %f", file.MinimumAltitude, file.MaximumAltitude, 245.0, 304.0)
+	}
+
+	if file.StartTime.Format(time.RFC3339) != "2014-08-22T16:48:52Z" || file.EndTime.Format(time.RFC3339) != "2014-08-22T17:19:42Z" {
+		t.Errorf("The StartTime and EndTime are %s and %s, but should be %s and %s", file.StartTime.Format(time.RFC3339), file.EndTime.Format(time.RFC3339), "2014-08-22T16:48:52Z", "2014-08-22T17:19:42Z")
+	}
+
+	pnt := file.Tracks[0].TrackSegments[0].TrackPoints[20]
+	if pnt.ElevationMissing != true || pnt.Elevation != file.Tracks[0].TrackSegments[0].TrackPoints[19].Elevation {
+		t.Errorf("The point without altitude has the Elevation %f and ElevationMissing %t", pnt.Elevation, pnt.ElevationMissing)
+	}
+}
+
+func TestConvertUnicsvWithSensors(t *testing.T) {
+	unicsv, err := ReadUnicsv(testhelper.GetValidFile("unicsv", "02.unicsv"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	file, convErr := ConvertUnicsv(unicsv, "my/path.unicsv", gpsabl.NO, 0.3, 10.0)
+	if convErr != nil {
+		t.Fatalf("Got error \"%s\" but expected none", convErr)
+	}
+
+	if file.HeartRate.Maximum != 110 || file.HeartRate.Samples != 3 {
+		t.Errorf("The HeartRate is %v, but should have the maximum 110 and 3 samples", file.HeartRate)
+	}
+
+	if file.MinimumAltitude != 257 || file.MaximumAltitude != 271 {
+		t.Errorf("The MinimumAltitude and MaximumAltitude are %f and %f, but should be %f and %f", file.MinimumAltitude, file.MaximumAltitude, 257.0, 271.0)
+	}
+}
+
+func TestConvertEmptyUnicsv(t *testing.T) {
+	_, convErr := ConvertUnicsv(Unicsv{}, "my/path.csv", gpsabl.NO, 0.3, 10.0)
+	switch convErr.(type) {
+	case *EmptyUnicsvFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *EmptyUnicsvFileError, got \"%v\"", convErr)
+	}
+}
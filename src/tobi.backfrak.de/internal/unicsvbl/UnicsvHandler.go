@@ -0,0 +1,149 @@
+package unicsvbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"strings"
+
+	"tobi.backfrak.de/internal/gpsabl"
+)
+
+const UnicsvBuffer gpsabl.InputFileType = "UnicsvBuffer"
+
+// The file extension this Reader can read
+const FileExtension string = ".unicsv"
+
+// The file extension of the csv files GPSBabel writes in unicsv format
+const CsvFileExtension string = ".csv"
+
+// UnicsvFile - The struct to handle GPSBabel unicsv files
+type UnicsvFile struct {
+	gpsabl.TrackFile
+	input gpsabl.InputFile
+}
+
+// NewUnicsvFile - Constructor for the UnicsvFile struct
+func NewUnicsvFile(filePath string) UnicsvFile {
+	unicsv := UnicsvFile{}
+	unicsv.FilePath = filePath
+	unicsv.input = *gpsabl.NewInputFileWithPath(filePath)
+
+	return unicsv
+}
+
+// NewReader - Get a new reader for unicsv files that will read the data in the given gpsabl.InputFile
+// Implement the gpsabl.TrackReader interface for unicsv files
+func (unicsv *UnicsvFile) NewReader(data gpsabl.InputFile) gpsabl.TrackReader {
+	newUnicsv := UnicsvFile{}
+	newUnicsv.input = data
+	if data.Type == gpsabl.FilePath {
+		newUnicsv.FilePath = data.Name
+	}
+
+	return &newUnicsv
+}
+
+// ReadTracks - Read the unicsv from the input from FilePath or InputFile.Buffer, and return a gpsabl.TrackFile struct that contains all information
+// Implement the gpsabl.TrackReader interface for unicsv files
+func (unicsv *UnicsvFile) ReadTracks(correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	var err error
+	var ret gpsabl.TrackFile
+	if unicsv.input.Type == gpsabl.FilePath {
+		ret, err = ReadUnicsvFile(unicsv.FilePath, correction, minimalMovingSpeed, minimalStepHight)
+	} else if unicsv.input.Type == UnicsvBuffer {
+		var buffer []byte
+		buffer, err = unicsv.input.ReadContent()
+		if err == nil {
+			ret, err = ReadBuffer(buffer, unicsv.input.Name, correction, minimalMovingSpeed, minimalStepHight)
+		}
+	} else {
+		err = gpsabl.NewUnKnownInputFileTypeError(unicsv.input.Name)
+	}
+
+	if err == nil {
+		unicsv.TrackFile = ret
+	}
+
+	return ret, err
+}
+
+// CheckInputFile - Check if a gpsabl.InputFile can be handled by the UnicsvFile reader
+// Implement the gpsabl.TrackReader interface for unicsv files
+func (unicsv *UnicsvFile) CheckInputFile(input gpsabl.InputFile) bool {
+	if input.Type == UnicsvBuffer {
+		return true
+	} else if input.Type == gpsabl.FilePath && unicsv.CheckFile(input.Name) {
+		return true
+	}
+
+	return false
+}
+
+// ReadBuffer - Read the unicsv data from a buffer, and return a gpsabl.TrackFile struct that contains all information
+func ReadBuffer(buffer []byte, name string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	content, readErr := readUnicsvBuffer(buffer, name)
+	if readErr != nil {
+		return gpsabl.TrackFile{}, readErr
+	}
+	ret, convertError := ConvertUnicsv(content, name, correction, minimalMovingSpeed, minimalStepHight)
+	if convertError != nil {
+		return gpsabl.TrackFile{}, convertError
+	}
+
+	return ret, nil
+}
+
+// CheckFile - Check if a file can be read by the UnicsvFile "class"
+// Implement the gpsabl.TrackReader interface for unicsv files
+func (unicsv *UnicsvFile) CheckFile(path string) bool {
+	lowerPath := strings.ToLower(path)
+	if strings.HasSuffix(lowerPath, FileExtension) || strings.HasSuffix(lowerPath, CsvFileExtension) { // If the file is a *.unicsv or *.csv, we can read it
+		return true
+	}
+
+	return false
+}
+
+// CheckBuffer - Check if a buffer can be read by he UnicsvFile "class". This is the case if the header line contains a latitude and a longitude column
+// Implement the gpsabl.TrackReader interface for unicsv files
+func (unicsv *UnicsvFile) CheckBuffer(buffer []byte) bool {
+	return isUnicsvBuffer(buffer)
+}
+
+// NewInputFileForBuffer - Get a new InputFile for a buffer containing a unicsv files content
+// Implement the gpsabl.TrackReader interface for unicsv files
+func (unicsv *UnicsvFile) NewInputFileForBuffer(buffer []byte, name string) *gpsabl.InputFile {
+	file := gpsabl.InputFile{}
+	file.Name = name
+	file.Type = UnicsvBuffer
+	file.Buffer = buffer
+
+	return &file
+}
+
+// GetValidFileExtensions - Get a list of file extensions this reader can read
+// Implement the gpsabl.TrackReader interface for unicsv files
+func (unicsv *UnicsvFile) GetValidFileExtensions() []string {
+
+	extensions := []string{FileExtension, CsvFileExtension}
+
+	return extensions
+}
+
+// ReadUnicsvFile - Reads a unicsv file
+func ReadUnicsvFile(filePath string, correction gpsabl.CorrectionParameter, minimalMovingSpeed float64, minimalStepHight float64) (gpsabl.TrackFile, error) {
+	unicsv, fileError := ReadUnicsv(filePath)
+
+	if fileError != nil {
+		return gpsabl.TrackFile{}, fileError
+	}
+
+	ret, convertError := ConvertUnicsv(unicsv, filePath, correction, minimalMovingSpeed, minimalStepHight)
+	if convertError != nil {
+		return gpsabl.TrackFile{}, convertError
+	}
+
+	return ret, nil
+}
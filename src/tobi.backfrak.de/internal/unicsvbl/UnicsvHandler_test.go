@@ -0,0 +1,180 @@
+package unicsvbl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"tobi.backfrak.de/internal/gpsabl"
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+
+func TestTrackReaderInValidCorrectionParameter(t *testing.T) {
+	unicsv := NewUnicsvFile(testhelper.GetValidFile("unicsv", "02.unicsv"))
+
+	_, err := unicsv.ReadTracks("asdfg", 0.3, 10.0)
+	if err != nil {
+		switch ty := err.(type) {
+		case *gpsabl.CorrectionParameterNotKnownError:
+			fmt.Println("OK")
+		default:
+			t.Errorf("The Error ReadTracks gave is of the wrong type. The type is %v", ty)
+		}
+	} else {
+		t.Errorf("ReadTracks did not return a error, but was expected")
+	}
+}
+
+func TestReadAllValidUnicsvDueInterface(t *testing.T) {
+	files, _ := ioutil.ReadDir(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-unicsv"))
+
+	for _, file := range files {
+		unicsvFile := NewUnicsvFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-unicsv", file.Name()))
+		if unicsvFile.CheckFile(file.Name()) && file.IsDir() == false {
+			iUnicsv := gpsabl.TrackReader(&unicsvFile)
+
+			track, err := iUnicsv.ReadTracks("none", 0.3, 10.0)
+			if err != nil {
+				t.Errorf("Got the error \"%s\" while reading file %s.", err.Error(), filepath.Join(testhelper.GetProjectRoot(), "testdata", "valid-unicsv", file.Name()))
+			}
+			if track.Distance <= 0.0 {
+				t.Errorf("The track.Distance is %f but should not be less then %f", track.Distance, 0.0)
+			}
+		}
+	}
+}
+
+func TestReadAllInValidUnicsvDueInterface(t *testing.T) {
+	files, _ := ioutil.ReadDir(filepath.Join(testhelper.GetProjectRoot(), "testdata", "invalid-unicsv"))
+
+	for _, file := range files {
+		unicsvFile := NewUnicsvFile(filepath.Join(testhelper.GetProjectRoot(), "testdata", "invalid-unicsv", file.Name()))
+		if unicsvFile.CheckFile(file.Name()) && file.IsDir() == false {
+			iUnicsv := gpsabl.TrackReader(&unicsvFile)
+
+			_, err := iUnicsv.ReadTracks("none", 0.3, 10.0)
+			if err == nil {
+				t.Errorf("Got no error while reading file %s.", filepath.Join(testhelper.GetProjectRoot(), "testdata", "invalid-unicsv", file.Name()))
+			}
+		}
+	}
+}
+
+func TestNewReaderWithValidFilePath(t *testing.T) {
+	unicsv := UnicsvFile{}
+	file := testhelper.GetValidFile("unicsv", "01.csv")
+	checkRes := unicsv.CheckFile(file)
+	input := *gpsabl.NewInputFileWithPath(file)
+
+	sut := unicsv.NewReader(input)
+
+	if checkRes != true {
+		t.Errorf("UnicsvFile can not read %s", file)
+	}
+
+	if unicsv.CheckInputFile(input) != true {
+		t.Errorf("UnicsvFile can not read the input %s", file)
+	}
+
+	trk, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+	if err != nil {
+		t.Errorf("Got error \"%s\" but expect none", err)
+	}
+
+	if trk.FilePath != file {
+		t.Errorf("The trk.FilePath %s is not the given path %s", trk.FilePath, file)
+	}
+}
+
+func TestNewReaderWithValidBuffer(t *testing.T) {
+	for _, name := range []string{"01.csv", "02.unicsv"} {
+		unicsv := UnicsvFile{}
+		buffer, createErr := testhelper.GetValidFileBuffer("unicsv", name)
+		if createErr != nil {
+			t.Fatalf("Got error \"%s\" while creating the input buffer", createErr)
+		}
+		checkRes := unicsv.CheckBuffer(buffer)
+		input := *unicsv.NewInputFileForBuffer(buffer, "Buffer 1")
+
+		sut := unicsv.NewReader(input)
+
+		if checkRes != true {
+			t.Errorf("UnicsvFile can not read %s from buffer", name)
+		}
+
+		if unicsv.CheckInputFile(input) != true {
+			t.Errorf("UnicsvFile can not read the input buffer")
+		}
+
+		trk, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+		if err != nil {
+			t.Errorf("Got error \"%s\" but expect none", err)
+		}
+
+		if trk.FilePath != "Buffer 1" {
+			t.Errorf("The trk.FilePath %s is not the given path %s", trk.FilePath, "Buffer 1")
+		}
+	}
+}
+
+func TestNewReaderWithUnknownInputType(t *testing.T) {
+	unicsv := UnicsvFile{}
+	input := gpsabl.InputFile{Type: "Unknown", Name: "Buffer 1"}
+	sut := unicsv.NewReader(input)
+
+	if unicsv.CheckInputFile(input) != false {
+		t.Errorf("UnicsvFile can read an input of unknown type")
+	}
+
+	_, err := sut.ReadTracks(gpsabl.STEPS, 0.01, 10)
+	switch err.(type) {
+	case *gpsabl.UnKnownInputFileTypeError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *gpsabl.UnKnownInputFileTypeError, got \"%v\"", err)
+	}
+}
+
+func TestCheckBuffer(t *testing.T) {
+	unicsv := UnicsvFile{}
+	gpx, _ := testhelper.GetValidGpxBuffer("01.gpx")
+	if unicsv.CheckBuffer(gpx) != false {
+		t.Errorf("UnicsvFile can read a gpx buffer")
+	}
+
+	invalid, _ := testhelper.GetInvalidFileBuffer("unicsv", "01.csv")
+	if unicsv.CheckBuffer(invalid) != false {
+		t.Errorf("UnicsvFile can read a buffer without latitude and longitude column")
+	}
+}
+
+func TestCheckFile(t *testing.T) {
+	unicsv := UnicsvFile{}
+
+	if unicsv.CheckFile("my/path/file.UNICSV") != true {
+		t.Errorf("UnicsvFile can not read *.UNICSV files")
+	}
+
+	if unicsv.CheckFile("my/path/file.csv") != true {
+		t.Errorf("UnicsvFile can not read *.csv files")
+	}
+
+	if unicsv.CheckFile("my/path/file.gpx") != false {
+		t.Errorf("UnicsvFile can read *.gpx files")
+	}
+}
+
+func TestGetValidFileExtensions(t *testing.T) {
+	unicsv := UnicsvFile{}
+	extensions := unicsv.GetValidFileExtensions()
+
+	if len(extensions) != 2 || extensions[0] != FileExtension || extensions[1] != CsvFileExtension {
+		t.Errorf("The valid file extensions are %v, but should be [%s %s]", extensions, FileExtension, CsvFileExtension)
+	}
+}
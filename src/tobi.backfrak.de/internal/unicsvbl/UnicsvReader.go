@@ -0,0 +1,256 @@
+package unicsvbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"io/ioutil"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NoAltitude - The altitude some programs write for points without altitude
+const NoAltitude float64 = -999
+
+// unknownAltitude - The altitude GPSBabel uses internally for points without altitude
+const unknownAltitude float64 = -99999999
+
+const feetToMeter float64 = 0.3048
+
+// column - The values of a unicsv file gpsa can use
+type column string
+
+const (
+	latitudeColumn  column = "latitude"
+	longitudeColumn column = "longitude"
+	altitudeColumn  column = "altitude"
+	dateColumn      column = "date"
+	timeColumn      column = "time"
+	heartRateColumn column = "heartrate"
+	cadenceColumn   column = "cadence"
+	powerColumn     column = "power"
+)
+
+// columnNames - The header names GPSBabel knows for the columns, in lower case without spaces and underscores
+var columnNames = map[string]column{
+	"lat": latitudeColumn, "latitude": latitudeColumn,
+	"lon": longitudeColumn, "long": longitudeColumn, "lng": longitudeColumn, "longitude": longitudeColumn,
+	"alt": altitudeColumn, "altitude": altitudeColumn, "ele": altitudeColumn, "elevation": altitudeColumn, "height": altitudeColumn,
+	"date": dateColumn, "utcd": dateColumn, "utcdate": dateColumn,
+	"time": timeColumn, "utct": timeColumn, "utctime": timeColumn,
+	"hr": heartRateColumn, "heartrate": heartRateColumn,
+	"cad": cadenceColumn, "cadence": cadenceColumn,
+	"power": powerColumn,
+}
+
+// separators - The separators GPSBabel accepts, the one found most often in the header line is used
+var separators = []rune{',', ';', '\t', '|'}
+
+var dateLayouts = []string{"2006/01/02", "2006-01-02", "02.01.2006", "01/02/2006"}
+
+// Unicsv - Represents the content of a unicsv file
+type Unicsv struct {
+	Points []UnicsvPoint
+}
+
+// UnicsvPoint - Represents one line of a unicsv file
+type UnicsvPoint struct {
+	Latitude  float64
+	Longitude float64
+	// Altitude - The altitude in m
+	Altitude float32
+	// AltitudeValid - False when the altitude is empty or the -999 "no altitude" value
+	AltitudeValid  bool
+	Time           time.Time
+	TimeValid      bool
+	HeartRate      int
+	HeartRateValid bool
+	Cadence        int
+	CadenceValid   bool
+	Power          int
+	PowerValid     bool
+}
+
+// ReadUnicsv - Read a unicsv file
+func ReadUnicsv(fileName string) (Unicsv, error) {
+	fileBuffer, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return Unicsv{}, err
+	}
+	return readUnicsvBuffer(fileBuffer, fileName)
+}
+
+func readUnicsvBuffer(fileBuffer []byte, fileName string) (Unicsv, error) {
+	header, separator := getHeader(fileBuffer)
+	columns := getColumnIndexes(header)
+	if !hasPositionColumns(columns) {
+		return Unicsv{}, newUnicsvFileError(fileName)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(fileBuffer))
+	reader.Comma = separator
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	ret := Unicsv{}
+	for i := 0; ; i++ {
+		record, errRead := reader.Read()
+		if errRead == io.EOF {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if errRead != nil {
+			return Unicsv{}, newUnicsvPointError(fileName, line)
+		}
+		if i == 0 || isEmptyRecord(record) {
+			continue
+		}
+
+		point, valid := parseRecord(record, columns)
+		if !valid {
+			return Unicsv{}, newUnicsvPointError(fileName, line)
+		}
+		ret.Points = append(ret.Points, point)
+	}
+
+	return ret, nil
+}
+
+// isUnicsvBuffer - Tell if the header line of a buffer contains a latitude and a longitude column
+func isUnicsvBuffer(buffer []byte) bool {
+	header, _ := getHeader(buffer)
+
+	return hasPositionColumns(getColumnIndexes(header))
+}
+
+// getHeader - Get the fields of the first line and the separator used in it
+func getHeader(buffer []byte) ([]string, rune) {
+	firstLine := string(buffer)
+	if index := strings.IndexAny(firstLine, "\r\n"); index >= 0 {
+		firstLine = firstLine[:index]
+	}
+	firstLine = strings.TrimPrefix(firstLine, "\ufeff")
+
+	separator := separators[0]
+	for _, sep := range separators[1:] {
+		if strings.Count(firstLine, string(sep)) > strings.Count(firstLine, string(separator)) {
+			separator = sep
+		}
+	}
+
+	return strings.Split(firstLine, string(separator)), separator
+}
+
+// getColumnIndexes - Map the known columns to their index. Unknown columns are ignored
+func getColumnIndexes(header []string) map[column]int {
+	ret := make(map[column]int)
+	replacer := strings.NewReplacer(" ", "", "_", "", "-", "", "\"", "")
+	for i, name := range header {
+		col, known := columnNames[replacer.Replace(strings.ToLower(strings.TrimSpace(name)))]
+		if _, found := ret[col]; known && !found {
+			ret[col] = i
+		}
+	}
+
+	return ret
+}
+
+func hasPositionColumns(columns map[column]int) bool {
+	_, lat := columns[latitudeColumn]
+	_, lon := columns[longitudeColumn]
+
+	return lat && lon
+}
+
+func isEmptyRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseRecord - Parse the values of a line. Only latitude and longitude are mandatory
+func parseRecord(record []string, columns map[column]int) (UnicsvPoint, bool) {
+	point := UnicsvPoint{}
+	lat, latErr := strconv.ParseFloat(getValue(record, columns, latitudeColumn), 64)
+	lon, lonErr := strconv.ParseFloat(getValue(record, columns, longitudeColumn), 64)
+	if latErr != nil || lonErr != nil || math.Abs(lat) > 90 || math.Abs(lon) > 180 {
+		return point, false
+	}
+	point.Latitude = lat
+	point.Longitude = lon
+
+	point.Altitude, point.AltitudeValid = parseAltitude(getValue(record, columns, altitudeColumn))
+	point.Time, point.TimeValid = parseTime(getValue(record, columns, dateColumn), getValue(record, columns, timeColumn))
+	point.HeartRate, point.HeartRateValid = parseSensorValue(getValue(record, columns, heartRateColumn))
+	point.Cadence, point.CadenceValid = parseSensorValue(getValue(record, columns, cadenceColumn))
+	point.Power, point.PowerValid = parseSensorValue(getValue(record, columns, powerColumn))
+
+	return point, true
+}
+
+func getValue(record []string, columns map[column]int, col column) string {
+	index, found := columns[col]
+	if !found || index >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[index])
+}
+
+// parseAltitude - Parse an altitude in m. A "ft" suffix marks values in feet
+func parseAltitude(value string) (float32, bool) {
+	factor := 1.0
+	lower := strings.ToLower(value)
+	if strings.HasSuffix(lower, "ft") {
+		factor = feetToMeter
+		lower = strings.TrimSuffix(lower, "ft")
+	}
+	lower = strings.TrimSpace(strings.TrimSuffix(lower, "m"))
+
+	altitude, err := strconv.ParseFloat(lower, 64)
+	if err != nil || altitude == NoAltitude || altitude <= unknownAltitude {
+		return 0, false
+	}
+
+	return float32(altitude * factor), true
+}
+
+// parseTime - Parse the UTC date and time of a point. Both are needed for a valid time
+func parseTime(date string, timeOfDay string) (time.Time, bool) {
+	if date == "" || timeOfDay == "" {
+		return time.Time{}, false
+	}
+
+	for _, layout := range dateLayouts {
+		ret, err := time.Parse(layout+" 15:04:05", date+" "+timeOfDay)
+		if err == nil {
+			return ret, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func parseSensorValue(value string) (int, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	ret, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return int(math.Round(ret)), true
+}
@@ -0,0 +1,136 @@
+package unicsvbl
+
+// Copyright 2025 by tobi@backfrak.de. All
+// rights reserved. Use of this source code is governed
+// by a BSD-style license that can be found in the
+// LICENSE file.
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"tobi.backfrak.de/internal/testhelper"
+)
+
+func TestReadValidUnicsv01(t *testing.T) {
+	unicsv, err := ReadUnicsv(testhelper.GetValidFile("unicsv", "01.csv"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(unicsv.Points) != 413 {
+		t.Fatalf("The number of Points is %d, but should be %d", len(unicsv.Points), 413)
+	}
+
+	first := unicsv.Points[0]
+	if first.Latitude != 45.273245 || first.Longitude != 13.715185 || first.Altitude != 271 || first.AltitudeValid != true {
+		t.Errorf("The first point is %v", first)
+	}
+
+	if first.TimeValid != true || first.Time.Format(time.RFC3339) != "2014-08-22T16:48:52Z" {
+		t.Errorf("The Time is %s, but should be %s", first.Time.Format(time.RFC3339), "2014-08-22T16:48:52Z")
+	}
+
+	if unicsv.Points[10].AltitudeValid != false || unicsv.Points[20].AltitudeValid != false {
+		t.Errorf("The points with empty and -999 altitude have a valid altitude")
+	}
+
+	if first.HeartRateValid != false {
+		t.Errorf("The point has a heart rate, but the file has no heart rate column")
+	}
+}
+
+func TestReadValidUnicsvSemicolonWithSensors(t *testing.T) {
+	unicsv, err := ReadUnicsv(testhelper.GetValidFile("unicsv", "02.unicsv"))
+	if err != nil {
+		t.Fatalf("Got error \"%s\" but expected none", err)
+	}
+
+	if len(unicsv.Points) != 4 {
+		t.Fatalf("The number of Points is %d, but should be %d", len(unicsv.Points), 4)
+	}
+
+	first := unicsv.Points[0]
+	if first.HeartRate != 101 || first.Cadence != 80 || first.Power != 150 || !first.HeartRateValid || !first.CadenceValid || !first.PowerValid {
+		t.Errorf("The sensor values of the first point are %v", first)
+	}
+
+	if unicsv.Points[2].CadenceValid != false || unicsv.Points[3].HeartRateValid != false {
+		t.Errorf("Empty sensor values are valid")
+	}
+
+	if unicsv.Points[3].Time.Format(time.RFC3339) != "2014-08-22T16:49:20Z" {
+		t.Errorf("The Time is %s, but should be %s", unicsv.Points[3].Time.Format(time.RFC3339), "2014-08-22T16:49:20Z")
+	}
+}
+
+func TestReadUnicsvWithoutPositionColumns(t *testing.T) {
+	_, err := ReadUnicsv(testhelper.GetInvalidFile("unicsv", "01.csv"))
+	switch err.(type) {
+	case *UnicsvFileError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *UnicsvFileError, got \"%v\"", err)
+	}
+}
+
+func TestReadUnicsvInvalidPoint(t *testing.T) {
+	_, err := ReadUnicsv(testhelper.GetInvalidFile("unicsv", "02.csv"))
+	switch ty := err.(type) {
+	case *UnicsvPointError:
+		if ty.Line != 3 {
+			t.Errorf("The Line is %d, but should be %d", ty.Line, 3)
+		}
+	default:
+		t.Errorf("Expected a *UnicsvPointError, got \"%v\"", err)
+	}
+}
+
+func TestReadUnicsvNotExistingFile(t *testing.T) {
+	_, err := ReadUnicsv(testhelper.GetInvalidFile("unicsv", "not-existing.csv"))
+	switch err.(type) {
+	case *os.PathError:
+		fmt.Println("OK")
+	default:
+		t.Errorf("Expected a *os.PathError, got \"%v\"", err)
+	}
+}
+
+func TestGetHeader(t *testing.T) {
+	header, separator := getHeader([]byte("\ufeffLat\tLon\tAlt\r\n1\t2\t3\r\n"))
+	if separator != '\t' || len(header) != 3 || header[0] != "Lat" {
+		t.Errorf("The header is %v with separator %q", header, separator)
+	}
+
+	columns := getColumnIndexes([]string{"No", "UTC_Date", "Latitude", "\"Longitude\"", "Heart Rate"})
+	if columns[dateColumn] != 1 || columns[latitudeColumn] != 2 || columns[longitudeColumn] != 3 || columns[heartRateColumn] != 4 {
+		t.Errorf("The column indexes are %v", columns)
+	}
+}
+
+func TestParseAltitude(t *testing.T) {
+	if alt, valid := parseAltitude("100ft"); !valid || int(alt*100) != 3048 {
+		t.Errorf("The altitude of \"100ft\" is %f, but should be %f", alt, 30.48)
+	}
+
+	if alt, valid := parseAltitude("271.5m"); !valid || alt != 271.5 {
+		t.Errorf("The altitude of \"271.5m\" is %f, but should be %f", alt, 271.5)
+	}
+
+	for _, value := range []string{"", "-999", "-99999999", "high"} {
+		if _, valid := parseAltitude(value); valid {
+			t.Errorf("The altitude \"%s\" is valid", value)
+		}
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	if ret, valid := parseTime("22.08.2014", "16:48:52.5"); !valid || ret.Format(time.RFC3339Nano) != "2014-08-22T16:48:52.5Z" {
+		t.Errorf("The time is %s, but should be %s", ret.Format(time.RFC3339Nano), "2014-08-22T16:48:52.5Z")
+	}
+
+	if _, valid := parseTime("", "16:48:52"); valid {
+		t.Errorf("A time without date is valid")
+	}
+}
@@ -0,0 +1,4 @@
+OziExplorer Waypoint File Version 1.1
+WGS 84
+Reserved 2
+garmin
@@ -0,0 +1,8 @@
+OziExplorer Track Point File Version 2.1
+WGS 84
+Altitude is in Feet
+Reserved 3
+0,2,255,Broken,0,0,2,8421376
+2
+  45.273245,  13.715185,1,846.5,41873.7006019, 22-Aug-14, 4:48:52 PM
+  north,  13.715221,0,846.5,41873.7007755, 22-Aug-14, 4:49:07 PM
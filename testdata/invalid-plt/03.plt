@@ -0,0 +1,6 @@
+OziExplorer Track Point File Version 2.1
+WGS 84
+Altitude is in Feet
+Reserved 3
+0,2,255,Empty,0,0,2,8421376
+0
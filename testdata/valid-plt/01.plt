@@ -0,0 +1,419 @@
+OziExplorer Track Point File Version 2.1
+WGS 84
+Altitude is in Feet
+Reserved 3
+0,2,255,Istria Hike,0,0,2,8421376
+413
+  45.273245,  13.715185,1,889.1,41873.7006019,22-Aug-14, 4:48:52 PM
+  45.273178,  13.715221,0,846.5,41873.7007755,22-Aug-14, 4:49:07 PM
+  45.273144,  13.715237,0,843.2,41873.7007870,22-Aug-14, 4:49:08 PM
+  45.273084,  13.715298,0,849.7,41873.7008333,22-Aug-14, 4:49:12 PM
+  45.273047,  13.715432,0,862.9,41873.7008912,22-Aug-14, 4:49:17 PM
+  45.272971,  13.715532,0,869.4,41873.7009491,22-Aug-14, 4:49:22 PM
+  45.272916,  13.715658,0,862.9,41873.7010069,22-Aug-14, 4:49:27 PM
+  45.272843,  13.71578,0,859.6,41873.7010648,22-Aug-14, 4:49:32 PM
+  45.272788,  13.715899,0,866.1,41873.7011227,22-Aug-14, 4:49:37 PM
+  45.272744,  13.716027,0,876.0,41873.7011690,22-Aug-14, 4:49:41 PM
+  45.272673,  13.716124,0,-999,41873.7012153,22-Aug-14, 4:49:45 PM
+  45.272612,  13.716222,0,869.4,41873.7012616,22-Aug-14, 4:49:49 PM
+  45.27255,  13.716327,0,872.7,41873.7013079,22-Aug-14, 4:49:53 PM
+  45.272488,  13.716445,0,862.9,41873.7013542,22-Aug-14, 4:49:57 PM
+  45.272433,  13.716581,0,856.3,41873.7014005,22-Aug-14, 4:50:01 PM
+  45.272374,  13.716691,0,853.0,41873.7014583,22-Aug-14, 4:50:06 PM
+  45.272311,  13.716809,0,856.3,41873.7015162,22-Aug-14, 4:50:11 PM
+  45.272248,  13.716934,0,856.3,41873.7015741,22-Aug-14, 4:50:16 PM
+  45.272183,  13.71704,0,849.7,41873.7016204,22-Aug-14, 4:50:20 PM
+  45.272124,  13.717144,0,846.5,41873.7016667,22-Aug-14, 4:50:24 PM
+  45.272072,  13.717277,0,862.9,41873.7017130,22-Aug-14, 4:50:28 PM
+  45.272009,  13.717371,0,856.3,41873.7017593,22-Aug-14, 4:50:32 PM
+  45.271941,  13.717474,0,856.3,41873.7018056,22-Aug-14, 4:50:36 PM
+  45.271867,  13.717551,0,853.0,41873.7018519,22-Aug-14, 4:50:40 PM
+  45.27178,  13.717643,0,849.7,41873.7018981,22-Aug-14, 4:50:44 PM
+  45.271751,  13.717671,0,843.2,41873.7019097,22-Aug-14, 4:50:45 PM
+  45.271688,  13.717716,0,839.9,41873.7019444,22-Aug-14, 4:50:48 PM
+  45.271594,  13.717755,0,853.0,41873.7020023,22-Aug-14, 4:50:53 PM
+  45.271519,  13.717831,0,856.3,41873.7020486,22-Aug-14, 4:50:57 PM
+  45.271424,  13.717858,0,859.6,41873.7020949,22-Aug-14, 4:51:01 PM
+  45.271348,  13.717941,0,859.6,41873.7021412,22-Aug-14, 4:51:05 PM
+  45.271389,  13.718076,0,843.2,41873.7022106,22-Aug-14, 4:51:11 PM
+  45.271489,  13.718096,0,823.5,41873.7022917,22-Aug-14, 4:51:18 PM
+  45.271574,  13.718142,0,826.8,41873.7023495,22-Aug-14, 4:51:23 PM
+  45.271683,  13.718166,0,833.3,41873.7023958,22-Aug-14, 4:51:27 PM
+  45.27179,  13.718188,0,833.3,41873.7024421,22-Aug-14, 4:51:31 PM
+  45.271898,  13.718205,0,836.6,41873.7024884,22-Aug-14, 4:51:35 PM
+  45.271987,  13.718217,0,836.6,41873.7025347,22-Aug-14, 4:51:39 PM
+  45.272078,  13.718235,0,830.1,41873.7025810,22-Aug-14, 4:51:43 PM
+  45.272169,  13.718296,0,833.3,41873.7026273,22-Aug-14, 4:51:47 PM
+  45.272234,  13.718394,0,836.6,41873.7026736,22-Aug-14, 4:51:51 PM
+  45.272262,  13.718532,0,830.1,41873.7027199,22-Aug-14, 4:51:55 PM
+  45.272249,  13.718663,0,826.8,41873.7027662,22-Aug-14, 4:51:59 PM
+  45.272216,  13.718791,0,810.4,41873.7028125,22-Aug-14, 4:52:03 PM
+  45.272184,  13.718939,0,820.2,41873.7028588,22-Aug-14, 4:52:07 PM
+  45.272164,  13.719084,0,816.9,41873.7029051,22-Aug-14, 4:52:11 PM
+  45.272148,  13.719217,0,816.9,41873.7029514,22-Aug-14, 4:52:15 PM
+  45.272161,  13.719361,0,820.2,41873.7029977,22-Aug-14, 4:52:19 PM
+  45.272177,  13.719504,0,836.6,41873.7030440,22-Aug-14, 4:52:23 PM
+  45.272206,  13.719642,0,830.1,41873.7030903,22-Aug-14, 4:52:27 PM
+  45.27224,  13.71978,0,833.3,41873.7031366,22-Aug-14, 4:52:31 PM
+  45.272281,  13.719904,0,820.2,41873.7031829,22-Aug-14, 4:52:35 PM
+  45.272338,  13.720026,0,820.2,41873.7032407,22-Aug-14, 4:52:40 PM
+  45.272425,  13.720098,0,823.5,41873.7032870,22-Aug-14, 4:52:44 PM
+  45.272531,  13.720152,0,833.3,41873.7033449,22-Aug-14, 4:52:49 PM
+  45.272627,  13.720198,0,836.6,41873.7034144,22-Aug-14, 4:52:55 PM
+  45.272724,  13.720227,0,833.3,41873.7034838,22-Aug-14, 4:53:01 PM
+  45.272819,  13.720263,0,836.6,41873.7035301,22-Aug-14, 4:53:05 PM
+  45.272914,  13.720297,0,830.1,41873.7035764,22-Aug-14, 4:53:09 PM
+  45.273006,  13.72037,0,826.8,41873.7036458,22-Aug-14, 4:53:15 PM
+  45.273075,  13.720468,0,823.5,41873.7037153,22-Aug-14, 4:53:21 PM
+  45.27314,  13.72055,0,826.8,41873.7037616,22-Aug-14, 4:53:25 PM
+  45.273236,  13.720615,0,836.6,41873.7038194,22-Aug-14, 4:53:30 PM
+  45.273332,  13.720647,0,836.6,41873.7038773,22-Aug-14, 4:53:35 PM
+  45.273439,  13.720666,0,853.0,41873.7039352,22-Aug-14, 4:53:40 PM
+  45.273538,  13.720676,0,853.0,41873.7039815,22-Aug-14, 4:53:44 PM
+  45.273652,  13.720689,0,859.6,41873.7040509,22-Aug-14, 4:53:50 PM
+  45.273751,  13.720704,0,859.6,41873.7041204,22-Aug-14, 4:53:56 PM
+  45.273839,  13.720729,0,866.1,41873.7041782,22-Aug-14, 4:54:01 PM
+  45.27394,  13.720753,0,866.1,41873.7042361,22-Aug-14, 4:54:06 PM
+  45.274048,  13.720787,0,859.6,41873.7042940,22-Aug-14, 4:54:11 PM
+  45.274146,  13.720783,0,856.3,41873.7043403,22-Aug-14, 4:54:15 PM
+  45.274238,  13.720797,0,856.3,41873.7043866,22-Aug-14, 4:54:19 PM
+  45.274341,  13.720827,0,853.0,41873.7044444,22-Aug-14, 4:54:24 PM
+  45.274434,  13.720895,0,846.5,41873.7045023,22-Aug-14, 4:54:29 PM
+  45.274486,  13.721021,0,849.7,41873.7045602,22-Aug-14, 4:54:34 PM
+  45.274534,  13.721149,0,849.7,41873.7046181,22-Aug-14, 4:54:39 PM
+  45.274549,  13.721302,0,856.3,41873.7046759,22-Aug-14, 4:54:44 PM
+  45.274543,  13.721436,0,849.7,41873.7047222,22-Aug-14, 4:54:48 PM
+  45.27454,  13.721571,0,853.0,41873.7047685,22-Aug-14, 4:54:52 PM
+  45.274539,  13.721715,0,862.9,41873.7048264,22-Aug-14, 4:54:57 PM
+  45.274568,  13.721851,0,862.9,41873.7048727,22-Aug-14, 4:55:01 PM
+  45.274587,  13.721985,0,846.5,41873.7049190,22-Aug-14, 4:55:05 PM
+  45.274606,  13.722109,0,843.2,41873.7049653,22-Aug-14, 4:55:09 PM
+  45.274633,  13.722244,0,843.2,41873.7050116,22-Aug-14, 4:55:13 PM
+  45.274656,  13.72239,0,836.6,41873.7050579,22-Aug-14, 4:55:17 PM
+  45.274672,  13.722544,0,846.5,41873.7051042,22-Aug-14, 4:55:21 PM
+  45.27467,  13.722686,0,846.5,41873.7051505,22-Aug-14, 4:55:25 PM
+  45.274667,  13.722817,0,839.9,41873.7051968,22-Aug-14, 4:55:29 PM
+  45.274644,  13.722943,0,839.9,41873.7052431,22-Aug-14, 4:55:33 PM
+  45.274648,  13.723073,0,836.6,41873.7052894,22-Aug-14, 4:55:37 PM
+  45.274637,  13.723228,0,836.6,41873.7053472,22-Aug-14, 4:55:42 PM
+  45.274636,  13.723366,0,853.0,41873.7054051,22-Aug-14, 4:55:47 PM
+  45.274622,  13.723515,0,859.6,41873.7054630,22-Aug-14, 4:55:52 PM
+  45.274601,  13.723642,0,872.7,41873.7055093,22-Aug-14, 4:55:56 PM
+  45.27457,  13.723777,0,876.0,41873.7055556,22-Aug-14, 4:56:00 PM
+  45.274548,  13.72393,0,885.8,41873.7056134,22-Aug-14, 4:56:05 PM
+  45.274535,  13.724069,0,885.8,41873.7056713,22-Aug-14, 4:56:10 PM
+  45.274524,  13.724192,0,889.1,41873.7057176,22-Aug-14, 4:56:14 PM
+  45.274493,  13.724321,0,885.8,41873.7057639,22-Aug-14, 4:56:18 PM
+  45.274457,  13.724468,0,882.5,41873.7058218,22-Aug-14, 4:56:23 PM
+  45.274444,  13.724615,0,872.7,41873.7058796,22-Aug-14, 4:56:28 PM
+  45.274432,  13.724747,0,876.0,41873.7059259,22-Aug-14, 4:56:32 PM
+  45.274417,  13.724886,0,876.0,41873.7059722,22-Aug-14, 4:56:36 PM
+  45.274421,  13.725036,0,879.3,41873.7060301,22-Aug-14, 4:56:41 PM
+  45.274433,  13.725169,0,889.1,41873.7060880,22-Aug-14, 4:56:46 PM
+  45.274433,  13.725198,0,859.6,41873.7060995,22-Aug-14, 4:56:47 PM
+  45.274419,  13.7253,0,876.0,41873.7061458,22-Aug-14, 4:56:51 PM
+  45.274372,  13.725434,0,879.3,41873.7062384,22-Aug-14, 4:56:59 PM
+  45.274322,  13.725551,0,876.0,41873.7063079,22-Aug-14, 4:57:05 PM
+  45.274263,  13.725664,0,895.7,41873.7063773,22-Aug-14, 4:57:11 PM
+  45.2742,  13.725764,0,899.0,41873.7064352,22-Aug-14, 4:57:16 PM
+  45.274132,  13.725867,0,899.0,41873.7065046,22-Aug-14, 4:57:22 PM
+  45.274083,  13.725967,0,902.2,41873.7065625,22-Aug-14, 4:57:27 PM
+  45.274014,  13.726082,0,895.7,41873.7066319,22-Aug-14, 4:57:33 PM
+  45.273953,  13.72619,0,905.5,41873.7067014,22-Aug-14, 4:57:39 PM
+  45.273899,  13.726317,0,905.5,41873.7067708,22-Aug-14, 4:57:45 PM
+  45.273846,  13.726432,0,902.2,41873.7068287,22-Aug-14, 4:57:50 PM
+  45.273781,  13.726529,0,905.5,41873.7068981,22-Aug-14, 4:57:56 PM
+  45.27377,  13.726547,0,918.6,41873.7069097,22-Aug-14, 4:57:57 PM
+  45.273723,  13.726635,0,918.6,41873.7069676,22-Aug-14, 4:58:02 PM
+  45.273669,  13.726746,0,925.2,41873.7070255,22-Aug-14, 4:58:07 PM
+  45.273605,  13.726867,0,931.8,41873.7071065,22-Aug-14, 4:58:14 PM
+  45.27353,  13.72694,0,938.3,41873.7071759,22-Aug-14, 4:58:20 PM
+  45.273469,  13.72706,0,931.8,41873.7072454,22-Aug-14, 4:58:26 PM
+  45.273536,  13.727153,0,928.5,41873.7073264,22-Aug-14, 4:58:33 PM
+  45.273636,  13.72721,0,928.5,41873.7073958,22-Aug-14, 4:58:39 PM
+  45.27374,  13.727259,0,938.3,41873.7074421,22-Aug-14, 4:58:43 PM
+  45.27382,  13.727356,0,941.6,41873.7075000,22-Aug-14, 4:58:48 PM
+  45.273894,  13.727427,0,958.0,41873.7075463,22-Aug-14, 4:58:52 PM
+  45.273973,  13.727513,0,961.3,41873.7075926,22-Aug-14, 4:58:56 PM
+  45.27404,  13.727629,0,961.3,41873.7076389,22-Aug-14, 4:59:00 PM
+  45.274123,  13.72771,0,961.3,41873.7076852,22-Aug-14, 4:59:04 PM
+  45.274214,  13.72779,0,951.4,41873.7077315,22-Aug-14, 4:59:08 PM
+  45.274282,  13.72788,0,961.3,41873.7077778,22-Aug-14, 4:59:12 PM
+  45.274357,  13.727993,0,951.4,41873.7078356,22-Aug-14, 4:59:17 PM
+  45.27442,  13.728089,0,951.4,41873.7078935,22-Aug-14, 4:59:22 PM
+  45.274491,  13.728195,0,961.3,41873.7079630,22-Aug-14, 4:59:28 PM
+  45.274575,  13.728282,0,961.3,41873.7080208,22-Aug-14, 4:59:33 PM
+  45.274665,  13.728327,0,944.9,41873.7080787,22-Aug-14, 4:59:38 PM
+  45.274762,  13.728369,0,931.8,41873.7081366,22-Aug-14, 4:59:43 PM
+  45.274836,  13.728383,0,931.8,41873.7081713,22-Aug-14, 4:59:46 PM
+  45.274874,  13.728394,0,928.5,41873.7081829,22-Aug-14, 4:59:47 PM
+  45.274947,  13.728424,0,925.2,41873.7082060,22-Aug-14, 4:59:49 PM
+  45.27506,  13.728457,0,925.2,41873.7082639,22-Aug-14, 4:59:54 PM
+  45.275149,  13.728496,0,928.5,41873.7083102,22-Aug-14, 4:59:58 PM
+  45.275256,  13.728532,0,931.8,41873.7083796,22-Aug-14, 5:00:04 PM
+  45.275343,  13.728544,0,921.9,41873.7084375,22-Aug-14, 5:00:09 PM
+  45.27544,  13.728574,0,912.1,41873.7084954,22-Aug-14, 5:00:14 PM
+  45.275536,  13.728609,0,908.8,41873.7085532,22-Aug-14, 5:00:19 PM
+  45.275632,  13.728625,0,912.1,41873.7086111,22-Aug-14, 5:00:24 PM
+  45.275734,  13.728649,0,918.6,41873.7086574,22-Aug-14, 5:00:28 PM
+  45.27584,  13.728665,0,925.2,41873.7087153,22-Aug-14, 5:00:33 PM
+  45.275932,  13.728673,0,931.8,41873.7087616,22-Aug-14, 5:00:37 PM
+  45.276022,  13.728692,0,935.0,41873.7088079,22-Aug-14, 5:00:41 PM
+  45.276127,  13.728684,0,944.9,41873.7088773,22-Aug-14, 5:00:47 PM
+  45.276214,  13.72873,0,958.0,41873.7089583,22-Aug-14, 5:00:54 PM
+  45.27628,  13.728782,0,951.4,41873.7090046,22-Aug-14, 5:00:58 PM
+  45.276393,  13.728813,0,954.7,41873.7090509,22-Aug-14, 5:01:02 PM
+  45.276481,  13.728815,0,961.3,41873.7090972,22-Aug-14, 5:01:06 PM
+  45.276593,  13.72883,0,958.0,41873.7091551,22-Aug-14, 5:01:11 PM
+  45.2767,  13.728853,0,961.3,41873.7092130,22-Aug-14, 5:01:16 PM
+  45.276792,  13.728881,0,964.6,41873.7092708,22-Aug-14, 5:01:21 PM
+  45.276894,  13.728914,0,951.4,41873.7093287,22-Aug-14, 5:01:26 PM
+  45.276985,  13.728941,0,948.2,41873.7093981,22-Aug-14, 5:01:32 PM
+  45.277079,  13.728952,0,948.2,41873.7094329,22-Aug-14, 5:01:35 PM
+  45.277194,  13.728952,0,944.9,41873.7094792,22-Aug-14, 5:01:39 PM
+  45.277301,  13.728986,0,948.2,41873.7095486,22-Aug-14, 5:01:45 PM
+  45.277397,  13.729016,0,958.0,41873.7096296,22-Aug-14, 5:01:52 PM
+  45.277489,  13.729092,0,944.9,41873.7096991,22-Aug-14, 5:01:58 PM
+  45.277577,  13.729126,0,944.9,41873.7097569,22-Aug-14, 5:02:03 PM
+  45.277658,  13.729184,0,967.8,41873.7098264,22-Aug-14, 5:02:09 PM
+  45.277766,  13.729214,0,971.1,41873.7098843,22-Aug-14, 5:02:14 PM
+  45.277874,  13.729231,0,981.0,41873.7099421,22-Aug-14, 5:02:19 PM
+  45.277979,  13.729219,0,964.6,41873.7100000,22-Aug-14, 5:02:24 PM
+  45.278084,  13.72923,0,971.1,41873.7100579,22-Aug-14, 5:02:29 PM
+  45.278154,  13.729316,0,971.1,41873.7101157,22-Aug-14, 5:02:34 PM
+  45.278235,  13.729348,0,974.4,41873.7101620,22-Aug-14, 5:02:38 PM
+  45.278343,  13.729333,0,981.0,41873.7102199,22-Aug-14, 5:02:43 PM
+  45.278446,  13.729309,0,921.9,41873.7102894,22-Aug-14, 5:02:49 PM
+  45.278537,  13.729246,0,948.2,41873.7103588,22-Aug-14, 5:02:55 PM
+  45.278635,  13.729192,0,967.8,41873.7104398,22-Aug-14, 5:03:02 PM
+  45.278714,  13.729103,0,971.1,41873.7105208,22-Aug-14, 5:03:09 PM
+  45.278794,  13.72903,0,954.7,41873.7105787,22-Aug-14, 5:03:14 PM
+  45.278891,  13.728958,0,958.0,41873.7106481,22-Aug-14, 5:03:20 PM
+  45.278955,  13.728865,0,958.0,41873.7107060,22-Aug-14, 5:03:25 PM
+  45.279023,  13.728751,0,971.1,41873.7107523,22-Aug-14, 5:03:29 PM
+  45.279083,  13.728645,0,987.5,41873.7108102,22-Aug-14, 5:03:34 PM
+  45.27916,  13.72854,0,990.8,41873.7108796,22-Aug-14, 5:03:40 PM
+  45.279123,  13.728412,0,990.8,41873.7109491,22-Aug-14, 5:03:46 PM
+  45.279093,  13.728273,0,974.4,41873.7110185,22-Aug-14, 5:03:52 PM
+  45.279069,  13.728134,0,977.7,41873.7110880,22-Aug-14, 5:03:58 PM
+  45.278991,  13.728074,0,987.5,41873.7111574,22-Aug-14, 5:04:04 PM
+  45.278898,  13.727996,0,981.0,41873.7112037,22-Aug-14, 5:04:08 PM
+  45.27881,  13.727908,0,981.0,41873.7112500,22-Aug-14, 5:04:12 PM
+  45.278725,  13.727832,0,987.5,41873.7113194,22-Aug-14, 5:04:18 PM
+  45.278656,  13.727906,0,987.5,41873.7113773,22-Aug-14, 5:04:23 PM
+  45.27862,  13.728042,0,994.1,41873.7114352,22-Aug-14, 5:04:28 PM
+  45.278566,  13.72817,0,990.8,41873.7114931,22-Aug-14, 5:04:33 PM
+  45.278493,  13.728241,0,990.8,41873.7115394,22-Aug-14, 5:04:37 PM
+  45.278418,  13.728307,0,990.8,41873.7115741,22-Aug-14, 5:04:40 PM
+  45.278313,  13.728389,0,997.4,41873.7116204,22-Aug-14, 5:04:44 PM
+  45.278221,  13.728483,0,997.4,41873.7116782,22-Aug-14, 5:04:49 PM
+  45.27814,  13.728566,0,990.8,41873.7117593,22-Aug-14, 5:04:56 PM
+  45.278104,  13.728579,0,990.8,41873.7117708,22-Aug-14, 5:04:57 PM
+  45.278032,  13.728612,0,984.3,41873.7117940,22-Aug-14, 5:04:59 PM
+  45.277942,  13.728697,0,990.8,41873.7118634,22-Aug-14, 5:05:05 PM
+  45.277909,  13.72872,0,984.3,41873.7118750,22-Aug-14, 5:05:06 PM
+  45.27783,  13.72877,0,987.5,41873.7118981,22-Aug-14, 5:05:08 PM
+  45.277799,  13.728795,0,984.3,41873.7119097,22-Aug-14, 5:05:09 PM
+  45.277742,  13.728851,0,984.3,41873.7119329,22-Aug-14, 5:05:11 PM
+  45.277654,  13.728955,0,984.3,41873.7119792,22-Aug-14, 5:05:15 PM
+  45.277558,  13.729027,0,967.8,41873.7120370,22-Aug-14, 5:05:20 PM
+  45.277457,  13.729075,0,977.7,41873.7120833,22-Aug-14, 5:05:24 PM
+  45.27735,  13.729082,0,974.4,41873.7121644,22-Aug-14, 5:05:31 PM
+  45.277255,  13.729068,0,961.3,41873.7122106,22-Aug-14, 5:05:35 PM
+  45.277157,  13.729013,0,961.3,41873.7122569,22-Aug-14, 5:05:39 PM
+  45.277053,  13.728972,0,964.6,41873.7123148,22-Aug-14, 5:05:44 PM
+  45.276947,  13.728938,0,951.4,41873.7123727,22-Aug-14, 5:05:49 PM
+  45.276852,  13.728911,0,951.4,41873.7124190,22-Aug-14, 5:05:53 PM
+  45.276751,  13.728883,0,958.0,41873.7124653,22-Aug-14, 5:05:57 PM
+  45.276656,  13.728855,0,944.9,41873.7125116,22-Aug-14, 5:06:01 PM
+  45.276565,  13.728858,0,948.2,41873.7125463,22-Aug-14, 5:06:04 PM
+  45.276474,  13.728847,0,944.9,41873.7125810,22-Aug-14, 5:06:07 PM
+  45.276438,  13.728843,0,944.9,41873.7125926,22-Aug-14, 5:06:08 PM
+  45.276353,  13.728813,0,935.0,41873.7126273,22-Aug-14, 5:06:11 PM
+  45.276242,  13.728793,0,941.6,41873.7126852,22-Aug-14, 5:06:16 PM
+  45.276151,  13.728777,0,928.5,41873.7127315,22-Aug-14, 5:06:20 PM
+  45.276047,  13.728739,0,925.2,41873.7127894,22-Aug-14, 5:06:25 PM
+  45.275956,  13.728714,0,925.2,41873.7128356,22-Aug-14, 5:06:29 PM
+  45.275845,  13.728708,0,931.8,41873.7128935,22-Aug-14, 5:06:34 PM
+  45.275748,  13.728669,0,941.6,41873.7129514,22-Aug-14, 5:06:39 PM
+  45.275644,  13.728623,0,944.9,41873.7130093,22-Aug-14, 5:06:44 PM
+  45.275542,  13.728611,0,935.0,41873.7130556,22-Aug-14, 5:06:48 PM
+  45.275437,  13.728565,0,935.0,41873.7131134,22-Aug-14, 5:06:53 PM
+  45.275338,  13.728527,0,938.3,41873.7131597,22-Aug-14, 5:06:57 PM
+  45.275228,  13.728496,0,938.3,41873.7132060,22-Aug-14, 5:07:01 PM
+  45.275202,  13.728493,0,925.2,41873.7132176,22-Aug-14, 5:07:02 PM
+  45.275128,  13.728476,0,928.5,41873.7132523,22-Aug-14, 5:07:05 PM
+  45.27503,  13.728454,0,931.8,41873.7132986,22-Aug-14, 5:07:09 PM
+  45.274926,  13.728434,0,938.3,41873.7133565,22-Aug-14, 5:07:14 PM
+  45.274914,  13.728326,0,941.6,41873.7134144,22-Aug-14, 5:07:19 PM
+  45.274961,  13.728198,0,931.8,41873.7134606,22-Aug-14, 5:07:23 PM
+  45.275003,  13.728077,0,931.8,41873.7135069,22-Aug-14, 5:07:27 PM
+  45.275016,  13.727931,0,928.5,41873.7135532,22-Aug-14, 5:07:31 PM
+  45.275042,  13.727803,0,925.2,41873.7135995,22-Aug-14, 5:07:35 PM
+  45.275057,  13.727673,0,921.9,41873.7136458,22-Aug-14, 5:07:39 PM
+  45.275073,  13.727542,0,908.8,41873.7136921,22-Aug-14, 5:07:43 PM
+  45.27515,  13.727481,0,915.4,41873.7137500,22-Aug-14, 5:07:48 PM
+  45.275234,  13.727564,0,921.9,41873.7137963,22-Aug-14, 5:07:52 PM
+  45.275335,  13.727553,0,921.9,41873.7138426,22-Aug-14, 5:07:56 PM
+  45.275353,  13.72753,0,912.1,41873.7138542,22-Aug-14, 5:07:57 PM
+  45.275387,  13.727442,0,915.4,41873.7138889,22-Aug-14, 5:08:00 PM
+  45.275408,  13.727306,0,908.8,41873.7139352,22-Aug-14, 5:08:04 PM
+  45.275429,  13.727174,0,902.2,41873.7139815,22-Aug-14, 5:08:08 PM
+  45.275472,  13.72706,0,905.5,41873.7140278,22-Aug-14, 5:08:12 PM
+  45.275507,  13.726937,0,902.2,41873.7140741,22-Aug-14, 5:08:16 PM
+  45.275552,  13.726822,0,899.0,41873.7141204,22-Aug-14, 5:08:20 PM
+  45.275599,  13.726703,0,899.0,41873.7141667,22-Aug-14, 5:08:24 PM
+  45.275639,  13.726576,0,902.2,41873.7142130,22-Aug-14, 5:08:28 PM
+  45.275679,  13.726443,0,895.7,41873.7142593,22-Aug-14, 5:08:32 PM
+  45.275726,  13.726323,0,899.0,41873.7143056,22-Aug-14, 5:08:36 PM
+  45.275782,  13.726216,0,885.8,41873.7143519,22-Aug-14, 5:08:40 PM
+  45.275811,  13.726082,0,889.1,41873.7143981,22-Aug-14, 5:08:44 PM
+  45.275844,  13.725952,0,882.5,41873.7144444,22-Aug-14, 5:08:48 PM
+  45.275883,  13.725827,0,879.3,41873.7144907,22-Aug-14, 5:08:52 PM
+  45.275944,  13.725694,0,882.5,41873.7145486,22-Aug-14, 5:08:57 PM
+  45.275949,  13.725553,0,872.7,41873.7145949,22-Aug-14, 5:09:01 PM
+  45.27598,  13.725435,0,872.7,41873.7146296,22-Aug-14, 5:09:04 PM
+  45.276023,  13.725291,0,866.1,41873.7146759,22-Aug-14, 5:09:08 PM
+  45.276048,  13.725144,0,869.4,41873.7147222,22-Aug-14, 5:09:12 PM
+  45.276074,  13.725008,0,866.1,41873.7147685,22-Aug-14, 5:09:16 PM
+  45.276149,  13.724897,0,862.9,41873.7148264,22-Aug-14, 5:09:21 PM
+  45.276182,  13.724761,0,862.9,41873.7148843,22-Aug-14, 5:09:26 PM
+  45.276192,  13.724625,0,859.6,41873.7149306,22-Aug-14, 5:09:30 PM
+  45.276207,  13.724481,0,866.1,41873.7149769,22-Aug-14, 5:09:34 PM
+  45.276256,  13.724363,0,862.9,41873.7150231,22-Aug-14, 5:09:38 PM
+  45.276313,  13.724265,0,859.6,41873.7150694,22-Aug-14, 5:09:42 PM
+  45.27635,  13.724143,0,859.6,41873.7151157,22-Aug-14, 5:09:46 PM
+  45.276375,  13.724009,0,853.0,41873.7151620,22-Aug-14, 5:09:50 PM
+  45.276424,  13.723866,0,846.5,41873.7152199,22-Aug-14, 5:09:55 PM
+  45.276463,  13.723726,0,862.9,41873.7152778,22-Aug-14, 5:10:00 PM
+  45.276486,  13.723579,0,882.5,41873.7153356,22-Aug-14, 5:10:05 PM
+  45.27658,  13.723509,0,885.8,41873.7154051,22-Aug-14, 5:10:11 PM
+  45.276672,  13.723531,0,889.1,41873.7154745,22-Aug-14, 5:10:17 PM
+  45.276723,  13.723415,0,869.4,41873.7155440,22-Aug-14, 5:10:23 PM
+  45.276746,  13.723283,0,885.8,41873.7155903,22-Aug-14, 5:10:27 PM
+  45.276792,  13.723151,0,905.5,41873.7156366,22-Aug-14, 5:10:31 PM
+  45.27684,  13.723024,0,921.9,41873.7161111,22-Aug-14, 5:11:12 PM
+  45.276898,  13.722878,0,915.4,41873.7161574,22-Aug-14, 5:11:16 PM
+  45.276948,  13.722763,0,918.6,41873.7162037,22-Aug-14, 5:11:20 PM
+  45.276907,  13.722648,0,918.6,41873.7162500,22-Aug-14, 5:11:24 PM
+  45.276881,  13.722629,0,931.8,41873.7162616,22-Aug-14, 5:11:25 PM
+  45.276802,  13.722578,0,938.3,41873.7162963,22-Aug-14, 5:11:28 PM
+  45.276707,  13.722508,0,944.9,41873.7163426,22-Aug-14, 5:11:32 PM
+  45.276612,  13.722425,0,941.6,41873.7163773,22-Aug-14, 5:11:35 PM
+  45.276581,  13.722389,0,941.6,41873.7163889,22-Aug-14, 5:11:36 PM
+  45.276541,  13.722312,0,928.5,41873.7164120,22-Aug-14, 5:11:38 PM
+  45.276478,  13.722196,0,944.9,41873.7164583,22-Aug-14, 5:11:42 PM
+  45.276399,  13.722087,0,958.0,41873.7165162,22-Aug-14, 5:11:47 PM
+  45.276318,  13.722079,0,954.7,41873.7165509,22-Aug-14, 5:11:50 PM
+  45.276203,  13.722054,0,954.7,41873.7165972,22-Aug-14, 5:11:54 PM
+  45.276099,  13.721973,0,938.3,41873.7166435,22-Aug-14, 5:11:58 PM
+  45.276003,  13.72187,0,938.3,41873.7166898,22-Aug-14, 5:12:02 PM
+  45.275917,  13.721751,0,935.0,41873.7167361,22-Aug-14, 5:12:06 PM
+  45.275824,  13.721653,0,928.5,41873.7167824,22-Aug-14, 5:12:10 PM
+  45.275746,  13.721525,0,928.5,41873.7168287,22-Aug-14, 5:12:14 PM
+  45.275693,  13.72141,0,921.9,41873.7168750,22-Aug-14, 5:12:18 PM
+  45.275625,  13.721332,0,912.1,41873.7169213,22-Aug-14, 5:12:22 PM
+  45.275527,  13.721288,0,912.1,41873.7169676,22-Aug-14, 5:12:26 PM
+  45.275422,  13.721226,0,915.4,41873.7170139,22-Aug-14, 5:12:30 PM
+  45.275323,  13.721153,0,902.2,41873.7170602,22-Aug-14, 5:12:34 PM
+  45.275229,  13.721084,0,889.1,41873.7171065,22-Aug-14, 5:12:38 PM
+  45.275139,  13.721004,0,879.3,41873.7171528,22-Aug-14, 5:12:42 PM
+  45.275048,  13.720972,0,879.3,41873.7171875,22-Aug-14, 5:12:45 PM
+  45.274957,  13.72092,0,879.3,41873.7172222,22-Aug-14, 5:12:48 PM
+  45.274874,  13.720814,0,885.8,41873.7172801,22-Aug-14, 5:12:53 PM
+  45.274785,  13.720831,0,866.1,41873.7173495,22-Aug-14, 5:12:59 PM
+  45.27472,  13.720929,0,866.1,41873.7173958,22-Aug-14, 5:13:03 PM
+  45.27464,  13.72103,0,866.1,41873.7174421,22-Aug-14, 5:13:07 PM
+  45.27455,  13.721107,0,866.1,41873.7174884,22-Aug-14, 5:13:11 PM
+  45.274458,  13.721058,0,866.1,41873.7175347,22-Aug-14, 5:13:15 PM
+  45.274395,  13.720957,0,859.6,41873.7175810,22-Aug-14, 5:13:19 PM
+  45.274316,  13.720883,0,853.0,41873.7176273,22-Aug-14, 5:13:23 PM
+  45.27421,  13.720848,0,908.8,41873.7176852,22-Aug-14, 5:13:28 PM
+  45.274188,  13.720851,0,892.4,41873.7176968,22-Aug-14, 5:13:29 PM
+  45.274103,  13.720826,0,862.9,41873.7177431,22-Aug-14, 5:13:33 PM
+  45.274006,  13.720802,0,849.7,41873.7177894,22-Aug-14, 5:13:37 PM
+  45.273905,  13.720761,0,849.7,41873.7178356,22-Aug-14, 5:13:41 PM
+  45.273798,  13.720723,0,846.5,41873.7178935,22-Aug-14, 5:13:46 PM
+  45.273694,  13.720691,0,849.7,41873.7179398,22-Aug-14, 5:13:50 PM
+  45.273576,  13.720693,0,839.9,41873.7179861,22-Aug-14, 5:13:54 PM
+  45.273471,  13.720676,0,830.1,41873.7180324,22-Aug-14, 5:13:58 PM
+  45.273371,  13.720654,0,830.1,41873.7180787,22-Aug-14, 5:14:02 PM
+  45.273267,  13.72065,0,879.3,41873.7181250,22-Aug-14, 5:14:06 PM
+  45.273244,  13.720641,0,866.1,41873.7181366,22-Aug-14, 5:14:07 PM
+  45.273168,  13.720619,0,846.5,41873.7181713,22-Aug-14, 5:14:10 PM
+  45.273089,  13.720529,0,833.3,41873.7182176,22-Aug-14, 5:14:14 PM
+  45.273031,  13.720416,0,833.3,41873.7182639,22-Aug-14, 5:14:18 PM
+  45.272962,  13.720304,0,830.1,41873.7183218,22-Aug-14, 5:14:23 PM
+  45.272872,  13.720267,0,830.1,41873.7183681,22-Aug-14, 5:14:27 PM
+  45.272762,  13.720247,0,836.6,41873.7184259,22-Aug-14, 5:14:32 PM
+  45.272662,  13.72023,0,836.6,41873.7184722,22-Aug-14, 5:14:36 PM
+  45.272559,  13.720196,0,830.1,41873.7185185,22-Aug-14, 5:14:40 PM
+  45.272467,  13.720146,0,816.9,41873.7185532,22-Aug-14, 5:14:43 PM
+  45.272368,  13.720067,0,823.5,41873.7185995,22-Aug-14, 5:14:47 PM
+  45.272296,  13.719952,0,810.4,41873.7186458,22-Aug-14, 5:14:51 PM
+  45.272236,  13.719826,0,807.1,41873.7186921,22-Aug-14, 5:14:55 PM
+  45.2722,  13.7197,0,803.8,41873.7187384,22-Aug-14, 5:14:59 PM
+  45.272176,  13.719577,0,816.9,41873.7187847,22-Aug-14, 5:15:03 PM
+  45.272164,  13.71943,0,823.5,41873.7188310,22-Aug-14, 5:15:07 PM
+  45.272176,  13.719296,0,826.8,41873.7188773,22-Aug-14, 5:15:11 PM
+  45.2722,  13.719166,0,823.5,41873.7189236,22-Aug-14, 5:15:15 PM
+  45.272203,  13.719012,0,820.2,41873.7189699,22-Aug-14, 5:15:19 PM
+  45.272197,  13.718868,0,816.9,41873.7190162,22-Aug-14, 5:15:23 PM
+  45.272206,  13.71874,0,816.9,41873.7190625,22-Aug-14, 5:15:27 PM
+  45.27224,  13.718589,0,823.5,41873.7191319,22-Aug-14, 5:15:33 PM
+  45.272252,  13.718457,0,833.3,41873.7191898,22-Aug-14, 5:15:38 PM
+  45.272218,  13.718313,0,839.9,41873.7192593,22-Aug-14, 5:15:44 PM
+  45.272144,  13.718234,0,846.5,41873.7193287,22-Aug-14, 5:15:50 PM
+  45.272049,  13.718204,0,849.7,41873.7193866,22-Aug-14, 5:15:55 PM
+  45.271948,  13.718186,0,856.3,41873.7194444,22-Aug-14, 5:16:00 PM
+  45.271855,  13.718179,0,856.3,41873.7194907,22-Aug-14, 5:16:04 PM
+  45.271752,  13.718167,0,853.0,41873.7195370,22-Aug-14, 5:16:08 PM
+  45.271651,  13.718167,0,849.7,41873.7195833,22-Aug-14, 5:16:12 PM
+  45.271557,  13.718145,0,856.3,41873.7196296,22-Aug-14, 5:16:16 PM
+  45.271462,  13.718115,0,846.5,41873.7196759,22-Aug-14, 5:16:20 PM
+  45.271376,  13.71807,0,846.5,41873.7197222,22-Aug-14, 5:16:24 PM
+  45.271333,  13.717945,0,849.7,41873.7197801,22-Aug-14, 5:16:29 PM
+  45.271411,  13.717875,0,843.2,41873.7198264,22-Aug-14, 5:16:33 PM
+  45.271503,  13.717839,0,846.5,41873.7198843,22-Aug-14, 5:16:38 PM
+  45.271598,  13.717773,0,846.5,41873.7199421,22-Aug-14, 5:16:43 PM
+  45.271695,  13.717699,0,853.0,41873.7200000,22-Aug-14, 5:16:48 PM
+  45.271789,  13.717621,0,853.0,41873.7200579,22-Aug-14, 5:16:53 PM
+  45.271867,  13.717544,0,853.0,41873.7201042,22-Aug-14, 5:16:57 PM
+  45.271951,  13.717439,0,859.6,41873.7201620,22-Aug-14, 5:17:02 PM
+  45.272005,  13.717319,0,862.9,41873.7202199,22-Aug-14, 5:17:07 PM
+  45.272079,  13.717241,0,859.6,41873.7202662,22-Aug-14, 5:17:11 PM
+  45.272139,  13.717145,0,856.3,41873.7203125,22-Aug-14, 5:17:15 PM
+  45.272174,  13.717006,0,843.2,41873.7203588,22-Aug-14, 5:17:19 PM
+  45.272234,  13.716886,0,826.8,41873.7204167,22-Aug-14, 5:17:24 PM
+  45.272289,  13.716784,0,823.5,41873.7204745,22-Aug-14, 5:17:29 PM
+  45.272338,  13.716687,0,833.3,41873.7205208,22-Aug-14, 5:17:33 PM
+  45.272423,  13.716597,0,839.9,41873.7205671,22-Aug-14, 5:17:37 PM
+  45.272482,  13.716467,0,839.9,41873.7206134,22-Aug-14, 5:17:41 PM
+  45.27254,  13.716325,0,833.3,41873.7206597,22-Aug-14, 5:17:45 PM
+  45.272606,  13.716196,0,843.2,41873.7207176,22-Aug-14, 5:17:50 PM
+  45.272653,  13.716068,0,846.5,41873.7207870,22-Aug-14, 5:17:56 PM
+  45.272725,  13.715965,0,853.0,41873.7208449,22-Aug-14, 5:18:01 PM
+  45.272785,  13.715829,0,849.7,41873.7208912,22-Aug-14, 5:18:05 PM
+  45.27286,  13.715712,0,856.3,41873.7209375,22-Aug-14, 5:18:09 PM
+  45.272922,  13.715606,0,856.3,41873.7209838,22-Aug-14, 5:18:13 PM
+  45.272987,  13.715492,0,869.4,41873.7210301,22-Aug-14, 5:18:17 PM
+  45.27305,  13.715394,0,862.9,41873.7210764,22-Aug-14, 5:18:21 PM
+  45.273123,  13.715285,0,859.6,41873.7211343,22-Aug-14, 5:18:26 PM
+  45.273198,  13.715174,0,856.3,41873.7212153,22-Aug-14, 5:18:33 PM
+  45.273294,  13.715119,0,856.3,41873.7212616,22-Aug-14, 5:18:37 PM
+  45.273395,  13.715096,0,872.7,41873.7213079,22-Aug-14, 5:18:41 PM
+  45.27349,  13.71506,0,856.3,41873.7213426,22-Aug-14, 5:18:44 PM
+  45.273595,  13.715055,0,859.6,41873.7213889,22-Aug-14, 5:18:48 PM
+  45.273693,  13.715027,0,866.1,41873.7214468,22-Aug-14, 5:18:53 PM
+  45.273779,  13.714957,0,846.5,41873.7215046,22-Aug-14, 5:18:58 PM
+  45.273842,  13.714833,0,843.2,41873.7215625,22-Aug-14, 5:19:03 PM
+  45.273784,  13.714724,0,839.9,41873.7216204,22-Aug-14, 5:19:08 PM
+  45.273731,  13.714614,0,853.0,41873.7216782,22-Aug-14, 5:19:13 PM
+  45.273684,  13.714491,0,849.7,41873.7217245,22-Aug-14, 5:19:17 PM
+  45.273662,  13.714447,0,846.5,41873.7217361,22-Aug-14, 5:19:18 PM
+  45.273613,  13.714341,0,839.9,41873.7217708,22-Aug-14, 5:19:21 PM
+  45.273532,  13.714235,0,833.3,41873.7218171,22-Aug-14, 5:19:25 PM
+  45.273461,  13.71412,0,826.8,41873.7218634,22-Aug-14, 5:19:29 PM
+  45.27336,  13.71407,0,833.3,41873.7219097,22-Aug-14, 5:19:33 PM
+  45.273302,  13.713961,0,830.1,41873.7219560,22-Aug-14, 5:19:37 PM
+  45.273292,  13.71389,0,833.3,41873.7220139,22-Aug-14, 5:19:42 PM
@@ -0,0 +1,12 @@
+OziExplorer Track Point File Version 2.1
+WGS 84
+Altitude is in Feet
+Reserved 3
+0,2,255,Two Segments,0,0,2,8421376
+6
+  45.273245,  13.715185,1,-777,0, 22-Aug-14, 4:48:52 PM
+  45.273178,  13.715221,0,846.5,0, 22-Aug-14, 4:49:07 PM
+  45.273144,  13.715237,0,843.2,0, 22-Aug-14, 4:49:08 PM
+  45.273084,  13.715298,1,840.0,0, 22-Aug-14, 5:10:00 PM
+  45.273000,  13.715400,0,-777,0, 22-Aug-14, 5:10:20 PM
+  45.272900,  13.715500,0,850.0,0, 22-Aug-14, 5:10:40 PM